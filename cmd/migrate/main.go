@@ -0,0 +1,84 @@
+// migrate applies the SQL migrations embedded in the binary.
+// Run: go run ./cmd/migrate [up|down|status]   (default: up)
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/infrastructure/postgres"
+)
+
+func main() {
+	command := "up"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL is not set — run: direnv allow")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := postgres.NewPool(ctx, dbURL, postgres.DefaultPoolConfig())
+	if err != nil {
+		log.Fatalf("db connect: %v", err)
+	}
+	defer pool.Close()
+
+	m, err := postgres.NewMigrator(pool)
+	if err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+	defer m.Close()
+
+	if err := run(ctx, m, command); err != nil {
+		log.Printf("migrate %s: %v", command, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, m *postgres.Migrator, command string) error {
+	switch command {
+	case "up":
+		results, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		for _, r := range results {
+			fmt.Printf("OK   %s (%s)\n", r.Source.Path, r.Duration.Round(time.Millisecond))
+		}
+		if len(results) == 0 {
+			fmt.Println("no migrations to apply")
+		}
+	case "down":
+		r, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("OK   %s rolled back (%s)\n", r.Source.Path, r.Duration.Round(time.Millisecond))
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if !s.AppliedAt.IsZero() {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-19s  %s\n", applied, s.Source.Path)
+		}
+	default:
+		return fmt.Errorf("unknown command %q (want up, down or status)", command)
+	}
+	return nil
+}
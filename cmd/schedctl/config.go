@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Profile is one API endpoint and the bearer token to call it with.
+type Profile struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// Config is stored as JSON in <user config dir>/schedctl/config.json, readable only by
+// its owner since it holds tokens.
+type Config struct {
+	Current  string             `json:"current"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+const defaultProfile = "default"
+
+func configPath() (string, error) {
+	if p := os.Getenv("SCHEDCTL_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config dir: %w", err)
+	}
+	return filepath.Join(dir, "schedctl", "config.json"), nil
+}
+
+// loadConfig returns an empty config when the file doesn't exist yet.
+func loadConfig() (*Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{Profiles: map[string]Profile{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Profile{}
+	}
+	return cfg, nil
+}
+
+func (c *Config) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// resolve picks the profile to use: the -profile flag, then SCHEDCTL_PROFILE, then the
+// current profile. SCHEDCTL_URL and SCHEDCTL_TOKEN override its fields, so CI can run
+// without a config file.
+func (c *Config) resolve(name string) (Profile, error) {
+	if name == "" {
+		name = os.Getenv("SCHEDCTL_PROFILE")
+	}
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+	p, ok := c.Profiles[name]
+	if url := os.Getenv("SCHEDCTL_URL"); url != "" {
+		p.URL, ok = url, true
+	}
+	if token := os.Getenv("SCHEDCTL_TOKEN"); token != "" {
+		p.Token = token
+	}
+	if !ok {
+		return Profile{}, fmt.Errorf("profile %q not found; run: schedctl profile set %s -url URL -token TOKEN", name, name)
+	}
+	return p, nil
+}
+
+func (c *Config) names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
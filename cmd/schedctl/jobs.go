@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const followInterval = 2 * time.Second
+
+type jobSummary struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	URL         string     `json:"url"`
+	Method      string     `json:"method"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	LastError   *string    `json:"last_error"`
+}
+
+type attempt struct {
+	ID          string     `json:"id"`
+	AttemptNum  int        `json:"attempt_num"`
+	WorkerID    string     `json:"worker_id"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	StatusCode  *int       `json:"status_code"`
+	Error       *string    `json:"error"`
+	DurationMS  *int64     `json:"duration_ms"`
+}
+
+func runJobs(ctx context.Context, c *Client, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "create":
+		return jobsCreate(ctx, c, args[1:])
+	case "list":
+		return jobsList(ctx, c, args[1:])
+	case "get":
+		return getByID(ctx, c, "/jobs", args[1:])
+	case "cancel":
+		id, err := oneID(args[1:])
+		if err != nil {
+			return err
+		}
+		if err := c.do(ctx, "DELETE", "/jobs/"+url.PathEscape(id), nil, nil); err != nil {
+			return err
+		}
+		fmt.Printf("job %s cancelled\n", id)
+		return nil
+	case "attempts":
+		return jobsAttempts(ctx, c, args[1:])
+	}
+	return fmt.Errorf("unknown jobs command %q", args[0])
+}
+
+func jobsCreate(ctx context.Context, c *Client, args []string) error {
+	fs := flag.NewFlagSet("jobs create", flag.ContinueOnError)
+	target := fs.String("url", "", "URL to call (required)")
+	method := fs.String("method", "POST", "HTTP method")
+	at := fs.String("at", "now", `when to run: RFC 3339 time, "now" or a delay like "+10m"`)
+	key := fs.String("key", "", "idempotency key (default: random)")
+	body := fs.String("body", "", "request body; @file reads it from a file")
+	binary := fs.Bool("binary", false, "send -body as raw bytes, base64-encoded in transit")
+	contentType := fs.String("content-type", "", "Content-Type header for the body")
+	maxRetries := fs.Int("max-retries", 0, "retries after the first attempt")
+	timeout := fs.Int("timeout", 0, "per-attempt timeout in seconds (default: server default)")
+	dependsOn := fs.String("depends-on", "", "parent job ID; run only after it finishes")
+	queue := fs.String("queue", "", "run only on workers serving this queue (default: default)")
+	headers := headerFlag{}
+	fs.Var(headers, "header", `request header "Name: value" (repeatable)`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *target == "" {
+		return errors.New("-url is required")
+	}
+
+	scheduledAt, err := parseWhen(*at, time.Now())
+	if err != nil {
+		return err
+	}
+	if *key == "" {
+		*key = randomKey()
+	}
+	req := map[string]any{
+		"idempotency_key": *key,
+		"url":             *target,
+		"method":          strings.ToUpper(*method),
+		"scheduled_at":    scheduledAt,
+		"max_retries":     *maxRetries,
+	}
+	if len(headers) > 0 {
+		req["headers"] = map[string]string(headers)
+	}
+	if err := setBody(req, *body, *binary, *contentType); err != nil {
+		return err
+	}
+	if *timeout > 0 {
+		req["timeout_seconds"] = *timeout
+	}
+	if *dependsOn != "" {
+		req["depends_on"] = *dependsOn
+	}
+	if *queue != "" {
+		req["queue"] = *queue
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, "POST", "/jobs", req, &resp); err != nil {
+		return err
+	}
+	fmt.Println(resp.ID)
+	return nil
+}
+
+func jobsList(ctx context.Context, c *Client, args []string) error {
+	fs := flag.NewFlagSet("jobs list", flag.ContinueOnError)
+	status := fs.String("status", "", "only jobs in this status")
+	limit := fs.Int("limit", 20, "page size")
+	cursor := fs.String("cursor", "", "cursor from a previous page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	q.Set("limit", fmt.Sprint(*limit))
+	if *status != "" {
+		q.Set("status", *status)
+	}
+	if *cursor != "" {
+		q.Set("cursor", *cursor)
+	}
+	var resp struct {
+		Jobs       []jobSummary `json:"jobs"`
+		NextCursor *string      `json:"next_cursor"`
+	}
+	if err := c.do(ctx, "GET", "/jobs?"+q.Encode(), nil, &resp); err != nil {
+		return err
+	}
+
+	t := newTable("ID", "STATUS", "METHOD", "URL", "SCHEDULED", "ERROR")
+	for _, j := range resp.Jobs {
+		t.row(j.ID, j.Status, j.Method, j.URL, formatTime(&j.ScheduledAt), deref(j.LastError))
+	}
+	t.flush()
+	printNextCursor(resp.NextCursor)
+	return nil
+}
+
+// jobsAttempts prints a job's attempts. With -follow it keeps polling, printing attempts
+// as they complete, until the job reaches a terminal status.
+func jobsAttempts(ctx context.Context, c *Client, args []string) error {
+	fs := flag.NewFlagSet("jobs attempts", flag.ContinueOnError)
+	follow := fs.Bool("follow", false, "keep printing new attempts until the job finishes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := oneID(fs.Args())
+	if err != nil {
+		return err
+	}
+	path := "/jobs/" + url.PathEscape(id)
+
+	t := newTable("ATTEMPT", "STARTED", "DURATION", "STATUS", "WORKER", "ERROR")
+	printed := map[string]bool{}
+	for {
+		attempts, err := listAttempts(ctx, c, path)
+		if err != nil {
+			return err
+		}
+		for _, a := range attempts {
+			// An attempt still in flight is printed once it completes.
+			if printed[a.ID] || (*follow && a.CompletedAt == nil) {
+				continue
+			}
+			printed[a.ID] = true
+			t.row(fmt.Sprint(a.AttemptNum), formatTime(&a.StartedAt), formatDuration(a.DurationMS),
+				formatStatusCode(a.StatusCode), a.WorkerID, deref(a.Error))
+		}
+		t.flush()
+		if !*follow {
+			return nil
+		}
+
+		var job jobSummary
+		if err := c.do(ctx, "GET", path, nil, &job); err != nil {
+			return err
+		}
+		if terminal(job.Status) && len(printed) == len(attempts) {
+			fmt.Printf("job %s %s\n", id, job.Status)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(followInterval):
+		}
+	}
+}
+
+// listAttempts fetches every page of a job's attempts, oldest first.
+func listAttempts(ctx context.Context, c *Client, path string) ([]attempt, error) {
+	var all []attempt
+	q := url.Values{}
+	for {
+		var resp struct {
+			Attempts   []attempt `json:"attempts"`
+			NextCursor *string   `json:"next_cursor"`
+		}
+		if err := c.do(ctx, "GET", path+"/attempts?"+q.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Attempts...)
+		if resp.NextCursor == nil {
+			return all, nil
+		}
+		q.Set("cursor", *resp.NextCursor)
+	}
+}
+
+func terminal(status string) bool {
+	switch status {
+	case "completed", "failed", "cancelled", "expired":
+		return true
+	}
+	return false
+}
+
+// getByID prints the resource at prefix/ID as indented JSON.
+func getByID(ctx context.Context, c *Client, prefix string, args []string) error {
+	id, err := oneID(args)
+	if err != nil {
+		return err
+	}
+	var raw json.RawMessage
+	if err := c.do(ctx, "GET", prefix+"/"+url.PathEscape(id), nil, &raw); err != nil {
+		return err
+	}
+	return printJSON(raw)
+}
@@ -0,0 +1,143 @@
+// schedctl is a command-line client for the scheduler API: it creates, lists and cancels
+// jobs, manages schedules and tails job attempts. Credentials are kept in named profiles.
+//
+// Run: go run ./cmd/schedctl profile set default -url http://localhost:8080 -token $JWT
+//
+//	go run ./cmd/schedctl jobs create -url https://example.com/hook -at +5m
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const usage = `usage: schedctl [-profile NAME] <command> [args]
+
+commands:
+  jobs create -url URL [-method M] [-at WHEN] [-body B [-binary]] [-header H]... [-depends-on ID]
+  jobs list [-status S] [-limit N] [-cursor C]
+  jobs get ID
+  jobs cancel ID
+  jobs attempts [-follow] ID
+  schedules create -name N -url URL (-cron EXPR | -every DUR) [-timezone TZ]
+  schedules list [-limit N] [-cursor C]
+  schedules get ID
+  schedules pause ID
+  schedules resume ID
+  profile set NAME -url URL -token TOKEN
+  profile use NAME
+  profile list
+
+Run "schedctl <command> <subcommand> -h" for a subcommand's flags.
+SCHEDCTL_PROFILE, SCHEDCTL_URL and SCHEDCTL_TOKEN override the config file.
+`
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	fs := flag.NewFlagSet("schedctl", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	profile := fs.String("profile", "", "profile to use (default: the current profile)")
+	_ = fs.Parse(os.Args[1:])
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := run(ctx, *profile, fs.Args())
+	switch {
+	case errors.Is(err, errUsage):
+		fs.Usage()
+		os.Exit(2)
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(0)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "schedctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, profileName string, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if args[0] == "profile" {
+		return runProfile(cfg, args[1:])
+	}
+
+	p, err := cfg.resolve(profileName)
+	if err != nil {
+		return err
+	}
+	c := NewClient(p)
+	switch args[0] {
+	case "jobs":
+		return runJobs(ctx, c, args[1:])
+	case "schedules":
+		return runSchedules(ctx, c, args[1:])
+	}
+	return errUsage
+}
+
+func runProfile(cfg *Config, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "set":
+		if len(args) < 2 {
+			return errUsage
+		}
+		name := args[1]
+		fs := flag.NewFlagSet("profile set", flag.ContinueOnError)
+		url := fs.String("url", "", "API base URL, e.g. https://scheduler.example.com")
+		token := fs.String("token", "", "bearer token")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		p := cfg.Profiles[name]
+		if *url != "" {
+			p.URL = *url
+		}
+		if *token != "" {
+			p.Token = *token
+		}
+		if p.URL == "" {
+			return errors.New("-url is required for a new profile")
+		}
+		cfg.Profiles[name] = p
+		if cfg.Current == "" {
+			cfg.Current = name
+		}
+		return cfg.save()
+	case "use":
+		if len(args) != 2 {
+			return errUsage
+		}
+		if _, ok := cfg.Profiles[args[1]]; !ok {
+			return fmt.Errorf("profile %q not found", args[1])
+		}
+		cfg.Current = args[1]
+		return cfg.save()
+	case "list":
+		t := newTable("", "NAME", "URL")
+		for _, name := range cfg.names() {
+			mark := ""
+			if name == cfg.Current {
+				mark = "*"
+			}
+			t.row(mark, name, cfg.Profiles[name].URL)
+		}
+		t.flush()
+		return nil
+	}
+	return errUsage
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type table struct {
+	w *tabwriter.Writer
+}
+
+func newTable(columns ...string) *table {
+	t := &table{w: tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)}
+	t.row(columns...)
+	return t
+}
+
+func (t *table) row(cells ...string) {
+	fmt.Fprintln(t.w, strings.Join(cells, "\t"))
+}
+
+func (t *table) flush() {
+	t.w.Flush()
+}
+
+func printJSON(raw []byte) error {
+	var b bytes.Buffer
+	if err := json.Indent(&b, raw, "", "  "); err != nil {
+		return err
+	}
+	b.WriteByte('\n')
+	_, err := b.WriteTo(os.Stdout)
+	return err
+}
+
+func printNextCursor(cursor *string) {
+	if cursor != nil && *cursor != "" {
+		fmt.Fprintf(os.Stderr, "\nmore results: -cursor %s\n", *cursor)
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func formatDuration(ms *int64) string {
+	if ms == nil {
+		return "-"
+	}
+	return (time.Duration(*ms) * time.Millisecond).String()
+}
+
+func formatStatusCode(code *int) string {
+	if code == nil {
+		return "-"
+	}
+	return fmt.Sprint(*code)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// parseWhen accepts an RFC 3339 time, "now", or a delay from now such as "+10m".
+func parseWhen(s string, now time.Time) (time.Time, error) {
+	switch {
+	case s == "now":
+		return now, nil
+	case strings.HasPrefix(s, "+"):
+		d, err := time.ParseDuration(s[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid delay %q: %w", s, err)
+		}
+		return now.Add(d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: want RFC 3339, \"now\" or \"+<duration>\"", s)
+	}
+	return t, nil
+}
+
+// readArg returns s, or the contents of the file it names when it starts with "@".
+func readArg(s string) (string, error) {
+	if !strings.HasPrefix(s, "@") {
+		return s, nil
+	}
+	b, err := os.ReadFile(s[1:])
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// setBody adds the -body, -binary and -content-type flags to a create request.
+func setBody(req map[string]any, body string, binary bool, contentType string) error {
+	if body != "" {
+		b, err := readArg(body)
+		if err != nil {
+			return err
+		}
+		req["body"] = b
+		if binary {
+			req["body"] = base64.StdEncoding.EncodeToString([]byte(b))
+			req["body_encoding"] = "base64"
+		}
+	}
+	if contentType != "" {
+		req["content_type"] = contentType
+	}
+	return nil
+}
+
+func randomKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func oneID(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("expected exactly one ID")
+	}
+	return args[0], nil
+}
+
+// headerFlag collects repeated -header "Name: value" flags.
+type headerFlag map[string]string
+
+func (h headerFlag) String() string { return "" }
+
+func (h headerFlag) Set(v string) error {
+	name, value, ok := strings.Cut(v, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("header %q: want \"Name: value\"", v)
+	}
+	h[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	return nil
+}
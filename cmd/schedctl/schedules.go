@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type scheduleSummary struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CronExpr  string    `json:"cron_expr"`
+	Every     string    `json:"every"`
+	Timezone  string    `json:"timezone"`
+	Paused    bool      `json:"paused"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+func runSchedules(ctx context.Context, c *Client, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "create":
+		return schedulesCreate(ctx, c, args[1:])
+	case "list":
+		return schedulesList(ctx, c, args[1:])
+	case "get":
+		return getByID(ctx, c, "/schedules", args[1:])
+	case "pause", "resume":
+		id, err := oneID(args[1:])
+		if err != nil {
+			return err
+		}
+		if err := c.do(ctx, "POST", "/schedules/"+url.PathEscape(id)+"/"+args[0], nil, nil); err != nil {
+			return err
+		}
+		fmt.Printf("schedule %s %sd\n", id, args[0])
+		return nil
+	}
+	return fmt.Errorf("unknown schedules command %q", args[0])
+}
+
+func schedulesCreate(ctx context.Context, c *Client, args []string) error {
+	fs := flag.NewFlagSet("schedules create", flag.ContinueOnError)
+	name := fs.String("name", "", "schedule name (required)")
+	cron := fs.String("cron", "", `cron expression, e.g. "*/5 * * * *"`)
+	every := fs.String("every", "", `fixed interval instead of -cron, e.g. "90s"`)
+	timezone := fs.String("timezone", "", "IANA timezone for -cron (default UTC)")
+	target := fs.String("url", "", "URL to call (required)")
+	method := fs.String("method", "POST", "HTTP method")
+	body := fs.String("body", "", "request body; @file reads it from a file")
+	binary := fs.Bool("binary", false, "send -body as raw bytes, base64-encoded in transit")
+	contentType := fs.String("content-type", "", "Content-Type header for the body")
+	maxRetries := fs.Int("max-retries", 0, "retries per run")
+	overlap := fs.String("overlap", "", "queue, skip or replace when the previous run is still active")
+	queue := fs.String("queue", "", "job queue fired jobs run in (default: default)")
+	headers := headerFlag{}
+	fs.Var(headers, "header", `request header "Name: value" (repeatable)`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" || *target == "" {
+		return errors.New("-name and -url are required")
+	}
+	if (*cron == "") == (*every == "") {
+		return errors.New("exactly one of -cron or -every is required")
+	}
+
+	req := map[string]any{
+		"name":        *name,
+		"url":         *target,
+		"method":      strings.ToUpper(*method),
+		"max_retries": *maxRetries,
+	}
+	if *cron != "" {
+		req["cron_expr"] = *cron
+	} else {
+		req["every"] = *every
+	}
+	if *timezone != "" {
+		req["timezone"] = *timezone
+	}
+	if *overlap != "" {
+		req["overlap_policy"] = *overlap
+	}
+	if *queue != "" {
+		req["queue"] = *queue
+	}
+	if len(headers) > 0 {
+		req["headers"] = map[string]string(headers)
+	}
+	if err := setBody(req, *body, *binary, *contentType); err != nil {
+		return err
+	}
+
+	var resp scheduleSummary
+	if err := c.do(ctx, "POST", "/schedules", req, &resp); err != nil {
+		return err
+	}
+	fmt.Printf("%s (next run %s)\n", resp.ID, formatTime(&resp.NextRunAt))
+	return nil
+}
+
+func schedulesList(ctx context.Context, c *Client, args []string) error {
+	fs := flag.NewFlagSet("schedules list", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "page size")
+	cursor := fs.String("cursor", "", "cursor from a previous page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	q.Set("limit", fmt.Sprint(*limit))
+	if *cursor != "" {
+		q.Set("cursor", *cursor)
+	}
+	var resp struct {
+		Schedules  []scheduleSummary `json:"schedules"`
+		NextCursor *string           `json:"next_cursor"`
+	}
+	if err := c.do(ctx, "GET", "/schedules?"+q.Encode(), nil, &resp); err != nil {
+		return err
+	}
+
+	t := newTable("ID", "NAME", "SPEC", "TIMEZONE", "NEXT RUN", "PAUSED")
+	for _, s := range resp.Schedules {
+		spec := s.CronExpr
+		if s.Every != "" {
+			spec = "every " + s.Every
+		}
+		t.row(s.ID, s.Name, spec, s.Timezone, formatTime(&s.NextRunAt), fmt.Sprint(s.Paused))
+	}
+	t.flush()
+	printNextCursor(resp.NextCursor)
+	return nil
+}
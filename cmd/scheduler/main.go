@@ -12,11 +12,18 @@ import (
 	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/config"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/health"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/infrastructure/kafka"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/infrastructure/postgres"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/infrastructure/redis"
 	ctxlog "github.com/ErlanBelekov/dist-job-scheduler/internal/log"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/notify"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/scheduler"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
 	"github.com/lmittmann/tint"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -26,12 +33,27 @@ func main() {
 	if err != nil {
 		log.Fatalf("config: %v", err)
 	}
+	for _, queue := range cfg.WorkerQueues {
+		if err := domain.ValidateQueue(queue); err != nil {
+			log.Fatalf("config: WORKER_QUEUES: %q is not a valid queue name", queue)
+		}
+	}
 
-	logger := newLogger(cfg.Env, cfg.SlogLevel())
+	// A LevelVar, so a config reload can change the level of the running logger.
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.SlogLevel())
+	logger := newLogger(cfg.Env, logLevel)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
-	pool, err := postgres.NewPool(ctx, cfg.DatabaseURL)
+	pool, err := postgres.NewPool(ctx, cfg.DatabaseURL, postgres.PoolConfig{
+		MaxConns:          int32(cfg.DBMaxConns),
+		MinConns:          int32(cfg.DBMinConns),
+		MaxConnLifetime:   time.Duration(cfg.DBMaxConnLifetimeSec) * time.Second,
+		MaxConnIdleTime:   time.Duration(cfg.DBMaxConnIdleSec) * time.Second,
+		HealthCheckPeriod: time.Duration(cfg.DBHealthCheckSec) * time.Second,
+		ConnectTimeout:    time.Duration(cfg.DBConnectTimeoutSec) * time.Second,
+	})
 	if err != nil {
 		stop()
 		log.Fatalf("db: %v", err)
@@ -40,30 +62,198 @@ func main() {
 
 	logger.Info("db connected")
 
+	if cfg.MigrateOnStart {
+		if err := postgres.MigrateUp(ctx, pool, logger); err != nil {
+			stop()
+			log.Fatalf("migrate: %v", err)
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, "dist-job-scheduler-worker", cfg.OTLPTracesEndpoint)
+	if err != nil {
+		stop()
+		log.Fatalf("tracing: %v", err)
+	}
+
 	metrics.Register()
+	postgres.RegisterPoolMetrics(pool, prometheus.DefaultRegisterer)
 	checker := health.NewChecker(pool, logger, prometheus.DefaultRegisterer)
 
-	jobRepo := postgres.NewJobRepository(pool)
+	// Opening values sealed under an organization's own key needs the same KMS as the API.
+	var box *secrets.Box
+	if cfg.VaultAddr != "" {
+		box, _, err = secrets.NewTenantKeyringBox(cfg.EncryptionKeys,
+			secrets.NewVaultTransit(cfg.VaultAddr, cfg.VaultToken, cfg.VaultTransitMount),
+			postgres.NewOrgRepository(pool).KeyRef)
+	} else {
+		box, err = secrets.NewKeyringBox(cfg.EncryptionKeys)
+	}
+	if err != nil {
+		stop()
+		log.Fatalf("encryption keys: %v", err)
+	}
+	if box == nil {
+		logger.Warn("ENCRYPTION_KEYS not set, storing headers and bodies in plaintext")
+	}
+
+	jobRepo := postgres.NewJobRepository(pool, box)
 	attemptRepo := postgres.NewAttemptRepository(pool)
-	scheduleRepo := postgres.NewScheduleRepository(pool, logger)
+	scheduleRepo := postgres.NewScheduleRepository(pool, logger, box)
+	pingRepo := postgres.NewPingRepository(pool)
+	callbackRepo := postgres.NewCallbackRepository(pool)
+	notificationRepo := postgres.NewNotificationRuleRepository(pool)
+
+	resendAPIKey := cfg.ResendAPIKey
+	if cfg.Env == "local" {
+		resendAPIKey = "" // never send real email from local dev
+	}
+	emailChannel := notify.NewEmailChannel(resendAPIKey, cfg.ResendFrom, logger)
+	checker.Add(health.Check{Name: "email", Func: emailChannel.Check, Timeout: 3 * time.Second, Optional: true})
+	notifyChannels := map[domain.NotificationChannel]notify.Channel{
+		domain.NotificationChannelEmail: emailChannel,
+		domain.NotificationChannelSlack: notify.NewSlackChannel(),
+	}
+	notifier := notify.New(notificationRepo, jobRepo, notifyChannels, logger)
+	go notifier.Start(ctx)
+
+	// In redis claim mode the mover feeds due job IDs to the workers through Redis.
+	var claimQueue repository.ClaimQueue
+	if cfg.ClaimMode == "redis" {
+		redisClient, err := redis.NewClient(ctx, cfg.RedisURL)
+		if err != nil {
+			stop()
+			log.Fatalf("redis: %v", err)
+		}
+		defer redisClient.Close()
+		logger.Info("redis connected")
+		checker.Add(health.Check{Name: "redis", Func: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }})
+
+		queue := redis.NewClaimQueue(redisClient, cfg.RedisQueueKey)
+		claimQueue = queue
+		mover := scheduler.NewMover(
+			jobRepo,
+			queue,
+			logger,
+			time.Duration(cfg.MoverIntervalMS)*time.Millisecond,
+			domain.ClaimPolicy(cfg.ClaimPolicy),
+			time.Duration(cfg.QueueRedeliverSec)*time.Second,
+		)
+		go mover.Start(ctx)
+	}
 
 	worker := scheduler.NewWorker(
 		jobRepo,
 		attemptRepo,
+		pingRepo,
+		postgres.NewClientCertRepository(pool, box),
 		logger,
-		time.Duration(cfg.PollIntervalSec)*time.Second,
-		cfg.WorkerCount,
+		workerSettings(cfg),
+		cfg.WorkerQueues,
+		cfg.WorkerPrefetch,
+		domain.ClaimPolicy(cfg.ClaimPolicy),
+		cfg.ResponseCaptureBytes,
+		cfg.MaxResponseBytes,
+		time.Duration(cfg.ExecutorClientTimeoutSec)*time.Second,
+		scheduler.CircuitBreaker{
+			Threshold: cfg.CircuitBreakerThreshold,
+			Cooldown:  time.Duration(cfg.CircuitBreakerCooldownSec) * time.Second,
+		},
+		cfg.ExecutorMetricsHosts,
+		notifier,
+		postgres.NewJobEventListener(pool, logger),
+		claimQueue,
 	)
+	worker.RegisterExecutor(domain.JobTypeEmail, scheduler.NewEmailExecutor(emailChannel, logger))
+	if len(cfg.KafkaBrokers) > 0 {
+		kafkaClient, err := kafka.NewClient(ctx, kafka.Options{
+			Brokers:       cfg.KafkaBrokers,
+			TLS:           cfg.KafkaTLS,
+			SASLMechanism: cfg.KafkaSASLMechanism,
+			Username:      cfg.KafkaUsername,
+			Password:      cfg.KafkaPassword,
+		})
+		if err != nil {
+			stop()
+			log.Fatalf("kafka: %v", err)
+		}
+		defer kafkaClient.Close()
+		logger.Info("kafka connected")
+		worker.RegisterExecutor(domain.JobTypeKafka, scheduler.NewKafkaExecutor(kafkaClient, logger))
+	}
 	go worker.Start(ctx)
+	checker.ReportDrain(func() health.DrainState {
+		s := worker.DrainStatus()
+		return health.DrainState{Draining: s.Draining, InFlight: s.InFlight}
+	})
 
 	// heartbeat fires every 10s — 30s timeout means 3 missed beats before a job is stale
-	reaper := scheduler.NewReaper(jobRepo, logger, 30*time.Second, 30*time.Second)
-	go reaper.Start(ctx)
+	stuck := scheduler.StuckPendingCheck{
+		After:  time.Duration(cfg.StuckPendingSec) * time.Second,
+		Queues: cfg.WorkerQueues,
+	}
+	if cfg.StuckPendingAlertURL != "" {
+		stuck.Hook = notify.NewOpsAlerter(notify.NewSlackChannel(), cfg.StuckPendingAlertURL, logger)
+	}
+	reaper := scheduler.NewReaper(jobRepo, logger, 30*time.Second, 30*time.Second, notifier, stuck)
+	dispatcher := scheduler.NewDispatcher(scheduleRepo, logger, time.Duration(cfg.DispatchIntervalSec)*time.Second, cfg.ScheduleFireMetrics)
+
+	// Cluster-wide loops run on every replica, or only on the elected leader.
+	digester := notify.NewDigester(notificationRepo, postgres.NewStatsRepository(pool), notifyChannels, 5*time.Minute, logger)
+	clusterLoops := []func(context.Context){reaper.Start, dispatcher.Start, digester.Start}
+
+	stats := scheduler.NewStatsCollector(jobRepo, scheduleRepo, logger,
+		time.Duration(cfg.StatsIntervalSec)*time.Second, time.Duration(cfg.ScheduleOverdueSec)*time.Second)
+	go stats.Start(ctx)
+
+	if cfg.AttemptRetentionDays > 0 || cfg.JobRetentionDays > 0 {
+		const day = 24 * time.Hour
+		janitor := scheduler.NewJanitor(postgres.NewRetentionRepository(pool), logger, 10*time.Minute, scheduler.RetentionPolicy{
+			Attempts: time.Duration(cfg.AttemptRetentionDays) * day,
+			Jobs:     time.Duration(cfg.JobRetentionDays) * day,
+			Archive:  cfg.RetentionArchive,
+		})
+		clusterLoops = append(clusterLoops, janitor.Start)
+	}
+	if cfg.LeaderElection {
+		lock := postgres.NewAdvisoryLeaderLock(pool, cfg.LeaderLockKey, 5*time.Second)
+		go scheduler.NewLeader(lock, logger, 5*time.Second, clusterLoops...).Start(ctx)
+	} else {
+		for _, loop := range clusterLoops {
+			go loop(ctx)
+		}
+	}
 
-	dispatcher := scheduler.NewDispatcher(scheduleRepo, logger, time.Duration(cfg.DispatchIntervalSec)*time.Second)
-	go dispatcher.Start(ctx)
+	callbackDispatcher := scheduler.NewCallbackDispatcher(callbackRepo, logger, time.Duration(cfg.PollIntervalSec)*time.Second)
+	go callbackDispatcher.Start(ctx)
 
-	metricsSrv := metrics.NewServer(":"+cfg.MetricsPort, checker)
+	// A reload re-reads the environment and CONFIG_OVERRIDES_FILE; an invalid result is
+	// rejected whole and the running settings are kept.
+	reload := func(ctx context.Context) error {
+		next, err := config.Load()
+		if err != nil {
+			logger.ErrorContext(ctx, "config reload failed", "error", err)
+			return err
+		}
+		logLevel.Set(next.SlogLevel())
+		worker.Reload(ctx, workerSettings(next))
+		logger.InfoContext(ctx, "config reloaded", "log_level", next.LogLevel)
+		return nil
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				signal.Stop(hup)
+				return
+			case <-hup:
+				_ = reload(ctx)
+			}
+		}
+	}()
+
+	metricsSrv := metrics.NewServer(":"+cfg.MetricsPort, checker, scheduler.NewAdminHandler(worker, reload, cfg.SchedulerAdminToken))
 	go func() {
 		logger.Info("metrics server started", "port", cfg.MetricsPort)
 		if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -74,16 +264,37 @@ func main() {
 	<-ctx.Done()
 	stop()
 
+	// Stop claiming and let in-flight jobs finish; /readyz reports the drain meanwhile.
+	grace := time.Duration(cfg.ShutdownGraceSec) * time.Second
+	logger.Info("draining worker", "grace", grace, "in_flight", worker.DrainStatus().InFlight)
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), grace)
+	if err := worker.Shutdown(drainCtx); err != nil {
+		logger.Warn("worker drain incomplete", "error", err)
+	}
+	cancelDrain()
+
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := metricsSrv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("metrics server shutdown", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("tracing shutdown", "error", err)
+	}
 
 	logger.Info("scheduler shut down")
 }
 
-func newLogger(env string, level slog.Level) *slog.Logger {
+// workerSettings are the worker's settings that a config reload can change.
+func workerSettings(cfg *config.Config) scheduler.WorkerSettings {
+	return scheduler.WorkerSettings{
+		Concurrency:  cfg.WorkerCount,
+		PollInterval: time.Duration(cfg.PollIntervalSec) * time.Second,
+		Limits:       domain.ConcurrencyLimits{PerUser: cfg.UserMaxConcurrentJobs, PerHost: cfg.HostMaxConcurrentJobs},
+	}
+}
+
+func newLogger(env string, level slog.Leveler) *slog.Logger {
 	var inner slog.Handler
 	if env == "local" {
 		inner = tint.NewHandler(os.Stdout, &tint.Options{
@@ -0,0 +1,40 @@
+// sdkgen writes the TypeScript and Python client stubs under sdk/ from the server's
+// OpenAPI document. Rerun it after changing a route or a request or response type;
+// TestGenerate_UpToDate fails until the checked-in stubs match.
+// Run: go run ./cmd/sdkgen
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/handler"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/sdkgen"
+)
+
+func main() {
+	out := flag.String("out", "sdk", "SDK root directory")
+	flag.Parse()
+
+	spec, err := json.Marshal(handler.OpenAPIDocument())
+	if err != nil {
+		log.Fatalf("marshal openapi document: %v", err)
+	}
+	files, err := sdkgen.Generate(spec)
+	if err != nil {
+		log.Fatalf("generate: %v", err)
+	}
+	for _, f := range files {
+		path := filepath.Join(*out, f.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatalf("create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			log.Fatalf("write %s: %v", path, err)
+		}
+		log.Printf("wrote %s", path)
+	}
+}
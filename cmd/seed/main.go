@@ -65,7 +65,7 @@ func main() {
 		log.Fatal("DATABASE_URL is not set — run: direnv allow")
 	}
 
-	pool, err := postgres.NewPool(ctx, dbURL)
+	pool, err := postgres.NewPool(ctx, dbURL, postgres.DefaultPoolConfig())
 	if err != nil {
 		log.Fatalf("db connect: %v", err)
 	}
@@ -12,12 +12,19 @@ import (
 	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/config"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/health"
+	httptransport "github.com/ErlanBelekov/dist-job-scheduler/internal/http"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/handler"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/ui"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/infrastructure/postgres"
 	ctxlog "github.com/ErlanBelekov/dist-job-scheduler/internal/log"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
-	httptransport "github.com/ErlanBelekov/dist-job-scheduler/internal/http"
-	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/handler"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/notify"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/scheduler"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/lmittmann/tint"
@@ -38,36 +45,190 @@ func main() {
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
-	pool, err := postgres.NewPool(ctx, cfg.DatabaseURL)
+	pool, err := postgres.NewPool(ctx, cfg.DatabaseURL, postgres.PoolConfig{
+		MaxConns:          int32(cfg.DBMaxConns),
+		MinConns:          int32(cfg.DBMinConns),
+		MaxConnLifetime:   time.Duration(cfg.DBMaxConnLifetimeSec) * time.Second,
+		MaxConnIdleTime:   time.Duration(cfg.DBMaxConnIdleSec) * time.Second,
+		HealthCheckPeriod: time.Duration(cfg.DBHealthCheckSec) * time.Second,
+		ConnectTimeout:    time.Duration(cfg.DBConnectTimeoutSec) * time.Second,
+	})
 	if err != nil {
 		stop()
 		log.Fatalf("db: %v", err)
 	}
 	defer pool.Close()
 
+	if cfg.MigrateOnStart {
+		if err := postgres.MigrateUp(ctx, pool, logger); err != nil {
+			stop()
+			log.Fatalf("migrate: %v", err)
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, "dist-job-scheduler-api", cfg.OTLPTracesEndpoint)
+	if err != nil {
+		stop()
+		log.Fatalf("tracing: %v", err)
+	}
+
+	// Organizations can bring their own KMS key when Vault is configured; their rows are
+	// sealed under it and everyone else's under ENCRYPTION_KEYS.
+	orgRepo := postgres.NewOrgRepository(pool)
+	var (
+		box     *secrets.Box
+		keyRefs usecase.KeyRefVerifier
+	)
+	if cfg.VaultAddr != "" {
+		var tenantKeys *secrets.TenantKeys
+		box, tenantKeys, err = secrets.NewTenantKeyringBox(cfg.EncryptionKeys,
+			secrets.NewVaultTransit(cfg.VaultAddr, cfg.VaultToken, cfg.VaultTransitMount), orgRepo.KeyRef)
+		keyRefs = tenantKeys
+	} else {
+		box, err = secrets.NewKeyringBox(cfg.EncryptionKeys)
+	}
+	if err != nil {
+		stop()
+		log.Fatalf("encryption keys: %v", err)
+	}
+	if box == nil {
+		logger.Warn("ENCRYPTION_KEYS not set, storing headers and bodies in plaintext")
+	}
+
 	// Users
 	userRepo := postgres.NewUserRepository(pool)
 
+	// Quotas. Soft-limit warnings go to the user's quota.warning notification rules, and
+	// approval requests to the organization's approval.requested rules.
+	notificationRepo := postgres.NewNotificationRuleRepository(pool)
+	resendAPIKey := cfg.ResendAPIKey
+	if cfg.Env == "local" {
+		resendAPIKey = "" // never send real email from local dev
+	}
+	alerter := notify.NewAlerter(notificationRepo, map[domain.NotificationChannel]notify.Channel{
+		domain.NotificationChannelEmail: notify.NewEmailChannel(resendAPIKey, cfg.ResendFrom, logger),
+		domain.NotificationChannelSlack: notify.NewSlackChannel(),
+	}, logger)
+	go alerter.Start(ctx)
+	usageRepo := postgres.NewUsageRepository(pool)
+	quotaUsecase := usecase.NewQuotaUsecase(usageRepo, usecase.QuotaLimits{
+		MaxPendingJobs:     cfg.QuotaMaxPendingJobs,
+		MaxSchedules:       cfg.QuotaMaxSchedules,
+		MaxDailyExecutions: cfg.QuotaMaxDailyExecutions,
+	}, alerter, logger)
+
+	// Per-user job defaults
+	defaultsRepo := postgres.NewDefaultsRepository(pool, box)
+	defaultsUsecase := usecase.NewDefaultsUsecase(defaultsRepo)
+
+	// Per-user API usage stats
+	apiUsageRepo := postgres.NewAPIUsageRepository(pool)
+	apiUsageUsecase := usecase.NewAPIUsageUsecase(apiUsageRepo, logger, 30*time.Second)
+	// Runs until after the HTTP server has drained so the final flush includes every request.
+	usageCtx, stopUsage := context.WithCancel(context.Background())
+	usageDone := make(chan struct{})
+	go func() {
+		defer close(usageDone)
+		apiUsageUsecase.Start(usageCtx)
+	}()
+
 	// Jobs
-	jobRepo := postgres.NewJobRepository(pool)
+	jobRepo := postgres.NewJobRepository(pool, box)
 	attemptRepo := postgres.NewAttemptRepository(pool)
-	jobUsecase := usecase.NewJobUsecase(jobRepo, attemptRepo)
-	jobHandler := handler.NewJobHandler(jobUsecase, logger)
+	callbackRepo := postgres.NewCallbackRepository(pool)
+	// Organizations in approval mode hold their members' jobs for an owner's review.
+	approvalUsecase := usecase.NewApprovalUsecase(orgRepo, jobRepo, alerter)
+	jobUsecase := usecase.NewJobUsecase(jobRepo, attemptRepo, callbackRepo, quotaUsecase, defaultsUsecase, approvalUsecase)
+	// Stops with ctx, ending open streams before the server drains.
+	jobStream := usecase.NewJobStreamHub(postgres.NewJobEventListener(pool, logger), logger)
+	go jobStream.Start(ctx)
+
+	// Dry runs send requests from the API process. The breaker stays off: a user checking
+	// a flaky endpoint wants to see each failure, not a deferral.
+	scheduleRepo := postgres.NewScheduleRepository(pool, logger, box)
+	clientCertRepo := postgres.NewClientCertRepository(pool, box)
+	executorTimeout := time.Duration(cfg.ExecutorClientTimeoutSec) * time.Second
+	dryRunExecutor := scheduler.NewHTTPExecutor(logger, cfg.ResponseCaptureBytes, cfg.MaxResponseBytes, executorTimeout, scheduler.CircuitBreaker{}, clientCertRepo, cfg.ExecutorMetricsHosts)
+	dryRunUsecase := usecase.NewDryRunUsecase(dryRunExecutor, defaultsUsecase, scheduleRepo)
+	jobHandler := handler.NewJobHandler(jobUsecase, jobStream, dryRunUsecase, logger)
+
+	egressUsecase := usecase.NewEgressUsecase(attemptRepo)
+	accountHandler := handler.NewAccountHandler(quotaUsecase, defaultsUsecase, apiUsageUsecase, egressUsecase, logger)
 
 	// Schedules
-	scheduleRepo := postgres.NewScheduleRepository(pool, logger)
-	scheduleUsecase := usecase.NewScheduleUsecase(scheduleRepo, jobRepo)
-	scheduleHandler := handler.NewScheduleHandler(scheduleUsecase, logger)
+	pingRepo := postgres.NewPingRepository(pool)
+	scheduleUsecase := usecase.NewScheduleUsecase(scheduleRepo, jobRepo, pingRepo, quotaUsecase, defaultsUsecase)
+	scheduleHandler := handler.NewScheduleHandler(scheduleUsecase, dryRunUsecase, logger)
+
+	// Templates
+	templateUsecase := usecase.NewTemplateUsecase(jobUsecase, scheduleUsecase)
+	templateHandler := handler.NewTemplateHandler(templateUsecase, logger)
+
+	// Search
+	searchUsecase := usecase.NewSearchUsecase(jobRepo, scheduleRepo)
+	searchHandler := handler.NewSearchHandler(searchUsecase, logger)
+
+	// Stats
+	statsUsecase := usecase.NewStatsUsecase(postgres.NewStatsRepository(pool), scheduleRepo)
+	statsHandler := handler.NewStatsHandler(statsUsecase, logger)
+
+	// Notifications
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo, scheduleRepo)
+	notificationHandler := handler.NewNotificationHandler(notificationUsecase, logger)
+
+	// Client certificates
+	clientCertHandler := handler.NewClientCertHandler(usecase.NewClientCertUsecase(clientCertRepo), logger)
+
+	// Organizations
+	orgUsecase := usecase.NewOrgUsecase(orgRepo, keyRefs)
+	orgHandler := handler.NewOrgHandler(orgUsecase, logger)
+	approvalHandler := handler.NewApprovalHandler(approvalUsecase, logger)
+
+	// Notices
+	noticeRepo := postgres.NewNoticeRepository(pool)
+	noticeUsecase := usecase.NewNoticeUsecase(noticeRepo)
+	noticeHandler := handler.NewNoticeHandler(noticeUsecase, logger)
 
 	metrics.Register()
+	postgres.RegisterPoolMetrics(pool, prometheus.DefaultRegisterer)
 	checker := health.NewChecker(pool, logger, prometheus.DefaultRegisterer)
+	if cfg.ClerkJWKSURL != "" {
+		// Keys are cached, so an unreachable JWKS endpoint only breaks auth once they rotate.
+		checker.Add(health.Check{Name: "jwks", Func: health.HTTPCheck(cfg.ClerkJWKSURL), Optional: true})
+	}
+
+	routes := httptransport.NewRegistry()
+	routes.Protected("/jobs", jobHandler.Routes)
+	routes.Protected("/schedules", scheduleHandler.Routes)
+	routes.ReadOnly("/schedules/preview")
+	routes.Privileged("/schedules/export")
+	routes.Protected("/account", accountHandler.Routes)
+	routes.Protected("/templates", templateHandler.Routes)
+	routes.Protected("/search", searchHandler.Routes)
+	routes.Protected("/stats", statsHandler.Routes)
+	routes.Protected("/schedules", statsHandler.ScheduleRoutes)
+	routes.Protected("/notifications", notificationHandler.Routes)
+	routes.Protected("/certificates", clientCertHandler.Routes)
+	routes.Protected("/orgs", orgHandler.Routes)
+	routes.Protected("/orgs", approvalHandler.Routes)
+	routes.Public("/ui", ui.Routes)
+	routes.Public("/notices", noticeHandler.Routes)
+	routes.Public("/schemas", handler.NewSchemaHandler().Routes)
+	routes.Public("", handler.NewOpenAPIHandler(cfg.DocsUI).Routes)
+	routes.Protected("/admin/notices", noticeHandler.AdminRoutes, middleware.RequireAdmin(cfg.AdminUserIDs))
 
+	cors := middleware.CORSConfig{
+		AllowedOrigins: cfg.CORSAllowedOrigins,
+		AllowedMethods: cfg.CORSAllowedMethods,
+		AllowedHeaders: cfg.CORSAllowedHeaders,
+		MaxAgeSec:      cfg.CORSMaxAgeSec,
+	}
 	srv := http.Server{
 		Addr:    ":" + cfg.Port,
-		Handler: httptransport.NewRouter(logger, jobHandler, scheduleHandler, userRepo, cfg.ClerkJWKSURL, []byte(cfg.JWTSecret)),
+		Handler: httptransport.NewRouter(logger, routes, userRepo, orgUsecase, apiUsageUsecase, cfg.ClerkJWKSURL, []byte(cfg.JWTSecret), cors, cfg.MaxRequestBodyBytes),
 	}
 
-	metricsSrv := metrics.NewServer(":"+cfg.MetricsPort, checker)
+	metricsSrv := metrics.NewServer(":"+cfg.MetricsPort, checker, nil)
 
 	go func() {
 		logger.Info("server started", "port", cfg.Port)
@@ -92,9 +253,14 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("server shutdown", "error", err)
 	}
+	stopUsage()
+	<-usageDone
 	if err := metricsSrv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("metrics server shutdown", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("tracing shutdown", "error", err)
+	}
 }
 
 func newLogger(env string, level slog.Level) *slog.Logger {
@@ -3,6 +3,10 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/go-playground/validator/v10"
@@ -12,13 +16,177 @@ type Config struct {
 	Env  string `env:"ENV" envDefault:"local" validate:"required,oneof=local staging production"`
 	Port string `env:"PORT" envDefault:"8080" validate:"required"`
 
-	DatabaseURL        string `env:"DATABASE_URL,required" validate:"required"`
-	WorkerCount        int    `env:"WORKER_COUNT" envDefault:"5" validate:"min=1,max=100"`
-	PollIntervalSec    int    `env:"POLL_INTERVAL_SEC" envDefault:"1" validate:"min=1,max=60"`
-	DispatchIntervalSec int   `env:"DISPATCH_INTERVAL_SEC" envDefault:"5" validate:"min=1,max=60"`
+	DatabaseURL         string `env:"DATABASE_URL,required" validate:"required"`
+	WorkerCount         int    `env:"WORKER_COUNT" envDefault:"5" validate:"min=1,max=100"`
+	PollIntervalSec     int    `env:"POLL_INTERVAL_SEC" envDefault:"1" validate:"min=1,max=60"`
+	DispatchIntervalSec int    `env:"DISPATCH_INTERVAL_SEC" envDefault:"5" validate:"min=1,max=60"`
+	StatsIntervalSec    int    `env:"STATS_INTERVAL_SEC" envDefault:"15" validate:"min=1,max=300"`
+
+	// Postgres connection pool, per process. DBMaxConns across every replica must stay
+	// under the server's max_connections (or the pooler's pool size); workers hold one
+	// connection per in-flight claim or heartbeat, so keep it above WORKER_COUNT.
+	// Connections are recycled after DBMaxConnLifetimeSec, or DBMaxConnIdleSec unused.
+	DBMaxConns           int `env:"DB_MAX_CONNS" envDefault:"25" validate:"min=1,max=1000"`
+	DBMinConns           int `env:"DB_MIN_CONNS" envDefault:"5" validate:"min=0,ltefield=DBMaxConns"`
+	DBMaxConnLifetimeSec int `env:"DB_MAX_CONN_LIFETIME_SEC" envDefault:"3600" validate:"min=60,max=86400"`
+	DBMaxConnIdleSec     int `env:"DB_MAX_CONN_IDLE_SEC" envDefault:"1800" validate:"min=10,max=86400"`
+	DBHealthCheckSec     int `env:"DB_HEALTH_CHECK_SEC" envDefault:"30" validate:"min=1,max=3600"`
+	DBConnectTimeoutSec  int `env:"DB_CONNECT_TIMEOUT_SEC" envDefault:"5" validate:"min=1,max=120"`
+
+	// MigrateOnStart applies pending migrations before serving. Replicas take turns under
+	// an advisory lock, so it is safe with several starting at once; leave it off where a
+	// migration Job runs before the rollout instead.
+	MigrateOnStart bool `env:"MIGRATE_ON_START" envDefault:"false"`
+
+	// ScheduleOverdueSec is how far past its next_run_at an active schedule must be to
+	// count in scheduler_schedules_overdue. Keep it well above DISPATCH_INTERVAL_SEC.
+	// ScheduleFireMetrics exports each schedule's last fire time as its own series; leave
+	// it off with many schedules.
+	ScheduleOverdueSec  int  `env:"SCHEDULE_OVERDUE_SEC" envDefault:"60" validate:"min=1,max=86400"`
+	ScheduleFireMetrics bool `env:"SCHEDULE_FIRE_METRICS" envDefault:"false"`
+
+	// WorkerQueues are the job queues this worker claims from, e.g. "eu" on EU replicas
+	// or "cpu-heavy" on a dedicated pool. Jobs in a queue no worker serves stay pending.
+	WorkerQueues []string `env:"WORKER_QUEUES" envDefault:"default" envSeparator:"," validate:"min=1,dive,required"`
+
+	// WorkerPrefetch is the most jobs a worker claims beyond its free slots and holds until
+	// slots free up, saving a claim round trip per finished job on deep backlogs. The
+	// worker adapts up to this limit as claims come back full or short, and stops
+	// prefetching while jobs run longer than the poll interval. 0 disables prefetch;
+	// CLAIM_MODE=redis ignores it.
+	WorkerPrefetch int `env:"WORKER_PREFETCH" envDefault:"0" validate:"min=0,max=1000"`
+
+	// ShutdownGraceSec is how long a terminating worker waits for in-flight jobs after it
+	// stops claiming; jobs still running then are aborted and retried elsewhere. Keep the
+	// orchestrator's termination grace period (e.g. terminationGracePeriodSeconds) longer.
+	ShutdownGraceSec int `env:"SHUTDOWN_GRACE_SEC" envDefault:"30" validate:"min=0,max=3600"`
+
+	// ClaimPolicy orders due jobs within a priority band: "fifo" by current scheduled_at,
+	// "overdue" by the time a job was first due so retried jobs don't lose their place.
+	ClaimPolicy string `env:"CLAIM_POLICY" envDefault:"fifo" validate:"required,oneof=fifo overdue"`
+
+	// ClaimMode picks how workers find due jobs. "postgres" claims straight from the jobs
+	// table. "redis" has a mover push due job IDs to a Redis list that workers pop, taking
+	// the claim scan off every worker; the per-user and per-host caps can't be applied
+	// there, so config with either cap set is rejected in that mode.
+	// QueueRedeliverSec is how long a pushed job may stay unclaimed before it is pushed again.
+	ClaimMode         string `env:"CLAIM_MODE" envDefault:"postgres" validate:"required,oneof=postgres redis"`
+	RedisURL          string `env:"REDIS_URL" validate:"required_if=ClaimMode redis"`
+	RedisQueueKey     string `env:"REDIS_QUEUE_KEY" envDefault:"scheduler:jobs:due" validate:"required"`
+	MoverIntervalMS   int    `env:"MOVER_INTERVAL_MS" envDefault:"200" validate:"min=10,max=10000"`
+	QueueRedeliverSec int    `env:"QUEUE_REDELIVER_SEC" envDefault:"60" validate:"min=5,max=3600"`
+
+	// KafkaBrokers (comma-separated host:port) is the cluster kafka jobs publish to. Unset
+	// leaves workers without a kafka executor, so kafka jobs fail. KafkaTLS connects over
+	// TLS; KafkaSASLMechanism authenticates with KafkaUsername and KafkaPassword.
+	KafkaBrokers       []string `env:"KAFKA_BROKERS" envSeparator:","`
+	KafkaTLS           bool     `env:"KAFKA_TLS" envDefault:"false"`
+	KafkaSASLMechanism string   `env:"KAFKA_SASL_MECHANISM" validate:"omitempty,oneof=plain scram-sha-256 scram-sha-512"`
+	KafkaUsername      string   `env:"KAFKA_USERNAME" validate:"required_with=KafkaSASLMechanism"`
+	KafkaPassword      string   `env:"KAFKA_PASSWORD" validate:"required_with=KafkaSASLMechanism"`
+
+	// Concurrency caps enforced at claim time so one tenant or one slow endpoint can't take
+	// every worker slot. 0 disables a cap. users.max_concurrent_jobs overrides the per-user cap.
+	// Both must stay 0 with CLAIM_MODE=redis, which claims by ID without checking them.
+	UserMaxConcurrentJobs int `env:"USER_MAX_CONCURRENT_JOBS" envDefault:"0" validate:"min=0,excluded_if=ClaimMode redis"`
+	HostMaxConcurrentJobs int `env:"HOST_MAX_CONCURRENT_JOBS" envDefault:"0" validate:"min=0,excluded_if=ClaimMode redis"`
+
+	// ResponseCaptureBytes is how much of each target response body the worker stores on the
+	// attempt record, along with the response headers. 0 disables capture.
+	ResponseCaptureBytes int `env:"RESPONSE_CAPTURE_BYTES" envDefault:"4096" validate:"min=0,max=65536"`
+
+	// MaxResponseBytes is how much of a target's response body the worker reads. Past it
+	// the connection is dropped and the attempt is marked response_truncated; the job's
+	// outcome still follows the status code.
+	MaxResponseBytes int `env:"MAX_RESPONSE_BYTES" envDefault:"1048576" validate:"min=1024,max=1073741824"`
+
+	// Per-host circuit breaker: after CircuitBreakerThreshold consecutive transport errors,
+	// timeouts or 5xx responses from a host, the worker defers that host's jobs for
+	// CircuitBreakerCooldownSec without spending an attempt, then lets one probe through.
+	// State is per worker process. 0 disables the breaker.
+	CircuitBreakerThreshold   int `env:"CIRCUIT_BREAKER_THRESHOLD" envDefault:"0" validate:"min=0,max=1000"`
+	CircuitBreakerCooldownSec int `env:"CIRCUIT_BREAKER_COOLDOWN_SEC" envDefault:"30" validate:"min=1,max=3600"`
+
+	// ExecutorMetricsHosts is how many target hosts get their own series in the executor's
+	// request metrics; requests to hosts seen after that are labelled "other". Each host
+	// costs a counter and histogram per status class. 0 labels every host "other".
+	ExecutorMetricsHosts int `env:"EXECUTOR_METRICS_HOSTS" envDefault:"100" validate:"min=0,max=10000"`
+
+	// ExecutorClientTimeoutSec caps a whole HTTP attempt, response body included, whatever
+	// the job's timeout_seconds; jobs are normally cut off earlier by their own timeout.
+	ExecutorClientTimeoutSec int `env:"EXECUTOR_CLIENT_TIMEOUT_SEC" envDefault:"300" validate:"min=1,max=86400"`
+
+	// LeaderElection runs the dispatcher, reaper and janitor on one replica at a time,
+	// elected through a Postgres advisory lock on LeaderLockKey; workers run everywhere.
+	// Deployments sharing a database but meant to elect separately need distinct keys.
+	LeaderElection bool  `env:"LEADER_ELECTION" envDefault:"false"`
+	LeaderLockKey  int64 `env:"LEADER_LOCK_KEY" envDefault:"7301"`
+
+	// StuckPendingSec is how long past its scheduled_at a pending job in one of
+	// WORKER_QUEUES may go unclaimed before the reaper reports it stuck (e.g. workers are
+	// down). 0 disables the check. StuckPendingAlertURL is an optional Slack incoming
+	// webhook alerted when a queue becomes stuck and when it recovers.
+	StuckPendingSec      int    `env:"STUCK_PENDING_SEC" envDefault:"600" validate:"min=0,max=86400"`
+	StuckPendingAlertURL string `env:"STUCK_PENDING_ALERT_URL" validate:"omitempty,url"`
+
+	// History retention, set per environment. The janitor deletes finished attempts and
+	// terminal jobs older than their window; 0 keeps them forever. RetentionArchive copies
+	// rows to jobs_archive / job_attempts_archive before deleting them.
+	AttemptRetentionDays int  `env:"ATTEMPT_RETENTION_DAYS" envDefault:"0" validate:"min=0"`
+	JobRetentionDays     int  `env:"JOB_RETENTION_DAYS" envDefault:"0" validate:"min=0"`
+	RetentionArchive     bool `env:"RETENTION_ARCHIVE" envDefault:"false"`
+
+	// Resend credentials for notification email. Unset in ENV=local, where emails are logged.
+	ResendAPIKey string `env:"RESEND_API_KEY"`
+	ResendFrom   string `env:"RESEND_FROM"`
+
+	// EncryptionKeys are the KEKs that encrypt stored header values, bodies and signing
+	// secrets, as comma-separated "<id>:<base64 32-byte key>" entries. The first wraps new
+	// values; keep retired keys listed until nothing sealed with them remains. Unset
+	// stores those columns in plaintext.
+	EncryptionKeys []string `env:"ENCRYPTION_KEYS" envSeparator:"," validate:"required_with=VaultAddr"`
+
+	// Customer-managed keys (BYOK). With VaultAddr set, organization owners can name a
+	// key in this Vault's transit engine (PUT /orgs/:id/encryption-key) to wrap their
+	// organization's data keys instead of ENCRYPTION_KEYS, which still cover everyone
+	// else. VaultToken needs encrypt and decrypt on those keys.
+	VaultAddr         string `env:"VAULT_ADDR" validate:"omitempty,url"`
+	VaultToken        string `env:"VAULT_TOKEN" validate:"required_with=VaultAddr"`
+	VaultTransitMount string `env:"VAULT_TRANSIT_MOUNT" envDefault:"transit"`
+
+	// DocsUI serves a Swagger UI page for GET /openapi.json at /docs. The page loads its
+	// assets from a public CDN, so it is off by default.
+	DocsUI bool `env:"DOCS_UI" envDefault:"false"`
+
+	// MaxRequestBodyBytes caps API request bodies, after gzip decompression; larger ones
+	// get a 413. The biggest legitimate bodies are schedule imports of up to 500 entries.
+	MaxRequestBodyBytes int64 `env:"MAX_REQUEST_BODY_BYTES" envDefault:"5242880" validate:"min=1024,max=1073741824"`
+
+	// CORS lets browser dashboards on other origins call the API directly.
+	// CORSAllowedOrigins are exact origins, subdomain wildcards like https://*.example.com
+	// or "*"; unset sends no CORS headers, so browsers only allow same-origin calls.
+	// Preflight answers are cached by browsers for CORSMaxAgeSec.
+	CORSAllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS" envSeparator:"," validate:"dive,required"`
+	CORSAllowedMethods []string `env:"CORS_ALLOWED_METHODS" envDefault:"GET,POST,PUT,PATCH,DELETE" envSeparator:"," validate:"min=1,dive,required"`
+	CORSAllowedHeaders []string `env:"CORS_ALLOWED_HEADERS" envDefault:"Authorization,Content-Type,Content-Encoding,If-None-Match,X-Org-ID,X-Request-ID" envSeparator:"," validate:"dive,required"`
+	CORSMaxAgeSec      int      `env:"CORS_MAX_AGE_SEC" envDefault:"600" validate:"min=0,max=86400"`
+
+	// ConfigOverridesFile is an optional file of KEY=VALUE lines (a mounted ConfigMap,
+	// say) that override the environment. The scheduler re-reads it on SIGHUP or
+	// POST /admin/reload and applies LOG_LEVEL, WORKER_COUNT, POLL_INTERVAL_SEC and the
+	// *_MAX_CONCURRENT_JOBS caps without a restart; other keys take effect on the next one.
+	ConfigOverridesFile string `env:"CONFIG_OVERRIDES_FILE"`
 
 	MetricsPort string `env:"METRICS_PORT" envDefault:"9090"`
-	LogLevel    string `env:"LOG_LEVEL" envDefault:"info" validate:"required,oneof=debug info warn error"`
+
+	// SchedulerAdminToken is the bearer token POST /admin/drain and POST /admin/reload on
+	// the scheduler's metrics port require. Unset disables both; SIGHUP still reloads.
+	SchedulerAdminToken string `env:"SCHEDULER_ADMIN_TOKEN" validate:"omitempty,min=16"`
+	LogLevel            string `env:"LOG_LEVEL" envDefault:"info" validate:"required,oneof=debug info warn error"`
+
+	// OTLPTracesEndpoint is the full OTLP/HTTP traces URL, e.g. http://collector:4318/v1/traces.
+	// Unset disables span export; trace context is still propagated.
+	OTLPTracesEndpoint string `env:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT" validate:"omitempty,url"`
 
 	// ClerkJWKSURL is the JWKS endpoint for RS256 token verification (Clerk).
 	// When set, it takes precedence over JWTSecret.
@@ -26,12 +194,29 @@ type Config struct {
 
 	// JWTSecret is used for HS256 verification in local dev (when ClerkJWKSURL is empty).
 	JWTSecret string `env:"JWT_SECRET"`
+
+	// AdminUserIDs lists the user IDs allowed to call /admin endpoints.
+	AdminUserIDs []string `env:"ADMIN_USER_IDS" envSeparator:","`
+
+	// Per-user quotas. 0 (the default) disables the quota. Users get a soft-limit warning at 80%.
+	QuotaMaxPendingJobs     int `env:"QUOTA_MAX_PENDING_JOBS" envDefault:"0" validate:"min=0"`
+	QuotaMaxSchedules       int `env:"QUOTA_MAX_SCHEDULES" envDefault:"0" validate:"min=0"`
+	QuotaMaxDailyExecutions int `env:"QUOTA_MAX_DAILY_EXECUTIONS" envDefault:"0" validate:"min=0"`
 }
 
 func Load() (*Config, error) {
+	environ := env.ToMap(os.Environ())
+	if path := environ["CONFIG_OVERRIDES_FILE"]; path != "" {
+		overrides, err := readOverrides(path)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(environ, overrides)
+	}
+
 	cfg := &Config{}
 
-	if err := env.Parse(cfg); err != nil {
+	if err := env.ParseWithOptions(cfg, env.Options{Environment: environ}); err != nil {
 		return nil, fmt.Errorf("parse env: %w", err)
 	}
 
@@ -42,6 +227,33 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// readOverrides parses a file of KEY=VALUE lines; blank lines and lines starting with #
+// are skipped, and a value may be wrapped in double quotes.
+func readOverrides(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config overrides: %w", err)
+	}
+	overrides := make(map[string]string)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("config overrides %s:%d: want KEY=VALUE", path, i+1)
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		}
+		overrides[key] = value
+	}
+	return overrides, nil
+}
+
 // SlogLevel converts the LOG_LEVEL string to a slog.Level.
 func (c *Config) SlogLevel() slog.Level {
 	switch c.LogLevel {
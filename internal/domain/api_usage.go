@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// APIUsage counts one user's requests to one endpoint. Route is the route template
+// (e.g. /jobs/:id), not the raw path, so IDs don't fragment the counts.
+type APIUsage struct {
+	UserID          string
+	BucketStart     time.Time // start of the hour; zero when the usage spans several buckets
+	Method          string
+	Route           string
+	Requests        int64
+	ClientErrors    int64 // 4xx responses
+	ServerErrors    int64 // 5xx responses
+	TotalDurationMS int64
+}
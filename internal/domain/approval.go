@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrJobNotAwaitingApproval = errors.New("job is not awaiting approval")
+
+// ApprovalRequest is raised when jobs a member created start awaiting approval; a batch
+// raises one request for all of its jobs.
+type ApprovalRequest struct {
+	OrgID       string
+	RequestedBy string
+	JobIDs      []string
+	RequestedAt time.Time
+}
+
+// RejectedError is the last_error of a job an owner rejected.
+const RejectedError = "rejected in review"
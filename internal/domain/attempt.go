@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IdempotencyKeyHeader carries an attempt's idempotency key on the job's request.
+const IdempotencyKeyHeader = "X-Idempotency-Key"
+
+// AttemptIdempotencyKey is the key sent with attempt attemptNum of a job:
+// "<job id>:<attempt num>". It is derived, not stored, so the attempt record and the
+// receiver always agree on it; a receiver that sees the key twice is seeing the same
+// attempt delivered twice.
+func AttemptIdempotencyKey(jobID string, attemptNum int) string {
+	return jobID + ":" + strconv.Itoa(attemptNum)
+}
+
+// IdempotencyKey is the X-Idempotency-Key the attempt's request was sent with.
+func (a *JobAttempt) IdempotencyKey() string {
+	return AttemptIdempotencyKey(a.JobID, a.AttemptNum)
+}
+
+// Error classes group attempt failures by cause so flapping targets can be compared
+// across attempts without diffing raw error strings.
+const (
+	ErrorClassNone              = ""
+	ErrorClassTimeout           = "timeout"
+	ErrorClassDNS               = "dns"
+	ErrorClassConnectionRefused = "connection_refused"
+	ErrorClassConnectionReset   = "connection_reset"
+	ErrorClassTLS               = "tls"
+	ErrorClassHTTP4xx           = "http_4xx"
+	ErrorClassHTTP5xx           = "http_5xx"
+	ErrorClassHTTPOther         = "http_other"
+	ErrorClassOther             = "other"
+)
+
+// ErrorClass classifies the attempt's outcome. It returns ErrorClassNone for a
+// successful or still-running attempt.
+func (a *JobAttempt) ErrorClass() string {
+	if a.Error == nil {
+		return ErrorClassNone
+	}
+	if a.StatusCode != nil {
+		switch {
+		case *a.StatusCode >= 500:
+			return ErrorClassHTTP5xx
+		case *a.StatusCode >= 400:
+			return ErrorClassHTTP4xx
+		default:
+			return ErrorClassHTTPOther
+		}
+	}
+
+	msg := *a.Error
+	switch {
+	case strings.Contains(msg, "context deadline exceeded"), strings.Contains(msg, "Client.Timeout"), strings.Contains(msg, "i/o timeout"):
+		return ErrorClassTimeout
+	case strings.Contains(msg, "no such host"), strings.Contains(msg, "server misbehaving"):
+		return ErrorClassDNS
+	case strings.Contains(msg, "connection refused"):
+		return ErrorClassConnectionRefused
+	case strings.Contains(msg, "connection reset"), strings.Contains(msg, "EOF"):
+		return ErrorClassConnectionReset
+	case strings.Contains(msg, "tls:"), strings.Contains(msg, "x509:"):
+		return ErrorClassTLS
+	default:
+		return ErrorClassOther
+	}
+}
+
+// AttemptChange is one field that differs between two consecutive attempts.
+type AttemptChange struct {
+	Field string
+	From  any
+	To    any
+}
+
+// AttemptDiff describes what changed from attempt FromAttempt to ToAttempt.
+// LatencyDeltaMS is nil unless both attempts recorded a duration.
+type AttemptDiff struct {
+	FromAttempt    int
+	ToAttempt      int
+	Changes        []AttemptChange
+	LatencyDeltaMS *int64
+}
+
+// DiffAttempts compares two attempts of the same job. Latency is reported as a delta
+// rather than a change because it almost never matches exactly.
+func DiffAttempts(prev, next *JobAttempt) AttemptDiff {
+	d := AttemptDiff{FromAttempt: prev.AttemptNum, ToAttempt: next.AttemptNum}
+
+	add := func(field string, from, to any) {
+		if from != to {
+			d.Changes = append(d.Changes, AttemptChange{Field: field, From: from, To: to})
+		}
+	}
+	add("status_code", deref(prev.StatusCode), deref(next.StatusCode))
+	add("error_class", prev.ErrorClass(), next.ErrorClass())
+	add("error", deref(prev.Error), deref(next.Error))
+	add("remote_addr", deref(prev.RemoteAddr), deref(next.RemoteAddr))
+	add("response_bytes", deref(prev.ResponseBytes), deref(next.ResponseBytes))
+	add("response_truncated", prev.ResponseTruncated, next.ResponseTruncated)
+	add("worker_id", prev.WorkerID, next.WorkerID)
+
+	if prev.DurationMS != nil && next.DurationMS != nil {
+		delta := *next.DurationMS - *prev.DurationMS
+		d.LatencyDeltaMS = &delta
+	}
+	return d
+}
+
+// deref returns the pointed-to value, or nil for a nil pointer, so absent fields compare
+// equal to each other and unequal to any recorded value.
+func deref[T comparable](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
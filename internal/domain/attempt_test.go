@@ -0,0 +1,71 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestJobAttemptErrorClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt domain.JobAttempt
+		want    string
+	}{
+		{"success", domain.JobAttempt{StatusCode: ptr(200)}, domain.ErrorClassNone},
+		{"5xx", domain.JobAttempt{StatusCode: ptr(503), Error: ptr("unexpected status code: 503")}, domain.ErrorClassHTTP5xx},
+		{"4xx", domain.JobAttempt{StatusCode: ptr(404), Error: ptr("unexpected status code: 404")}, domain.ErrorClassHTTP4xx},
+		{"timeout", domain.JobAttempt{Error: ptr("do request: context deadline exceeded")}, domain.ErrorClassTimeout},
+		{"dns", domain.JobAttempt{Error: ptr("dial tcp: lookup example.invalid: no such host")}, domain.ErrorClassDNS},
+		{"refused", domain.JobAttempt{Error: ptr("dial tcp 10.0.0.1:443: connect: connection refused")}, domain.ErrorClassConnectionRefused},
+		{"tls", domain.JobAttempt{Error: ptr("tls: failed to verify certificate: x509: certificate has expired")}, domain.ErrorClassTLS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.attempt.ErrorClass(); got != tt.want {
+				t.Errorf("ErrorClass() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffAttempts_ReportsChangedFieldsOnly(t *testing.T) {
+	prev := &domain.JobAttempt{
+		AttemptNum: 1, WorkerID: "w1", StatusCode: ptr(503), Error: ptr("unexpected status code: 503"),
+		DurationMS: ptr(int64(900)), RemoteAddr: ptr("10.0.0.1:443"), ResponseBytes: ptr(int64(12)),
+	}
+	next := &domain.JobAttempt{
+		AttemptNum: 2, WorkerID: "w1", StatusCode: ptr(200),
+		DurationMS: ptr(int64(150)), RemoteAddr: ptr("10.0.0.2:443"), ResponseBytes: ptr(int64(12)),
+	}
+
+	d := domain.DiffAttempts(prev, next)
+
+	got := map[string]bool{}
+	for _, c := range d.Changes {
+		got[c.Field] = true
+	}
+	for _, f := range []string{"status_code", "error_class", "error", "remote_addr"} {
+		if !got[f] {
+			t.Errorf("expected change in %s", f)
+		}
+	}
+	if got["worker_id"] || got["response_bytes"] || got["response_truncated"] {
+		t.Errorf("unchanged fields reported: %+v", d.Changes)
+	}
+	if d.LatencyDeltaMS == nil || *d.LatencyDeltaMS != -750 {
+		t.Errorf("LatencyDeltaMS = %v, want -750", d.LatencyDeltaMS)
+	}
+}
+
+func TestJobAttemptIdempotencyKey(t *testing.T) {
+	a := domain.JobAttempt{JobID: "job-1", AttemptNum: 3}
+	if got := a.IdempotencyKey(); got != "job-1:3" {
+		t.Errorf("IdempotencyKey() = %q, want %q", got, "job-1:3")
+	}
+	if a.IdempotencyKey() == domain.AttemptIdempotencyKey("job-1", 4) {
+		t.Error("attempts 3 and 4 share an idempotency key")
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"mime"
+	"strings"
+)
+
+var (
+	ErrInvalidBodyEncoding = errors.New("body must be valid for its body_encoding, and templated bodies must be utf8")
+	ErrInvalidContentType  = errors.New("content type must be a media type like application/json")
+)
+
+// BodyEncoding says how a stored body maps to the bytes sent. Bodies are stored as text,
+// so binary payloads are stored base64-encoded and decoded by the executor.
+type BodyEncoding string
+
+const (
+	BodyEncodingUTF8   BodyEncoding = "utf8"
+	BodyEncodingBase64 BodyEncoding = "base64"
+)
+
+const MaxContentTypeLen = 255
+
+// ValidateBody checks that body decodes under enc. Templates expand the body as text, so
+// a templated body must be utf8.
+func ValidateBody(body *string, enc BodyEncoding, templated bool) error {
+	switch enc {
+	case BodyEncodingUTF8:
+		return nil
+	case BodyEncodingBase64:
+		if templated {
+			return ErrInvalidBodyEncoding
+		}
+		if _, err := DecodeBody(body, enc); err != nil {
+			return ErrInvalidBodyEncoding
+		}
+		return nil
+	default:
+		return ErrInvalidBodyEncoding
+	}
+}
+
+// DecodeBody returns the bytes to send for body; nil when there is no body.
+func DecodeBody(body *string, enc BodyEncoding) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	if enc == BodyEncodingBase64 {
+		return base64.StdEncoding.DecodeString(*body)
+	}
+	return []byte(*body), nil
+}
+
+// ValidateContentType accepts nil (no Content-Type set by the executor) or a parseable
+// media type.
+func ValidateContentType(ct *string) error {
+	if ct == nil {
+		return nil
+	}
+	if len(*ct) > MaxContentTypeLen {
+		return ErrInvalidContentType
+	}
+	mediaType, _, err := mime.ParseMediaType(*ct)
+	if err != nil || !strings.Contains(mediaType, "/") {
+		return ErrInvalidContentType
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestValidateBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      *string
+		enc       domain.BodyEncoding
+		templated bool
+		wantErr   bool
+	}{
+		{name: "utf8", body: ptr(`{"a":1}`), enc: domain.BodyEncodingUTF8},
+		{name: "utf8 templated", body: ptr(`{{.JobID}}`), enc: domain.BodyEncodingUTF8, templated: true},
+		{name: "base64", body: ptr("AAEC/w=="), enc: domain.BodyEncodingBase64},
+		{name: "base64 no body", enc: domain.BodyEncodingBase64},
+		{name: "base64 malformed", body: ptr("not base64!"), enc: domain.BodyEncodingBase64, wantErr: true},
+		{name: "base64 templated", body: ptr("AAEC/w=="), enc: domain.BodyEncodingBase64, templated: true, wantErr: true},
+		{name: "unknown encoding", body: ptr("x"), enc: "hex", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := domain.ValidateBody(tt.body, tt.enc, tt.templated)
+			if tt.wantErr != errors.Is(err, domain.ErrInvalidBodyEncoding) {
+				t.Errorf("ValidateBody() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeBody(t *testing.T) {
+	got, err := domain.DecodeBody(ptr("AAEC/w=="), domain.BodyEncodingBase64)
+	if err != nil || string(got) != "\x00\x01\x02\xff" {
+		t.Errorf("DecodeBody(base64) = %q, %v", got, err)
+	}
+	got, err = domain.DecodeBody(ptr("AAEC/w=="), domain.BodyEncodingUTF8)
+	if err != nil || string(got) != "AAEC/w==" {
+		t.Errorf("DecodeBody(utf8) = %q, %v", got, err)
+	}
+	if got, _ := domain.DecodeBody(nil, domain.BodyEncodingBase64); got != nil {
+		t.Errorf("DecodeBody(nil) = %q, want nil", got)
+	}
+}
+
+func TestValidateContentType(t *testing.T) {
+	for _, ct := range []string{"application/json", "application/octet-stream", "text/plain; charset=utf-8"} {
+		if err := domain.ValidateContentType(&ct); err != nil {
+			t.Errorf("ValidateContentType(%q) = %v", ct, err)
+		}
+	}
+	for _, ct := range []string{"", "json", "text/plain; charset"} {
+		if err := domain.ValidateContentType(&ct); !errors.Is(err, domain.ErrInvalidContentType) {
+			t.Errorf("ValidateContentType(%q) = %v, want ErrInvalidContentType", ct, err)
+		}
+	}
+	if err := domain.ValidateContentType(nil); err != nil {
+		t.Errorf("ValidateContentType(nil) = %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrNoCallbacks = errors.New("job has no callbacks to redeliver")
+
+type CallbackEvent string
+
+const (
+	CallbackEventJobCompleted CallbackEvent = "job.completed"
+	CallbackEventJobFailed    CallbackEvent = "job.failed"
+)
+
+type CallbackStatus string
+
+const (
+	CallbackStatusPending   CallbackStatus = "pending"
+	CallbackStatusDelivered CallbackStatus = "delivered"
+	CallbackStatusFailed    CallbackStatus = "failed"
+)
+
+// MaxCallbackAttempts is how many automatic deliveries a callback gets before it is
+// marked failed. A manual redelivery past the limit gets a single attempt.
+const MaxCallbackAttempts = 8
+
+// CallbackResponseSnippetBytes caps how much of a receiver's response body is logged.
+const CallbackResponseSnippetBytes = 1024
+
+// Callback is a notification about a job's terminal state, enqueued in the same
+// statement that finishes the job. Its ID doubles as the idempotency key receivers see.
+type Callback struct {
+	ID            string
+	JobID         string
+	Event         CallbackEvent
+	URL           string
+	Status        CallbackStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+
+	// History is the delivery log, oldest first. Only populated when listing.
+	History []*CallbackAttempt
+}
+
+type CallbackAttempt struct {
+	ID              string
+	CallbackID      string
+	AttemptNum      int
+	StatusCode      *int
+	Error           *string
+	ResponseSnippet *string
+	DurationMS      int64
+	CreatedAt       time.Time
+}
+
+// CallbackPayload is the JSON body POSTed to a job's callback URL.
+type CallbackPayload struct {
+	ID          string        `json:"id"`
+	Event       CallbackEvent `json:"event"`
+	JobID       string        `json:"job_id"`
+	Status      Status        `json:"status"`
+	Attempts    int           `json:"attempts"`
+	ScheduleID  *string       `json:"schedule_id,omitempty"`
+	RequestID   *string       `json:"request_id,omitempty"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	LastError   *string       `json:"last_error,omitempty"`
+	OccurredAt  time.Time     `json:"occurred_at"`
+}
+
+// NewCallbackPayload builds the payload for cb from the job's final state.
+func NewCallbackPayload(cb *Callback, job *Job) CallbackPayload {
+	return CallbackPayload{
+		ID:          cb.ID,
+		Event:       cb.Event,
+		JobID:       job.ID,
+		Status:      job.Status,
+		Attempts:    job.RetryCount + 1,
+		ScheduleID:  job.ScheduleID,
+		RequestID:   job.RequestID,
+		CompletedAt: job.CompletedAt,
+		LastError:   job.LastError,
+		OccurredAt:  cb.CreatedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrClientCertNotFound     = errors.New("client certificate not found")
+	ErrInvalidClientCert      = errors.New("invalid client certificate")
+	ErrClientCertInUse        = errors.New("client certificate is used by pending jobs or schedules")
+	ErrClientCertNameConflict = errors.New("client certificate name already exists")
+)
+
+// MaxClientCertPEMLen bounds each of the certificate chain and the key an upload may carry.
+const MaxClientCertPEMLen = 64 << 10
+
+// ClientCertificate is a certificate and private key a user uploads once and references
+// from jobs and schedules by ID, so the executor can authenticate to targets that require
+// mutual TLS. KeyPEM is sealed at rest and never returned by the API; Subject, Fingerprint
+// and NotAfter describe the leaf certificate.
+type ClientCertificate struct {
+	ID          string
+	UserID      string
+	Name        string
+	CertPEM     string
+	KeyPEM      string
+	Subject     string
+	Fingerprint string // hex SHA-256 of the leaf's DER encoding
+	NotAfter    time.Time
+	CreatedAt   time.Time
+}
+
+// NewClientCertificate checks that certPEM (the leaf first, then any intermediates) and
+// keyPEM form a pair whose leaf is valid at now, and describes the leaf.
+func NewClientCertificate(userID, name, certPEM, keyPEM string, now time.Time) (*ClientCertificate, error) {
+	if len(certPEM) > MaxClientCertPEMLen || len(keyPEM) > MaxClientCertPEMLen {
+		return nil, ErrInvalidClientCert
+	}
+	c := &ClientCertificate{UserID: userID, Name: name, CertPEM: certPEM, KeyPEM: keyPEM}
+	pair, err := c.KeyPair()
+	if err != nil {
+		return nil, err
+	}
+	leaf := pair.Leaf
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return nil, ErrInvalidClientCert
+	}
+	sum := sha256.Sum256(leaf.Raw)
+	c.Subject = leaf.Subject.String()
+	c.Fingerprint = hex.EncodeToString(sum[:])
+	c.NotAfter = leaf.NotAfter
+	return c, nil
+}
+
+// KeyPair parses the certificate and key for a TLS client config, with Leaf set.
+func (c *ClientCertificate) KeyPair() (tls.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(c.CertPEM), []byte(c.KeyPEM))
+	if err != nil {
+		return tls.Certificate{}, ErrInvalidClientCert
+	}
+	if pair.Leaf == nil {
+		if pair.Leaf, err = x509.ParseCertificate(pair.Certificate[0]); err != nil {
+			return tls.Certificate{}, ErrInvalidClientCert
+		}
+	}
+	return pair, nil
+}
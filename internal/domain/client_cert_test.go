@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// selfSigned returns a PEM certificate and key valid from notBefore to notAfter.
+func selfSigned(t *testing.T, notBefore, notAfter time.Time) (certPEM, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client.example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestNewClientCertificate(t *testing.T) {
+	now := time.Now()
+	certPEM, keyPEM := selfSigned(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	c, err := domain.NewClientCertificate("user-1", "bank", certPEM, keyPEM, now)
+	if err != nil {
+		t.Fatalf("NewClientCertificate() = %v", err)
+	}
+	if c.Subject != "CN=client.example.com" || len(c.Fingerprint) != 64 || !c.NotAfter.Equal(now.Add(24*time.Hour).Truncate(time.Second)) {
+		t.Errorf("described leaf as %q %q %v", c.Subject, c.Fingerprint, c.NotAfter)
+	}
+
+	_, otherKey := selfSigned(t, now.Add(-time.Hour), now.Add(time.Hour))
+	expiredCert, expiredKey := selfSigned(t, now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	tests := []struct {
+		name            string
+		certPEM, keyPEM string
+	}{
+		{name: "mismatched key", certPEM: certPEM, keyPEM: otherKey},
+		{name: "expired", certPEM: expiredCert, keyPEM: expiredKey},
+		{name: "not pem", certPEM: "cert", keyPEM: "key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := domain.NewClientCertificate("user-1", "bank", tt.certPEM, tt.keyPEM, now); !errors.Is(err, domain.ErrInvalidClientCert) {
+				t.Errorf("NewClientCertificate() = %v, want ErrInvalidClientCert", err)
+			}
+		})
+	}
+}
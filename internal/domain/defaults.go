@@ -0,0 +1,74 @@
+package domain
+
+// JobDefaults fill in fields a job or schedule creation request omits. Nil fields
+// fall back to the system defaults below.
+type JobDefaults struct {
+	TimeoutSeconds *int
+	MaxRetries     *int
+	Backoff        *Backoff
+	Headers        map[string]string // merged under request headers; request values win
+}
+
+const (
+	DefaultTimeoutSeconds = 30
+	DefaultMaxRetries     = 3
+	DefaultBackoff        = BackoffExponential
+)
+
+// EffectiveJobDefaults is JobDefaults with every system fallback applied.
+type EffectiveJobDefaults struct {
+	TimeoutSeconds int
+	MaxRetries     int
+	Backoff        Backoff
+	Headers        map[string]string
+}
+
+// Merge overwrites d's fields with every non-nil field of patch.
+func (d *JobDefaults) Merge(patch JobDefaults) {
+	if patch.TimeoutSeconds != nil {
+		d.TimeoutSeconds = patch.TimeoutSeconds
+	}
+	if patch.MaxRetries != nil {
+		d.MaxRetries = patch.MaxRetries
+	}
+	if patch.Backoff != nil {
+		d.Backoff = patch.Backoff
+	}
+	if patch.Headers != nil {
+		d.Headers = patch.Headers
+	}
+}
+
+func (d JobDefaults) Effective() EffectiveJobDefaults {
+	e := EffectiveJobDefaults{
+		TimeoutSeconds: DefaultTimeoutSeconds,
+		MaxRetries:     DefaultMaxRetries,
+		Backoff:        DefaultBackoff,
+		Headers:        d.Headers,
+	}
+	if d.TimeoutSeconds != nil {
+		e.TimeoutSeconds = *d.TimeoutSeconds
+	}
+	if d.MaxRetries != nil {
+		e.MaxRetries = *d.MaxRetries
+	}
+	if d.Backoff != nil {
+		e.Backoff = *d.Backoff
+	}
+	if e.Headers == nil {
+		e.Headers = map[string]string{}
+	}
+	return e
+}
+
+// MergeHeaders returns the default headers overlaid with the request's own.
+func (e EffectiveJobDefaults) MergeHeaders(headers map[string]string) map[string]string {
+	merged := make(map[string]string, len(e.Headers)+len(headers))
+	for k, v := range e.Headers {
+		merged[k] = v
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+	return merged
+}
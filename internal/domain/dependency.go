@@ -0,0 +1,39 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidDependency = errors.New("depends_on must reference one of the user's jobs")
+	ErrParentJobFailed   = errors.New("parent job already finished without completing")
+)
+
+// ParentFailurePolicy decides what happens to a job whose parent (Job.DependsOn) reaches
+// a terminal state other than completed.
+type ParentFailurePolicy string
+
+const (
+	// ParentFailureSkip cancels the child, and in turn its own dependents.
+	ParentFailureSkip ParentFailurePolicy = "skip"
+	// ParentFailureRun releases the child as if the parent had completed.
+	ParentFailureRun ParentFailurePolicy = "run"
+
+	DefaultParentFailurePolicy = ParentFailureSkip
+)
+
+// StatusAfterParent returns the status a new job depending on a parent in parentStatus
+// starts in: blocked while the parent is unfinished, pending once it completed. A parent
+// that already failed releases the child under ParentFailureRun and is an error otherwise,
+// since the child would be cancelled before it ever ran.
+func StatusAfterParent(parentStatus Status, policy ParentFailurePolicy) (Status, error) {
+	switch parentStatus {
+	case StatusCompleted:
+		return StatusPending, nil
+	case StatusFailed, StatusCancelled, StatusExpired:
+		if policy == ParentFailureRun {
+			return StatusPending, nil
+		}
+		return "", ErrParentJobFailed
+	default:
+		return StatusBlocked, nil
+	}
+}
@@ -0,0 +1,37 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestStatusAfterParent(t *testing.T) {
+	tests := []struct {
+		parent  domain.Status
+		policy  domain.ParentFailurePolicy
+		want    domain.Status
+		wantErr error
+	}{
+		{parent: domain.StatusPending, policy: domain.ParentFailureSkip, want: domain.StatusBlocked},
+		{parent: domain.StatusRunning, policy: domain.ParentFailureRun, want: domain.StatusBlocked},
+		{parent: domain.StatusPaused, policy: domain.ParentFailureSkip, want: domain.StatusBlocked},
+		{parent: domain.StatusBlocked, policy: domain.ParentFailureSkip, want: domain.StatusBlocked},
+		{parent: domain.StatusCompleted, policy: domain.ParentFailureSkip, want: domain.StatusPending},
+		{parent: domain.StatusFailed, policy: domain.ParentFailureRun, want: domain.StatusPending},
+		{parent: domain.StatusExpired, policy: domain.ParentFailureRun, want: domain.StatusPending},
+		{parent: domain.StatusFailed, policy: domain.ParentFailureSkip, wantErr: domain.ErrParentJobFailed},
+		{parent: domain.StatusCancelled, policy: domain.ParentFailureSkip, wantErr: domain.ErrParentJobFailed},
+	}
+	for _, tt := range tests {
+		got, err := domain.StatusAfterParent(tt.parent, tt.policy)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("StatusAfterParent(%s, %s) err = %v, want %v", tt.parent, tt.policy, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("StatusAfterParent(%s, %s) = %q, want %q", tt.parent, tt.policy, got, tt.want)
+		}
+	}
+}
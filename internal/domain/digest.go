@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// DigestPeriod returns the last complete period of a digest event before now, in UTC: the
+// previous day for digest.daily, the previous Monday-to-Monday week for digest.weekly.
+func DigestPeriod(event NotificationEvent, now time.Time) (from, to time.Time) {
+	now = now.UTC()
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if event == NotifyDigestWeekly {
+		to = to.AddDate(0, 0, -(int(to.Weekday())+6)%7) // back to Monday
+		return to.AddDate(0, 0, -7), to
+	}
+	return to.AddDate(0, 0, -1), to
+}
+
+// Digest is the summary a digest rule sends for one period.
+type Digest struct {
+	Rule      *NotificationRule
+	From, To  time.Time
+	Schedules []ScheduleDigest // schedules with runs in the period, by name
+}
+
+// ScheduleDigest summarises the runs one schedule fired in a digest period. Runs counts
+// jobs that completed, failed or expired; the p95 durations are nil when no attempt
+// measured one, and PrevP95DurationMS covers the period before, so the digest can show
+// the trend.
+type ScheduleDigest struct {
+	ScheduleID        string
+	Name              string
+	Runs              int64
+	Succeeded         int64
+	Failed            int64 // failed or expired
+	P95DurationMS     *int64
+	PrevP95DurationMS *int64
+}
+
+// Totals adds up the runs of every schedule in the digest.
+func (d *Digest) Totals() (runs, succeeded, failed int64) {
+	for _, s := range d.Schedules {
+		runs += s.Runs
+		succeeded += s.Succeeded
+		failed += s.Failed
+	}
+	return runs, succeeded, failed
+}
@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestDigestPeriod(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 4, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		event    domain.NotificationEvent
+		now      time.Time
+		from, to time.Time
+	}{
+		{"daily", domain.NotifyDigestDaily, day(15).Add(9 * time.Hour), day(14), day(15)},
+		{"daily at midnight", domain.NotifyDigestDaily, day(15), day(14), day(15)},
+		{"daily, other zone", domain.NotifyDigestDaily, day(15).Add(time.Hour).In(time.FixedZone("UTC-5", -5*3600)), day(14), day(15)},
+		{"weekly on wednesday", domain.NotifyDigestWeekly, day(15).Add(9 * time.Hour), day(6), day(13)},
+		{"weekly on monday", domain.NotifyDigestWeekly, day(13), day(6), day(13)},
+		{"weekly on sunday", domain.NotifyDigestWeekly, day(19).Add(23 * time.Hour), day(6), day(13)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := domain.DigestPeriod(tt.event, tt.now)
+			if !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Errorf("DigestPeriod() = %v, %v, want %v, %v", from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrDryRunUnsupported is returned for jobs that don't send an HTTP request.
+var ErrDryRunUnsupported = errors.New("only http jobs can be dry run")
+
+// MaxDryRunTimeout caps a dry run's request timeout. Dry runs execute inside an API
+// request, so they can't wait as long as a job.
+const MaxDryRunTimeout = 30 * time.Second
+
+// DryRunResult is the outcome of sending a job's request once without persisting
+// anything. StatusCode and the response fields are nil when no response arrived.
+type DryRunResult struct {
+	Succeeded         bool // the job's success codes match the response
+	StatusCode        *int
+	Error             *string
+	Duration          time.Duration
+	ResponseHeaders   map[string]string
+	ResponseBody      *string // truncated to the capture limit
+	ResponseBytes     *int64
+	ResponseTruncated bool
+}
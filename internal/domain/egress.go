@@ -0,0 +1,11 @@
+package domain
+
+// EgressUsage totals the traffic of one schedule's attempts over a window. ScheduleID is
+// nil for ad-hoc jobs and for jobs whose schedule has since been deleted.
+type EgressUsage struct {
+	ScheduleID    *string
+	ScheduleName  *string
+	Attempts      int64
+	RequestBytes  int64
+	ResponseBytes int64
+}
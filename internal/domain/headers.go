@@ -0,0 +1,46 @@
+package domain
+
+import "strings"
+
+// RedactedValue replaces secret header values wherever headers are shown back to users.
+const RedactedValue = "[redacted]"
+
+// secretHeaders always carry credentials; any header whose name contains one of
+// secretHeaderHints is treated as secret too.
+var secretHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+}
+
+var secretHeaderHints = []string{"token", "secret", "password", "api-key", "apikey", "signature", "session", "credential"}
+
+// IsSecretHeader reports whether a request header's name suggests it carries a credential.
+// Names are matched case-insensitively.
+func IsSecretHeader(name string) bool {
+	lower := strings.ToLower(name)
+	if secretHeaders[lower] {
+		return true
+	}
+	for _, hint := range secretHeaderHints {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactHeaders returns a copy of headers with secret values replaced by RedactedValue.
+func RedactHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	out := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if IsSecretHeader(k) {
+			v = RedactedValue
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestIsSecretHeader(t *testing.T) {
+	for _, name := range []string{"Authorization", "authorization", "Cookie", "X-Api-Key", "X-Auth-Token", "X-Signature"} {
+		if !domain.IsSecretHeader(name) {
+			t.Errorf("IsSecretHeader(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"Content-Type", "Accept", "X-Request-ID"} {
+		if domain.IsSecretHeader(name) {
+			t.Errorf("IsSecretHeader(%q) = true, want false", name)
+		}
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	in := map[string]string{"Authorization": "Bearer abc", "Content-Type": "application/json"}
+	got := domain.RedactHeaders(in)
+	want := map[string]string{"Authorization": domain.RedactedValue, "Content-Type": "application/json"}
+	if !maps.Equal(got, want) {
+		t.Errorf("RedactHeaders = %v, want %v", got, want)
+	}
+	if in["Authorization"] != "Bearer abc" {
+		t.Error("RedactHeaders modified its input")
+	}
+	if domain.RedactHeaders(nil) != nil {
+		t.Error("RedactHeaders(nil) != nil")
+	}
+}
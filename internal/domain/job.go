@@ -6,10 +6,17 @@ import (
 )
 
 var (
-	ErrJobNotFound      = errors.New("job not found")
-	ErrDuplicateJob     = errors.New("job with this idempotency key already exists")
-	ErrInvalidStatus    = errors.New("invalid status value")
-	ErrJobNotCancellable = errors.New("job is not in a cancellable state")
+	ErrJobNotFound        = errors.New("job not found")
+	ErrDuplicateJob       = errors.New("job with this idempotency key already exists")
+	ErrInvalidStatus      = errors.New("invalid status value")
+	ErrInvalidJobFilter   = errors.New("invalid job filter")
+	ErrJobNotCancellable  = errors.New("job is not in a cancellable state")
+	ErrJobNotPausable     = errors.New("only pending jobs can be paused")
+	ErrJobNotPaused       = errors.New("job is not paused")
+	ErrAttemptNotFound    = errors.New("attempt not found")
+	ErrInvalidDeadline    = errors.New("deadline must be after scheduled_at")
+	ErrInvalidExpiry      = errors.New("expires_at must be after scheduled_at")
+	ErrInvalidRetryDelays = errors.New("retry delays must be between 1s and 24h, at most 20 entries")
 )
 
 type Status string
@@ -20,32 +27,147 @@ const (
 	StatusCompleted Status = "completed"
 	StatusFailed    Status = "failed"
 	StatusCancelled Status = "cancelled"
+	// StatusPaused holds a pending job: workers skip it until it is resumed. It still
+	// counts towards the pending-jobs quota.
+	StatusPaused Status = "paused"
+	// StatusExpired is a pending job that was not claimed before its ExpiresAt.
+	StatusExpired Status = "expired"
+	// StatusBlocked is a job waiting for its parent (DependsOn) to finish; it becomes
+	// pending, or cancelled under ParentFailureSkip, when the parent does.
+	StatusBlocked Status = "blocked"
+	// StatusAwaitingApproval is a job a member created in an organization that requires
+	// approval; workers skip it until an owner approves it (see Org.RequireApproval).
+	StatusAwaitingApproval Status = "awaiting_approval"
 )
 
+// DeadlineExceededError is the last_error of a job failed because its deadline passed.
+const DeadlineExceededError = "deadline exceeded"
+
 type Backoff string
 
+const (
+	MaxRetryDelays = 20
+	MinRetryDelay  = time.Second
+	MaxRetryDelay  = 24 * time.Hour
+)
+
+// ValidateRetryDelays checks a custom retry delay list against the allowed bounds.
+func ValidateRetryDelays(delays []time.Duration) error {
+	if len(delays) > MaxRetryDelays {
+		return ErrInvalidRetryDelays
+	}
+	for _, d := range delays {
+		if d < MinRetryDelay || d > MaxRetryDelay {
+			return ErrInvalidRetryDelays
+		}
+	}
+	return nil
+}
+
 const (
 	BackoffExponential Backoff = "exponential"
 	BackoffLinear      Backoff = "linear"
 )
 
+// ClaimPolicy controls the order in which workers claim due jobs within a priority band.
+type ClaimPolicy string
+
+const (
+	// ClaimPolicyFIFO claims by current scheduled_at — a retried job queues behind
+	// everything that became due before its retry time.
+	ClaimPolicyFIFO ClaimPolicy = "fifo"
+	// ClaimPolicyOverdue claims by the time a job was first due, so the jobs that have
+	// been late the longest go first even after retries pushed scheduled_at forward.
+	ClaimPolicyOverdue ClaimPolicy = "overdue"
+)
+
+// ConcurrencyLimits cap how many jobs may run at once, enforced when workers claim.
+// 0 disables a limit. A user's users.max_concurrent_jobs, when set, overrides PerUser
+// for that user (0 there means unlimited); overrides only apply while limiting is enabled.
+type ConcurrencyLimits struct {
+	PerUser int // running jobs per user
+	PerHost int // running jobs per target host, across all users
+}
+
+// Enabled reports whether any limit is set.
+func (l ConcurrencyLimits) Enabled() bool {
+	return l.PerUser > 0 || l.PerHost > 0
+}
+
 type Job struct {
 	ID             string            `json:"id"`
 	UserID         string            `json:"userID"`
 	IdempotencyKey string            `json:"idempotencyKey"`
+	JobType        JobType           `json:"jobType"`
 	URL            string            `json:"url"`
 	Method         string            `json:"method"`
 	Headers        map[string]string `json:"headers"`
 	Body           *string           `json:"body,omitempty"`
 	TimeoutSeconds int               `json:"timeoutSeconds"`
 
+	// Topic and MessageKey are where a kafka job publishes its body; URL and Method are
+	// empty for it. See JobTarget.
+	Topic      *string `json:"topic,omitempty"`
+	MessageKey *string `json:"messageKey,omitempty"`
+
+	// EmailTo and EmailSubject address the message an email job sends, with Body as its
+	// HTML; URL and Method are empty for it.
+	EmailTo      *string `json:"emailTo,omitempty"`
+	EmailSubject *string `json:"emailSubject,omitempty"`
+
+	// BodyEncoding says how Body maps to the bytes sent; ContentType, when set, is sent as
+	// the Content-Type header, overriding one in Headers.
+	BodyEncoding BodyEncoding `json:"bodyEncoding"`
+	ContentType  *string      `json:"contentType,omitempty"`
+
 	Status      Status    `json:"status"`
 	ScheduledAt time.Time `json:"scheduledAt"`
+	Priority    int       `json:"priority"`
+
+	// Deadline is the absolute time by which the job must reach a terminal state; past it
+	// the job is failed with DeadlineExceededError instead of being retried.
+	Deadline *time.Time `json:"deadline,omitempty"`
+
+	// ExpiresAt is the last moment the job may start; a pending job still unclaimed then
+	// becomes StatusExpired instead of running late.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// DependsOn is the job this one runs after; OnParentFailure applies when that job
+	// finishes without completing.
+	DependsOn       *string             `json:"dependsOn,omitempty"`
+	OnParentFailure ParentFailurePolicy `json:"onParentFailure"`
+
+	// RetriedFrom is the finished job this one was resubmitted from; see Resubmission.
+	RetriedFrom *string `json:"retriedFrom,omitempty"`
 
 	RetryCount int     `json:"retryCount"`
 	MaxRetries int     `json:"maxRetries"`
 	Backoff    Backoff `json:"backoff"`
 
+	// RetryBaseSeconds and RetryMaxSeconds are the delay Backoff starts from and the cap
+	// it grows to; RetryJitter randomizes the result. See RetryDelay.
+	RetryBaseSeconds int    `json:"retryBaseSeconds"`
+	RetryMaxSeconds  int    `json:"retryMaxSeconds"`
+	RetryJitter      Jitter `json:"retryJitter"`
+
+	// RetryDelays overrides Backoff for the first len(RetryDelays) retries:
+	// retry n waits RetryDelays[n]. Later retries fall back to Backoff.
+	RetryDelays []time.Duration `json:"retryDelays,omitempty"`
+
+	// SuccessCodes decides which response statuses complete the job; empty = 200 only.
+	SuccessCodes SuccessCodes `json:"successCodes,omitempty"`
+
+	// Templated jobs have their URL, header values and body expanded with TemplateVars
+	// before each attempt.
+	Templated bool `json:"templated,omitempty"`
+
+	// Debug jobs record the outgoing request and the start of the response on every
+	// attempt, regardless of the worker's capture setting.
+	Debug bool `json:"debug,omitempty"`
+
+	// FirstDueAt is the original scheduled_at, kept once retries move scheduled_at on.
+	FirstDueAt *time.Time `json:"firstDueAt,omitempty"`
+
 	ClaimedAt   *time.Time `json:"claimedAt"`
 	ClaimedBy   *string    `json:"claimedBy"`
 	HeartbeatAt *time.Time `json:"heartbeatAt"`
@@ -54,10 +176,96 @@ type Job struct {
 
 	ScheduleID *string `json:"scheduleID,omitempty"`
 
+	// Ping marks a job fired by a ping-mode schedule: it keeps no attempt history and is
+	// deleted once its outcome is rolled up.
+	Ping bool `json:"ping"`
+
+	// RequestID is the X-Request-ID of the API call that created the job.
+	// Nil for jobs fired by the dispatcher.
+	RequestID *string `json:"requestID,omitempty"`
+
+	// TraceParent is the W3C traceparent of the API call that created the job; the worker
+	// continues that trace when it executes the job.
+	TraceParent *string `json:"-"`
+
+	// Queue picks which workers may claim the job: only those whose WORKER_QUEUES
+	// include it.
+	Queue string `json:"queue"`
+
+	// SigningSecret, when set, makes the executor sign each request with an X-Signature
+	// HMAC header (see SignRequest). It is never returned by the API.
+	SigningSecret *string `json:"-"`
+
+	// ProxyURL, when set, routes the job's requests through an HTTP(S) or SOCKS5 proxy.
+	// It may carry credentials, so the API returns it only redacted.
+	ProxyURL *string `json:"-"`
+	// ClientCertID, when set, names one of the user's ClientCertificates the executor
+	// presents to targets that require mutual TLS.
+	ClientCertID *string `json:"clientCertID,omitempty"`
+	// CABundle, when set, replaces the system roots the target's certificate is verified
+	// against; TLSSkipVerify turns verification off. At most one is set.
+	CABundle      *string `json:"caBundle,omitempty"`
+	TLSSkipVerify bool    `json:"tlsSkipVerify,omitempty"`
+
+	// CallbackURL, when set, is POSTed a CallbackPayload once the job completes or fails.
+	CallbackURL *string `json:"callbackURL,omitempty"`
+
+	// CancelRequestedAt is set when the user cancels the job while it is running. The
+	// worker aborts the execution and moves the job to cancelled.
+	CancelRequestedAt *time.Time `json:"cancelRequestedAt,omitempty"`
+
+	// CreatedBy is the member who created the job in an organization; nil for personal
+	// and fired jobs. ReviewedBy and ReviewedAt record the owner who approved or rejected
+	// a job that awaited approval.
+	CreatedBy  *string    `json:"createdBy,omitempty"`
+	ReviewedBy *string    `json:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// JobStatusEvent is a committed job status transition. PreviousStatus is empty when the
+// job was just created.
+type JobStatusEvent struct {
+	JobID          string
+	UserID         string
+	ScheduleID     *string
+	Status         Status
+	PreviousStatus Status
+	At             time.Time
+}
+
+// QueueStats is a snapshot of the pending-job backlog across all users, in all queues or
+// the ones asked for.
+type QueueStats struct {
+	Pending int64 // pending jobs, due or not
+	Overdue int64 // pending jobs whose scheduled_at has passed
+	// OldestDueAt is the earliest scheduled_at among overdue jobs; nil when none are.
+	OldestDueAt *time.Time
+}
+
+// StuckQueue counts a queue's pending jobs that have waited past the reaper's stuck
+// threshold without being claimed.
+type StuckQueue struct {
+	Queue string
+	Count int64
+	// OldestDueAt is the earliest scheduled_at among the stuck jobs; nil when none are.
+	OldestDueAt *time.Time
+}
+
+// ReapedJob is a job recovered by the reaper, carrying the lease it held before recovery.
+type ReapedJob struct {
+	ID          string
+	UserID      string
+	ScheduleID  *string
+	URL         string
+	LastError   *string
+	ClaimedBy   *string
+	ClaimedAt   *time.Time
+	HeartbeatAt *time.Time
+}
+
 type JobAttempt struct {
 	ID          string
 	JobID       string
@@ -68,4 +276,34 @@ type JobAttempt struct {
 	StatusCode  *int
 	Error       *string
 	DurationMS  *int64
+
+	// RequestBytes is the request body size sent; nil when no connection was made.
+	RequestBytes *int64
+
+	// ResponseBytes is the response body size observed by the executor; nil when no response arrived.
+	ResponseBytes *int64
+
+	// ResponseTruncated is set when the body exceeded the worker's response size limit and
+	// was not read to the end. ResponseBytes is then a lower bound, or the Content-Length.
+	ResponseTruncated bool
+
+	// RemoteAddr is the ip:port that served the final response; nil if no connection was made.
+	RemoteAddr *string
+
+	// ResponseHeaders and ResponseBody are captured from the target's response when the
+	// worker has capture enabled. The body is truncated to the capture limit; compare its
+	// length with ResponseBytes to tell.
+	ResponseHeaders map[string]string
+	ResponseBody    *string
+
+	// RequestMethod, RequestURL, RequestHeaders and RequestBody snapshot the request as sent,
+	// after template expansion. Recorded only for debug jobs; secret header values are
+	// redacted and the body is truncated to the debug capture limit.
+	RequestMethod  *string
+	RequestURL     *string
+	RequestHeaders map[string]string
+	RequestBody    *string
+
+	// Cancelled marks an attempt aborted because the job was cancelled while running.
+	Cancelled bool
 }
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrUnknownJobType   = errors.New("unknown job type")
+	ErrInvalidJobTarget = errors.New("invalid job target")
+)
+
+// JobType picks the executor a worker runs a job with. Every type listed in JobTypes
+// needs an executor registered on the workers; a job whose type a worker has none for
+// fails without running.
+type JobType string
+
+const (
+	// JobTypeHTTP sends the job's request to its URL. It is the type of jobs and
+	// schedules created without one.
+	JobTypeHTTP JobType = "http"
+	// JobTypeKafka publishes the job's body to its Topic, keyed by MessageKey, with its
+	// headers as record headers. Workers run it only when a broker is configured.
+	JobTypeKafka JobType = "kafka"
+	// JobTypeEmail sends the job's body as the HTML of a message to EmailTo with subject
+	// EmailSubject, from the deployment's notification sender.
+	JobTypeEmail JobType = "email"
+)
+
+// JobTypes are the types jobs and schedules may be created with.
+var JobTypes = []JobType{JobTypeHTTP, JobTypeKafka, JobTypeEmail}
+
+// ValidateJobType checks that t is one of JobTypes.
+func ValidateJobType(t JobType) error {
+	for _, known := range JobTypes {
+		if t == known {
+			return nil
+		}
+	}
+	return ErrUnknownJobType
+}
+
+const (
+	MaxMessageKeyLen   = 1024
+	MaxEmailSubjectLen = 998 // the longest header line RFC 5322 allows
+)
+
+// Kafka topic names: up to 249 ASCII letters, digits, '.', '_' and '-'.
+var topicPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+// JobTarget is where a job of Type sends its payload: http jobs use URL and Method,
+// kafka jobs Topic and MessageKey, email jobs EmailTo and EmailSubject.
+type JobTarget struct {
+	Type         JobType
+	URL          string
+	Method       string
+	Topic        *string
+	MessageKey   *string
+	EmailTo      *string
+	EmailSubject *string
+
+	// HTTPOptions is set when the job has a proxy, client certificate or TLS settings,
+	// which only apply to http jobs.
+	HTTPOptions bool
+}
+
+// Validate checks that t sets the fields of its type and none of another's.
+func (t JobTarget) Validate() error {
+	kafka := t.Topic != nil || t.MessageKey != nil
+	email := t.EmailTo != nil || t.EmailSubject != nil
+	switch t.Type {
+	case JobTypeHTTP:
+		if t.URL == "" || t.Method == "" || kafka || email {
+			return ErrInvalidJobTarget
+		}
+	case JobTypeKafka:
+		if t.URL != "" || t.Method != "" || t.HTTPOptions || email {
+			return ErrInvalidJobTarget
+		}
+		if t.Topic == nil || !topicPattern.MatchString(*t.Topic) || *t.Topic == "." || *t.Topic == ".." {
+			return ErrInvalidJobTarget
+		}
+		if t.MessageKey != nil && len(*t.MessageKey) > MaxMessageKeyLen {
+			return ErrInvalidJobTarget
+		}
+	case JobTypeEmail:
+		if t.URL != "" || t.Method != "" || t.HTTPOptions || kafka {
+			return ErrInvalidJobTarget
+		}
+		if t.EmailTo == nil || !validEmailAddress(*t.EmailTo) {
+			return ErrInvalidJobTarget
+		}
+		if t.EmailSubject == nil || *t.EmailSubject == "" || len(*t.EmailSubject) > MaxEmailSubjectLen ||
+			strings.ContainsAny(*t.EmailSubject, "\r\n") {
+			return ErrInvalidJobTarget
+		}
+	default:
+		return ErrUnknownJobType
+	}
+	return nil
+}
+
+// Target returns where the job sends its payload.
+func (j *Job) Target() JobTarget {
+	return JobTarget{
+		Type:         j.JobType,
+		URL:          j.URL,
+		Method:       j.Method,
+		Topic:        j.Topic,
+		MessageKey:   j.MessageKey,
+		EmailTo:      j.EmailTo,
+		EmailSubject: j.EmailSubject,
+		HTTPOptions:  j.ProxyURL != nil || j.ClientCertID != nil || j.CABundle != nil || j.TLSSkipVerify,
+	}
+}
+
+// Target returns where the schedule's jobs send their payload.
+func (s *Schedule) Target() JobTarget {
+	return JobTarget{
+		Type:         s.JobType,
+		URL:          s.URL,
+		Method:       s.Method,
+		Topic:        s.Topic,
+		MessageKey:   s.MessageKey,
+		EmailTo:      s.EmailTo,
+		EmailSubject: s.EmailSubject,
+		HTTPOptions:  s.ProxyURL != nil || s.ClientCertID != nil || s.CABundle != nil || s.TLSSkipVerify,
+	}
+}
+
+// validEmailAddress reports whether addr is a single bare address, like
+// "ops@example.com", rather than a name-addr or a list.
+func validEmailAddress(addr string) bool {
+	a, err := mail.ParseAddress(addr)
+	return err == nil && a.Name == "" && a.Address == addr
+}
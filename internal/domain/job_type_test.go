@@ -0,0 +1,48 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestJobTarget_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  domain.JobTarget
+		wantErr error
+	}{
+		{name: "http", target: domain.JobTarget{Type: domain.JobTypeHTTP, URL: "https://example.com", Method: "POST"}},
+		{name: "http without url", target: domain.JobTarget{Type: domain.JobTypeHTTP, Method: "POST"}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "http with topic", target: domain.JobTarget{Type: domain.JobTypeHTTP, URL: "https://example.com", Method: "POST", Topic: ptr("orders")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "kafka", target: domain.JobTarget{Type: domain.JobTypeKafka, Topic: ptr("orders.v1")}},
+		{name: "kafka with key", target: domain.JobTarget{Type: domain.JobTypeKafka, Topic: ptr("orders"), MessageKey: ptr("customer-42")}},
+		{name: "kafka without topic", target: domain.JobTarget{Type: domain.JobTypeKafka}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "kafka with bad topic", target: domain.JobTarget{Type: domain.JobTypeKafka, Topic: ptr("orders/v1")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "kafka dot topic", target: domain.JobTarget{Type: domain.JobTypeKafka, Topic: ptr("..")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "kafka long key", target: domain.JobTarget{Type: domain.JobTypeKafka, Topic: ptr("orders"), MessageKey: ptr(strings.Repeat("k", domain.MaxMessageKeyLen+1))}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "kafka with url", target: domain.JobTarget{Type: domain.JobTypeKafka, Topic: ptr("orders"), URL: "https://example.com"}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "kafka with proxy", target: domain.JobTarget{Type: domain.JobTypeKafka, Topic: ptr("orders"), HTTPOptions: true}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "email", target: domain.JobTarget{Type: domain.JobTypeEmail, EmailTo: ptr("ops@example.com"), EmailSubject: ptr("Renew the certificate")}},
+		{name: "email without subject", target: domain.JobTarget{Type: domain.JobTypeEmail, EmailTo: ptr("ops@example.com")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "email with named address", target: domain.JobTarget{Type: domain.JobTypeEmail, EmailTo: ptr("Ops <ops@example.com>"), EmailSubject: ptr("Hi")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "email with address list", target: domain.JobTarget{Type: domain.JobTypeEmail, EmailTo: ptr("a@example.com, b@example.com"), EmailSubject: ptr("Hi")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "email subject with newline", target: domain.JobTarget{Type: domain.JobTypeEmail, EmailTo: ptr("ops@example.com"), EmailSubject: ptr("Hi\r\nBcc: x@example.com")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "email with url", target: domain.JobTarget{Type: domain.JobTypeEmail, EmailTo: ptr("ops@example.com"), EmailSubject: ptr("Hi"), URL: "https://example.com"}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "http with email_to", target: domain.JobTarget{Type: domain.JobTypeHTTP, URL: "https://example.com", Method: "POST", EmailTo: ptr("ops@example.com")}, wantErr: domain.ErrInvalidJobTarget},
+		{name: "unknown type", target: domain.JobTarget{Type: "smtp"}, wantErr: domain.ErrUnknownJobType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.target.Validate()
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Validate() = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoticeNotFound      = errors.New("notice not found")
+	ErrInvalidNoticeWindow = errors.New("notice ends_at must be after starts_at")
+)
+
+type NoticeSeverity string
+
+const (
+	NoticeSeverityInfo     NoticeSeverity = "info"
+	NoticeSeverityWarning  NoticeSeverity = "warning"
+	NoticeSeverityCritical NoticeSeverity = "critical"
+)
+
+// Notice is an operator-authored message shown to every user between StartsAt and
+// EndsAt (nil = until deleted).
+type Notice struct {
+	ID        string
+	Title     string
+	Body      string
+	Severity  NoticeSeverity
+	StartsAt  time.Time
+	EndsAt    *time.Time
+	CreatedBy string
+	CreatedAt time.Time
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotificationRuleNotFound = errors.New("notification rule not found")
+	ErrInvalidNotificationRule  = errors.New("invalid notification rule")
+)
+
+type NotificationEvent string
+
+const (
+	// NotifyJobFailed fires when a job fails permanently (retries exhausted or deadline passed).
+	NotifyJobFailed NotificationEvent = "job.failed"
+	// NotifyScheduleFailing fires when a schedule's last Threshold runs have all failed.
+	NotifyScheduleFailing NotificationEvent = "schedule.failing"
+	// NotifyDigestDaily and NotifyDigestWeekly send a summary of the user's schedule runs
+	// after each UTC day, or each week starting Monday.
+	NotifyDigestDaily  NotificationEvent = "digest.daily"
+	NotifyDigestWeekly NotificationEvent = "digest.weekly"
+	// NotifyQuotaWarning fires when a create crosses the soft-limit ratio of a quota.
+	NotifyQuotaWarning NotificationEvent = "quota.warning"
+	// NotifyApprovalRequested fires when members' jobs start awaiting an owner's approval.
+	NotifyApprovalRequested NotificationEvent = "approval.requested"
+)
+
+// IsDigest reports whether e is a periodic summary rather than a failure alert.
+func (e NotificationEvent) IsDigest() bool {
+	return e == NotifyDigestDaily || e == NotifyDigestWeekly
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSlack NotificationChannel = "slack"
+)
+
+// NotificationRule routes one kind of event to one channel target (an email address or
+// a Slack incoming-webhook URL). ScheduleID narrows the rule to a single schedule.
+type NotificationRule struct {
+	ID         string
+	UserID     string
+	ScheduleID *string
+	Event      NotificationEvent
+	Channel    NotificationChannel
+	Target     string
+	Threshold  int // consecutive failed runs; only used by NotifyScheduleFailing
+	CreatedAt  time.Time
+
+	// LastDigestAt is the end of the last period a digest rule was sent for; a new rule
+	// starts at its creation, so its first digest waits for the next boundary.
+	LastDigestAt time.Time
+}
+
+// Matches reports whether the rule applies to a failure of a job fired by scheduleID
+// (nil for a one-off job). Digest, quota and approval rules match no failure. The schedule.failing
+// threshold is checked separately.
+func (r *NotificationRule) Matches(scheduleID *string) bool {
+	if r.Event.IsDigest() || r.Event == NotifyQuotaWarning || r.Event == NotifyApprovalRequested || (r.Event == NotifyScheduleFailing && scheduleID == nil) {
+		return false
+	}
+	return r.ScheduleID == nil || (scheduleID != nil && *r.ScheduleID == *scheduleID)
+}
+
+// JobFailure is published by the worker and the reaper when a job fails permanently.
+type JobFailure struct {
+	JobID      string
+	UserID     string
+	ScheduleID *string
+	URL        string
+	Error      string
+	FailedAt   time.Time
+}
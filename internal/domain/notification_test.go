@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestNotificationRuleMatches(t *testing.T) {
+	sched, other := "s1", "s2"
+	tests := []struct {
+		name       string
+		rule       domain.NotificationRule
+		scheduleID *string
+		want       bool
+	}{
+		{"job rule, one-off job", domain.NotificationRule{Event: domain.NotifyJobFailed}, nil, true},
+		{"job rule, scheduled job", domain.NotificationRule{Event: domain.NotifyJobFailed}, &sched, true},
+		{"scoped job rule, one-off job", domain.NotificationRule{Event: domain.NotifyJobFailed, ScheduleID: &sched}, nil, false},
+		{"scoped job rule, other schedule", domain.NotificationRule{Event: domain.NotifyJobFailed, ScheduleID: &sched}, &other, false},
+		{"schedule rule, one-off job", domain.NotificationRule{Event: domain.NotifyScheduleFailing}, nil, false},
+		{"schedule rule, any schedule", domain.NotificationRule{Event: domain.NotifyScheduleFailing}, &other, true},
+		{"scoped schedule rule, same schedule", domain.NotificationRule{Event: domain.NotifyScheduleFailing, ScheduleID: &sched}, &sched, true},
+		{"digest rule", domain.NotificationRule{Event: domain.NotifyDigestDaily}, &sched, false},
+		{"quota rule", domain.NotificationRule{Event: domain.NotifyQuotaWarning}, nil, false},
+		{"approval rule", domain.NotificationRule{Event: domain.NotifyApprovalRequested}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.scheduleID); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOrgNotFound        = errors.New("organization not found")
+	ErrOrgForbidden       = errors.New("organization role does not allow this")
+	ErrInvalidOrgRole     = errors.New("invalid organization role")
+	ErrOrgMemberNotFound  = errors.New("organization member not found")
+	ErrLastOrgOwner       = errors.New("organization must keep at least one owner")
+	ErrAlreadyOrgMember   = errors.New("already a member of the organization")
+	ErrInvitationNotFound = errors.New("invitation not found")
+
+	// ErrKeyRefUnsupported is returned for key references when the deployment has no KMS
+	// configured; ErrInvalidKeyRef when the KMS can't encrypt and decrypt with the key.
+	ErrKeyRefUnsupported = errors.New("customer-managed encryption keys are not configured")
+	ErrInvalidKeyRef     = errors.New("encryption key is not usable")
+)
+
+// OrgRole is a member's role in an organization. Owners manage membership; editors and
+// owners can change the organization's jobs and schedules; viewers can only read them.
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleEditor OrgRole = "editor"
+	OrgRoleViewer OrgRole = "viewer"
+)
+
+// CanWrite reports whether the role may change jobs, schedules and settings.
+func (r OrgRole) CanWrite() bool {
+	return r == OrgRoleOwner || r == OrgRoleEditor
+}
+
+func (r OrgRole) Valid() bool {
+	switch r {
+	case OrgRoleOwner, OrgRoleEditor, OrgRoleViewer:
+		return true
+	}
+	return false
+}
+
+// InvitationTTL is how long an invitation can be accepted.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// Org is a team workspace. Its ID is also the ID of the account that owns the
+// organization's jobs, schedules, defaults and notification rules, so everything scoped
+// by user ID is scoped by organization when a member acts in it.
+type Org struct {
+	ID   string
+	Name string
+	// KMSKeyRef names the organization's own KMS key, which wraps the data keys of its
+	// newly sealed values. Nil uses the deployment's keys.
+	KMSKeyRef *string
+	// RequireApproval holds jobs created by members other than owners in
+	// StatusAwaitingApproval until an owner reviews them.
+	RequireApproval bool
+	CreatedAt       time.Time
+}
+
+// OrgMembership is an organization as seen by one of its members.
+type OrgMembership struct {
+	Org  *Org
+	Role OrgRole
+}
+
+type OrgMember struct {
+	OrgID     string
+	UserID    string
+	Email     *string
+	Role      OrgRole
+	CreatedAt time.Time
+}
+
+// OrgInvitation grants Role to whoever accepts it with its token before ExpiresAt. Only a
+// hash of the token is stored; Email records who the inviter meant it for.
+type OrgInvitation struct {
+	ID         string
+	OrgID      string
+	Email      *string
+	Role       OrgRole
+	InvitedBy  string
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidPageSize = errors.New("page size must be between 1 and 100")
+	ErrInvalidOrder    = errors.New("order must be asc or desc")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// SortOrder is the direction a list runs along its sort key (scheduled_at for jobs,
+// created_at for schedules), with the ID breaking ties.
+type SortOrder string
+
+const (
+	SortDesc SortOrder = "desc" // newest first, the default
+	SortAsc  SortOrder = "asc"
+)
+
+// ResolvePage fills in the default page size (for 0) and order (for "") and validates
+// both. A size outside 1..MaxPageSize is an error rather than being clamped, so a client
+// never gets a page shorter than it asked for without knowing why.
+func ResolvePage(size int, order SortOrder) (int, SortOrder, error) {
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	if size < 1 || size > MaxPageSize {
+		return 0, "", ErrInvalidPageSize
+	}
+	switch order {
+	case "":
+		order = SortDesc
+	case SortDesc, SortAsc:
+	default:
+		return 0, "", ErrInvalidOrder
+	}
+	return size, order, nil
+}
@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestResolvePage(t *testing.T) {
+	tests := []struct {
+		size      int
+		order     domain.SortOrder
+		wantSize  int
+		wantOrder domain.SortOrder
+		wantErr   error
+	}{
+		{size: 0, order: "", wantSize: domain.DefaultPageSize, wantOrder: domain.SortDesc},
+		{size: 1, order: domain.SortAsc, wantSize: 1, wantOrder: domain.SortAsc},
+		{size: domain.MaxPageSize, order: domain.SortDesc, wantSize: domain.MaxPageSize, wantOrder: domain.SortDesc},
+		{size: domain.MaxPageSize + 1, wantErr: domain.ErrInvalidPageSize},
+		{size: -1, wantErr: domain.ErrInvalidPageSize},
+		{size: 10, order: "DESC", wantErr: domain.ErrInvalidOrder},
+		{size: 10, order: "newest", wantErr: domain.ErrInvalidOrder},
+	}
+	for _, tt := range tests {
+		size, order, err := domain.ResolvePage(tt.size, tt.order)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("ResolvePage(%d, %q) err = %v, want %v", tt.size, tt.order, err, tt.wantErr)
+			continue
+		}
+		if size != tt.wantSize || order != tt.wantOrder {
+			t.Errorf("ResolvePage(%d, %q) = %d, %q; want %d, %q", tt.size, tt.order, size, order, tt.wantSize, tt.wantOrder)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var ErrInvalidPayloadTemplate = errors.New("invalid payload template")
+
+// TemplateVars are the values a templated job can embed in its URL, header values and
+// body, e.g. {{.ScheduledAt}} or {{.AttemptNum}}. They are expanded by the worker just
+// before each attempt.
+type TemplateVars struct {
+	JobID       string
+	ScheduleID  string       // empty for jobs not fired by a schedule
+	ScheduledAt TemplateTime // when the job was first due; unchanged across retries
+	AttemptNum  int          // 1-based
+}
+
+// TemplateTime renders as RFC 3339 in UTC; its time.Time methods stay available, so
+// {{.ScheduledAt.Unix}} and {{.ScheduledAt.Format "2006-01-02"}} work too.
+type TemplateTime struct {
+	time.Time
+}
+
+func (t TemplateTime) String() string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// TemplateVars returns the values for the job's next attempt.
+func (j *Job) TemplateVars() TemplateVars {
+	v := TemplateVars{
+		JobID:       j.ID,
+		ScheduledAt: TemplateTime{j.ScheduledAt},
+		AttemptNum:  j.RetryCount + 1,
+	}
+	if j.FirstDueAt != nil {
+		v.ScheduledAt = TemplateTime{*j.FirstDueAt}
+	}
+	if j.ScheduleID != nil {
+		v.ScheduleID = *j.ScheduleID
+	}
+	return v
+}
+
+// ValidatePayloadTemplate checks that url, every header value and body parse as templates
+// and only reference fields of TemplateVars.
+func ValidatePayloadTemplate(url string, headers map[string]string, body *string) error {
+	texts := []string{url}
+	for _, v := range headers {
+		texts = append(texts, v)
+	}
+	if body != nil {
+		texts = append(texts, *body)
+	}
+	for _, text := range texts {
+		if _, err := RenderPayloadTemplate(text, TemplateVars{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderPayloadTemplate expands text with vars. Unknown fields are an error rather than
+// rendering as "<no value>".
+func RenderPayloadTemplate(text string, vars TemplateVars) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("payload").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidPayloadTemplate, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidPayloadTemplate, err)
+	}
+	return b.String(), nil
+}
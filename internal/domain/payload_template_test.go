@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestRenderPayloadTemplate(t *testing.T) {
+	vars := domain.TemplateVars{
+		JobID:       "job-1",
+		ScheduleID:  "sched-1",
+		ScheduledAt: domain.TemplateTime{Time: time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("", 3600))},
+		AttemptNum:  2,
+	}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"https://example.com/report", "https://example.com/report"},
+		{"{{.JobID}}/{{.ScheduleID}}", "job-1/sched-1"},
+		{`{"at":"{{.ScheduledAt}}","attempt":{{.AttemptNum}}}`, `{"at":"2026-03-01T08:30:00Z","attempt":2}`},
+		{`{{.ScheduledAt.Format "2006-01-02"}}`, "2026-03-01"},
+	}
+	for _, tt := range tests {
+		got, err := domain.RenderPayloadTemplate(tt.text, vars)
+		if err != nil {
+			t.Errorf("RenderPayloadTemplate(%q): unexpected error %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("RenderPayloadTemplate(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePayloadTemplate(t *testing.T) {
+	body := `{"id":"{{.JobID}}"}`
+	if err := domain.ValidatePayloadTemplate("https://example.com/{{.AttemptNum}}", map[string]string{"X-Run": "{{.ScheduledAt}}"}, &body); err != nil {
+		t.Errorf("valid template: unexpected error %v", err)
+	}
+
+	for _, text := range []string{"{{.Unknown}}", "{{.JobID"} {
+		err := domain.ValidatePayloadTemplate("https://example.com", nil, &text)
+		if !errors.Is(err, domain.ErrInvalidPayloadTemplate) {
+			t.Errorf("ValidatePayloadTemplate(%q) = %v, want ErrInvalidPayloadTemplate", text, err)
+		}
+	}
+}
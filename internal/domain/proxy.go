@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+)
+
+var ErrInvalidProxyURL = errors.New("proxy url must be an http, https, socks5 or socks5h url with a host")
+
+const MaxProxyURLLen = 2048
+
+// ParseProxyURL parses a job's proxy URL. The schemes are those net/http's transport
+// can proxy through; credentials go in the userinfo.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	if len(raw) > MaxProxyURLLen {
+		return nil, ErrInvalidProxyURL
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, ErrInvalidProxyURL
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	default:
+		return nil, ErrInvalidProxyURL
+	}
+}
+
+func ValidateProxyURL(raw string) error {
+	_, err := ParseProxyURL(raw)
+	return err
+}
+
+// RedactProxyURL masks the password of a proxy URL for display; nil stays nil.
+func RedactProxyURL(raw *string) *string {
+	if raw == nil {
+		return nil
+	}
+	u, err := url.Parse(*raw)
+	if err != nil {
+		return nil
+	}
+	redacted := u.Redacted()
+	return &redacted
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+var ErrInvalidQueue = errors.New("invalid queue")
+
+// DefaultQueue is the queue of jobs and schedules created without one, and the queue a
+// worker serves when WORKER_QUEUES is unset.
+const DefaultQueue = "default"
+
+// Queue names are short lowercase slugs, e.g. "eu", "cpu-heavy".
+var queuePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ValidateQueue checks a queue name. Workers claim only the queues they are configured
+// for, so a job in a queue no worker serves stays pending until it expires.
+func ValidateQueue(name string) error {
+	if !queuePattern.MatchString(name) {
+		return ErrInvalidQueue
+	}
+	return nil
+}
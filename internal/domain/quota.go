@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+type QuotaKind string
+
+const (
+	QuotaPendingJobs     QuotaKind = "pending_jobs"
+	QuotaSchedules       QuotaKind = "schedules"
+	QuotaDailyExecutions QuotaKind = "daily_executions"
+)
+
+// QuotaWarningRatio is the fraction of a quota at which a soft-limit warning is raised.
+const QuotaWarningRatio = 0.8
+
+// Usage is a point-in-time snapshot of a user's consumption against each quota.
+type Usage struct {
+	PendingJobs     int
+	Schedules       int
+	DailyExecutions int
+}
+
+// QuotaStatus reports usage against a single quota. Limit 0 means unlimited.
+type QuotaStatus struct {
+	Kind     QuotaKind
+	Used     int
+	Limit    int
+	Warning  bool
+	Exceeded bool
+}
+
+// QuotaWarning is raised when a create takes a user across the soft-limit ratio of a quota.
+type QuotaWarning struct {
+	UserID   string
+	Kind     QuotaKind
+	Used     int
+	Limit    int
+	RaisedAt time.Time
+}
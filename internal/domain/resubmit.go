@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+)
+
+var ErrJobNotFinished = errors.New("only completed, failed, cancelled or expired jobs can be retried")
+
+// Finished reports whether s is terminal: the job will not run again on its own.
+func (s Status) Finished() bool {
+	switch s {
+	case StatusCompleted, StatusFailed, StatusCancelled, StatusExpired:
+		return true
+	}
+	return false
+}
+
+// ResubmitIdempotencyKey is the key of the job that resubmits jobID at now. Keys differ by
+// the millisecond, so an operator can retry the same job more than once.
+func ResubmitIdempotencyKey(jobID string, now time.Time) string {
+	return fmt.Sprintf("retry:%s:%d", jobID, now.UnixMilli())
+}
+
+// Resubmission clones a finished job into a new pending job due at now, with RetriedFrom
+// pointing back at j. The clone keeps j's target, payload and retry settings but starts a
+// fresh attempt history. Deadline and ExpiresAt keep their distance from when j was first
+// due. The clone belongs to no schedule and depends on no job: j's parent has already
+// finished, and a schedule's history should only hold the runs it fired.
+func (j *Job) Resubmission(now time.Time) (*Job, error) {
+	if !j.Status.Finished() {
+		return nil, ErrJobNotFinished
+	}
+
+	due := j.ScheduledAt
+	if j.FirstDueAt != nil {
+		due = *j.FirstDueAt
+	}
+	shift := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		shifted := now.Add(t.Sub(due))
+		return &shifted
+	}
+
+	clone := *j
+	clone.ID = ""
+	clone.IdempotencyKey = ResubmitIdempotencyKey(j.ID, now)
+	clone.Headers = maps.Clone(j.Headers)
+	clone.RetryDelays = append([]time.Duration(nil), j.RetryDelays...)
+	clone.SuccessCodes = append(SuccessCodes(nil), j.SuccessCodes...)
+	clone.Status = StatusPending
+	clone.ScheduledAt = now
+	clone.Deadline = shift(j.Deadline)
+	clone.ExpiresAt = shift(j.ExpiresAt)
+	clone.DependsOn = nil
+	clone.ScheduleID = nil
+	clone.Ping = false
+	clone.RetryCount = 0
+	clone.FirstDueAt = nil
+	clone.ClaimedAt = nil
+	clone.ClaimedBy = nil
+	clone.HeartbeatAt = nil
+	clone.CompletedAt = nil
+	clone.LastError = nil
+	clone.CancelRequestedAt = nil
+	clone.RequestID = nil
+	clone.TraceParent = nil
+	clone.CreatedBy = nil
+	clone.ReviewedBy = nil
+	clone.ReviewedAt = nil
+	clone.CreatedAt = time.Time{}
+	clone.UpdatedAt = time.Time{}
+	id := j.ID
+	clone.RetriedFrom = &id
+	return &clone, nil
+}
@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestJobResubmission(t *testing.T) {
+	due := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	now := due.Add(48 * time.Hour)
+	deadline := due.Add(time.Hour)
+	retriedAt := due.Add(10 * time.Minute)
+	scheduleID, parentID, lastErr := "sched-1", "job-0", "503 Service Unavailable"
+
+	job := &domain.Job{
+		ID:             "job-1",
+		IdempotencyKey: "original",
+		URL:            "https://example.com/hook",
+		Method:         "POST",
+		Headers:        map[string]string{"X-Env": "prod"},
+		Status:         domain.StatusFailed,
+		ScheduledAt:    retriedAt,
+		FirstDueAt:     &due,
+		Deadline:       &deadline,
+		RetryCount:     3,
+		MaxRetries:     3,
+		LastError:      &lastErr,
+		CompletedAt:    &retriedAt,
+		ScheduleID:     &scheduleID,
+		DependsOn:      &parentID,
+	}
+
+	clone, err := job.Resubmission(now)
+	if err != nil {
+		t.Fatalf("Resubmission: %v", err)
+	}
+	if clone.RetriedFrom == nil || *clone.RetriedFrom != "job-1" {
+		t.Errorf("RetriedFrom = %v, want job-1", clone.RetriedFrom)
+	}
+	if want := domain.ResubmitIdempotencyKey("job-1", now); clone.IdempotencyKey != want {
+		t.Errorf("IdempotencyKey = %q, want %q", clone.IdempotencyKey, want)
+	}
+	if clone.Status != domain.StatusPending || !clone.ScheduledAt.Equal(now) {
+		t.Errorf("Status, ScheduledAt = %s, %v; want pending, %v", clone.Status, clone.ScheduledAt, now)
+	}
+	if clone.Deadline == nil || !clone.Deadline.Equal(now.Add(time.Hour)) {
+		t.Errorf("Deadline = %v, want %v", clone.Deadline, now.Add(time.Hour))
+	}
+	if clone.RetryCount != 0 || clone.MaxRetries != 3 {
+		t.Errorf("RetryCount, MaxRetries = %d, %d; want 0, 3", clone.RetryCount, clone.MaxRetries)
+	}
+	if clone.ID != "" || clone.LastError != nil || clone.CompletedAt != nil || clone.FirstDueAt != nil {
+		t.Error("clone kept the original's run state")
+	}
+	if clone.ScheduleID != nil || clone.DependsOn != nil {
+		t.Error("clone kept the original's schedule or parent")
+	}
+	clone.Headers["X-Env"] = "staging"
+	if job.Headers["X-Env"] != "prod" {
+		t.Error("clone shares the original's headers")
+	}
+}
+
+func TestJobResubmissionNotFinished(t *testing.T) {
+	for _, status := range []domain.Status{
+		domain.StatusPending, domain.StatusRunning, domain.StatusPaused, domain.StatusBlocked,
+	} {
+		job := &domain.Job{ID: "job-1", Status: status}
+		if _, err := job.Resubmission(time.Now()); !errors.Is(err, domain.ErrJobNotFinished) {
+			t.Errorf("Resubmission of %s job: err = %v, want ErrJobNotFinished", status, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var ErrInvalidRetryBackoff = errors.New("retry base and max must be between 1s and 24h, with base <= max")
+
+// Jitter controls how a backoff delay is randomized, so that jobs which failed together
+// don't all retry at the same instant.
+type Jitter string
+
+const (
+	// JitterAuto spreads exponential backoff by ±25% and leaves other backoffs exact.
+	JitterAuto Jitter = "auto"
+	JitterNone Jitter = "none"
+	// JitterFull picks uniformly from [0, delay].
+	JitterFull Jitter = "full"
+	// JitterEqual picks uniformly from [delay/2, delay].
+	JitterEqual Jitter = "equal"
+)
+
+const (
+	DefaultRetryBaseSeconds = 30
+	DefaultRetryMaxSeconds  = 3600
+	DefaultJitter           = JitterAuto
+	MaxRetryBackoffSeconds  = 24 * 60 * 60
+)
+
+// ValidateRetryBackoff checks a retry base delay and cap, both in seconds.
+func ValidateRetryBackoff(baseSeconds, maxSeconds int) error {
+	if baseSeconds < 1 || maxSeconds > MaxRetryBackoffSeconds || baseSeconds > maxSeconds {
+		return ErrInvalidRetryBackoff
+	}
+	return nil
+}
+
+// RetryStrategy names what decides the delay before retry number retryCount (0-based):
+// "delays" while the job's RetryDelays list lasts, then its Backoff ("exponential",
+// "linear", or "fixed" for any other value).
+func (j *Job) RetryStrategy(retryCount int) string {
+	switch {
+	case retryCount < len(j.RetryDelays):
+		return "delays"
+	case j.Backoff == BackoffExponential, j.Backoff == BackoffLinear:
+		return string(j.Backoff)
+	default:
+		return "fixed"
+	}
+}
+
+// RetryDelay returns how long to wait before retry number retryCount (0-based). Explicit
+// RetryDelays take precedence; past the end of that list Backoff grows from
+// RetryBaseSeconds, is capped at RetryMaxSeconds and then randomized by RetryJitter.
+func (j *Job) RetryDelay(retryCount int) time.Duration {
+	if retryCount < len(j.RetryDelays) {
+		return j.RetryDelays[retryCount]
+	}
+
+	base := time.Duration(j.RetryBaseSeconds) * time.Second
+	limit := time.Duration(j.RetryMaxSeconds) * time.Second
+
+	var delay time.Duration
+	switch j.Backoff {
+	case BackoffExponential:
+		// Compare in float64 so large retry counts can't overflow time.Duration.
+		delay = time.Duration(math.Min(float64(base)*math.Pow(2, float64(retryCount)), float64(limit)))
+	case BackoffLinear:
+		delay = min(base*time.Duration(retryCount+1), limit)
+	default:
+		delay = base
+	}
+
+	if delay <= 0 {
+		return delay
+	}
+	switch j.RetryJitter {
+	case JitterFull:
+		return time.Duration(rand.Int63n(int64(delay) + 1))
+	case JitterEqual:
+		return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	case JitterAuto:
+		if j.Backoff == BackoffExponential && delay >= 4 {
+			return delay + time.Duration(rand.Int63n(int64(delay/2))) - delay/4
+		}
+	}
+	return delay
+}
@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestRetryDelay(t *testing.T) {
+	job := func(backoff domain.Backoff) *domain.Job {
+		return &domain.Job{Backoff: backoff, RetryBaseSeconds: 10, RetryMaxSeconds: 60, RetryJitter: domain.JitterNone}
+	}
+
+	tests := []struct {
+		name  string
+		job   *domain.Job
+		retry int
+		want  time.Duration
+	}{
+		{"exponential first", job(domain.BackoffExponential), 0, 10 * time.Second},
+		{"exponential doubles", job(domain.BackoffExponential), 2, 40 * time.Second},
+		{"exponential capped", job(domain.BackoffExponential), 3, time.Minute},
+		{"exponential huge retry count", job(domain.BackoffExponential), 500, time.Minute},
+		{"linear", job(domain.BackoffLinear), 2, 30 * time.Second},
+		{"linear capped", job(domain.BackoffLinear), 9, time.Minute},
+		{"explicit delays win", &domain.Job{
+			Backoff: domain.BackoffExponential, RetryBaseSeconds: 10, RetryMaxSeconds: 60,
+			RetryDelays: []time.Duration{time.Second},
+		}, 0, time.Second},
+	}
+	for _, tt := range tests {
+		if got := tt.job.RetryDelay(tt.retry); got != tt.want {
+			t.Errorf("%s: RetryDelay(%d) = %v, want %v", tt.name, tt.retry, got, tt.want)
+		}
+	}
+}
+
+func TestRetryStrategy(t *testing.T) {
+	delays := []time.Duration{time.Second, time.Minute}
+	tests := []struct {
+		name  string
+		job   *domain.Job
+		retry int
+		want  string
+	}{
+		{"exponential", &domain.Job{Backoff: domain.BackoffExponential}, 0, "exponential"},
+		{"linear", &domain.Job{Backoff: domain.BackoffLinear}, 3, "linear"},
+		{"fixed", &domain.Job{Backoff: "fixed"}, 0, "fixed"},
+		{"unset", &domain.Job{}, 0, "fixed"},
+		{"explicit delays", &domain.Job{Backoff: domain.BackoffExponential, RetryDelays: delays}, 1, "delays"},
+		{"past explicit delays", &domain.Job{Backoff: domain.BackoffExponential, RetryDelays: delays}, 2, "exponential"},
+	}
+	for _, tt := range tests {
+		if got := tt.job.RetryStrategy(tt.retry); got != tt.want {
+			t.Errorf("%s: RetryStrategy(%d) = %q, want %q", tt.name, tt.retry, got, tt.want)
+		}
+	}
+}
+
+func TestRetryDelayJitterBounds(t *testing.T) {
+	tests := []struct {
+		jitter   domain.Jitter
+		min, max time.Duration
+	}{
+		{domain.JitterFull, 0, 40 * time.Second},
+		{domain.JitterEqual, 20 * time.Second, 40 * time.Second},
+		{domain.JitterAuto, 30 * time.Second, 50 * time.Second},
+	}
+	for _, tt := range tests {
+		j := &domain.Job{Backoff: domain.BackoffExponential, RetryBaseSeconds: 10, RetryMaxSeconds: 60, RetryJitter: tt.jitter}
+		for range 100 {
+			if got := j.RetryDelay(2); got < tt.min || got > tt.max {
+				t.Fatalf("jitter %s: RetryDelay(2) = %v, want within [%v, %v]", tt.jitter, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestValidateRetryBackoff(t *testing.T) {
+	tests := []struct {
+		base, max int
+		valid     bool
+	}{
+		{30, 3600, true},
+		{1, 1, true},
+		{0, 60, false},
+		{60, 30, false},
+		{1, 86401, false},
+	}
+	for _, tt := range tests {
+		if err := domain.ValidateRetryBackoff(tt.base, tt.max); (err == nil) != tt.valid {
+			t.Errorf("ValidateRetryBackoff(%d, %d) = %v, want valid=%v", tt.base, tt.max, err, tt.valid)
+		}
+	}
+}
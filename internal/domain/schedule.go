@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"math/rand"
 	"time"
 )
 
@@ -11,6 +12,118 @@ var (
 	ErrScheduleAlreadyPaused = errors.New("schedule is already paused")
 	ErrScheduleNotPaused     = errors.New("schedule is not paused")
 	ErrScheduleNameConflict  = errors.New("schedule with this name already exists")
+	ErrInvalidPingSchedule   = errors.New("ping schedules must be http jobs using HEAD or GET with no body")
+	ErrNotPingSchedule       = errors.New("schedule is not a ping schedule")
+	ErrInvalidTimezone       = errors.New("invalid timezone")
+	ErrInvalidInterval       = errors.New("invalid interval")
+	ErrInvalidCadence        = errors.New("schedule needs exactly one of cron_expr or every")
+	ErrInvalidScheduleJitter = errors.New("invalid schedule jitter")
+	ErrInvalidPauseThreshold = errors.New("invalid pause_after_failures")
+)
+
+// MinInterval is the shortest fixed cadence a schedule may use. Runs are also never closer
+// together than the scheduler's dispatch interval.
+const MinInterval = 5 * time.Second
+
+// Interval is a fixed schedule cadence, an alternative to a cron expression for cadences
+// cron can't express ("every 90s"). It is written in JSON as a duration string.
+type Interval time.Duration
+
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(i).String()), nil
+}
+
+func (i *Interval) UnmarshalText(b []byte) error {
+	d, err := time.ParseDuration(string(b))
+	if err != nil {
+		return ErrInvalidInterval
+	}
+	*i = Interval(d)
+	return nil
+}
+
+// Validate rejects intervals shorter than MinInterval. The zero value means "not set"
+// and is valid.
+func (i Interval) Validate() error {
+	if i != 0 && time.Duration(i) < MinInterval {
+		return ErrInvalidInterval
+	}
+	return nil
+}
+
+// MaxScheduleJitterSeconds caps Schedule.JitterSeconds.
+const MaxScheduleJitterSeconds = 3600
+
+// ValidateScheduleJitter checks jitterSeconds against MaxScheduleJitterSeconds and, for
+// interval schedules, keeps it below the interval so fires stay in order.
+func ValidateScheduleJitter(jitterSeconds int, every Interval) error {
+	if jitterSeconds < 0 || jitterSeconds > MaxScheduleJitterSeconds {
+		return ErrInvalidScheduleJitter
+	}
+	if every != 0 && time.Duration(jitterSeconds)*time.Second >= time.Duration(every) {
+		return ErrInvalidScheduleJitter
+	}
+	return nil
+}
+
+// DefaultTimezone is the zone a schedule's cron expression is evaluated in when none is given.
+const DefaultTimezone = "UTC"
+
+// LoadTimezone resolves an IANA zone name such as "Europe/Berlin". Empty means
+// DefaultTimezone; "Local" is rejected because it would depend on the server's zone.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		name = DefaultTimezone
+	}
+	if name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// MaxPauseAfterFailures caps Schedule.PauseAfterFailures.
+const MaxPauseAfterFailures = 100
+
+// SystemActorID is the actor of revisions the scheduler records itself, such as an
+// automatic pause after repeated failures.
+const SystemActorID = "system"
+
+// ValidatePauseAfterFailures checks n against MaxPauseAfterFailures. Ping checks never
+// fail jobs, so ping schedules can't use it.
+func ValidatePauseAfterFailures(n int, mode ScheduleMode) error {
+	if n < 0 || n > MaxPauseAfterFailures || (n > 0 && mode == ScheduleModePing) {
+		return ErrInvalidPauseThreshold
+	}
+	return nil
+}
+
+type ScheduleMode string
+
+const (
+	ScheduleModeStandard ScheduleMode = "standard"
+	// ScheduleModePing is for frequent, tiny uptime checks: no attempt history is kept,
+	// only hourly rollups and downtime incidents.
+	ScheduleModePing ScheduleMode = "ping"
+)
+
+// OverlapPolicy decides what a schedule's fire does while the job from an earlier fire
+// is still pending or running.
+type OverlapPolicy string
+
+const (
+	// OverlapQueue fires anyway, so runs may overlap.
+	OverlapQueue OverlapPolicy = "queue"
+	// OverlapSkip drops the fire; the schedule moves on to its next run time.
+	OverlapSkip OverlapPolicy = "skip"
+	// OverlapReplace cancels the earlier job — a running one cooperatively, at its next
+	// heartbeat — and fires.
+	OverlapReplace OverlapPolicy = "replace"
+
+	DefaultOverlapPolicy = OverlapQueue
 )
 
 type Schedule struct {
@@ -18,16 +131,85 @@ type Schedule struct {
 	UserID         string
 	Name           string
 	CronExpr       string
+	Timezone       string   // IANA name the cron expression is evaluated in
+	Every          Interval // set instead of CronExpr for fixed-interval schedules
 	URL            string
 	Method         string
+	Topic          *string // passed on to fired jobs; see Job.Topic
+	MessageKey     *string // passed on to fired jobs; see Job.Topic
+	EmailTo        *string // passed on to fired jobs; see Job.EmailTo
+	EmailSubject   *string // passed on to fired jobs; see Job.EmailTo
 	Headers        map[string]string
 	Body           *string
+	BodyEncoding   BodyEncoding // passed on to fired jobs, with ContentType; see Job.BodyEncoding
+	ContentType    *string
 	TimeoutSeconds int
 	MaxRetries     int
 	Backoff        Backoff
-	Paused         bool
-	NextRunAt      time.Time
+	// RetryBaseSeconds, RetryMaxSeconds and RetryJitter are passed on to fired jobs.
+	RetryBaseSeconds int
+	RetryMaxSeconds  int
+	RetryJitter      Jitter
+	SuccessCodes     SuccessCodes
+	Templated        bool // passed on to fired jobs; see Job.Templated
+	OverlapPolicy    OverlapPolicy
+	JitterSeconds    int     // fired jobs start up to this much after the nominal fire time
+	SigningSecret    *string // passed on to fired jobs; see Job.SigningSecret
+	ProxyURL         *string // passed on to fired jobs; see Job.ProxyURL
+	ClientCertID     *string // passed on to fired jobs; see Job.ClientCertID
+	CABundle         *string // passed on to fired jobs; see Job.CABundle
+	TLSSkipVerify    bool    // passed on to fired jobs; see Job.CABundle
+	Queue            string  // passed on to fired jobs; see Job.Queue
+	Paused           bool
+	Mode             ScheduleMode
+	JobType          JobType // fixed at creation, like Mode; passed on to fired jobs
+	NextRunAt        time.Time
+	LastRunAt        *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	// PauseAfterFailures pauses the schedule once ConsecutiveFailures reaches it; 0 never
+	// does. ConsecutiveFailures counts permanently failed runs since the last completed
+	// one or the last resume, and is maintained by the database.
+	PauseAfterFailures  int
+	ConsecutiveFailures int
+}
+
+// FireOffset picks the delay, uniform in [0, JitterSeconds], that one fire's job waits
+// past the nominal fire time.
+func (s *Schedule) FireOffset() time.Duration {
+	if s.JitterSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.JitterSeconds)*int64(time.Second) + 1))
+}
+
+// ScheduleRunSummary aggregates the jobs a schedule has fired. Last* describe the most
+// recent job that reached a terminal state and are nil if none has yet.
+type ScheduleRunSummary struct {
+	TotalRuns      int
+	CompletedRuns  int
+	FailedRuns     int
+	LastStatus     *Status
 	LastRunAt      *time.Time
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	LastFinishedAt *time.Time
+	LastError      *string
+}
+
+// PingIncident is a contiguous run of failed checks. EndedAt is nil while ongoing.
+type PingIncident struct {
+	StartedAt time.Time
+	EndedAt   *time.Time
+	LastError *string
+}
+
+// ScheduleUptime summarises a ping schedule's checks since a point in time.
+type ScheduleUptime struct {
+	ScheduleID      string
+	Since           time.Time
+	Checks          int
+	Failures        int
+	AvgDurationMS   int64
+	DowntimeSeconds float64
+	Incidents       []PingIncident
 }
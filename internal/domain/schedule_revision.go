@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("schedule revision not found")
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate RevisionAction = "create"
+	RevisionActionUpdate RevisionAction = "update"
+	RevisionActionPause  RevisionAction = "pause"
+	RevisionActionResume RevisionAction = "resume"
+	RevisionActionRevert RevisionAction = "revert"
+	RevisionActionSync   RevisionAction = "sync" // an update made by PUT /schedules/sync
+)
+
+// ScheduleSpec is the user-editable configuration of a schedule — what a revision
+// snapshots. Mode and JobType are fixed at creation and next_run_at is derived, so none
+// of them is included.
+// The signing secret and proxy URL are never snapshotted; Signed and Proxied record only
+// whether one was set.
+type ScheduleSpec struct {
+	Name             string            `json:"name"`
+	CronExpr         string            `json:"cron_expr"`
+	Timezone         string            `json:"timezone,omitempty"`
+	Every            Interval          `json:"every,omitempty"`
+	URL              string            `json:"url"`
+	Method           string            `json:"method"`
+	Topic            *string           `json:"topic,omitempty"`
+	MessageKey       *string           `json:"message_key,omitempty"`
+	EmailTo          *string           `json:"email_to,omitempty"`
+	EmailSubject     *string           `json:"email_subject,omitempty"`
+	Headers          map[string]string `json:"headers,omitempty"`
+	Body             *string           `json:"body,omitempty"`
+	BodyEncoding     BodyEncoding      `json:"body_encoding,omitempty"` // empty in revisions recorded before body encodings existed
+	ContentType      *string           `json:"content_type,omitempty"`
+	TimeoutSeconds   int               `json:"timeout_seconds"`
+	MaxRetries       int               `json:"max_retries"`
+	Backoff          Backoff           `json:"backoff"`
+	RetryBaseSeconds int               `json:"retry_base_seconds,omitempty"`
+	RetryMaxSeconds  int               `json:"retry_max_seconds,omitempty"`
+	RetryJitter      Jitter            `json:"retry_jitter,omitempty"`
+	SuccessCodes     SuccessCodes      `json:"success_codes,omitempty"`
+	Templated        bool              `json:"templated,omitempty"`
+	OverlapPolicy    OverlapPolicy     `json:"overlap_policy,omitempty"`
+	JitterSeconds    int               `json:"jitter_seconds,omitempty"`
+	Signed           bool              `json:"signed,omitempty"`
+	Proxied          bool              `json:"proxied,omitempty"`
+	ClientCertID     *string           `json:"client_cert_id,omitempty"`
+	CABundle         *string           `json:"ca_bundle,omitempty"`
+	TLSSkipVerify    bool              `json:"tls_skip_verify,omitempty"`
+	Queue            string            `json:"queue,omitempty"` // empty in revisions recorded before queues existed
+	PauseAfter       int               `json:"pause_after_failures,omitempty"`
+	Paused           bool              `json:"paused"`
+}
+
+func (s *Schedule) Spec() ScheduleSpec {
+	return ScheduleSpec{
+		Name:             s.Name,
+		CronExpr:         s.CronExpr,
+		Timezone:         s.Timezone,
+		Every:            s.Every,
+		URL:              s.URL,
+		Method:           s.Method,
+		Topic:            s.Topic,
+		MessageKey:       s.MessageKey,
+		EmailTo:          s.EmailTo,
+		EmailSubject:     s.EmailSubject,
+		Headers:          s.Headers,
+		Body:             s.Body,
+		BodyEncoding:     s.BodyEncoding,
+		ContentType:      s.ContentType,
+		TimeoutSeconds:   s.TimeoutSeconds,
+		MaxRetries:       s.MaxRetries,
+		Backoff:          s.Backoff,
+		RetryBaseSeconds: s.RetryBaseSeconds,
+		RetryMaxSeconds:  s.RetryMaxSeconds,
+		RetryJitter:      s.RetryJitter,
+		SuccessCodes:     s.SuccessCodes,
+		Templated:        s.Templated,
+		OverlapPolicy:    s.OverlapPolicy,
+		JitterSeconds:    s.JitterSeconds,
+		Signed:           s.SigningSecret != nil,
+		Proxied:          s.ProxyURL != nil,
+		ClientCertID:     s.ClientCertID,
+		CABundle:         s.CABundle,
+		TLSSkipVerify:    s.TLSSkipVerify,
+		Queue:            s.Queue,
+		PauseAfter:       s.PauseAfterFailures,
+		Paused:           s.Paused,
+	}
+}
+
+// ApplySpec overwrites the schedule's editable fields with spec. NextRunAt is left to
+// the caller, which must recompute it when the cron expression changes. The signing
+// secret and proxy URL aren't part of a spec, so they are left as is.
+func (s *Schedule) ApplySpec(spec ScheduleSpec) {
+	s.Name = spec.Name
+	s.CronExpr = spec.CronExpr
+	s.Timezone = spec.Timezone
+	s.Every = spec.Every
+	s.URL = spec.URL
+	s.Method = spec.Method
+	s.Topic = spec.Topic
+	s.MessageKey = spec.MessageKey
+	s.EmailTo = spec.EmailTo
+	s.EmailSubject = spec.EmailSubject
+	s.Headers = spec.Headers
+	s.Body = spec.Body
+	s.BodyEncoding = cmp.Or(spec.BodyEncoding, BodyEncodingUTF8)
+	s.ContentType = spec.ContentType
+	s.TimeoutSeconds = spec.TimeoutSeconds
+	s.MaxRetries = spec.MaxRetries
+	s.Backoff = spec.Backoff
+	s.RetryBaseSeconds = spec.RetryBaseSeconds
+	s.RetryMaxSeconds = spec.RetryMaxSeconds
+	s.RetryJitter = spec.RetryJitter
+	s.SuccessCodes = spec.SuccessCodes
+	s.Templated = spec.Templated
+	s.OverlapPolicy = spec.OverlapPolicy
+	s.JitterSeconds = spec.JitterSeconds
+	s.ClientCertID = spec.ClientCertID
+	s.CABundle = spec.CABundle
+	s.TLSSkipVerify = spec.TLSSkipVerify
+	s.Queue = cmp.Or(spec.Queue, DefaultQueue)
+	s.PauseAfterFailures = spec.PauseAfter
+	s.Paused = spec.Paused
+}
+
+// ChangedFields lists the JSON names of the fields that differ between two specs.
+func ChangedFields(before, after ScheduleSpec) []string {
+	var changed []string
+	add := func(field string, differs bool) {
+		if differs {
+			changed = append(changed, field)
+		}
+	}
+	add("name", before.Name != after.Name)
+	add("cron_expr", before.CronExpr != after.CronExpr)
+	add("timezone", before.Timezone != after.Timezone)
+	add("every", before.Every != after.Every)
+	add("url", before.URL != after.URL)
+	add("method", before.Method != after.Method)
+	add("topic", deref(before.Topic) != deref(after.Topic))
+	add("message_key", deref(before.MessageKey) != deref(after.MessageKey))
+	add("email_to", deref(before.EmailTo) != deref(after.EmailTo))
+	add("email_subject", deref(before.EmailSubject) != deref(after.EmailSubject))
+	add("headers", !maps.Equal(before.Headers, after.Headers))
+	add("body", deref(before.Body) != deref(after.Body))
+	add("body_encoding", before.BodyEncoding != after.BodyEncoding)
+	add("content_type", deref(before.ContentType) != deref(after.ContentType))
+	add("timeout_seconds", before.TimeoutSeconds != after.TimeoutSeconds)
+	add("max_retries", before.MaxRetries != after.MaxRetries)
+	add("backoff", before.Backoff != after.Backoff)
+	add("retry_base_seconds", before.RetryBaseSeconds != after.RetryBaseSeconds)
+	add("retry_max_seconds", before.RetryMaxSeconds != after.RetryMaxSeconds)
+	add("retry_jitter", before.RetryJitter != after.RetryJitter)
+	add("success_codes", !slices.Equal(before.SuccessCodes, after.SuccessCodes))
+	add("templated", before.Templated != after.Templated)
+	add("overlap_policy", before.OverlapPolicy != after.OverlapPolicy)
+	add("jitter_seconds", before.JitterSeconds != after.JitterSeconds)
+	add("signed", before.Signed != after.Signed)
+	add("proxied", before.Proxied != after.Proxied)
+	add("client_cert_id", deref(before.ClientCertID) != deref(after.ClientCertID))
+	add("ca_bundle", deref(before.CABundle) != deref(after.CABundle))
+	add("tls_skip_verify", before.TLSSkipVerify != after.TLSSkipVerify)
+	add("queue", before.Queue != after.Queue)
+	add("pause_after_failures", before.PauseAfter != after.PauseAfter)
+	add("paused", before.Paused != after.Paused)
+	return changed
+}
+
+// ScheduleRevision records one mutation of a schedule. Before is nil for the create revision.
+type ScheduleRevision struct {
+	ID         string
+	ScheduleID string
+	Revision   int
+	Action     RevisionAction
+	ActorID    string
+	Before     *ScheduleSpec
+	After      ScheduleSpec
+	CreatedAt  time.Time
+}
+
+// Changed lists the fields this revision modified; nil for the create revision.
+func (r *ScheduleRevision) Changed() []string {
+	if r.Before == nil {
+		return nil
+	}
+	return ChangedFields(*r.Before, r.After)
+}
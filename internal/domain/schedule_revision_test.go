@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestChangedFields(t *testing.T) {
+	before := domain.ScheduleSpec{
+		Name: "nightly", CronExpr: "0 3 * * *", URL: "https://example.com/a", Method: "POST",
+		Headers: map[string]string{"X-Env": "prod"}, Body: ptr("{}"), TimeoutSeconds: 30,
+	}
+
+	if got := domain.ChangedFields(before, before); len(got) != 0 {
+		t.Errorf("identical specs: got %v, want no changes", got)
+	}
+
+	after := before
+	after.CronExpr = "0 4 * * *"
+	after.Headers = map[string]string{"X-Env": "staging"}
+	after.Body = ptr("{}") // same content, different pointer
+	after.Paused = true
+
+	want := []string{"cron_expr", "headers", "paused"}
+	if got := domain.ChangedFields(before, after); !slices.Equal(got, want) {
+		t.Errorf("ChangedFields() = %v, want %v", got, want)
+	}
+}
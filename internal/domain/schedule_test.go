@@ -0,0 +1,125 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "", want: "UTC"},
+		{name: "UTC", want: "UTC"},
+		{name: "America/New_York", want: "America/New_York"},
+		{name: "Local", wantErr: true},
+		{name: "Mars/Olympus_Mons", wantErr: true},
+	}
+	for _, tt := range tests {
+		loc, err := domain.LoadTimezone(tt.name)
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrInvalidTimezone) {
+				t.Errorf("LoadTimezone(%q) err = %v, want ErrInvalidTimezone", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("LoadTimezone(%q) unexpected error: %v", tt.name, err)
+			continue
+		}
+		if loc.String() != tt.want {
+			t.Errorf("LoadTimezone(%q) = %s, want %s", tt.name, loc, tt.want)
+		}
+	}
+}
+
+func TestInterval_JSONAndValidate(t *testing.T) {
+	var spec domain.ScheduleSpec
+	if err := json.Unmarshal([]byte(`{"every":"1m30s"}`), &spec); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if time.Duration(spec.Every) != 90*time.Second {
+		t.Fatalf("Every = %v, want 1m30s", time.Duration(spec.Every))
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"every":"1m30s"`) {
+		t.Errorf("marshal = %s, want every as a duration string", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"every":"soon"}`), &spec); !errors.Is(err, domain.ErrInvalidInterval) {
+		t.Errorf("unmarshal bad duration: err = %v, want ErrInvalidInterval", err)
+	}
+	if err := domain.Interval(time.Second).Validate(); !errors.Is(err, domain.ErrInvalidInterval) {
+		t.Errorf("Validate(1s) = %v, want ErrInvalidInterval", err)
+	}
+	if err := domain.Interval(0).Validate(); err != nil {
+		t.Errorf("Validate(0) = %v, want nil", err)
+	}
+}
+
+func TestValidateScheduleJitter(t *testing.T) {
+	tests := []struct {
+		seconds int
+		every   domain.Interval
+		wantErr bool
+	}{
+		{seconds: 0},
+		{seconds: 300},
+		{seconds: domain.MaxScheduleJitterSeconds},
+		{seconds: -1, wantErr: true},
+		{seconds: domain.MaxScheduleJitterSeconds + 1, wantErr: true},
+		{seconds: 59, every: domain.Interval(time.Minute)},
+		{seconds: 60, every: domain.Interval(time.Minute), wantErr: true},
+	}
+	for _, tt := range tests {
+		err := domain.ValidateScheduleJitter(tt.seconds, tt.every)
+		if tt.wantErr != errors.Is(err, domain.ErrInvalidScheduleJitter) {
+			t.Errorf("ValidateScheduleJitter(%d, %v) = %v, wantErr %v", tt.seconds, time.Duration(tt.every), err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidatePauseAfterFailures(t *testing.T) {
+	tests := []struct {
+		n       int
+		mode    domain.ScheduleMode
+		wantErr bool
+	}{
+		{n: 0, mode: domain.ScheduleModeStandard},
+		{n: 3, mode: domain.ScheduleModeStandard},
+		{n: domain.MaxPauseAfterFailures, mode: domain.ScheduleModeStandard},
+		{n: 0, mode: domain.ScheduleModePing},
+		{n: -1, mode: domain.ScheduleModeStandard, wantErr: true},
+		{n: domain.MaxPauseAfterFailures + 1, mode: domain.ScheduleModeStandard, wantErr: true},
+		{n: 3, mode: domain.ScheduleModePing, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := domain.ValidatePauseAfterFailures(tt.n, tt.mode)
+		if tt.wantErr != errors.Is(err, domain.ErrInvalidPauseThreshold) {
+			t.Errorf("ValidatePauseAfterFailures(%d, %s) = %v, wantErr %v", tt.n, tt.mode, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScheduleFireOffset(t *testing.T) {
+	if got := (&domain.Schedule{}).FireOffset(); got != 0 {
+		t.Errorf("FireOffset without jitter = %v, want 0", got)
+	}
+
+	s := &domain.Schedule{JitterSeconds: 30}
+	for range 1000 {
+		if got := s.FireOffset(); got < 0 || got > 30*time.Second {
+			t.Fatalf("FireOffset = %v, want within [0, 30s]", got)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// SealedPrefix marks a value encrypted at rest (see package secrets). Stored plaintext
+// starting with it would be read back as ciphertext, so user-supplied values can't.
+const SealedPrefix = "enc:v1:"
+
+var ErrSealedPrefix = errors.New(`header values, bodies and signing secrets can't start with "` + SealedPrefix + `"`)
+
+// ValidateUnsealed rejects header values and other stored values (bodies, signing
+// secrets; nil is skipped) that start with SealedPrefix.
+func ValidateUnsealed(headers map[string]string, values ...*string) error {
+	for _, v := range headers {
+		if strings.HasPrefix(v, SealedPrefix) {
+			return ErrSealedPrefix
+		}
+	}
+	for _, v := range values {
+		if v != nil && strings.HasPrefix(*v, SealedPrefix) {
+			return ErrSealedPrefix
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestValidateUnsealed(t *testing.T) {
+	if err := domain.ValidateUnsealed(map[string]string{"Authorization": "Bearer enc:v1:x"}, ptr("enc:v2"), nil); err != nil {
+		t.Errorf("ValidateUnsealed() = %v, want nil", err)
+	}
+	for name, tc := range map[string]struct {
+		headers map[string]string
+		value   *string
+	}{
+		"header": {headers: map[string]string{"X-Token": "enc:v1:abc"}},
+		"body":   {value: ptr("enc:v1:abc:def:ghi")},
+	} {
+		if err := domain.ValidateUnsealed(tc.headers, tc.value); !errors.Is(err, domain.ErrSealedPrefix) {
+			t.Errorf("%s: ValidateUnsealed() = %v, want ErrSealedPrefix", name, err)
+		}
+	}
+}
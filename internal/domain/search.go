@@ -0,0 +1,10 @@
+package domain
+
+import "errors"
+
+var ErrInvalidSearchQuery = errors.New("search query must be 2 to 200 characters")
+
+const (
+	MinSearchQueryLength = 2
+	MaxSearchQueryLength = 200
+)
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSigningSecret = errors.New("invalid signing secret")
+
+// SignatureHeader carries the signature of a signed job's request.
+const SignatureHeader = "X-Signature"
+
+const (
+	MinSigningSecretLen = 16
+	MaxSigningSecretLen = 256
+)
+
+func ValidateSigningSecret(secret string) error {
+	if len(secret) < MinSigningSecretLen || len(secret) > MaxSigningSecretLen {
+		return ErrInvalidSigningSecret
+	}
+	return nil
+}
+
+// SignRequest returns the X-Signature value for a request body sent at ts:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">". Receivers recompute
+// the HMAC with their copy of the secret and reject stale timestamps to stop replays.
+func SignRequest(secret string, ts time.Time, body []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	return "t=" + unix + ",v1=" + signature(secret, unix, body)
+}
+
+// VerifySignature checks header against body and rejects signatures older than
+// tolerance. It is what a receiver runs; the scheduler itself only signs.
+func VerifySignature(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var unix, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			unix = v
+		case "v1":
+			sig = v
+		}
+	}
+	ts, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || sig == "" {
+		return fmt.Errorf("malformed signature header %q", header)
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("signature timestamp outside tolerance: %s", age)
+	}
+	if !hmac.Equal([]byte(sig), []byte(signature(secret, unix, body))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func signature(secret, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
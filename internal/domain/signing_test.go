@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestSignRequest(t *testing.T) {
+	const secret = "0123456789abcdef"
+	ts := time.Unix(1700000000, 0)
+	body := []byte(`{"hello":"world"}`)
+
+	header := domain.SignRequest(secret, ts, body)
+	// Fixed vector so receivers in other languages can check their implementation.
+	const want = "t=1700000000,v1=66997eb7c1d13335f141deda66669e544a2c7f62745300308aec8f7042fb18be"
+	if header != want {
+		t.Fatalf("SignRequest() = %q, want %q", header, want)
+	}
+
+	if err := domain.VerifySignature(secret, header, body, ts.Add(time.Minute), 5*time.Minute); err != nil {
+		t.Errorf("VerifySignature() = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   string
+		now    time.Time
+	}{
+		{"wrong secret", "fedcba9876543210", header, string(body), ts},
+		{"tampered body", secret, header, `{"hello":"there"}`, ts},
+		{"stale", secret, header, string(body), ts.Add(10 * time.Minute)},
+		{"malformed", secret, "v1=abc", string(body), ts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := domain.VerifySignature(tt.secret, tt.header, []byte(tt.body), tt.now, 5*time.Minute); err == nil {
+				t.Error("VerifySignature() = nil, want error")
+			}
+		})
+	}
+}
+
+func TestValidateSigningSecret(t *testing.T) {
+	for _, secret := range []string{"", "short"} {
+		if err := domain.ValidateSigningSecret(secret); err == nil {
+			t.Errorf("ValidateSigningSecret(%q) = nil, want error", secret)
+		}
+	}
+	if err := domain.ValidateSigningSecret("0123456789abcdef"); err != nil {
+		t.Errorf("ValidateSigningSecret() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// JobStats summarises the jobs scheduled within a window and their attempts. Durations
+// are nil when no attempt in the window completed with a measured duration.
+type JobStats struct {
+	Since          time.Time
+	Counts         map[Status]int64 // statuses with no jobs are absent
+	Attempts       int64            // finished attempts, excluding cancelled ones
+	FailedAttempts int64
+	P50DurationMS  *int64
+	P95DurationMS  *int64
+}
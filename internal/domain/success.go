@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+var ErrInvalidSuccessCodes = errors.New("success codes must be status codes (100-599) or classes like 2xx, at most 20 entries")
+
+const MaxSuccessCodes = 20
+
+// SuccessCodes lists the HTTP statuses that count as a successful execution: exact codes
+// ("204") or whole classes ("2xx"). Empty means 200 only.
+type SuccessCodes []string
+
+func (c SuccessCodes) Validate() error {
+	if len(c) > MaxSuccessCodes {
+		return ErrInvalidSuccessCodes
+	}
+	for _, code := range c {
+		if _, ok := parseStatusClass(code); ok {
+			continue
+		}
+		if n, err := strconv.Atoi(code); err != nil || len(code) != 3 || n < 100 || n > 599 {
+			return ErrInvalidSuccessCodes
+		}
+	}
+	return nil
+}
+
+// Matches reports whether status counts as success.
+func (c SuccessCodes) Matches(status int) bool {
+	if len(c) == 0 {
+		return status == http.StatusOK
+	}
+	for _, code := range c {
+		if class, ok := parseStatusClass(code); ok {
+			if status/100 == class {
+				return true
+			}
+		} else if strconv.Itoa(status) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStatusClass parses "1xx" through "5xx".
+func parseStatusClass(code string) (int, bool) {
+	if len(code) != 3 || code[1:] != "xx" || code[0] < '1' || code[0] > '5' {
+		return 0, false
+	}
+	return int(code[0] - '0'), true
+}
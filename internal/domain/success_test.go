@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestSuccessCodesMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		codes  domain.SuccessCodes
+		status int
+		want   bool
+	}{
+		{"default accepts 200", nil, 200, true},
+		{"default rejects 204", nil, 204, false},
+		{"exact code", domain.SuccessCodes{"200", "204"}, 204, true},
+		{"exact code miss", domain.SuccessCodes{"200", "204"}, 201, false},
+		{"class", domain.SuccessCodes{"2xx"}, 202, true},
+		{"class miss", domain.SuccessCodes{"2xx"}, 301, false},
+		{"mixed", domain.SuccessCodes{"2xx", "404"}, 404, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.codes.Matches(tt.status); got != tt.want {
+				t.Errorf("Matches(%d) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuccessCodesValidate(t *testing.T) {
+	for _, codes := range []domain.SuccessCodes{{"200"}, {"2xx", "304"}, nil} {
+		if err := codes.Validate(); err != nil {
+			t.Errorf("Validate(%v) = %v, want nil", codes, err)
+		}
+	}
+	for _, codes := range []domain.SuccessCodes{{"20"}, {"600"}, {"6xx"}, {"2XX"}, {"abc"}} {
+		if err := codes.Validate(); err == nil {
+			t.Errorf("Validate(%v) = nil, want error", codes)
+		}
+	}
+}
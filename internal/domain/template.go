@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+var (
+	ErrTemplateNotFound      = errors.New("template not found")
+	ErrInvalidTemplateParams = errors.New("invalid template parameters")
+)
+
+type TemplateKind string
+
+const (
+	TemplateKindJob      TemplateKind = "job"
+	TemplateKindSchedule TemplateKind = "schedule"
+)
+
+type TemplateParam struct {
+	Name        string
+	Description string
+	Required    bool
+	Default     string
+	// Pattern, when set, must match the value; anchor it to constrain the whole value.
+	// Parameters rendered into a URL path or header need one, since templates don't escape
+	// what they substitute.
+	Pattern *regexp.Regexp
+}
+
+// Template is a built-in job/schedule definition. URL, Headers values, and Body are
+// text/template strings rendered with the caller's parameters.
+type Template struct {
+	ID          string
+	Name        string
+	Description string
+	Kind        TemplateKind
+	Params      []TemplateParam
+
+	URL            string
+	Method         string
+	Headers        map[string]string
+	Body           string
+	CronExpr       string // default cron for schedule templates
+	TimeoutSeconds int
+	MaxRetries     int
+	Backoff        Backoff
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+var ErrInvalidTLSOptions = errors.New("ca bundle must hold PEM certificates and can't be combined with skipping verification")
+
+const MaxCABundleLen = 64 << 10
+
+// ValidateTLSOptions checks a job's TLS verification settings: an optional CA bundle
+// that replaces the system roots, or skipVerify, but not both.
+func ValidateTLSOptions(caBundle *string, skipVerify bool) error {
+	if caBundle == nil {
+		return nil
+	}
+	if skipVerify {
+		return ErrInvalidTLSOptions
+	}
+	_, err := ParseCABundle(*caBundle)
+	return err
+}
+
+// ParseCABundle returns a pool of the PEM certificates in bundle, which must hold at
+// least one.
+func ParseCABundle(bundle string) (*x509.CertPool, error) {
+	if len(bundle) > MaxCABundleLen {
+		return nil, ErrInvalidTLSOptions
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(bundle)) {
+		return nil, ErrInvalidTLSOptions
+	}
+	return pool, nil
+}
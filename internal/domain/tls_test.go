@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+func TestValidateTLSOptions(t *testing.T) {
+	now := time.Now()
+	ca, _ := selfSigned(t, now.Add(-time.Hour), now.Add(time.Hour))
+
+	tests := []struct {
+		name       string
+		caBundle   *string
+		skipVerify bool
+		wantErr    bool
+	}{
+		{name: "system roots"},
+		{name: "skip verify", skipVerify: true},
+		{name: "ca bundle", caBundle: &ca},
+		{name: "ca bundle and skip verify", caBundle: &ca, skipVerify: true, wantErr: true},
+		{name: "no certificates", caBundle: ptr("not pem"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := domain.ValidateTLSOptions(tt.caBundle, tt.skipVerify)
+			if tt.wantErr != errors.Is(err, domain.ErrInvalidTLSOptions) {
+				t.Errorf("ValidateTLSOptions() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package eventschema
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// eventStatus is the job status every payload of an event carries.
+var eventStatus = map[domain.CallbackEvent]domain.Status{
+	domain.CallbackEventJobCompleted: domain.StatusCompleted,
+	domain.CallbackEventJobFailed:    domain.StatusFailed,
+}
+
+var events = buildEvents()
+
+func buildEvents() map[domain.CallbackEvent]*Schema {
+	schemas := make(map[domain.CallbackEvent]*Schema, len(eventStatus))
+	for event, status := range eventStatus {
+		s := FromType(reflect.TypeFor[domain.CallbackPayload]())
+		s.Schema = draft
+		s.ID = Path(event)
+		s.Title = string(event)
+		s.Properties["event"].Const = string(event)
+		s.Properties["status"].Const = string(status)
+		schemas[event] = s
+	}
+	return schemas
+}
+
+// Path is where the event's schema is published, relative to the API root.
+func Path(event domain.CallbackEvent) string {
+	return "/schemas/events/" + string(event) + ".json"
+}
+
+// Events lists the events that have a published schema, sorted by name.
+func Events() []domain.CallbackEvent {
+	names := make([]domain.CallbackEvent, 0, len(events))
+	for event := range events {
+		names = append(names, event)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ForEvent returns the published schema for event, or nil if there is none.
+func ForEvent(event domain.CallbackEvent) *Schema {
+	return events[event]
+}
+
+// ValidatePayload checks an outgoing payload against its event's schema.
+func ValidatePayload(event domain.CallbackEvent, payload []byte) error {
+	s := ForEvent(event)
+	if s == nil {
+		return fmt.Errorf("no schema for event %q", event)
+	}
+	return s.Validate(payload)
+}
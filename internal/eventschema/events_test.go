@@ -0,0 +1,46 @@
+package eventschema_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+)
+
+func TestValidatePayload(t *testing.T) {
+	now := time.Now()
+	reqID := "req-1"
+	cb := &domain.Callback{ID: "cb-1", Event: domain.CallbackEventJobCompleted, CreatedAt: now}
+	job := &domain.Job{ID: "job-1", Status: domain.StatusCompleted, RequestID: &reqID, CompletedAt: &now}
+
+	valid, err := json.Marshal(domain.NewCallbackPayload(cb, job))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := eventschema.ValidatePayload(domain.CallbackEventJobCompleted, valid); err != nil {
+		t.Fatalf("payload built from the Go type must validate: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		event   domain.CallbackEvent
+		payload string
+		wantErr string
+	}{
+		{"wrong event schema", domain.CallbackEventJobFailed, string(valid), "$.event"},
+		{"missing property", domain.CallbackEventJobCompleted, `{"id":"cb-1"}`, "missing required property"},
+		{"unexpected property", domain.CallbackEventJobCompleted, strings.Replace(string(valid), `{`, `{"extra":1,`, 1), `unexpected property "extra"`},
+		{"bad date-time", domain.CallbackEventJobCompleted, strings.Replace(string(valid), now.Format(time.RFC3339Nano), "yesterday", 1), "date-time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := eventschema.ValidatePayload(tt.event, []byte(tt.payload))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidatePayload() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
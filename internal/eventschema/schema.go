@@ -0,0 +1,268 @@
+// Package eventschema generates JSON Schemas for outgoing event payloads from their Go
+// types and validates payloads against them. It implements the subset of JSON Schema
+// (draft 2020-12) the payload types need: objects, arrays, scalars, nullability,
+// const/enum, the date-time format and the length/range bounds the API request types use.
+package eventschema
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 []string           `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"` // false or *Schema
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// FromType builds a schema from a Go type using its encoding/json field names. Fields
+// without omitempty are required; pointer fields additionally accept null. Structs are
+// closed (additionalProperties: false) so any drift from the Go type fails validation.
+// Types implementing encoding.TextMarshaler are strings; interface fields accept any value.
+func FromType(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: []string{"string"}, Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		s := FromType(t.Elem())
+		if len(s.Type) > 0 {
+			s.Type = append(s.Type, "null")
+		}
+		return s
+	case t.Implements(textMarshalerType):
+		return &Schema{Type: []string{"string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: []string{"string"}}
+	case reflect.Bool:
+		return &Schema{Type: []string{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: []string{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: []string{"number"}}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: []string{"array"}, Items: FromType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: []string{"object"}, AdditionalProperties: FromType(t.Elem())}
+	case reflect.Struct:
+		return fromStruct(t)
+	case reflect.Interface:
+		return &Schema{}
+	default:
+		panic(fmt.Sprintf("eventschema: unsupported type %s", t))
+	}
+}
+
+func fromStruct(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 []string{"object"},
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		// Fields of an untagged embedded struct are promoted, as encoding/json does. The
+		// outer struct's own fields win on a name clash.
+		if f.Anonymous && f.Tag.Get("json") == "" && f.Type.Kind() == reflect.Struct {
+			embedded := fromStruct(f.Type)
+			for name, prop := range embedded.Properties {
+				if _, ok := s.Properties[name]; !ok {
+					s.Properties[name] = prop
+					if slices.Contains(embedded.Required, name) {
+						s.Required = append(s.Required, name)
+					}
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, promoted := s.Properties[name]; promoted {
+			s.Required = slices.DeleteFunc(s.Required, func(r string) bool { return r == name })
+		}
+		s.Properties[name] = FromType(f.Type)
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// Validate checks a JSON document against the schema.
+func (s *Schema) Validate(doc []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	return s.validate("$", v)
+}
+
+func (s *Schema) validate(path string, v any) error {
+	if len(s.Type) > 0 && !slices.Contains(s.Type, jsonType(v)) {
+		// JSON has no integer type; an integral number satisfies "integer".
+		if !(jsonType(v) == "number" && slices.Contains(s.Type, "integer") && isInteger(v)) {
+			return fmt.Errorf("%s: got %s, want %s", path, jsonType(v), strings.Join(s.Type, " or "))
+		}
+	}
+	if s.Const != nil && !equal(v, s.Const) {
+		return fmt.Errorf("%s: got %v, want %v", path, v, s.Const)
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return equal(v, e) }) {
+		return fmt.Errorf("%s: %v is not one of %v", path, v, s.Enum)
+	}
+	if str, ok := v.(string); ok && s.Format == "date-time" {
+		if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+			return fmt.Errorf("%s: %q is not an RFC 3339 date-time", path, str)
+		}
+	}
+	if err := s.validateBounds(path, v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		// Sorted so the reported error is deterministic.
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			val := v[name]
+			prop, ok := s.Properties[name]
+			if !ok {
+				switch ap := s.AdditionalProperties.(type) {
+				case *Schema:
+					prop = ap
+				case bool:
+					if !ap {
+						return fmt.Errorf("%s: unexpected property %q", path, name)
+					}
+					continue
+				default:
+					continue
+				}
+			}
+			if err := prop.validate(path+"."+name, val); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateBounds(path string, v any) error {
+	switch v := v.(type) {
+	case json.Number:
+		n, err := v.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			return fmt.Errorf("%s: %v is less than %v", path, n, *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return fmt.Errorf("%s: %v is greater than %v", path, n, *s.Maximum)
+		}
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			return fmt.Errorf("%s: shorter than %d characters", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return fmt.Errorf("%s: longer than %d characters", path, *s.MaxLength)
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			return fmt.Errorf("%s: fewer than %d items", path, *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			return fmt.Errorf("%s: more than %d items", path, *s.MaxItems)
+		}
+	}
+	return nil
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func isInteger(v any) bool {
+	n, ok := v.(json.Number)
+	if !ok {
+		return false
+	}
+	_, err := n.Int64()
+	return err == nil
+}
+
+// equal compares a decoded JSON value with a const/enum value from the schema.
+func equal(v, want any) bool {
+	return fmt.Sprint(v) == fmt.Sprint(want)
+}
@@ -2,7 +2,11 @@ package health
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,26 +17,59 @@ type Pinger interface {
 	Ping(ctx context.Context) error
 }
 
+// DefaultCheckTimeout bounds a check registered without a timeout of its own.
+const DefaultCheckTimeout = 2 * time.Second
+
+// CheckFunc reports a dependency unhealthy by returning an error.
+type CheckFunc func(ctx context.Context) error
+
+// Check is a named readiness check. Optional checks report on a dependency the replica
+// can mostly work without (e.g. the email provider): while one fails, readiness is
+// "degraded" but the replica keeps receiving traffic.
+type Check struct {
+	Name     string
+	Func     CheckFunc
+	Timeout  time.Duration // 0 = DefaultCheckTimeout
+	Optional bool
+}
+
 // CheckResult represents the health of a single dependency.
 type CheckResult struct {
-	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	Optional   bool   `json:"optional,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
 }
 
-// HealthResult is the top-level health response.
+// HealthResult is the top-level health response. Status is "up", "degraded" (only
+// optional checks fail), "draining" or "down"; the last two are not ready.
 type HealthResult struct {
 	Status string                 `json:"status"`
 	Checks map[string]CheckResult `json:"checks,omitempty"`
+	Drain  *DrainState            `json:"drain,omitempty"`
+}
+
+// Ready reports whether the replica should receive traffic.
+func (r HealthResult) Ready() bool {
+	return r.Status == "up" || r.Status == "degraded"
+}
+
+// DrainState is a worker's progress towards a graceful shutdown.
+type DrainState struct {
+	Draining bool `json:"draining"`
+	InFlight int  `json:"in_flight"`
 }
 
 // Checker verifies that all dependencies are reachable.
 type Checker struct {
-	db     Pinger
+	checks []Check
 	logger *slog.Logger
 	gauge  *prometheus.GaugeVec
+	drain  func() DrainState // nil on replicas without a worker
 }
 
-// NewChecker creates a health checker and registers its Prometheus gauge.
+// NewChecker creates a health checker with a required "postgres" check and registers its
+// Prometheus gauge.
 func NewChecker(db Pinger, logger *slog.Logger, reg prometheus.Registerer) *Checker {
 	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "scheduler",
@@ -42,36 +79,121 @@ func NewChecker(db Pinger, logger *slog.Logger, reg prometheus.Registerer) *Chec
 	reg.MustRegister(gauge)
 
 	return &Checker{
-		db:     db,
+		checks: []Check{{Name: "postgres", Func: db.Ping}},
 		logger: logger.With("component", "health"),
 		gauge:  gauge,
 	}
 }
 
-// Liveness returns a simple "up" response if the process is running.
+// Add registers a readiness check. Call it before the health endpoints are served.
+func (c *Checker) Add(check Check) {
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultCheckTimeout
+	}
+	c.checks = append(c.checks, check)
+}
+
+// HTTPCheck reports a dependency reachable when a GET of url gets any response below 500:
+// it checks the network path and that the service answers, not credentials.
+func HTTPCheck(url string) CheckFunc {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// ReportDrain adds the worker's drain state to every health response. Call it before
+// the health endpoints are served.
+func (c *Checker) ReportDrain(state func() DrainState) {
+	c.drain = state
+}
+
+// Liveness returns a simple "up" response if the process is running. A draining replica
+// is still live: it must not be restarted while its in-flight jobs finish.
 func (c *Checker) Liveness(_ context.Context) HealthResult {
-	return HealthResult{Status: "up"}
+	result := HealthResult{Status: "up"}
+	if c.drain != nil {
+		state := c.drain()
+		result.Drain = &state
+	}
+	return result
 }
 
-// Readiness pings every dependency and reports per-check status.
+// Readiness runs every check concurrently, each under its own timeout, and reports
+// per-check status.
 func (c *Checker) Readiness(ctx context.Context) HealthResult {
-	checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
-	defer cancel()
+	results := make([]CheckResult, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
 
 	result := HealthResult{
 		Status: "up",
-		Checks: make(map[string]CheckResult),
+		Checks: make(map[string]CheckResult, len(c.checks)),
+	}
+	for i, check := range c.checks {
+		r := results[i]
+		result.Checks[check.Name] = r
+		if r.Status == "up" {
+			c.gauge.WithLabelValues(check.Name).Set(1)
+			continue
+		}
+		c.gauge.WithLabelValues(check.Name).Set(0)
+		switch {
+		case !check.Optional:
+			result.Status = "down"
+		case result.Status == "up":
+			result.Status = "degraded"
+		}
 	}
 
-	if err := c.db.Ping(checkCtx); err != nil {
-		c.logger.Warn("postgres health check failed", "error", err)
-		result.Status = "down"
-		result.Checks["postgres"] = CheckResult{Status: "down", Error: err.Error()}
-		c.gauge.WithLabelValues("postgres").Set(0)
-	} else {
-		result.Checks["postgres"] = CheckResult{Status: "up"}
-		c.gauge.WithLabelValues("postgres").Set(1)
+	// A draining replica takes no new work, so it reports not ready.
+	if c.drain != nil {
+		state := c.drain()
+		result.Drain = &state
+		if state.Draining && result.Ready() {
+			result.Status = "draining"
+		}
 	}
 
 	return result
 }
+
+func (c *Checker) run(ctx context.Context, check Check) CheckResult {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Func(checkCtx)
+	result := CheckResult{Status: "up", Optional: check.Optional, DurationMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		if errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s: %w", timeout, err)
+		}
+		c.logger.WarnContext(ctx, "health check failed", "check", check.Name, "optional", check.Optional, "error", err)
+		result.Status = "down"
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/health"
 	"github.com/prometheus/client_golang/prometheus"
@@ -77,6 +79,77 @@ func TestReadiness_PostgresDown(t *testing.T) {
 	}
 }
 
+func TestReadiness_Draining(t *testing.T) {
+	c, _ := newTestChecker(&mockPinger{})
+	c.ReportDrain(func() health.DrainState { return health.DrainState{Draining: true, InFlight: 2} })
+
+	result := c.Readiness(context.Background())
+	if result.Status != "draining" {
+		t.Fatalf("expected status draining, got %s", result.Status)
+	}
+	if result.Drain == nil || result.Drain.InFlight != 2 {
+		t.Fatalf("expected drain state with 2 in flight, got %+v", result.Drain)
+	}
+
+	if live := c.Liveness(context.Background()); live.Status != "up" {
+		t.Fatalf("expected liveness up while draining, got %s", live.Status)
+	}
+}
+
+func TestReadiness_OptionalCheckDown(t *testing.T) {
+	c, reg := newTestChecker(&mockPinger{})
+	c.Add(health.Check{Name: "email", Func: func(context.Context) error { return errors.New("unreachable") }, Optional: true})
+
+	result := c.Readiness(context.Background())
+	if result.Status != "degraded" {
+		t.Fatalf("expected status degraded, got %s", result.Status)
+	}
+	if !result.Ready() {
+		t.Fatal("expected degraded to be ready")
+	}
+	email := result.Checks["email"]
+	if email.Status != "down" || !email.Optional {
+		t.Fatalf("expected optional email check down, got %+v", email)
+	}
+	if gauge := testGauge(t, reg, "scheduler_health_check_up", "email"); gauge != 0 {
+		t.Fatalf("expected gauge 0, got %f", gauge)
+	}
+}
+
+func TestReadiness_RequiredCheckDown(t *testing.T) {
+	c, _ := newTestChecker(&mockPinger{})
+	c.Add(health.Check{Name: "email", Func: func(context.Context) error { return errors.New("unreachable") }, Optional: true})
+	c.Add(health.Check{Name: "redis", Func: func(context.Context) error { return errors.New("connection refused") }})
+
+	result := c.Readiness(context.Background())
+	if result.Status != "down" {
+		t.Fatalf("expected status down, got %s", result.Status)
+	}
+	if result.Checks["postgres"].Status != "up" {
+		t.Fatalf("expected postgres up, got %s", result.Checks["postgres"].Status)
+	}
+}
+
+func TestReadiness_CheckTimeout(t *testing.T) {
+	c, _ := newTestChecker(&mockPinger{})
+	c.Add(health.Check{
+		Name: "redis",
+		Func: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		Timeout: 10 * time.Millisecond,
+	})
+
+	result := c.Readiness(context.Background())
+	if result.Status != "down" {
+		t.Fatalf("expected status down, got %s", result.Status)
+	}
+	if r := result.Checks["redis"]; !strings.Contains(r.Error, "timed out after 10ms") {
+		t.Fatalf("expected timeout error, got %q", r.Error)
+	}
+}
+
 func testGauge(t *testing.T, reg *prometheus.Registry, name, depLabel string) float64 {
 	t.Helper()
 	mfs, err := reg.Gather()
@@ -0,0 +1,214 @@
+// Package apierror renders every failed API request as the same JSON envelope, so
+// clients can branch on a stable code rather than on the message:
+//
+//	{"error": {"code": "job_not_found", "message": "Job not found", "request_id": "…"}}
+//
+// Codes are part of the API and never change once published; messages are for people
+// and may be reworded.
+package apierror
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Error is a failure the API reports.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func New(code, message string) Error {
+	return Error{Code: code, Message: message}
+}
+
+// Errors shared by every package that responds.
+var (
+	Internal       = New("internal_error", "Internal server error")
+	InvalidRequest = New("invalid_request", "Invalid request")
+	NotFound       = New("not_found", "Not found")
+	TooLarge       = New("request_too_large", "Request body is too large")
+)
+
+// FieldError is one request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"` // JSON path, e.g. jobs[2].url
+	Rule    string `json:"rule"`  // the rule it broke: required, max, oneof, type…
+	Message string `json:"message"`
+}
+
+// Body is the envelope's content.
+type Body struct {
+	Code        string       `json:"code"`
+	Message     string       `json:"message"`
+	FieldErrors []FieldError `json:"field_errors,omitempty"`
+	RequestID   string       `json:"request_id,omitempty"`
+}
+
+// Response is the body of every failed request.
+type Response struct {
+	Error Body `json:"error"`
+}
+
+// Respond writes e with status; the handler returns after it.
+func Respond(c *gin.Context, status int, e Error) {
+	c.JSON(status, envelope(c, e, nil))
+}
+
+// Abort writes e with status and stops the handler chain; for middleware.
+func Abort(c *gin.Context, status int, e Error) {
+	c.AbortWithStatusJSON(status, envelope(c, e, nil))
+}
+
+// RespondInvalid writes a 400 for a request body that failed to bind, listing the fields
+// that failed validation or had the wrong type, or a 413 when the body was cut off by
+// middleware.BodyLimit.
+func RespondInvalid(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		e := TooLarge
+		e.Message = fmt.Sprintf("Request body is larger than %d bytes", tooLarge.Limit)
+		c.JSON(http.StatusRequestEntityTooLarge, envelope(c, e, nil))
+		return
+	}
+
+	e := InvalidRequest
+	fields := fieldErrors(err)
+	switch {
+	case len(fields) > 0:
+		e.Message = "Invalid request: " + fields[0].Field + " " + fields[0].Message
+		if len(fields) > 1 {
+			e.Message += fmt.Sprintf(" (and %d more)", len(fields)-1)
+		}
+	case errors.Is(err, io.EOF):
+		e.Message = "Invalid request: body is empty"
+	case isSyntaxError(err):
+		e.Message = "Invalid request: body is not valid JSON"
+	case isGzipError(err):
+		e.Message = "Invalid request: body is not valid gzip"
+	default:
+		e.Message = "Invalid request: " + err.Error()
+	}
+	c.JSON(http.StatusBadRequest, envelope(c, e, fields))
+}
+
+func envelope(c *gin.Context, e Error, fields []FieldError) Response {
+	return Response{Error: Body{
+		Code:        e.Code,
+		Message:     e.Message,
+		FieldErrors: fields,
+		RequestID:   requestid.FromContext(c.Request.Context()),
+	}}
+}
+
+// UseJSONFieldNames makes binding validation report fields by their JSON names, which
+// field_errors shows clients. Call it once at startup, before any request is bound:
+// the validator caches field names per type.
+func UseJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return f.Name
+		}
+		return name
+	})
+}
+
+func fieldErrors(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		fields := make([]FieldError, len(verrs))
+		for i, fe := range verrs {
+			fields[i] = FieldError{Field: fieldPath(fe.Namespace()), Rule: fe.Tag(), Message: ruleMessage(fe)}
+		}
+		return fields
+	}
+	var terr *json.UnmarshalTypeError
+	if errors.As(err, &terr) && terr.Field != "" {
+		return []FieldError{{Field: terr.Field, Rule: "type", Message: "must be " + jsonType(terr.Type)}}
+	}
+	return nil
+}
+
+// fieldPath drops the request type's name from a validator namespace such as
+// createJobBatchRequest.jobs[2].url.
+func fieldPath(namespace string) string {
+	_, path, ok := strings.Cut(namespace, ".")
+	if !ok {
+		return namespace
+	}
+	return path
+}
+
+func ruleMessage(fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		return "must be at most " + fe.Param() + unit
+	case "min":
+		return "must be at least " + fe.Param() + unit
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "url", "http_url":
+		return "must be a URL"
+	case "email":
+		return "must be an email address"
+	default:
+		return "fails the " + fe.Tag() + " rule"
+	}
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func isSyntaxError(err error) bool {
+	var serr *json.SyntaxError
+	return errors.As(err, &serr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isGzipError(err error) bool {
+	var ferr flate.CorruptInputError
+	return errors.As(err, &ferr) || errors.Is(err, gzip.ErrChecksum) || errors.Is(err, gzip.ErrHeader)
+}
@@ -0,0 +1,106 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type createRequest struct {
+	Name  string `json:"name"  binding:"required,max=8"`
+	Items []struct {
+		URL string `json:"url" binding:"required,url"`
+	} `json:"items" binding:"max=2,dive"`
+	Count int `json:"count"`
+}
+
+func TestMain(m *testing.M) {
+	// Like the router, register JSON field names before anything is bound:
+	// the validator caches each struct's field names on first use.
+	apierror.UseJSONFieldNames()
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func serve(t *testing.T, body string) (int, apierror.Body) {
+	t.Helper()
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	r.POST("/things", func(c *gin.Context) {
+		var req createRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.RespondInvalid(c, err)
+			return
+		}
+		apierror.Respond(c, http.StatusConflict, apierror.New("thing_exists", "Thing already exists"))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(body))
+	req.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp apierror.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+	return w.Code, resp.Error
+}
+
+func TestRespond(t *testing.T) {
+	status, body := serve(t, `{"name": "a"}`)
+	if status != http.StatusConflict || body.Code != "thing_exists" || body.Message != "Thing already exists" {
+		t.Errorf("got %d %+v, want 409 thing_exists", status, body)
+	}
+	if body.RequestID != "req-1" {
+		t.Errorf("request_id = %q, want req-1", body.RequestID)
+	}
+}
+
+func TestRespondInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		fields []apierror.FieldError // compared by Field and Rule
+	}{
+		{
+			name: "validation",
+			body: `{"name": "far too long", "items": [{"url": "https://example.com"}, {"url": "nope"}]}`,
+			fields: []apierror.FieldError{
+				{Field: "name", Rule: "max"},
+				{Field: "items[1].url", Rule: "url"},
+			},
+		},
+		{name: "missing field", body: `{}`, fields: []apierror.FieldError{{Field: "name", Rule: "required"}}},
+		{name: "wrong type", body: `{"name": "a", "count": "x"}`, fields: []apierror.FieldError{{Field: "count", Rule: "type"}}},
+		{name: "malformed", body: `{"name":`},
+		{name: "empty", body: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := serve(t, tt.body)
+			if status != http.StatusBadRequest || body.Code != "invalid_request" {
+				t.Fatalf("got %d %s, want 400 invalid_request", status, body.Code)
+			}
+			if body.Message == "" || body.RequestID != "req-1" {
+				t.Errorf("message, request_id = %q, %q", body.Message, body.RequestID)
+			}
+			if len(body.FieldErrors) != len(tt.fields) {
+				t.Fatalf("field_errors = %+v, want %+v", body.FieldErrors, tt.fields)
+			}
+			for i, want := range tt.fields {
+				got := body.FieldErrors[i]
+				if got.Field != want.Field || got.Rule != want.Rule || got.Message == "" {
+					t.Errorf("field_errors[%d] = %+v, want %s %s", i, got, want.Field, want.Rule)
+				}
+			}
+		})
+	}
+}
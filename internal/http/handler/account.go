@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	quotas   *usecase.QuotaUsecase
+	defaults *usecase.DefaultsUsecase
+	apiUsage *usecase.APIUsageUsecase
+	egress   *usecase.EgressUsecase
+	logger   *slog.Logger
+}
+
+func NewAccountHandler(
+	quotas *usecase.QuotaUsecase,
+	defaults *usecase.DefaultsUsecase,
+	apiUsage *usecase.APIUsageUsecase,
+	egress *usecase.EgressUsecase,
+	logger *slog.Logger,
+) *AccountHandler {
+	return &AccountHandler{
+		quotas:   quotas,
+		defaults: defaults,
+		apiUsage: apiUsage,
+		egress:   egress,
+		logger:   logger.With("component", "account_handler"),
+	}
+}
+
+// Routes mounts the account endpoints on rg.
+func (h *AccountHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("/usage", h.Usage)
+	rg.GET("/api-usage", h.APIUsage)
+	rg.GET("/egress", h.Egress)
+	rg.GET("/defaults", h.GetDefaults)
+	rg.PATCH("/defaults", h.UpdateDefaults)
+}
+
+type quotaResponse struct {
+	Quota    domain.QuotaKind `json:"quota"`
+	Used     int              `json:"used"`
+	Limit    int              `json:"limit"` // 0 = unlimited
+	Warning  bool             `json:"warning"`
+	Exceeded bool             `json:"exceeded"`
+}
+
+type usageResponse struct {
+	Quotas   []quotaResponse    `json:"quotas"`
+	Warnings []domain.QuotaKind `json:"warnings"`
+}
+
+func (h *AccountHandler) Usage(ctx *gin.Context) {
+	statuses, err := h.quotas.Usage(ctx.Request.Context(), ctx.GetString("userID"))
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "get usage", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := usageResponse{
+		Quotas:   make([]quotaResponse, len(statuses)),
+		Warnings: []domain.QuotaKind{},
+	}
+	for i, s := range statuses {
+		resp.Quotas[i] = quotaResponse{
+			Quota:    s.Kind,
+			Used:     s.Used,
+			Limit:    s.Limit,
+			Warning:  s.Warning,
+			Exceeded: s.Exceeded,
+		}
+		if s.Warning {
+			resp.Warnings = append(resp.Warnings, s.Kind)
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// updateDefaultsRequest is a partial update: omitted fields are left unchanged.
+type updateDefaultsRequest struct {
+	TimeoutSeconds *int              `json:"timeout_seconds" binding:"omitempty,min=1,max=3600"`
+	MaxRetries     *int              `json:"max_retries"     binding:"omitempty,min=0,max=20"`
+	Backoff        *domain.Backoff   `json:"backoff"         binding:"omitempty,oneof=exponential linear"`
+	Headers        map[string]string `json:"headers"`
+}
+
+type defaultsResponse struct {
+	TimeoutSeconds int               `json:"timeout_seconds"`
+	MaxRetries     int               `json:"max_retries"`
+	Backoff        domain.Backoff    `json:"backoff"`
+	Headers        map[string]string `json:"headers"` // secret values are "[redacted]"
+}
+
+func toDefaultsResponse(d domain.EffectiveJobDefaults) defaultsResponse {
+	return defaultsResponse{
+		TimeoutSeconds: d.TimeoutSeconds,
+		MaxRetries:     d.MaxRetries,
+		Backoff:        d.Backoff,
+		Headers:        domain.RedactHeaders(d.Headers),
+	}
+}
+
+func (h *AccountHandler) GetDefaults(ctx *gin.Context) {
+	d, err := h.defaults.Effective(ctx.Request.Context(), ctx.GetString("userID"))
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "get defaults", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, toDefaultsResponse(d))
+}
+
+func (h *AccountHandler) UpdateDefaults(ctx *gin.Context) {
+	var req updateDefaultsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	d, err := h.defaults.Update(ctx.Request.Context(), ctx.GetString("userID"), domain.JobDefaults{
+		TimeoutSeconds: req.TimeoutSeconds,
+		MaxRetries:     req.MaxRetries,
+		Backoff:        req.Backoff,
+		Headers:        req.Headers,
+	})
+	if errors.Is(err, domain.ErrSealedPrefix) {
+		apierror.Respond(ctx, http.StatusBadRequest, errSealedPrefix)
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "update defaults", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, toDefaultsResponse(d))
+}
+
+type endpointUsageResponse struct {
+	Method        string `json:"method"`
+	Route         string `json:"route"`
+	Requests      int64  `json:"requests"`
+	ClientErrors  int64  `json:"client_errors"`
+	ServerErrors  int64  `json:"server_errors"`
+	AvgDurationMS int64  `json:"avg_duration_ms"`
+}
+
+type apiUsageResponse struct {
+	Since         time.Time               `json:"since"`
+	TotalRequests int64                   `json:"total_requests"`
+	Endpoints     []endpointUsageResponse `json:"endpoints"`
+}
+
+// APIUsage reports the caller's per-endpoint request counts over ?window= (default 24h).
+// Counts lag live traffic by up to one flush interval.
+func (h *AccountHandler) APIUsage(ctx *gin.Context) {
+	window := 24 * time.Hour
+	if raw := ctx.Query("window"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 || d > usecase.APIUsageRetention {
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidUsageWindow)
+			return
+		}
+		window = d
+	}
+
+	since := time.Now().Add(-window)
+	usage, err := h.apiUsage.Stats(ctx.Request.Context(), ctx.GetString("userID"), window)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "get api usage", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := apiUsageResponse{Since: since, Endpoints: make([]endpointUsageResponse, len(usage))}
+	for i, u := range usage {
+		resp.TotalRequests += u.Requests
+		resp.Endpoints[i] = endpointUsageResponse{
+			Method:       u.Method,
+			Route:        u.Route,
+			Requests:     u.Requests,
+			ClientErrors: u.ClientErrors,
+			ServerErrors: u.ServerErrors,
+		}
+		if u.Requests > 0 {
+			resp.Endpoints[i].AvgDurationMS = u.TotalDurationMS / u.Requests
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type scheduleEgressResponse struct {
+	ScheduleID    *string `json:"schedule_id"` // null = ad-hoc jobs
+	ScheduleName  *string `json:"schedule_name"`
+	Attempts      int64   `json:"attempts"`
+	RequestBytes  int64   `json:"request_bytes"`
+	ResponseBytes int64   `json:"response_bytes"`
+}
+
+type egressResponse struct {
+	Since         time.Time                `json:"since"`
+	Attempts      int64                    `json:"attempts"`
+	RequestBytes  int64                    `json:"request_bytes"`
+	ResponseBytes int64                    `json:"response_bytes"`
+	Schedules     []scheduleEgressResponse `json:"schedules"`
+}
+
+// Egress reports the bytes the caller's job attempts sent and received over ?window=
+// (default 24h), in total and per schedule, heaviest first.
+func (h *AccountHandler) Egress(ctx *gin.Context) {
+	window := 24 * time.Hour
+	if raw := ctx.Query("window"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 || d > usecase.MaxEgressWindow {
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidUsageWindow)
+			return
+		}
+		window = d
+	}
+
+	since := time.Now().Add(-window)
+	usage, err := h.egress.Stats(ctx.Request.Context(), ctx.GetString("userID"), window)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "get egress", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := egressResponse{Since: since, Schedules: make([]scheduleEgressResponse, len(usage))}
+	for i, u := range usage {
+		resp.Attempts += u.Attempts
+		resp.RequestBytes += u.RequestBytes
+		resp.ResponseBytes += u.ResponseBytes
+		resp.Schedules[i] = scheduleEgressResponse{
+			ScheduleID:    u.ScheduleID,
+			ScheduleName:  u.ScheduleName,
+			Attempts:      u.Attempts,
+			RequestBytes:  u.RequestBytes,
+			ResponseBytes: u.ResponseBytes,
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// ApprovalHandler manages organizations' approval mode and reviews the jobs it holds.
+// Like OrgHandler it acts as the authenticated user ("actorID"), and is mounted under
+// /orgs next to it.
+type ApprovalHandler struct {
+	uc     *usecase.ApprovalUsecase
+	logger *slog.Logger
+}
+
+func NewApprovalHandler(uc *usecase.ApprovalUsecase, logger *slog.Logger) *ApprovalHandler {
+	return &ApprovalHandler{uc: uc, logger: logger.With("component", "approval_handler")}
+}
+
+// Routes mounts the approval endpoints on rg.
+func (h *ApprovalHandler) Routes(rg *gin.RouterGroup) {
+	rg.PUT("/:id/approval-mode", h.SetMode)
+	rg.GET("/:id/approvals", h.List)
+	rg.POST("/:id/approvals/:job_id/approve", h.Approve)
+	rg.POST("/:id/approvals/:job_id/reject", h.Reject)
+}
+
+type setApprovalModeRequest struct {
+	RequireApproval *bool `json:"require_approval" binding:"required"`
+}
+
+type approvalItem struct {
+	ID          string         `json:"id"` // the job's ID
+	JobType     domain.JobType `json:"job_type"`
+	URL         string         `json:"url"`
+	Method      string         `json:"method"`
+	ScheduledAt time.Time      `json:"scheduled_at"`
+	CreatedBy   *string        `json:"created_by"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type reviewResponse struct {
+	ID         string        `json:"id"`
+	Status     domain.Status `json:"status"` // pending or blocked once approved, cancelled once rejected
+	ReviewedBy *string       `json:"reviewed_by"`
+	ReviewedAt *time.Time    `json:"reviewed_at"`
+}
+
+func (h *ApprovalHandler) SetMode(ctx *gin.Context) {
+	var req setApprovalModeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	if err := h.uc.SetRequired(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"), *req.RequireApproval); err != nil {
+		h.respondError(ctx, "set approval mode", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *ApprovalHandler) List(ctx *gin.Context) {
+	jobs, err := h.uc.List(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"))
+	if err != nil {
+		h.respondError(ctx, "list approvals", err)
+		return
+	}
+
+	resp := make([]approvalItem, len(jobs))
+	for i, j := range jobs {
+		resp[i] = approvalItem{
+			ID:          j.ID,
+			JobType:     j.JobType,
+			URL:         j.URL,
+			Method:      j.Method,
+			ScheduledAt: j.ScheduledAt,
+			CreatedBy:   j.CreatedBy,
+			CreatedAt:   j.CreatedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"approvals": resp})
+}
+
+func (h *ApprovalHandler) Approve(ctx *gin.Context) {
+	h.review(ctx, true)
+}
+
+func (h *ApprovalHandler) Reject(ctx *gin.Context) {
+	h.review(ctx, false)
+}
+
+func (h *ApprovalHandler) review(ctx *gin.Context, approve bool) {
+	job, err := h.uc.Review(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"), ctx.Param("job_id"), approve)
+	if err != nil {
+		h.respondError(ctx, "review job", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, reviewResponse{
+		ID:         job.ID,
+		Status:     job.Status,
+		ReviewedBy: job.ReviewedBy,
+		ReviewedAt: job.ReviewedAt,
+	})
+}
+
+func (h *ApprovalHandler) respondError(ctx *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOrgNotFound):
+		apierror.Respond(ctx, http.StatusNotFound, errOrgNotFound)
+	case errors.Is(err, domain.ErrOrgForbidden):
+		apierror.Respond(ctx, http.StatusForbidden, errOrgOwnerRequired)
+	case errors.Is(err, domain.ErrJobNotFound):
+		apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+	case errors.Is(err, domain.ErrJobNotAwaitingApproval):
+		apierror.Respond(ctx, http.StatusConflict, errJobNotAwaitingApproval)
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), op, "org_id", ctx.Param("id"), "job_id", ctx.Param("job_id"), "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ClientCertHandler struct {
+	uc     *usecase.ClientCertUsecase
+	logger *slog.Logger
+}
+
+func NewClientCertHandler(uc *usecase.ClientCertUsecase, logger *slog.Logger) *ClientCertHandler {
+	return &ClientCertHandler{uc: uc, logger: logger.With("component", "client_cert_handler")}
+}
+
+// Routes mounts the client certificate endpoints on rg.
+func (h *ClientCertHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("", h.List)
+	rg.POST("", h.Create)
+	rg.DELETE("/:id", h.Delete)
+}
+
+// createClientCertRequest uploads a PEM certificate chain, leaf first, and its private
+// key. The key is write-only.
+type createClientCertRequest struct {
+	Name    string `json:"name"     binding:"required,max=255"`
+	CertPEM string `json:"cert_pem" binding:"required"`
+	KeyPEM  string `json:"key_pem"  binding:"required"`
+}
+
+type clientCertResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Subject     string    `json:"subject"`
+	Fingerprint string    `json:"fingerprint"` // hex SHA-256 of the certificate
+	NotAfter    time.Time `json:"not_after"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func toClientCertResponse(c *domain.ClientCertificate) clientCertResponse {
+	return clientCertResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Subject:     c.Subject,
+		Fingerprint: c.Fingerprint,
+		NotAfter:    c.NotAfter,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+func (h *ClientCertHandler) List(ctx *gin.Context) {
+	certs, err := h.uc.List(ctx.Request.Context(), ctx.GetString("userID"))
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "list client certificates", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := make([]clientCertResponse, len(certs))
+	for i, c := range certs {
+		resp[i] = toClientCertResponse(c)
+	}
+	ctx.JSON(http.StatusOK, gin.H{"certificates": resp})
+}
+
+func (h *ClientCertHandler) Create(ctx *gin.Context) {
+	var req createClientCertRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	cert, err := h.uc.Create(ctx.Request.Context(), usecase.CreateClientCertInput{
+		UserID:  ctx.GetString("userID"),
+		Name:    req.Name,
+		CertPEM: req.CertPEM,
+		KeyPEM:  req.KeyPEM,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidClientCert):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidClientCert)
+		case errors.Is(err, domain.ErrClientCertNameConflict):
+			apierror.Respond(ctx, http.StatusConflict, errClientCertNameConflict)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "create client certificate", "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, toClientCertResponse(cert))
+}
+
+func (h *ClientCertHandler) Delete(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	if err := h.uc.Delete(ctx.Request.Context(), id, ctx.GetString("userID")); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrClientCertNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errClientCertNotFound)
+		case errors.Is(err, domain.ErrClientCertInUse):
+			apierror.Respond(ctx, http.StatusConflict, errClientCertInUse)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "delete client certificate", "cert_id", id, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// dryRunJobRequest is the request part of createJobRequest. The timeout is capped at
+// domain.MaxDryRunTimeout.
+type dryRunJobRequest struct {
+	URL            string            `json:"url"             binding:"required,url,max=2048"`
+	Method         string            `json:"method"          binding:"required,oneof=GET POST PUT PATCH DELETE"`
+	Headers        map[string]string `json:"headers"`
+	Body           *string           `json:"body"`
+	TimeoutSeconds int               `json:"timeout_seconds" binding:"omitempty,min=1,max=30"`
+	SuccessCodes   []string          `json:"success_codes"   binding:"omitempty,max=20"`
+	Templated      bool              `json:"templated"`
+	SigningSecret  *string           `json:"signing_secret"  binding:"omitempty,min=16,max=256"`
+
+	BodyEncoding domain.BodyEncoding `json:"body_encoding" binding:"omitempty,oneof=utf8 base64"`
+	ContentType  *string             `json:"content_type"  binding:"omitempty,max=255"`
+	ProxyURL     *string             `json:"proxy_url"     binding:"omitempty,max=2048"`
+	ClientCertID *string             `json:"client_cert_id" binding:"omitempty,max=64"`
+
+	CABundle      *string `json:"ca_bundle"       binding:"omitempty,max=65536"`
+	TLSSkipVerify bool    `json:"tls_skip_verify"`
+}
+
+// dryRunResponse reports what the target returned. The request itself succeeded even
+// when the target failed; succeeded says whether a real job would have.
+type dryRunResponse struct {
+	Succeeded  bool    `json:"succeeded"`
+	StatusCode *int    `json:"status_code"`
+	Error      *string `json:"error"`
+	DurationMS int64   `json:"duration_ms"`
+
+	// Start of the response, up to the capture limit; null when no response arrived.
+	ResponseHeaders   map[string]string `json:"response_headers"`
+	ResponseBody      *string           `json:"response_body"`
+	ResponseBytes     *int64            `json:"response_bytes"`
+	ResponseTruncated bool              `json:"response_truncated"`
+}
+
+func toDryRunResponse(r domain.DryRunResult) dryRunResponse {
+	return dryRunResponse{
+		Succeeded:         r.Succeeded,
+		StatusCode:        r.StatusCode,
+		Error:             r.Error,
+		DurationMS:        r.Duration.Milliseconds(),
+		ResponseHeaders:   r.ResponseHeaders,
+		ResponseBody:      r.ResponseBody,
+		ResponseBytes:     r.ResponseBytes,
+		ResponseTruncated: r.ResponseTruncated,
+	}
+}
+
+func (h *JobHandler) DryRun(ctx *gin.Context) {
+	var req dryRunJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	result, err := h.dryRun.DryRunJob(ctx.Request.Context(), usecase.CreateJobInput{
+		UserID:         ctx.GetString("userID"),
+		URL:            req.URL,
+		Method:         req.Method,
+		Headers:        req.Headers,
+		Body:           req.Body,
+		TimeoutSeconds: req.TimeoutSeconds,
+		SuccessCodes:   req.SuccessCodes,
+		Templated:      req.Templated,
+		SigningSecret:  req.SigningSecret,
+		BodyEncoding:   req.BodyEncoding,
+		ContentType:    req.ContentType,
+		ProxyURL:       req.ProxyURL,
+		ClientCertID:   req.ClientCertID,
+		CABundle:       req.CABundle,
+		TLSSkipVerify:  req.TLSSkipVerify,
+	})
+	if err != nil {
+		if e, ok := createJobError(err); ok {
+			apierror.Respond(ctx, http.StatusBadRequest, e)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "dry run job", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, toDryRunResponse(result))
+}
+
+func (h *ScheduleHandler) DryRun(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	result, err := h.dryRun.DryRunSchedule(ctx.Request.Context(), id, ctx.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrScheduleNotFound) {
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrDryRunUnsupported) {
+			apierror.Respond(ctx, http.StatusBadRequest, errDryRunUnsupported)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "dry run schedule", "schedule_id", id, "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, toDryRunResponse(result))
+}
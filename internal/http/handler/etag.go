@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag builds a strong ETag from a row's updated_at and any counters that change
+// without touching it.
+func etag(updatedAt time.Time, extra ...int) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	b.WriteString(strconv.FormatInt(updatedAt.UnixMicro(), 36))
+	for _, n := range extra {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(n))
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// notModified sets the response's ETag and, when the request's If-None-Match already
+// names it, answers 304 so the caller can skip building the body. Comparison is weak, as
+// RFC 9110 requires for If-None-Match: the compression middleware marks the ETags of
+// gzipped responses weak.
+func notModified(ctx *gin.Context, tag string) bool {
+	ctx.Header("ETag", tag)
+	for _, candidate := range strings.Split(ctx.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			ctx.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -8,45 +8,176 @@ import (
 	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
 
 type JobHandler struct {
 	jobUsecase *usecase.JobUsecase
+	stream     *usecase.JobStreamHub
+	dryRun     *usecase.DryRunUsecase
 	logger     *slog.Logger
 }
 
-func NewJobHandler(jobUsecase *usecase.JobUsecase, logger *slog.Logger) *JobHandler {
-	return &JobHandler{jobUsecase: jobUsecase, logger: logger.With("component", "job_handler")}
+func NewJobHandler(jobUsecase *usecase.JobUsecase, stream *usecase.JobStreamHub, dryRun *usecase.DryRunUsecase, logger *slog.Logger) *JobHandler {
+	return &JobHandler{jobUsecase: jobUsecase, stream: stream, dryRun: dryRun, logger: logger.With("component", "job_handler")}
+}
+
+// Routes mounts the job endpoints on rg.
+func (h *JobHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("", h.List)
+	rg.POST("", h.Create)
+	rg.POST("/batch", h.CreateBatch)
+	rg.POST("/dry-run", h.DryRun)
+	rg.GET("/stream", h.Stream)
+	rg.GET("/:id", h.GetByID)
+	rg.DELETE("/:id", h.Cancel)
+	rg.POST("/:id/pause", h.Pause)
+	rg.POST("/:id/resume", h.Resume)
+	rg.POST("/:id/retry", h.Retry)
+	rg.GET("/:id/attempts", h.ListAttempts)
+	rg.GET("/:id/attempts/diff", h.DiffAttempts)
+	rg.GET("/:id/attempts/:attempt_id", h.GetAttempt)
+	rg.GET("/:id/callbacks", h.ListCallbacks)
+	rg.POST("/:id/callbacks/retry", h.RetryCallbacks)
 }
 
 type createJobRequest struct {
-	IdempotencyKey string            `json:"idempotency_key" binding:"required,max=256"`
-	URL            string            `json:"url"             binding:"required,url,max=2048"`
-	Method         string            `json:"method"          binding:"required,oneof=GET POST PUT PATCH DELETE"`
-	Headers        map[string]string `json:"headers"`
-	Body           *string           `json:"body"`
-	TimeoutSeconds int               `json:"timeout_seconds" binding:"omitempty,min=1,max=3600"`
-	ScheduledAt    time.Time         `json:"scheduled_at"    binding:"required"`
-	MaxRetries     int               `json:"max_retries"     binding:"omitempty,min=0,max=20"`
-	Backoff        domain.Backoff    `json:"backoff"         binding:"omitempty,oneof=exponential linear"`
+	IdempotencyKey   string            `json:"idempotency_key" binding:"required,max=256"`
+	JobType          domain.JobType    `json:"job_type"        binding:"omitempty,max=32"`                          // default "http"
+	URL              string            `json:"url"             binding:"omitempty,url,max=2048"`                    // http jobs
+	Method           string            `json:"method"          binding:"omitempty,oneof=GET POST PUT PATCH DELETE"` // http jobs
+	Topic            *string           `json:"topic"           binding:"omitempty,max=249"`                         // kafka jobs
+	MessageKey       *string           `json:"message_key"     binding:"omitempty,max=1024"`                        // kafka jobs; optional
+	EmailTo          *string           `json:"email_to"        binding:"omitempty,email,max=254"`                   // email jobs
+	EmailSubject     *string           `json:"email_subject"   binding:"omitempty,max=998"`                         // email jobs
+	Headers          map[string]string `json:"headers"`
+	Body             *string           `json:"body"`
+	TimeoutSeconds   int               `json:"timeout_seconds" binding:"omitempty,min=1,max=3600"`
+	ScheduledAt      time.Time         `json:"scheduled_at"    binding:"required"`
+	MaxRetries       int               `json:"max_retries"     binding:"omitempty,min=0,max=20"`
+	Backoff          domain.Backoff    `json:"backoff"         binding:"omitempty,oneof=exponential linear"`
+	RetryBaseSeconds int               `json:"retry_base_seconds" binding:"omitempty,min=1,max=86400"` // default 30
+	RetryMaxSeconds  int               `json:"retry_max_seconds"  binding:"omitempty,min=1,max=86400"` // default 3600
+	RetryJitter      domain.Jitter     `json:"retry_jitter"       binding:"omitempty,oneof=auto none full equal"`
+	Priority         int               `json:"priority"        binding:"omitempty,min=0,max=9"`
+	Deadline         *time.Time        `json:"deadline"`
+	ExpiresAt        *time.Time        `json:"expires_at"`                                 // skip the job if it can't start by then
+	RetryDelays      []string          `json:"retry_delays"    binding:"omitempty,max=20"` // e.g. ["10s", "1m", "10m"]
+	CallbackURL      *string           `json:"callback_url"    binding:"omitempty,url,max=2048"`
+	SuccessCodes     []string          `json:"success_codes"   binding:"omitempty,max=20"` // e.g. ["2xx"] or ["200", "204"]; default ["200"]
+
+	// Templated expands {{.JobID}}, {{.ScheduleID}}, {{.ScheduledAt}} and {{.AttemptNum}}
+	// in url, header values and body before each attempt.
+	Templated bool `json:"templated"`
+
+	// Debug records the request as sent and the start of the response on every attempt;
+	// see GET /jobs/:id/attempts/:attempt_id.
+	Debug bool `json:"debug"`
+
+	// DependsOn chains the job after another of the user's jobs: it stays blocked until
+	// that job finishes. OnParentFailure decides what happens if the parent doesn't
+	// complete: "skip" (default) cancels this job, "run" runs it anyway.
+	DependsOn       *string                    `json:"depends_on"        binding:"omitempty,max=64"`
+	OnParentFailure domain.ParentFailurePolicy `json:"on_parent_failure" binding:"omitempty,oneof=skip run"`
+
+	// SigningSecret makes every request carry an X-Signature HMAC-SHA256 header over the
+	// timestamp and body. It is write-only; responses report only signed: true.
+	SigningSecret *string `json:"signing_secret" binding:"omitempty,min=16,max=256"`
+
+	// Queue restricts the job to workers serving that queue; default "default".
+	Queue string `json:"queue" binding:"omitempty,max=63"`
+
+	// BodyEncoding "base64" sends body decoded, for binary payloads; default "utf8".
+	// ContentType is sent as the Content-Type header, overriding one in headers.
+	BodyEncoding domain.BodyEncoding `json:"body_encoding" binding:"omitempty,oneof=utf8 base64"`
+	ContentType  *string             `json:"content_type"  binding:"omitempty,max=255"`
+
+	// ProxyURL routes the job's requests through an http, https, socks5 or socks5h proxy;
+	// credentials go in the URL. Responses return it with the password redacted.
+	ProxyURL *string `json:"proxy_url" binding:"omitempty,max=2048"`
+
+	// ClientCertID presents one of the caller's client certificates (POST /certificates)
+	// to targets that require mutual TLS.
+	ClientCertID *string `json:"client_cert_id" binding:"omitempty,max=64"`
+
+	// CABundle (PEM) replaces the system roots the target's certificate is verified
+	// against, for targets behind a private CA. TLSSkipVerify turns verification off
+	// altogether; every such request is logged and counted by the worker.
+	CABundle      *string `json:"ca_bundle"       binding:"omitempty,max=65536"`
+	TLSSkipVerify bool    `json:"tls_skip_verify"`
 }
 
 type createJobResponse struct {
-	ID        string    `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        string        `json:"id"`
+	Status    domain.Status `json:"status"` // awaiting_approval when an owner must approve it
+	CreatedAt time.Time     `json:"created_at"`
 }
 
 type getJobResponse struct {
 	ID          string        `json:"id"`
 	Status      domain.Status `json:"status"`
 	ScheduledAt time.Time     `json:"scheduled_at"`
+	Priority    int           `json:"priority"`
+	Deadline    *time.Time    `json:"deadline,omitempty"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty"`
+	RetryDelays []string      `json:"retry_delays,omitempty"`
 	CreatedAt   time.Time     `json:"created_at"`
 	UpdatedAt   time.Time     `json:"updated_at"`
 	CompletedAt *time.Time    `json:"completed_at,omitempty"`
 	LastError   *string       `json:"last_error,omitempty"`
 	ScheduleID  *string       `json:"schedule_id,omitempty"`
+	RequestID   *string       `json:"request_id,omitempty"`
+	CallbackURL *string       `json:"callback_url,omitempty"`
+
+	DependsOn       *string                     `json:"depends_on,omitempty"`
+	OnParentFailure *domain.ParentFailurePolicy `json:"on_parent_failure,omitempty"` // set with depends_on
+
+	// RetriedFrom is the job this one was resubmitted from with POST /jobs/:id/retry.
+	RetriedFrom *string `json:"retried_from,omitempty"`
+
+	// CancelRequestedAt is set while a cancelled running job waits for its worker to abort it.
+	CancelRequestedAt *time.Time `json:"cancel_requested_at,omitempty"`
+
+	// CreatedBy is the member who created an organization's job; ReviewedBy and
+	// ReviewedAt are set once an owner approved or rejected it.
+	CreatedBy  *string    `json:"created_by,omitempty"`
+	ReviewedBy *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+
+	// SuccessCodes is omitted when the job uses the default (200 only).
+	SuccessCodes []string `json:"success_codes,omitempty"`
+	Templated    bool     `json:"templated"`
+	Debug        bool     `json:"debug"`
+	Signed       bool     `json:"signed"`
+	Queue        string   `json:"queue"`
+
+	JobType    domain.JobType `json:"job_type"`
+	Topic      *string        `json:"topic,omitempty"`
+	MessageKey *string        `json:"message_key,omitempty"`
+
+	EmailTo      *string `json:"email_to,omitempty"`
+	EmailSubject *string `json:"email_subject,omitempty"`
+
+	BodyEncoding domain.BodyEncoding `json:"body_encoding"`
+	ContentType  *string             `json:"content_type,omitempty"`
+	ProxyURL     *string             `json:"proxy_url,omitempty"` // password redacted
+	ClientCertID *string             `json:"client_cert_id,omitempty"`
+
+	CABundle      *string `json:"ca_bundle,omitempty"`
+	TLSSkipVerify bool    `json:"tls_skip_verify"`
+
+	RetryBaseSeconds int           `json:"retry_base_seconds"`
+	RetryMaxSeconds  int           `json:"retry_max_seconds"`
+	RetryJitter      domain.Jitter `json:"retry_jitter"`
+
+	// Lease state from the most recent claim. A running job is rescued by the reaper
+	// once its heartbeat is older than the stale cutoff (30s).
+	ClaimedBy                 *string    `json:"claimed_by,omitempty"`
+	ClaimedAt                 *time.Time `json:"claimed_at,omitempty"`
+	HeartbeatAt               *time.Time `json:"heartbeat_at,omitempty"`
+	SecondsSinceLastHeartbeat *float64   `json:"seconds_since_last_heartbeat,omitempty"`
 }
 
 type listJobItem struct {
@@ -64,56 +195,264 @@ type listJobItem struct {
 type listJobsResponse struct {
 	Jobs       []listJobItem `json:"jobs"`
 	NextCursor *string       `json:"next_cursor"`
+	HasMore    bool          `json:"has_more"`
+	TotalCount *int64        `json:"total_count,omitempty"` // with include_total=true
 }
 
 type attemptResponse struct {
-	ID          string     `json:"id"`
-	JobID       string     `json:"job_id"`
-	AttemptNum  int        `json:"attempt_num"`
-	WorkerID    string     `json:"worker_id"`
-	StartedAt   time.Time  `json:"started_at"`
-	CompletedAt *time.Time `json:"completed_at"`
-	StatusCode  *int       `json:"status_code"`
-	Error       *string    `json:"error"`
-	DurationMS  *int64     `json:"duration_ms"`
+	ID            string     `json:"id"`
+	JobID         string     `json:"job_id"`
+	AttemptNum    int        `json:"attempt_num"`
+	WorkerID      string     `json:"worker_id"`
+	StartedAt     time.Time  `json:"started_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	StatusCode    *int       `json:"status_code"`
+	Error         *string    `json:"error"`
+	DurationMS    *int64     `json:"duration_ms"`
+	RequestBytes  *int64     `json:"request_bytes"`
+	ResponseBytes *int64     `json:"response_bytes"`
+	RemoteAddr    *string    `json:"remote_addr"`
+
+	// IdempotencyKey is the X-Idempotency-Key the attempt's request carried.
+	IdempotencyKey string `json:"idempotency_key"`
+
+	// ResponseTruncated is true when the body exceeded the worker's response size limit;
+	// response_bytes is then a lower bound.
+	ResponseTruncated bool `json:"response_truncated"`
+
+	// Captured start of the target's response; null when capture was off or no response arrived.
+	ResponseHeaders map[string]string `json:"response_headers"`
+	ResponseBody    *string           `json:"response_body"`
+
+	Cancelled bool `json:"cancelled"`
+}
+
+// attemptDetailResponse adds the request snapshot, which can be large, to a single attempt.
+type attemptDetailResponse struct {
+	attemptResponse
+
+	// Request is the request as sent; null unless the job has debug set.
+	Request *attemptRequestResponse `json:"request"`
+}
+
+type attemptRequestResponse struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"` // secret values are "[redacted]"
+	Body    *string           `json:"body"`
+}
+
+func toAttemptResponse(a *domain.JobAttempt) attemptResponse {
+	return attemptResponse{
+		ID:            a.ID,
+		JobID:         a.JobID,
+		AttemptNum:    a.AttemptNum,
+		WorkerID:      a.WorkerID,
+		StartedAt:     a.StartedAt,
+		CompletedAt:   a.CompletedAt,
+		StatusCode:    a.StatusCode,
+		Error:         a.Error,
+		DurationMS:    a.DurationMS,
+		RequestBytes:  a.RequestBytes,
+		ResponseBytes: a.ResponseBytes,
+		RemoteAddr:    a.RemoteAddr,
+
+		IdempotencyKey: a.IdempotencyKey(),
+
+		ResponseTruncated: a.ResponseTruncated,
+
+		ResponseHeaders: a.ResponseHeaders,
+		ResponseBody:    a.ResponseBody,
+
+		Cancelled: a.Cancelled,
+	}
+}
+
+type callbackAttemptResponse struct {
+	AttemptNum      int       `json:"attempt_num"`
+	StatusCode      *int      `json:"status_code"`
+	Error           *string   `json:"error"`
+	ResponseSnippet *string   `json:"response_snippet"`
+	DurationMS      int64     `json:"duration_ms"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type callbackResponse struct {
+	ID            string                    `json:"id"`
+	Event         domain.CallbackEvent      `json:"event"`
+	URL           string                    `json:"url"`
+	Status        domain.CallbackStatus     `json:"status"`
+	Attempts      int                       `json:"attempts"`
+	NextAttemptAt *time.Time                `json:"next_attempt_at,omitempty"`
+	LastError     *string                   `json:"last_error,omitempty"`
+	DeliveredAt   *time.Time                `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	History       []callbackAttemptResponse `json:"history"`
+}
+
+type attemptChangeResponse struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type attemptDiffResponse struct {
+	FromAttempt    int                     `json:"from_attempt"`
+	ToAttempt      int                     `json:"to_attempt"`
+	Changes        []attemptChangeResponse `json:"changes"`
+	LatencyDeltaMS *int64                  `json:"latency_delta_ms"`
 }
 
 func (h *JobHandler) Cancel(ctx *gin.Context) {
 	jobID := ctx.Param("id")
 
-	err := h.jobUsecase.CancelJob(ctx.Request.Context(), jobID, ctx.GetString("userID"))
+	requested, err := h.jobUsecase.CancelJob(ctx.Request.Context(), jobID, ctx.GetString("userID"))
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrJobNotFound):
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errJobNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
 		case errors.Is(err, domain.ErrJobNotCancellable):
-			ctx.JSON(http.StatusConflict, gin.H{"error": errJobNotCancellable})
+			apierror.Respond(ctx, http.StatusConflict, errJobNotCancellable)
 		default:
 			h.logger.ErrorContext(ctx.Request.Context(), "cancel job", "job_id", jobID, "error", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		}
 		return
 	}
 
+	// A running job is aborted asynchronously; the client watches its status for the outcome.
+	if requested {
+		ctx.Status(http.StatusAccepted)
+		return
+	}
 	ctx.Status(http.StatusNoContent)
 }
 
-func (h *JobHandler) List(ctx *gin.Context) {
-	limit, _ := strconv.Atoi(ctx.Query("limit"))
+// Pause holds a pending job so workers skip it; Resume makes it claimable again.
+func (h *JobHandler) Pause(ctx *gin.Context) {
+	jobID := ctx.Param("id")
 
-	result, err := h.jobUsecase.ListJobs(ctx.Request.Context(), usecase.ListJobsInput{
-		UserID: ctx.GetString("userID"),
-		Status: ctx.Query("status"),
-		Cursor: ctx.Query("cursor"),
-		Limit:  limit,
+	err := h.jobUsecase.PauseJob(ctx.Request.Context(), jobID, ctx.GetString("userID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrJobNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+		case errors.Is(err, domain.ErrJobNotPausable):
+			apierror.Respond(ctx, http.StatusConflict, errJobNotPausable)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "pause job", "job_id", jobID, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *JobHandler) Resume(ctx *gin.Context) {
+	jobID := ctx.Param("id")
+
+	err := h.jobUsecase.ResumeJob(ctx.Request.Context(), jobID, ctx.GetString("userID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrJobNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+		case errors.Is(err, domain.ErrJobNotPaused):
+			apierror.Respond(ctx, http.StatusConflict, errJobNotPaused)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "resume job", "job_id", jobID, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+type retryJobResponse struct {
+	ID             string    `json:"id"`
+	RetriedFrom    string    `json:"retried_from"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	ScheduledAt    time.Time `json:"scheduled_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Retry resubmits a finished job: a new pending job, due now, with the same target and
+// payload and a fresh attempt history. The original is left as it was.
+func (h *JobHandler) Retry(ctx *gin.Context) {
+	jobID := ctx.Param("id")
+
+	job, err := h.jobUsecase.RetryJob(ctx.Request.Context(), jobID, ctx.GetString("userID"), ctx.GetString("actorID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrJobNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+		case errors.Is(err, domain.ErrClientCertNotFound):
+			// The original's client certificate has since been deleted.
+			apierror.Respond(ctx, http.StatusBadRequest, errUnknownClientCert)
+		case errors.Is(err, domain.ErrJobNotFinished):
+			apierror.Respond(ctx, http.StatusConflict, errJobNotFinished)
+		case errors.Is(err, domain.ErrDuplicateJob):
+			// Two retries within the same millisecond.
+			apierror.Respond(ctx, http.StatusConflict, errDuplicateJob)
+		case errors.Is(err, domain.ErrQuotaExceeded):
+			apierror.Respond(ctx, http.StatusTooManyRequests, errQuotaExceeded)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "retry job", "job_id", jobID, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, retryJobResponse{
+		ID:             job.ID,
+		RetriedFrom:    jobID,
+		IdempotencyKey: job.IdempotencyKey,
+		ScheduledAt:    job.ScheduledAt,
+		CreatedAt:      job.CreatedAt,
 	})
+}
+
+func (h *JobHandler) List(ctx *gin.Context) {
+	page, err := pageQuery(ctx)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidStatus) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": errInvalidStatus})
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidPageSize)
+		return
+	}
+
+	input := usecase.ListJobsInput{
+		UserID:         ctx.GetString("userID"),
+		Status:         ctx.Query("status"),
+		RequestID:      ctx.Query("request_id"),
+		URLContains:    ctx.Query("url_contains"),
+		IdempotencyKey: ctx.Query("idempotency_key"),
+		ScheduleID:     ctx.Query("schedule_id"),
+		Page:           page,
+	}
+	var errAfter, errBefore error
+	input.ScheduledAfter, errAfter = queryTime(ctx, "scheduled_after")
+	input.ScheduledBefore, errBefore = queryTime(ctx, "scheduled_before")
+	if errAfter != nil || errBefore != nil {
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidJobFilter)
+		return
+	}
+
+	result, err := h.jobUsecase.ListJobs(ctx.Request.Context(), input)
+	if err != nil {
+		if e, ok := pageError(err); ok {
+			apierror.Respond(ctx, http.StatusBadRequest, e)
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrInvalidStatus):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidStatus)
+			return
+		case errors.Is(err, domain.ErrInvalidJobFilter):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidJobFilter)
 			return
 		}
 		h.logger.ErrorContext(ctx.Request.Context(), "list jobs", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		return
 	}
 
@@ -134,97 +473,528 @@ func (h *JobHandler) List(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, listJobsResponse{
 		Jobs:       items,
 		NextCursor: result.NextCursor,
+		HasMore:    result.NextCursor != nil,
+		TotalCount: result.TotalCount,
 	})
 }
 
+// queryTime parses an optional RFC 3339 query parameter.
+func queryTime(ctx *gin.Context, name string) (*time.Time, error) {
+	raw := ctx.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
 func (h *JobHandler) Create(ctx *gin.Context) {
 	var req createJobRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondInvalid(ctx, err)
 		return
 	}
 
-	job, err := h.jobUsecase.CreateJob(ctx.Request.Context(), usecase.CreateJobInput{
-		UserID:         ctx.GetString("userID"),
-		IdempotencyKey: req.IdempotencyKey,
-		URL:            req.URL,
-		Method:         req.Method,
-		Headers:        req.Headers,
-		Body:           req.Body,
-		TimeoutSeconds: req.TimeoutSeconds,
-		ScheduledAt:    req.ScheduledAt,
-		MaxRetries:     req.MaxRetries,
-		Backoff:        req.Backoff,
-	})
+	input, err := req.toInput(ctx.GetString("userID"))
+	if err != nil {
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidRetryDelays)
+		return
+	}
+	input.ActorID = ctx.GetString("actorID")
+
+	job, err := h.jobUsecase.CreateJob(ctx.Request.Context(), input)
 	if err != nil {
-		if errors.Is(err, domain.ErrDuplicateJob) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": errDuplicateJob})
+		if e, ok := createJobError(err); ok {
+			apierror.Respond(ctx, http.StatusBadRequest, e)
+			return
+		}
+		if errors.Is(err, domain.ErrQuotaExceeded) {
+			apierror.Respond(ctx, http.StatusTooManyRequests, errQuotaExceeded)
 			return
 		}
 		h.logger.ErrorContext(ctx.Request.Context(), "create job", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, createJobResponse{
 		ID:        job.ID,
+		Status:    job.Status,
 		CreatedAt: job.CreatedAt,
 	})
 }
 
+// toInput converts req into usecase input. The only error is domain.ErrInvalidRetryDelays.
+func (req createJobRequest) toInput(userID string) (usecase.CreateJobInput, error) {
+	retryDelays := make([]time.Duration, len(req.RetryDelays))
+	for i, raw := range req.RetryDelays {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return usecase.CreateJobInput{}, domain.ErrInvalidRetryDelays
+		}
+		retryDelays[i] = d
+	}
+
+	return usecase.CreateJobInput{
+		UserID:           userID,
+		IdempotencyKey:   req.IdempotencyKey,
+		JobType:          req.JobType,
+		URL:              req.URL,
+		Method:           req.Method,
+		Topic:            req.Topic,
+		MessageKey:       req.MessageKey,
+		EmailTo:          req.EmailTo,
+		EmailSubject:     req.EmailSubject,
+		Headers:          req.Headers,
+		Body:             req.Body,
+		TimeoutSeconds:   req.TimeoutSeconds,
+		ScheduledAt:      req.ScheduledAt,
+		MaxRetries:       req.MaxRetries,
+		Backoff:          req.Backoff,
+		RetryBaseSeconds: req.RetryBaseSeconds,
+		RetryMaxSeconds:  req.RetryMaxSeconds,
+		RetryJitter:      req.RetryJitter,
+		Priority:         req.Priority,
+		Deadline:         req.Deadline,
+		ExpiresAt:        req.ExpiresAt,
+		RetryDelays:      retryDelays,
+		CallbackURL:      req.CallbackURL,
+		SuccessCodes:     req.SuccessCodes,
+		Templated:        req.Templated,
+		Debug:            req.Debug,
+		DependsOn:        req.DependsOn,
+		OnParentFailure:  req.OnParentFailure,
+		SigningSecret:    req.SigningSecret,
+		Queue:            req.Queue,
+		BodyEncoding:     req.BodyEncoding,
+		ContentType:      req.ContentType,
+		ProxyURL:         req.ProxyURL,
+		ClientCertID:     req.ClientCertID,
+		CABundle:         req.CABundle,
+		TLSSkipVerify:    req.TLSSkipVerify,
+	}, nil
+}
+
+// createJobError maps the per-job errors of job creation — a duplicate key or an invalid
+// field — to their client-facing errors. ok is false for any other error.
+func createJobError(err error) (e apierror.Error, ok bool) {
+	switch {
+	case errors.Is(err, domain.ErrDuplicateJob):
+		return errDuplicateJob, true
+	case errors.Is(err, domain.ErrInvalidDeadline):
+		return errInvalidDeadline, true
+	case errors.Is(err, domain.ErrInvalidExpiry):
+		return errInvalidExpiry, true
+	case errors.Is(err, domain.ErrInvalidRetryDelays):
+		return errInvalidRetryDelays, true
+	case errors.Is(err, domain.ErrInvalidRetryBackoff):
+		return errInvalidRetryBackoff, true
+	case errors.Is(err, domain.ErrInvalidSuccessCodes):
+		return errInvalidSuccessCodes, true
+	case errors.Is(err, domain.ErrInvalidPayloadTemplate):
+		return errInvalidPayloadTemplate, true
+	case errors.Is(err, domain.ErrInvalidDependency):
+		return errInvalidDependency, true
+	case errors.Is(err, domain.ErrParentJobFailed):
+		return errParentJobFailed, true
+	case errors.Is(err, domain.ErrInvalidSigningSecret):
+		return errInvalidSigningSecret, true
+	case errors.Is(err, domain.ErrInvalidQueue):
+		return errInvalidQueue, true
+	case errors.Is(err, domain.ErrUnknownJobType):
+		return errUnknownJobType, true
+	case errors.Is(err, domain.ErrInvalidJobTarget):
+		return errInvalidJobTarget, true
+	case errors.Is(err, domain.ErrInvalidBodyEncoding):
+		return errInvalidBodyEncoding, true
+	case errors.Is(err, domain.ErrSealedPrefix):
+		return errSealedPrefix, true
+	case errors.Is(err, domain.ErrInvalidContentType):
+		return errInvalidContentType, true
+	case errors.Is(err, domain.ErrInvalidProxyURL):
+		return errInvalidProxyURL, true
+	case errors.Is(err, domain.ErrClientCertNotFound):
+		return errUnknownClientCert, true
+	case errors.Is(err, domain.ErrInvalidTLSOptions):
+		return errInvalidTLSOptions, true
+	default:
+		return apierror.Error{}, false
+	}
+}
+
+type createJobBatchRequest struct {
+	Jobs []createJobRequest `json:"jobs" binding:"required,min=1,max=100,dive"`
+}
+
+// batchItemStatus is the outcome of one item of POST /jobs/batch.
+type batchItemStatus string
+
+const (
+	batchItemCreated   batchItemStatus = "created"
+	batchItemDuplicate batchItemStatus = "duplicate"
+	batchItemError     batchItemStatus = "error"
+)
+
+type batchItemResponse struct {
+	Index          int             `json:"index"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	Status         batchItemStatus `json:"status"`
+	ID             *string         `json:"id,omitempty"`
+	JobStatus      *domain.Status  `json:"job_status,omitempty"` // of a created job
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	Error          *apierror.Error `json:"error,omitempty"`
+}
+
+type createJobBatchResponse struct {
+	Created    int                 `json:"created"`
+	Duplicates int                 `json:"duplicates"`
+	Errors     int                 `json:"errors"`
+	Results    []batchItemResponse `json:"results"`
+}
+
+// CreateBatch creates up to 100 jobs in one transaction. Items that are invalid or whose
+// idempotency key already exists are reported individually; the rest are still created.
+// Malformed JSON, a failed binding rule, an exceeded quota, or a depends_on that can't be
+// chained rejects the whole batch.
+func (h *JobHandler) CreateBatch(ctx *gin.Context) {
+	var req createJobBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	userID := ctx.GetString("userID")
+	resp := createJobBatchResponse{Results: make([]batchItemResponse, len(req.Jobs))}
+
+	// Items that fail conversion never reach the usecase; pending maps the rest back.
+	var (
+		inputs  []usecase.CreateJobInput
+		pending []int
+	)
+	for i, item := range req.Jobs {
+		resp.Results[i] = batchItemResponse{Index: i, IdempotencyKey: item.IdempotencyKey}
+		input, err := item.toInput(userID)
+		if err != nil {
+			itemErr := errInvalidRetryDelays
+			resp.Results[i].Status = batchItemError
+			resp.Results[i].Error = &itemErr
+			continue
+		}
+		inputs = append(inputs, input)
+		pending = append(pending, i)
+	}
+
+	results, err := h.jobUsecase.CreateJobBatch(ctx.Request.Context(), userID, ctx.GetString("actorID"), inputs)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuotaExceeded) {
+			apierror.Respond(ctx, http.StatusTooManyRequests, errQuotaExceeded)
+			return
+		}
+		if e, ok := createJobError(err); ok {
+			apierror.Respond(ctx, http.StatusBadRequest, e)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "create job batch", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	for k, r := range results {
+		item := &resp.Results[pending[k]]
+		switch {
+		case r.Job != nil:
+			item.Status = batchItemCreated
+			item.ID = &r.Job.ID
+			item.JobStatus = &r.Job.Status
+			item.CreatedAt = &r.Job.CreatedAt
+		case errors.Is(r.Err, domain.ErrDuplicateJob):
+			item.Status = batchItemDuplicate
+		default:
+			itemErr, ok := createJobError(r.Err)
+			if !ok {
+				h.logger.ErrorContext(ctx.Request.Context(), "create job batch item", "index", pending[k], "error", r.Err)
+				itemErr = errInternalServer
+			}
+			item.Status = batchItemError
+			item.Error = &itemErr
+		}
+	}
+
+	for _, item := range resp.Results {
+		switch item.Status {
+		case batchItemCreated:
+			resp.Created++
+		case batchItemDuplicate:
+			resp.Duplicates++
+		case batchItemError:
+			resp.Errors++
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type listAttemptsResponse struct {
+	Attempts   []attemptResponse `json:"attempts"`
+	NextCursor *string           `json:"next_cursor"`
+	HasMore    bool              `json:"has_more"`
+	TotalCount *int64            `json:"total_count,omitempty"` // with include_total=true
+}
+
+// ListAttempts pages through a job's attempts, oldest first unless order=desc.
 func (h *JobHandler) ListAttempts(ctx *gin.Context) {
 	jobID := ctx.Param("id")
+	page, err := pageQuery(ctx)
+	if err != nil {
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidPageSize)
+		return
+	}
 
-	attempts, err := h.jobUsecase.ListAttempts(ctx.Request.Context(), jobID, ctx.GetString("userID"))
+	result, err := h.jobUsecase.ListAttempts(ctx.Request.Context(), usecase.ListAttemptsInput{
+		JobID:  jobID,
+		UserID: ctx.GetString("userID"),
+		Page:   page,
+	})
 	if err != nil {
+		if e, ok := pageError(err); ok {
+			apierror.Respond(ctx, http.StatusBadRequest, e)
+			return
+		}
 		if errors.Is(err, domain.ErrJobNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errJobNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
 			return
 		}
 		h.logger.ErrorContext(ctx.Request.Context(), "list attempts", "job_id", jobID, "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		return
 	}
 
-	resp := make([]attemptResponse, len(attempts))
-	for i, a := range attempts {
-		resp[i] = attemptResponse{
-			ID:          a.ID,
-			JobID:       a.JobID,
-			AttemptNum:  a.AttemptNum,
-			WorkerID:    a.WorkerID,
-			StartedAt:   a.StartedAt,
-			CompletedAt: a.CompletedAt,
-			StatusCode:  a.StatusCode,
-			Error:       a.Error,
-			DurationMS:  a.DurationMS,
+	resp := listAttemptsResponse{
+		Attempts:   make([]attemptResponse, len(result.Attempts)),
+		NextCursor: result.NextCursor,
+		HasMore:    result.NextCursor != nil,
+		TotalCount: result.TotalCount,
+	}
+	for i, a := range result.Attempts {
+		resp.Attempts[i] = toAttemptResponse(a)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *JobHandler) GetAttempt(ctx *gin.Context) {
+	jobID := ctx.Param("id")
+
+	a, err := h.jobUsecase.GetAttempt(ctx.Request.Context(), jobID, ctx.Param("attempt_id"), ctx.GetString("userID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrJobNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+		case errors.Is(err, domain.ErrAttemptNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errAttemptNotFound)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "get attempt", "job_id", jobID, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	resp := attemptDetailResponse{attemptResponse: toAttemptResponse(a)}
+	if a.RequestMethod != nil && a.RequestURL != nil {
+		resp.Request = &attemptRequestResponse{
+			Method:  *a.RequestMethod,
+			URL:     *a.RequestURL,
+			Headers: a.RequestHeaders,
+			Body:    a.RequestBody,
 		}
 	}
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// DiffAttempts reports what changed between consecutive attempts. ?from=N limits the
+// response to the diff between attempt N and N+1.
+func (h *JobHandler) DiffAttempts(ctx *gin.Context) {
+	jobID := ctx.Param("id")
+
+	var from int
+	if raw := ctx.Query("from"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidAttemptNum)
+			return
+		}
+		from = n
+	}
+
+	diffs, err := h.jobUsecase.DiffAttempts(ctx.Request.Context(), jobID, ctx.GetString("userID"), from)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrJobNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+		case errors.Is(err, domain.ErrAttemptNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errAttemptNotFound)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "diff attempts", "job_id", jobID, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	resp := make([]attemptDiffResponse, len(diffs))
+	for i, d := range diffs {
+		changes := make([]attemptChangeResponse, len(d.Changes))
+		for j, c := range d.Changes {
+			changes[j] = attemptChangeResponse{Field: c.Field, From: c.From, To: c.To}
+		}
+		resp[i] = attemptDiffResponse{
+			FromAttempt:    d.FromAttempt,
+			ToAttempt:      d.ToAttempt,
+			Changes:        changes,
+			LatencyDeltaMS: d.LatencyDeltaMS,
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"job_id": jobID, "diffs": resp})
+}
+
 func (h *JobHandler) GetByID(ctx *gin.Context) {
 	jobID := ctx.Param("id")
 
 	job, err := h.jobUsecase.GetByID(ctx.Request.Context(), jobID, ctx.GetString("userID"))
 	if err != nil {
 		if errors.Is(err, domain.ErrJobNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errJobNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
 			return
 		}
 		h.logger.ErrorContext(ctx.Request.Context(), "get job by id", "job_id", jobID, "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	// Every job update moves updated_at. A running job's response also counts the
+	// seconds since its last heartbeat, which changes with no update, so it gets no ETag.
+	if job.Status != domain.StatusRunning && notModified(ctx, etag(job.UpdatedAt)) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, getJobResponse{
+	resp := getJobResponse{
 		ID:          job.ID,
 		Status:      job.Status,
 		ScheduledAt: job.ScheduledAt,
+		Priority:    job.Priority,
+		Deadline:    job.Deadline,
+		ExpiresAt:   job.ExpiresAt,
 		CreatedAt:   job.CreatedAt,
 		UpdatedAt:   job.UpdatedAt,
 		CompletedAt: job.CompletedAt,
 		LastError:   job.LastError,
 		ScheduleID:  job.ScheduleID,
-	})
+		RequestID:   job.RequestID,
+		CallbackURL: job.CallbackURL,
+		ClaimedBy:   job.ClaimedBy,
+		ClaimedAt:   job.ClaimedAt,
+		HeartbeatAt: job.HeartbeatAt,
+	}
+	resp.CancelRequestedAt = job.CancelRequestedAt
+	resp.CreatedBy = job.CreatedBy
+	resp.ReviewedBy = job.ReviewedBy
+	resp.ReviewedAt = job.ReviewedAt
+	resp.SuccessCodes = job.SuccessCodes
+	resp.Templated = job.Templated
+	resp.Debug = job.Debug
+	resp.Signed = job.SigningSecret != nil
+	resp.Queue = job.Queue
+	resp.JobType = job.JobType
+	resp.Topic = job.Topic
+	resp.MessageKey = job.MessageKey
+	resp.EmailTo = job.EmailTo
+	resp.EmailSubject = job.EmailSubject
+	resp.BodyEncoding = job.BodyEncoding
+	resp.ContentType = job.ContentType
+	resp.ProxyURL = domain.RedactProxyURL(job.ProxyURL)
+	resp.ClientCertID = job.ClientCertID
+	resp.CABundle = job.CABundle
+	resp.TLSSkipVerify = job.TLSSkipVerify
+	resp.RetryBaseSeconds = job.RetryBaseSeconds
+	resp.RetryMaxSeconds = job.RetryMaxSeconds
+	resp.RetryJitter = job.RetryJitter
+	if job.DependsOn != nil {
+		resp.DependsOn = job.DependsOn
+		resp.OnParentFailure = &job.OnParentFailure
+	}
+	resp.RetriedFrom = job.RetriedFrom
+	for _, d := range job.RetryDelays {
+		resp.RetryDelays = append(resp.RetryDelays, d.String())
+	}
+	if job.Status == domain.StatusRunning && job.HeartbeatAt != nil {
+		since := time.Since(*job.HeartbeatAt).Seconds()
+		resp.SecondsSinceLastHeartbeat = &since
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListCallbacks returns the job's completion callbacks with their delivery logs.
+func (h *JobHandler) ListCallbacks(ctx *gin.Context) {
+	jobID := ctx.Param("id")
+
+	callbacks, err := h.jobUsecase.ListCallbacks(ctx.Request.Context(), jobID, ctx.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrJobNotFound) {
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "list callbacks", "job_id", jobID, "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := make([]callbackResponse, len(callbacks))
+	for i, cb := range callbacks {
+		history := make([]callbackAttemptResponse, len(cb.History))
+		for j, a := range cb.History {
+			history[j] = callbackAttemptResponse{
+				AttemptNum:      a.AttemptNum,
+				StatusCode:      a.StatusCode,
+				Error:           a.Error,
+				ResponseSnippet: a.ResponseSnippet,
+				DurationMS:      a.DurationMS,
+				CreatedAt:       a.CreatedAt,
+			}
+		}
+		resp[i] = callbackResponse{
+			ID:          cb.ID,
+			Event:       cb.Event,
+			URL:         cb.URL,
+			Status:      cb.Status,
+			Attempts:    cb.Attempts,
+			LastError:   cb.LastError,
+			DeliveredAt: cb.DeliveredAt,
+			CreatedAt:   cb.CreatedAt,
+			History:     history,
+		}
+		if cb.Status == domain.CallbackStatusPending {
+			resp[i].NextAttemptAt = &cb.NextAttemptAt
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"job_id": jobID, "callbacks": resp})
+}
+
+// RetryCallbacks queues the job's callbacks for redelivery.
+func (h *JobHandler) RetryCallbacks(ctx *gin.Context) {
+	jobID := ctx.Param("id")
+
+	n, err := h.jobUsecase.RedeliverCallbacks(ctx.Request.Context(), jobID, ctx.GetString("userID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrJobNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errJobNotFound)
+		case errors.Is(err, domain.ErrNoCallbacks):
+			apierror.Respond(ctx, http.StatusNotFound, errNoCallbacks)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "redeliver callbacks", "job_id", jobID, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"job_id": jobID, "queued": n})
 }
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// streamHeartbeat keeps idle streams alive through proxies that close quiet connections
+// (nginx's default proxy read timeout is 60s).
+const streamHeartbeat = 15 * time.Second
+
+type jobStatusEventResponse struct {
+	JobID          string        `json:"job_id"`
+	ScheduleID     *string       `json:"schedule_id,omitempty"`
+	Status         domain.Status `json:"status"`
+	PreviousStatus domain.Status `json:"previous_status,omitempty"`
+	At             time.Time     `json:"at"`
+}
+
+// Stream pushes the user's job status transitions as Server-Sent Events named "status".
+// The stream ends when the client falls too far behind or the server shuts down; clients
+// should reconnect and re-list jobs to catch up on anything missed.
+func (h *JobHandler) Stream(ctx *gin.Context) {
+	events, cancel := h.stream.Subscribe(ctx.Request.Context(), ctx.GetString("userID"))
+	defer cancel()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no") // stop nginx buffering the stream
+	ctx.Status(http.StatusOK)
+	_, _ = io.WriteString(ctx.Writer, ": connected\n\n")
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent("status", jobStatusEventResponse{
+				JobID:          e.JobID,
+				ScheduleID:     e.ScheduleID,
+				Status:         e.Status,
+				PreviousStatus: e.PreviousStatus,
+				At:             e.At,
+			})
+			return true
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": ping\n\n")
+			return true
+		}
+	})
+}
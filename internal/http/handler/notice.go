@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type NoticeHandler struct {
+	uc     *usecase.NoticeUsecase
+	logger *slog.Logger
+}
+
+func NewNoticeHandler(uc *usecase.NoticeUsecase, logger *slog.Logger) *NoticeHandler {
+	return &NoticeHandler{uc: uc, logger: logger.With("component", "notice_handler")}
+}
+
+// Routes mounts the public notice feed on rg.
+func (h *NoticeHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("", h.Current)
+}
+
+// AdminRoutes mounts notice management on rg; the caller must gate it to admins.
+func (h *NoticeHandler) AdminRoutes(rg *gin.RouterGroup) {
+	rg.GET("", h.List)
+	rg.POST("", h.Create)
+	rg.DELETE("/:id", h.Delete)
+}
+
+type createNoticeRequest struct {
+	Title    string                `json:"title"     binding:"required,max=200"`
+	Body     string                `json:"body"      binding:"max=4000"`
+	Severity domain.NoticeSeverity `json:"severity"  binding:"required,oneof=info warning critical"`
+	StartsAt *time.Time            `json:"starts_at"`
+	EndsAt   *time.Time            `json:"ends_at"`
+}
+
+type noticeResponse struct {
+	ID       string                `json:"id"`
+	Title    string                `json:"title"`
+	Body     string                `json:"body"`
+	Severity domain.NoticeSeverity `json:"severity"`
+	StartsAt time.Time             `json:"starts_at"`
+	EndsAt   *time.Time            `json:"ends_at"`
+	Active   bool                  `json:"active"`
+}
+
+func toNoticeResponses(notices []*domain.Notice) []noticeResponse {
+	now := time.Now()
+	resp := make([]noticeResponse, len(notices))
+	for i, n := range notices {
+		resp[i] = noticeResponse{
+			ID:       n.ID,
+			Title:    n.Title,
+			Body:     n.Body,
+			Severity: n.Severity,
+			StartsAt: n.StartsAt,
+			EndsAt:   n.EndsAt,
+			Active:   !n.StartsAt.After(now) && (n.EndsAt == nil || n.EndsAt.After(now)),
+		}
+	}
+	return resp
+}
+
+func (h *NoticeHandler) Current(ctx *gin.Context) {
+	notices, err := h.uc.Current(ctx.Request.Context())
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "list current notices", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"notices": toNoticeResponses(notices)})
+}
+
+func (h *NoticeHandler) List(ctx *gin.Context) {
+	notices, err := h.uc.List(ctx.Request.Context())
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "list notices", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"notices": toNoticeResponses(notices)})
+}
+
+func (h *NoticeHandler) Create(ctx *gin.Context) {
+	var req createNoticeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	n, err := h.uc.Create(ctx.Request.Context(), usecase.CreateNoticeInput{
+		Title:     req.Title,
+		Body:      req.Body,
+		Severity:  req.Severity,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		CreatedBy: ctx.GetString("userID"),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidNoticeWindow) {
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidNoticeWindow)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "create notice", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusCreated, toNoticeResponses([]*domain.Notice{n})[0])
+}
+
+func (h *NoticeHandler) Delete(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	if err := h.uc.Delete(ctx.Request.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNoticeNotFound) {
+			apierror.Respond(ctx, http.StatusNotFound, errNoticeNotFound)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "delete notice", "notice_id", id, "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
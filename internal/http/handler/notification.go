@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	uc     *usecase.NotificationUsecase
+	logger *slog.Logger
+}
+
+func NewNotificationHandler(uc *usecase.NotificationUsecase, logger *slog.Logger) *NotificationHandler {
+	return &NotificationHandler{uc: uc, logger: logger.With("component", "notification_handler")}
+}
+
+// Routes mounts the notification rule endpoints on rg.
+func (h *NotificationHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("/rules", h.ListRules)
+	rg.POST("/rules", h.CreateRule)
+	rg.DELETE("/rules/:id", h.DeleteRule)
+}
+
+type createNotificationRuleRequest struct {
+	Event      domain.NotificationEvent   `json:"event"       binding:"required,oneof=job.failed schedule.failing digest.daily digest.weekly quota.warning approval.requested"`
+	Channel    domain.NotificationChannel `json:"channel"     binding:"required,oneof=email slack"`
+	Target     string                     `json:"target"      binding:"required,max=2048"`
+	ScheduleID *string                    `json:"schedule_id"`
+	Threshold  int                        `json:"threshold"   binding:"min=0,max=100"`
+}
+
+type notificationRuleResponse struct {
+	ID         string                     `json:"id"`
+	Event      domain.NotificationEvent   `json:"event"`
+	Channel    domain.NotificationChannel `json:"channel"`
+	Target     string                     `json:"target"`
+	ScheduleID *string                    `json:"schedule_id"`
+	Threshold  int                        `json:"threshold"`
+	CreatedAt  time.Time                  `json:"created_at"`
+}
+
+func toNotificationRuleResponse(r *domain.NotificationRule) notificationRuleResponse {
+	return notificationRuleResponse{
+		ID:         r.ID,
+		Event:      r.Event,
+		Channel:    r.Channel,
+		Target:     r.Target,
+		ScheduleID: r.ScheduleID,
+		Threshold:  r.Threshold,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func (h *NotificationHandler) ListRules(ctx *gin.Context) {
+	rules, err := h.uc.ListRules(ctx.Request.Context(), ctx.GetString("userID"))
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "list notification rules", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := make([]notificationRuleResponse, len(rules))
+	for i, r := range rules {
+		resp[i] = toNotificationRuleResponse(r)
+	}
+	ctx.JSON(http.StatusOK, gin.H{"rules": resp})
+}
+
+func (h *NotificationHandler) CreateRule(ctx *gin.Context) {
+	var req createNotificationRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	rule, err := h.uc.CreateRule(ctx.Request.Context(), usecase.CreateNotificationRuleInput{
+		UserID:     ctx.GetString("userID"),
+		ScheduleID: req.ScheduleID,
+		Event:      req.Event,
+		Channel:    req.Channel,
+		Target:     req.Target,
+		Threshold:  req.Threshold,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidNotificationRule):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidNotificationRule)
+		case errors.Is(err, domain.ErrScheduleNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "create notification rule", "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, toNotificationRuleResponse(rule))
+}
+
+func (h *NotificationHandler) DeleteRule(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	if err := h.uc.DeleteRule(ctx.Request.Context(), id, ctx.GetString("userID")); err != nil {
+		if errors.Is(err, domain.ErrNotificationRuleNotFound) {
+			apierror.Respond(ctx, http.StatusNotFound, errNotificationRuleNotFound)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "delete notification rule", "rule_id", id, "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,342 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIVersion pins the swagger-ui-dist release the docs page loads from the CDN.
+const swaggerUIVersion = "5.17.14"
+
+// OpenAPIHandler serves the API's OpenAPI 3.1 document and, optionally, a Swagger UI
+// page for it. The document is built once from apiOperations.
+type OpenAPIHandler struct {
+	spec   []byte
+	docsUI bool
+}
+
+func NewOpenAPIHandler(docsUI bool) *OpenAPIHandler {
+	spec, err := json.Marshal(OpenAPIDocument())
+	if err != nil {
+		panic(err) // the document is built from static types; TestOpenAPIDocument covers it
+	}
+	return &OpenAPIHandler{spec: spec, docsUI: docsUI}
+}
+
+// OpenAPIDocument describes every route mounted by the handlers in this package.
+func OpenAPIDocument() *openapi.Document {
+	return openapi.Build(openapi.Info{
+		Title:       "dist-job-scheduler API",
+		Version:     "1",
+		Description: "Schedule HTTP requests as one-off jobs or recurring schedules. Authenticate with a bearer JWT; send X-Org-ID to act in an organization.",
+	}, apiOperations())
+}
+
+// Routes mounts the document (and the docs page, if enabled) on rg.
+func (h *OpenAPIHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("/openapi.json", h.Spec)
+	if h.docsUI {
+		rg.GET("/docs", h.Docs)
+	}
+}
+
+func (h *OpenAPIHandler) Spec(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.Data(http.StatusOK, "application/json", h.spec)
+}
+
+func (h *OpenAPIHandler) Docs(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+const swaggerUIPage = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>dist-job-scheduler API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+var (
+	pageParams = []openapi.Param{
+		{Name: "page_size", Type: "integer", Description: "1 to 100 (default 20); limit is accepted as an alias"},
+		{Name: "order", Type: "string", Description: "asc or desc (default, newest first)"},
+		{Name: "cursor", Type: "string", Description: "next_cursor from the previous page, requested with the same order"},
+		{Name: "include_total", Type: "boolean", Description: "add total_count, which counts every match"},
+	}
+	notModified304 = openapi.Response{Status: http.StatusNotModified, Description: "Unchanged since the ETag in If-None-Match"}
+	windowParam    = openapi.Param{Name: "window", Type: "string", Description: `Go duration, e.g. "24h" (default)`}
+)
+
+// apiOperations lists every route with the types its handler binds and renders. Keep it
+// in step with the Routes methods; TestOpenAPIDocument fails on a missing or stale entry.
+// Paths carry the prefixes cmd/server mounts the handlers under.
+func apiOperations() []openapi.Operation {
+	const (
+		tagJobs          = "jobs"
+		tagSchedules     = "schedules"
+		tagAccount       = "account"
+		tagTemplates     = "templates"
+		tagNotifications = "notifications"
+		tagCertificates  = "certificates"
+		tagOrgs          = "organizations"
+		tagNotices       = "notices"
+		tagMeta          = "meta"
+	)
+	noContent := []openapi.Response{{Status: http.StatusNoContent}}
+
+	return []openapi.Operation{
+		// Jobs
+		{Method: "GET", Path: "/jobs", Tag: tagJobs, Summary: "List jobs",
+			Query: append([]openapi.Param{
+				{Name: "status", Type: "string", Description: "only jobs in this status"},
+				{Name: "request_id", Type: "string", Description: "only jobs created by this request"},
+				{Name: "url_contains", Type: "string", Description: "only jobs whose URL contains this, ignoring case"},
+				{Name: "idempotency_key", Type: "string", Description: "only the job with this idempotency key"},
+				{Name: "schedule_id", Type: "string", Description: "only jobs fired by this schedule"},
+				{Name: "scheduled_after", Type: "string", Description: "only jobs scheduled at or after this RFC 3339 time"},
+				{Name: "scheduled_before", Type: "string", Description: "only jobs scheduled before this RFC 3339 time"},
+			}, pageParams...),
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: listJobsResponse{}}}},
+		{Method: "POST", Path: "/jobs", Tag: tagJobs, Summary: "Create a job",
+			Request:   createJobRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: createJobResponse{}}}},
+		{Method: "POST", Path: "/jobs/batch", Tag: tagJobs, Summary: "Create up to 100 jobs in one transaction",
+			Request:   createJobBatchRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: createJobBatchResponse{}}}},
+		{Method: "POST", Path: "/jobs/dry-run", Tag: tagJobs, Summary: "Send a job's request once without creating the job",
+			Request:   dryRunJobRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: dryRunResponse{}}}},
+		{Method: "GET", Path: "/jobs/stream", Tag: tagJobs, Summary: `Stream job status transitions as Server-Sent Events named "status"`,
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: jobStatusEventResponse{}, ContentType: "text/event-stream"}}},
+		{Method: "GET", Path: "/jobs/:id", Tag: tagJobs, Summary: "Get a job; send If-None-Match with its ETag to poll",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: getJobResponse{}}, notModified304}},
+		{Method: "DELETE", Path: "/jobs/:id", Tag: tagJobs, Summary: "Cancel a job",
+			Responses: []openapi.Response{
+				{Status: http.StatusNoContent, Description: "Cancelled"},
+				{Status: http.StatusAccepted, Description: "Cancellation requested; the running attempt is being aborted"},
+			}},
+		{Method: "POST", Path: "/jobs/:id/pause", Tag: tagJobs, Summary: "Pause a pending job", Responses: noContent},
+		{Method: "POST", Path: "/jobs/:id/resume", Tag: tagJobs, Summary: "Resume a paused job", Responses: noContent},
+		{Method: "POST", Path: "/jobs/:id/retry", Tag: tagJobs, Summary: "Resubmit a finished job as a new job",
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: retryJobResponse{}}}},
+		{Method: "GET", Path: "/jobs/:id/attempts", Tag: tagJobs, Summary: "List a job's attempts, oldest first by default",
+			Query: []openapi.Param{
+				{Name: "page_size", Type: "integer", Description: "1 to 100 (default 100); limit is accepted as an alias"},
+				{Name: "order", Type: "string", Description: "asc (default) or desc for the latest attempt first"},
+				{Name: "cursor", Type: "string", Description: "next_cursor from the previous page, requested with the same order"},
+				{Name: "include_total", Type: "boolean", Description: "add total_count"},
+			},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: listAttemptsResponse{}}}},
+		{Method: "GET", Path: "/jobs/:id/attempts/diff", Tag: tagJobs, Summary: "Diff consecutive attempts",
+			Query: []openapi.Param{{Name: "from", Type: "integer", Description: "compare attempt N with N+1 only"}},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				JobID string                `json:"job_id"`
+				Diffs []attemptDiffResponse `json:"diffs"`
+			}{}}}},
+		{Method: "GET", Path: "/jobs/:id/attempts/:attempt_id", Tag: tagJobs, Summary: "Get one attempt with its request snapshot",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: attemptDetailResponse{}}}},
+		{Method: "GET", Path: "/jobs/:id/callbacks", Tag: tagJobs, Summary: "List a job's completion callbacks",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				JobID     string             `json:"job_id"`
+				Callbacks []callbackResponse `json:"callbacks"`
+			}{}}}},
+		{Method: "POST", Path: "/jobs/:id/callbacks/retry", Tag: tagJobs, Summary: "Redeliver failed callbacks",
+			Responses: []openapi.Response{{Status: http.StatusAccepted, Body: struct {
+				JobID  string `json:"job_id"`
+				Queued int    `json:"queued"`
+			}{}}}},
+
+		// Schedules
+		{Method: "POST", Path: "/schedules", Tag: tagSchedules, Summary: "Create a schedule",
+			Request:   createScheduleRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: scheduleResponse{}}}},
+		{Method: "GET", Path: "/schedules", Tag: tagSchedules, Summary: "List schedules",
+			Query:     pageParams,
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: listSchedulesResponse{}}}},
+		{Method: "GET", Path: "/schedules/export", Tag: tagSchedules, Summary: "Export all schedules, secrets included (viewers get 403)",
+			Query: []openapi.Param{
+				{Name: "include_history", Type: "boolean", Description: "add a run summary per schedule"},
+				{Name: "format", Type: "string", Description: "json (default) or yaml"},
+			},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: scheduleExportDocument{}}}},
+		{Method: "POST", Path: "/schedules/preview", Tag: tagSchedules, Summary: "List the next fire times of a cron expression or interval",
+			Request:   previewScheduleRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: previewScheduleResponse{}}}},
+		{Method: "POST", Path: "/schedules/import", Tag: tagSchedules, Summary: "Import an export document (JSON, or YAML with a YAML Content-Type)",
+			Query:     []openapi.Param{{Name: "mode", Type: "string", Description: "apply (default); dry_run reports without writing; diff also lists changed fields of existing schedules"}},
+			Request:   importSchedulesRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: importSchedulesResponse{}}}},
+		{Method: "PUT", Path: "/schedules/sync", Tag: tagSchedules, Summary: "Create, update and delete schedules to match a document (JSON, or YAML with a YAML Content-Type)",
+			Query:     []openapi.Param{{Name: "dry_run", Type: "boolean", Description: "return the plan without applying it"}},
+			Request:   syncSchedulesRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: syncSchedulesResponse{}}}},
+		{Method: "GET", Path: "/schedules/:id", Tag: tagSchedules, Summary: "Get a schedule; send If-None-Match with its ETag to poll",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: scheduleResponse{}}, notModified304}},
+		{Method: "PATCH", Path: "/schedules/:id", Tag: tagSchedules, Summary: "Update a schedule; omitted fields are unchanged",
+			Request:   updateScheduleRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: scheduleResponse{}}}},
+		{Method: "POST", Path: "/schedules/:id/pause", Tag: tagSchedules, Summary: "Pause a schedule", Responses: noContent},
+		{Method: "POST", Path: "/schedules/:id/resume", Tag: tagSchedules, Summary: "Resume a schedule", Responses: noContent},
+		{Method: "POST", Path: "/schedules/:id/trigger", Tag: tagSchedules, Summary: "Fire a schedule now",
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: triggerScheduleResponse{}}}},
+		{Method: "POST", Path: "/schedules/:id/dry-run", Tag: tagSchedules, Summary: "Send the schedule's request once without firing it",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: dryRunResponse{}}}},
+		{Method: "DELETE", Path: "/schedules/:id", Tag: tagSchedules, Summary: "Delete a schedule", Responses: noContent},
+		{Method: "GET", Path: "/schedules/:id/jobs", Tag: tagSchedules, Summary: "List the jobs a schedule fired",
+			Query:     pageParams,
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: listJobsResponse{}}}},
+		{Method: "GET", Path: "/schedules/:id/uptime", Tag: tagSchedules, Summary: "Uptime of a ping schedule",
+			Query:     []openapi.Param{windowParam},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: uptimeResponse{}}}},
+		{Method: "GET", Path: "/schedules/:id/stats", Tag: tagSchedules, Summary: "Job counts, success rate and durations for a schedule",
+			Query:     []openapi.Param{windowParam},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: statsResponse{}}}},
+		{Method: "GET", Path: "/schedules/:id/revisions", Tag: tagSchedules, Summary: "List a schedule's revisions",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				ScheduleID string             `json:"schedule_id"`
+				Revisions  []revisionResponse `json:"revisions"`
+			}{}}}},
+		{Method: "POST", Path: "/schedules/:id/revisions/:revision/revert", Tag: tagSchedules, Summary: "Restore a schedule to a revision",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: scheduleResponse{}}}},
+
+		// Account
+		{Method: "GET", Path: "/account/usage", Tag: tagAccount, Summary: "Quota usage",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: usageResponse{}}}},
+		{Method: "GET", Path: "/account/api-usage", Tag: tagAccount, Summary: "Per-endpoint request counts",
+			Query:     []openapi.Param{windowParam},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: apiUsageResponse{}}}},
+		{Method: "GET", Path: "/account/egress", Tag: tagAccount, Summary: "Bytes sent and received by job attempts",
+			Query:     []openapi.Param{windowParam},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: egressResponse{}}}},
+		{Method: "GET", Path: "/account/defaults", Tag: tagAccount, Summary: "Job defaults",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: defaultsResponse{}}}},
+		{Method: "PATCH", Path: "/account/defaults", Tag: tagAccount, Summary: "Update job defaults; omitted fields are unchanged",
+			Request:   updateDefaultsRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: defaultsResponse{}}}},
+
+		// Templates and search
+		{Method: "GET", Path: "/templates/catalog", Tag: tagTemplates, Summary: "List job and schedule templates",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Templates []templateResponse `json:"templates"`
+			}{}}}},
+		{Method: "POST", Path: "/templates/:id/instantiate", Tag: tagTemplates, Summary: "Create a job or schedule from a template",
+			Request: instantiateTemplateRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: struct {
+				Kind     domain.TemplateKind `json:"kind"`
+				Job      *createJobResponse  `json:"job,omitempty"`
+				Schedule *scheduleResponse   `json:"schedule,omitempty"`
+			}{}}}},
+		{Method: "GET", Path: "/stats", Tag: tagMeta, Summary: "Job counts, success rate and durations across the account",
+			Query:     []openapi.Param{windowParam},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: statsResponse{}}}},
+		{Method: "GET", Path: "/search", Tag: tagMeta, Summary: "Search jobs and schedules",
+			Query: []openapi.Param{{Name: "q", Type: "string", Description: "search text"}},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Query   string             `json:"query"`
+				Results []searchResultItem `json:"results"`
+			}{}}}},
+
+		// Notifications
+		{Method: "GET", Path: "/notifications/rules", Tag: tagNotifications, Summary: "List notification rules",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Rules []notificationRuleResponse `json:"rules"`
+			}{}}}},
+		{Method: "POST", Path: "/notifications/rules", Tag: tagNotifications, Summary: "Create a notification rule",
+			Request:   createNotificationRuleRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: notificationRuleResponse{}}}},
+		{Method: "DELETE", Path: "/notifications/rules/:id", Tag: tagNotifications, Summary: "Delete a notification rule", Responses: noContent},
+
+		// Client certificates
+		{Method: "GET", Path: "/certificates", Tag: tagCertificates, Summary: "List your client certificates for mutual TLS",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Certificates []clientCertResponse `json:"certificates"`
+			}{}}}},
+		{Method: "POST", Path: "/certificates", Tag: tagCertificates, Summary: "Upload a client certificate and key; reference it from jobs as client_cert_id",
+			Request:   createClientCertRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: clientCertResponse{}}}},
+		{Method: "DELETE", Path: "/certificates/:id", Tag: tagCertificates, Summary: "Delete a client certificate no unfinished job or schedule uses", Responses: noContent},
+
+		// Organizations
+		{Method: "GET", Path: "/orgs", Tag: tagOrgs, Summary: "List your organizations and your role in each",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Organizations []orgResponse `json:"organizations"`
+			}{}}}},
+		{Method: "POST", Path: "/orgs", Tag: tagOrgs, Summary: "Create an organization, with you as its owner",
+			Request:   createOrgRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: orgResponse{}}}},
+		{Method: "POST", Path: "/orgs/invitations/accept", Tag: tagOrgs, Summary: "Join an organization with an invitation token",
+			Request: acceptInvitationRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				OrgID string         `json:"org_id"`
+				Role  domain.OrgRole `json:"role"`
+			}{}}}},
+		{Method: "GET", Path: "/orgs/:id/members", Tag: tagOrgs, Summary: "List an organization's members",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Members []orgMemberResponse `json:"members"`
+			}{}}}},
+		{Method: "PATCH", Path: "/orgs/:id/members/:user_id", Tag: tagOrgs, Summary: "Change a member's role (owners only)",
+			Request: setMemberRoleRequest{}, Responses: noContent},
+		{Method: "DELETE", Path: "/orgs/:id/members/:user_id", Tag: tagOrgs, Summary: "Remove a member (owners), or leave the organization", Responses: noContent},
+		{Method: "GET", Path: "/orgs/:id/invitations", Tag: tagOrgs, Summary: "List pending invitations (owners only)",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Invitations []invitationResponse `json:"invitations"`
+			}{}}}},
+		{Method: "POST", Path: "/orgs/:id/invitations", Tag: tagOrgs, Summary: "Invite a member (owners only); the token is shown only in this response",
+			Request:   createInvitationRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: createInvitationResponse{}}}},
+		{Method: "DELETE", Path: "/orgs/:id/invitations/:invitation_id", Tag: tagOrgs, Summary: "Revoke a pending invitation (owners only)", Responses: noContent},
+		{Method: "PUT", Path: "/orgs/:id/encryption-key", Tag: tagOrgs, Summary: "Encrypt the organization's new secrets with its own KMS key (owners only)",
+			Request: setEncryptionKeyRequest{}, Responses: noContent},
+		{Method: "DELETE", Path: "/orgs/:id/encryption-key", Tag: tagOrgs, Summary: "Go back to the deployment's encryption keys for new secrets (owners only)", Responses: noContent},
+		{Method: "PUT", Path: "/orgs/:id/approval-mode", Tag: tagOrgs, Summary: "Hold jobs that members other than owners create until an owner approves them (owners only)",
+			Request: setApprovalModeRequest{}, Responses: noContent},
+		{Method: "GET", Path: "/orgs/:id/approvals", Tag: tagOrgs, Summary: "List the organization's jobs awaiting approval, those due first",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: approvalList{}}}},
+		{Method: "POST", Path: "/orgs/:id/approvals/:job_id/approve", Tag: tagOrgs, Summary: "Approve a job awaiting approval (owners only)",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: reviewResponse{}}}},
+		{Method: "POST", Path: "/orgs/:id/approvals/:job_id/reject", Tag: tagOrgs, Summary: "Reject a job awaiting approval, cancelling it (owners only)",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: reviewResponse{}}}},
+
+		// Notices
+		{Method: "GET", Path: "/notices", Tag: tagNotices, Summary: "Active service notices", Public: true,
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: noticeList{}}}},
+		{Method: "GET", Path: "/admin/notices", Tag: tagNotices, Summary: "List all notices (admin)",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: noticeList{}}}},
+		{Method: "POST", Path: "/admin/notices", Tag: tagNotices, Summary: "Create a notice (admin)",
+			Request:   createNoticeRequest{},
+			Responses: []openapi.Response{{Status: http.StatusCreated, Body: noticeResponse{}}}},
+		{Method: "DELETE", Path: "/admin/notices/:id", Tag: tagNotices, Summary: "Delete a notice (admin)", Responses: noContent},
+
+		// Schemas and this document
+		{Method: "GET", Path: "/schemas/events", Tag: tagMeta, Summary: "List callback event schemas", Public: true,
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: struct {
+				Events []eventSchemaLink `json:"events"`
+			}{}}}},
+		{Method: "GET", Path: "/schemas/events/:file", Tag: tagMeta, Summary: "JSON Schema of a callback event, e.g. job.completed.json", Public: true,
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "JSON Schema (draft 2020-12)"}}},
+		{Method: "GET", Path: "/openapi.json", Tag: tagMeta, Summary: "This document", Public: true,
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "OpenAPI 3.1 document"}}},
+	}
+}
+
+type approvalList struct {
+	Approvals []approvalItem `json:"approvals"`
+}
+
+type noticeList struct {
+	Notices []noticeResponse `json:"notices"`
+}
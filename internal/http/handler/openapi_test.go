@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestOpenAPIDocument checks that the document lists exactly the routes the handlers
+// mount, under the prefixes cmd/server mounts them at.
+func TestOpenAPIDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	(&JobHandler{}).Routes(r.Group("/jobs"))
+	(&ScheduleHandler{}).Routes(r.Group("/schedules"))
+	(&AccountHandler{}).Routes(r.Group("/account"))
+	(&TemplateHandler{}).Routes(r.Group("/templates"))
+	(&SearchHandler{}).Routes(r.Group("/search"))
+	(&StatsHandler{}).Routes(r.Group("/stats"))
+	(&StatsHandler{}).ScheduleRoutes(r.Group("/schedules"))
+	(&NotificationHandler{}).Routes(r.Group("/notifications"))
+	(&ClientCertHandler{}).Routes(r.Group("/certificates"))
+	(&OrgHandler{}).Routes(r.Group("/orgs"))
+	(&ApprovalHandler{}).Routes(r.Group("/orgs"))
+	(&NoticeHandler{}).Routes(r.Group("/notices"))
+	(&NoticeHandler{}).AdminRoutes(r.Group("/admin/notices"))
+	NewSchemaHandler().Routes(r.Group("/schemas"))
+	NewOpenAPIHandler(false).Routes(r.Group(""))
+
+	param := regexp.MustCompile(`:(\w+)`)
+	var mounted []string
+	for _, route := range r.Routes() {
+		path := strings.TrimSuffix(param.ReplaceAllString(route.Path, "{$1}"), "/")
+		mounted = append(mounted, strings.ToLower(route.Method)+" "+path)
+	}
+
+	doc := OpenAPIDocument()
+	var documented []string
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	slices.Sort(mounted)
+	slices.Sort(documented)
+	for _, route := range mounted {
+		if !slices.Contains(documented, route) {
+			t.Errorf("route %s is not in apiOperations", route)
+		}
+	}
+	for _, route := range documented {
+		if !slices.Contains(mounted, route) {
+			t.Errorf("apiOperations lists %s, which no handler mounts", route)
+		}
+	}
+
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatalf("marshal document: %v", err)
+	}
+	create := doc.Paths["/jobs"]["post"].RequestBody.Content["application/json"].Schema
+	if !slices.Contains(create.Required, "idempotency_key") || slices.Contains(create.Required, "headers") {
+		t.Errorf("create job required = %v, want binding-required fields only", create.Required)
+	}
+	if got := create.Properties["method"].Enum; len(got) != 5 {
+		t.Errorf("create job method enum = %v, want the oneof values", got)
+	}
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// OrgHandler manages organizations and their membership. It always acts as the
+// authenticated user ("actorID"), never as an organization selected with X-Org-ID.
+type OrgHandler struct {
+	uc     *usecase.OrgUsecase
+	logger *slog.Logger
+}
+
+func NewOrgHandler(uc *usecase.OrgUsecase, logger *slog.Logger) *OrgHandler {
+	return &OrgHandler{uc: uc, logger: logger.With("component", "org_handler")}
+}
+
+// Routes mounts the organization endpoints on rg.
+func (h *OrgHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("", h.ListOrgs)
+	rg.POST("", h.CreateOrg)
+	rg.POST("/invitations/accept", h.AcceptInvitation)
+	rg.GET("/:id/members", h.ListMembers)
+	rg.PATCH("/:id/members/:user_id", h.SetMemberRole)
+	rg.DELETE("/:id/members/:user_id", h.RemoveMember)
+	rg.GET("/:id/invitations", h.ListInvitations)
+	rg.POST("/:id/invitations", h.CreateInvitation)
+	rg.DELETE("/:id/invitations/:invitation_id", h.RevokeInvitation)
+	rg.PUT("/:id/encryption-key", h.SetEncryptionKey)
+	rg.DELETE("/:id/encryption-key", h.ClearEncryptionKey)
+}
+
+type createOrgRequest struct {
+	Name string `json:"name" binding:"required,max=200"`
+}
+
+type orgResponse struct {
+	ID              string         `json:"id"` // send as X-Org-ID to act in the organization
+	Name            string         `json:"name"`
+	Role            domain.OrgRole `json:"role"`
+	KMSKeyRef       *string        `json:"kms_key_ref"`
+	RequireApproval bool           `json:"require_approval"` // hold jobs that editors create for an owner's review
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type orgMemberResponse struct {
+	UserID    string         `json:"user_id"`
+	Email     *string        `json:"email"`
+	Role      domain.OrgRole `json:"role"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type setMemberRoleRequest struct {
+	Role domain.OrgRole `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+type createInvitationRequest struct {
+	Email *string        `json:"email" binding:"omitempty,email,max=320"`
+	Role  domain.OrgRole `json:"role"  binding:"required,oneof=owner editor viewer"`
+}
+
+type invitationResponse struct {
+	ID        string         `json:"id"`
+	Email     *string        `json:"email"`
+	Role      domain.OrgRole `json:"role"`
+	InvitedBy string         `json:"invited_by"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// createInvitationResponse carries the token, which is only ever shown here.
+type createInvitationResponse struct {
+	invitationResponse
+	Token string `json:"token"`
+}
+
+// setEncryptionKeyRequest names a key in the deployment's KMS (a Vault transit key).
+type setEncryptionKeyRequest struct {
+	KeyRef string `json:"key_ref" binding:"required,max=200"`
+}
+
+type acceptInvitationRequest struct {
+	Token string `json:"token" binding:"required,max=200"`
+}
+
+func toInvitationResponse(inv *domain.OrgInvitation) invitationResponse {
+	return invitationResponse{
+		ID:        inv.ID,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		InvitedBy: inv.InvitedBy,
+		ExpiresAt: inv.ExpiresAt,
+		CreatedAt: inv.CreatedAt,
+	}
+}
+
+func (h *OrgHandler) ListOrgs(ctx *gin.Context) {
+	orgs, err := h.uc.ListOrgs(ctx.Request.Context(), ctx.GetString("actorID"))
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "list organizations", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := make([]orgResponse, len(orgs))
+	for i, m := range orgs {
+		resp[i] = orgResponse{ID: m.Org.ID, Name: m.Org.Name, Role: m.Role, KMSKeyRef: m.Org.KMSKeyRef, RequireApproval: m.Org.RequireApproval, CreatedAt: m.Org.CreatedAt}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"organizations": resp})
+}
+
+func (h *OrgHandler) CreateOrg(ctx *gin.Context) {
+	var req createOrgRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	org, err := h.uc.CreateOrg(ctx.Request.Context(), ctx.GetString("actorID"), req.Name)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "create organization", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusCreated, orgResponse{ID: org.ID, Name: org.Name, Role: domain.OrgRoleOwner, CreatedAt: org.CreatedAt})
+}
+
+func (h *OrgHandler) ListMembers(ctx *gin.Context) {
+	members, err := h.uc.ListMembers(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"))
+	if err != nil {
+		h.respondError(ctx, "list members", err)
+		return
+	}
+
+	resp := make([]orgMemberResponse, len(members))
+	for i, m := range members {
+		resp[i] = orgMemberResponse{UserID: m.UserID, Email: m.Email, Role: m.Role, CreatedAt: m.CreatedAt}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"members": resp})
+}
+
+func (h *OrgHandler) SetMemberRole(ctx *gin.Context) {
+	var req setMemberRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	if err := h.uc.SetMemberRole(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"), ctx.Param("user_id"), req.Role); err != nil {
+		h.respondError(ctx, "set member role", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *OrgHandler) RemoveMember(ctx *gin.Context) {
+	if err := h.uc.RemoveMember(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"), ctx.Param("user_id")); err != nil {
+		h.respondError(ctx, "remove member", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *OrgHandler) ListInvitations(ctx *gin.Context) {
+	invitations, err := h.uc.ListInvitations(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"))
+	if err != nil {
+		h.respondError(ctx, "list invitations", err)
+		return
+	}
+
+	resp := make([]invitationResponse, len(invitations))
+	for i, inv := range invitations {
+		resp[i] = toInvitationResponse(inv)
+	}
+	ctx.JSON(http.StatusOK, gin.H{"invitations": resp})
+}
+
+func (h *OrgHandler) CreateInvitation(ctx *gin.Context) {
+	var req createInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	inv, token, err := h.uc.Invite(ctx.Request.Context(), usecase.CreateInvitationInput{
+		OrgID:  ctx.Param("id"),
+		UserID: ctx.GetString("actorID"),
+		Email:  req.Email,
+		Role:   req.Role,
+	})
+	if err != nil {
+		h.respondError(ctx, "create invitation", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, createInvitationResponse{invitationResponse: toInvitationResponse(inv), Token: token})
+}
+
+func (h *OrgHandler) RevokeInvitation(ctx *gin.Context) {
+	if err := h.uc.RevokeInvitation(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"), ctx.Param("invitation_id")); err != nil {
+		h.respondError(ctx, "revoke invitation", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *OrgHandler) SetEncryptionKey(ctx *gin.Context) {
+	var req setEncryptionKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	if err := h.uc.SetKeyRef(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"), &req.KeyRef); err != nil {
+		h.respondError(ctx, "set encryption key", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *OrgHandler) ClearEncryptionKey(ctx *gin.Context) {
+	if err := h.uc.SetKeyRef(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("actorID"), nil); err != nil {
+		h.respondError(ctx, "clear encryption key", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *OrgHandler) AcceptInvitation(ctx *gin.Context) {
+	var req acceptInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	m, err := h.uc.AcceptInvitation(ctx.Request.Context(), ctx.GetString("actorID"), req.Token)
+	if err != nil {
+		h.respondError(ctx, "accept invitation", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"org_id": m.OrgID, "role": m.Role})
+}
+
+func (h *OrgHandler) respondError(ctx *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOrgNotFound):
+		apierror.Respond(ctx, http.StatusNotFound, errOrgNotFound)
+	case errors.Is(err, domain.ErrOrgForbidden):
+		apierror.Respond(ctx, http.StatusForbidden, errOrgOwnerRequired)
+	case errors.Is(err, domain.ErrOrgMemberNotFound):
+		apierror.Respond(ctx, http.StatusNotFound, errOrgMemberNotFound)
+	case errors.Is(err, domain.ErrLastOrgOwner):
+		apierror.Respond(ctx, http.StatusConflict, errLastOrgOwner)
+	case errors.Is(err, domain.ErrInvalidOrgRole):
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidOrgRole)
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		apierror.Respond(ctx, http.StatusNotFound, errInvitationNotFound)
+	case errors.Is(err, domain.ErrAlreadyOrgMember):
+		apierror.Respond(ctx, http.StatusConflict, errAlreadyOrgMember)
+	case errors.Is(err, domain.ErrKeyRefUnsupported):
+		apierror.Respond(ctx, http.StatusNotImplemented, errKeyRefUnsupported)
+	case errors.Is(err, domain.ErrInvalidKeyRef):
+		// The KMS error says why (unknown key, missing policy) but describes the
+		// deployment's setup, so it is logged rather than returned.
+		h.logger.WarnContext(ctx.Request.Context(), op, "org_id", ctx.Param("id"), "error", err)
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidKeyRef)
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), op, "org_id", ctx.Param("id"), "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+	}
+}
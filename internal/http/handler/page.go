@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// pageQuery reads the paging parameters list endpoints share: page_size (limit is its
+// older name), order, cursor and include_total. A page_size that isn't a positive
+// integer is domain.ErrInvalidPageSize; the usecase checks the rest.
+func pageQuery(ctx *gin.Context) (usecase.PageInput, error) {
+	page := usecase.PageInput{
+		Cursor:       ctx.Query("cursor"),
+		Order:        domain.SortOrder(ctx.Query("order")),
+		IncludeTotal: ctx.Query("include_total") == "true",
+	}
+	raw := ctx.Query("page_size")
+	if raw == "" {
+		raw = ctx.Query("limit")
+	}
+	if raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size < 1 {
+			return usecase.PageInput{}, domain.ErrInvalidPageSize
+		}
+		page.Size = size
+	}
+	return page, nil
+}
+
+// pageError maps the paging errors of a list request to their response error.
+func pageError(err error) (e apierror.Error, ok bool) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPageSize):
+		return errInvalidPageSize, true
+	case errors.Is(err, domain.ErrInvalidOrder):
+		return errInvalidOrder, true
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return errInvalidCursor, true
+	default:
+		return apierror.Error{}, false
+	}
+}
@@ -8,114 +8,418 @@ import (
 	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
 
 type ScheduleHandler struct {
 	uc     *usecase.ScheduleUsecase
+	dryRun *usecase.DryRunUsecase
 	logger *slog.Logger
 }
 
-func NewScheduleHandler(uc *usecase.ScheduleUsecase, logger *slog.Logger) *ScheduleHandler {
-	return &ScheduleHandler{uc: uc, logger: logger.With("component", "schedule_handler")}
+func NewScheduleHandler(uc *usecase.ScheduleUsecase, dryRun *usecase.DryRunUsecase, logger *slog.Logger) *ScheduleHandler {
+	return &ScheduleHandler{uc: uc, dryRun: dryRun, logger: logger.With("component", "schedule_handler")}
+}
+
+// Routes mounts the schedule endpoints on rg.
+func (h *ScheduleHandler) Routes(rg *gin.RouterGroup) {
+	rg.POST("", h.Create)
+	rg.GET("", h.List)
+	rg.GET("/export", h.Export)
+	rg.POST("/preview", h.Preview)
+	rg.POST("/import", h.Import)
+	rg.PUT("/sync", h.Sync)
+	rg.GET("/:id", h.GetByID)
+	rg.PATCH("/:id", h.Update)
+	rg.POST("/:id/pause", h.Pause)
+	rg.POST("/:id/resume", h.Resume)
+	rg.POST("/:id/trigger", h.Trigger)
+	rg.POST("/:id/dry-run", h.DryRun)
+	rg.DELETE("/:id", h.Delete)
+	rg.GET("/:id/jobs", h.ListJobs)
+	rg.GET("/:id/uptime", h.Uptime)
+	rg.GET("/:id/revisions", h.ListRevisions)
+	rg.POST("/:id/revisions/:revision/revert", h.Revert)
 }
 
 type createScheduleRequest struct {
-	Name           string            `json:"name"            binding:"required,max=256"`
-	CronExpr       string            `json:"cron_expr"       binding:"required"`
-	URL            string            `json:"url"             binding:"required,url,max=2048"`
-	Method         string            `json:"method"          binding:"omitempty,oneof=GET POST PUT PATCH DELETE"`
-	Headers        map[string]string `json:"headers"`
-	Body           *string           `json:"body"`
-	TimeoutSeconds int               `json:"timeout_seconds" binding:"omitempty,min=1,max=3600"`
-	MaxRetries     int               `json:"max_retries"     binding:"omitempty,min=0,max=20"`
-	Backoff        domain.Backoff    `json:"backoff"         binding:"omitempty,oneof=exponential linear"`
+	Name             string               `json:"name"            binding:"required,max=256"`
+	CronExpr         string               `json:"cron_expr"       binding:"required_without=Every"`
+	Every            domain.Interval      `json:"every,omitempty"`
+	Timezone         string               `json:"timezone"        binding:"omitempty,max=64"`       // IANA name, e.g. "Europe/Berlin"; default UTC
+	URL              string               `json:"url"             binding:"omitempty,url,max=2048"` // http schedules
+	Method           string               `json:"method"          binding:"omitempty,oneof=GET HEAD POST PUT PATCH DELETE"`
+	Topic            *string              `json:"topic,omitempty"       binding:"omitempty,max=249"`         // kafka schedules
+	MessageKey       *string              `json:"message_key,omitempty" binding:"omitempty,max=1024"`        // kafka schedules; optional
+	EmailTo          *string              `json:"email_to,omitempty"      binding:"omitempty,email,max=254"` // email schedules
+	EmailSubject     *string              `json:"email_subject,omitempty" binding:"omitempty,max=998"`       // email schedules
+	Headers          map[string]string    `json:"headers"`
+	Body             *string              `json:"body"`
+	TimeoutSeconds   int                  `json:"timeout_seconds" binding:"omitempty,min=1,max=3600"`
+	MaxRetries       int                  `json:"max_retries"     binding:"omitempty,min=0,max=20"`
+	Backoff          domain.Backoff       `json:"backoff"         binding:"omitempty,oneof=exponential linear"`
+	RetryBaseSeconds int                  `json:"retry_base_seconds" binding:"omitempty,min=1,max=86400"`
+	RetryMaxSeconds  int                  `json:"retry_max_seconds"  binding:"omitempty,min=1,max=86400"`
+	RetryJitter      domain.Jitter        `json:"retry_jitter"       binding:"omitempty,oneof=auto none full equal"`
+	OverlapPolicy    domain.OverlapPolicy `json:"overlap_policy"    binding:"omitempty,oneof=queue skip replace"`
+	JitterSeconds    int                  `json:"jitter_seconds"    binding:"omitempty,min=0,max=3600"`
+	Mode             domain.ScheduleMode  `json:"mode"          binding:"omitempty,oneof=standard ping"`
+	JobType          domain.JobType       `json:"job_type,omitempty" binding:"omitempty,max=32"` // passed on to fired jobs; default "http"; fixed, like mode
+	SuccessCodes     []string             `json:"success_codes"   binding:"omitempty,max=20"`
+	Templated        bool                 `json:"templated"`
+	SigningSecret    *string              `json:"signing_secret,omitempty" binding:"omitempty,min=16,max=256"` // passed on to fired jobs; write-only
+	Queue            string               `json:"queue,omitempty"   binding:"omitempty,max=63"`                // passed on to fired jobs; default "default"
+	// PauseAfterFailures pauses the schedule after this many permanently failed runs in a
+	// row; 0 (default) never does.
+	PauseAfterFailures int `json:"pause_after_failures,omitempty" binding:"omitempty,min=0,max=100"`
+	// BodyEncoding and ContentType are passed on to fired jobs; see createJobRequest.
+	BodyEncoding domain.BodyEncoding `json:"body_encoding,omitempty" binding:"omitempty,oneof=utf8 base64"`
+	ContentType  *string             `json:"content_type,omitempty"  binding:"omitempty,max=255"`
+	ProxyURL     *string             `json:"proxy_url,omitempty"     binding:"omitempty,max=2048"` // passed on to fired jobs
+	ClientCertID *string             `json:"client_cert_id,omitempty" binding:"omitempty,max=64"`  // passed on to fired jobs
+	// CABundle and TLSSkipVerify are passed on to fired jobs; see createJobRequest.
+	CABundle      *string `json:"ca_bundle,omitempty"       binding:"omitempty,max=65536"`
+	TLSSkipVerify bool    `json:"tls_skip_verify,omitempty"`
 }
 
 type scheduleResponse struct {
-	ID             string         `json:"id"`
-	Name           string         `json:"name"`
-	CronExpr       string         `json:"cron_expr"`
-	URL            string         `json:"url"`
-	Method         string         `json:"method"`
-	TimeoutSeconds int            `json:"timeout_seconds"`
-	MaxRetries     int            `json:"max_retries"`
-	Backoff        domain.Backoff `json:"backoff"`
-	Paused         bool           `json:"paused"`
-	NextRunAt      time.Time      `json:"next_run_at"`
-	LastRunAt      *time.Time     `json:"last_run_at,omitempty"`
-	CreatedAt      time.Time      `json:"created_at"`
+	ID               string               `json:"id"`
+	Name             string               `json:"name"`
+	CronExpr         string               `json:"cron_expr"`
+	Every            domain.Interval      `json:"every,omitempty"`
+	Timezone         string               `json:"timezone"`
+	URL              string               `json:"url"`
+	Method           string               `json:"method"`
+	Topic            *string              `json:"topic,omitempty"`
+	MessageKey       *string              `json:"message_key,omitempty"`
+	EmailTo          *string              `json:"email_to,omitempty"`
+	EmailSubject     *string              `json:"email_subject,omitempty"`
+	TimeoutSeconds   int                  `json:"timeout_seconds"`
+	MaxRetries       int                  `json:"max_retries"`
+	Backoff          domain.Backoff       `json:"backoff"`
+	RetryBaseSeconds int                  `json:"retry_base_seconds"`
+	RetryMaxSeconds  int                  `json:"retry_max_seconds"`
+	RetryJitter      domain.Jitter        `json:"retry_jitter"`
+	OverlapPolicy    domain.OverlapPolicy `json:"overlap_policy"`
+	JitterSeconds    int                  `json:"jitter_seconds"`
+	Paused           bool                 `json:"paused"`
+	Mode             domain.ScheduleMode  `json:"mode"`
+	JobType          domain.JobType       `json:"job_type"`
+	SuccessCodes     []string             `json:"success_codes,omitempty"`
+	Templated        bool                 `json:"templated"`
+	Signed           bool                 `json:"signed"`
+	Queue            string               `json:"queue"`
+	NextRunAt        time.Time            `json:"next_run_at"`
+	LastRunAt        *time.Time           `json:"last_run_at,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+
+	PauseAfterFailures  int `json:"pause_after_failures"`
+	ConsecutiveFailures int `json:"consecutive_failures"` // since the last completed run or resume
+
+	BodyEncoding domain.BodyEncoding `json:"body_encoding"`
+	ContentType  *string             `json:"content_type,omitempty"`
+	ProxyURL     *string             `json:"proxy_url,omitempty"` // password redacted
+	ClientCertID *string             `json:"client_cert_id,omitempty"`
+
+	CABundle      *string `json:"ca_bundle,omitempty"`
+	TLSSkipVerify bool    `json:"tls_skip_verify"`
 }
 
 func toScheduleResponse(s *domain.Schedule) scheduleResponse {
 	return scheduleResponse{
-		ID:             s.ID,
-		Name:           s.Name,
-		CronExpr:       s.CronExpr,
-		URL:            s.URL,
-		Method:         s.Method,
-		TimeoutSeconds: s.TimeoutSeconds,
-		MaxRetries:     s.MaxRetries,
-		Backoff:        s.Backoff,
-		Paused:         s.Paused,
-		NextRunAt:      s.NextRunAt,
-		LastRunAt:      s.LastRunAt,
-		CreatedAt:      s.CreatedAt,
+		ID:               s.ID,
+		Name:             s.Name,
+		CronExpr:         s.CronExpr,
+		Every:            s.Every,
+		Timezone:         s.Timezone,
+		URL:              s.URL,
+		Method:           s.Method,
+		Topic:            s.Topic,
+		MessageKey:       s.MessageKey,
+		EmailTo:          s.EmailTo,
+		EmailSubject:     s.EmailSubject,
+		TimeoutSeconds:   s.TimeoutSeconds,
+		MaxRetries:       s.MaxRetries,
+		Backoff:          s.Backoff,
+		RetryBaseSeconds: s.RetryBaseSeconds,
+		RetryMaxSeconds:  s.RetryMaxSeconds,
+		RetryJitter:      s.RetryJitter,
+		OverlapPolicy:    s.OverlapPolicy,
+		JitterSeconds:    s.JitterSeconds,
+		Paused:           s.Paused,
+		Mode:             s.Mode,
+		JobType:          s.JobType,
+		SuccessCodes:     s.SuccessCodes,
+		Templated:        s.Templated,
+		Signed:           s.SigningSecret != nil,
+		Queue:            s.Queue,
+		NextRunAt:        s.NextRunAt,
+		LastRunAt:        s.LastRunAt,
+		CreatedAt:        s.CreatedAt,
+
+		PauseAfterFailures:  s.PauseAfterFailures,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+
+		BodyEncoding: s.BodyEncoding,
+		ContentType:  s.ContentType,
+		ProxyURL:     domain.RedactProxyURL(s.ProxyURL),
+		ClientCertID: s.ClientCertID,
+
+		CABundle:      s.CABundle,
+		TLSSkipVerify: s.TLSSkipVerify,
+	}
+}
+
+func (req createScheduleRequest) toInput(userID string) usecase.CreateScheduleInput {
+	method := req.Method
+	if method == "" && (req.JobType == "" || req.JobType == domain.JobTypeHTTP) {
+		method = "POST"
+		if req.Mode == domain.ScheduleModePing {
+			method = "HEAD"
+		}
+	}
+
+	return usecase.CreateScheduleInput{
+		UserID:           userID,
+		Name:             req.Name,
+		CronExpr:         req.CronExpr,
+		Every:            req.Every,
+		Timezone:         req.Timezone,
+		URL:              req.URL,
+		Method:           method,
+		Topic:            req.Topic,
+		MessageKey:       req.MessageKey,
+		EmailTo:          req.EmailTo,
+		EmailSubject:     req.EmailSubject,
+		Headers:          req.Headers,
+		Body:             req.Body,
+		TimeoutSeconds:   req.TimeoutSeconds,
+		MaxRetries:       req.MaxRetries,
+		Backoff:          req.Backoff,
+		RetryBaseSeconds: req.RetryBaseSeconds,
+		RetryMaxSeconds:  req.RetryMaxSeconds,
+		RetryJitter:      req.RetryJitter,
+		OverlapPolicy:    req.OverlapPolicy,
+		JitterSeconds:    req.JitterSeconds,
+		Mode:             req.Mode,
+		JobType:          req.JobType,
+		SuccessCodes:     req.SuccessCodes,
+		Templated:        req.Templated,
+		SigningSecret:    req.SigningSecret,
+		Queue:            req.Queue,
+
+		PauseAfterFailures: req.PauseAfterFailures,
+		BodyEncoding:       req.BodyEncoding,
+		ContentType:        req.ContentType,
+		ProxyURL:           req.ProxyURL,
+		ClientCertID:       req.ClientCertID,
+		CABundle:           req.CABundle,
+		TLSSkipVerify:      req.TLSSkipVerify,
+	}
+}
+
+// createScheduleError maps a CreateSchedule or UpdateSchedule error to a client-facing
+// status and error. ok is false for unexpected errors, which callers log and report
+// as internal.
+func createScheduleError(err error) (status int, e apierror.Error, ok bool) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCronExpr):
+		return http.StatusBadRequest, errInvalidCronExpr, true
+	case errors.Is(err, domain.ErrInvalidTimezone):
+		return http.StatusBadRequest, errInvalidTimezone, true
+	case errors.Is(err, domain.ErrInvalidInterval):
+		return http.StatusBadRequest, errInvalidInterval, true
+	case errors.Is(err, domain.ErrInvalidCadence):
+		return http.StatusBadRequest, errInvalidCadence, true
+	case errors.Is(err, domain.ErrInvalidScheduleJitter):
+		return http.StatusBadRequest, errInvalidScheduleJitter, true
+	case errors.Is(err, domain.ErrInvalidPauseThreshold):
+		return http.StatusBadRequest, errInvalidPauseThreshold, true
+	case errors.Is(err, domain.ErrInvalidPingSchedule):
+		return http.StatusBadRequest, errInvalidPingSchedule, true
+	case errors.Is(err, domain.ErrInvalidSuccessCodes):
+		return http.StatusBadRequest, errInvalidSuccessCodes, true
+	case errors.Is(err, domain.ErrInvalidRetryBackoff):
+		return http.StatusBadRequest, errInvalidRetryBackoff, true
+	case errors.Is(err, domain.ErrInvalidPayloadTemplate):
+		return http.StatusBadRequest, errInvalidPayloadTemplate, true
+	case errors.Is(err, domain.ErrInvalidSigningSecret):
+		return http.StatusBadRequest, errInvalidSigningSecret, true
+	case errors.Is(err, domain.ErrInvalidQueue):
+		return http.StatusBadRequest, errInvalidQueue, true
+	case errors.Is(err, domain.ErrUnknownJobType):
+		return http.StatusBadRequest, errUnknownJobType, true
+	case errors.Is(err, domain.ErrInvalidJobTarget):
+		return http.StatusBadRequest, errInvalidJobTarget, true
+	case errors.Is(err, domain.ErrInvalidBodyEncoding):
+		return http.StatusBadRequest, errInvalidBodyEncoding, true
+	case errors.Is(err, domain.ErrSealedPrefix):
+		return http.StatusBadRequest, errSealedPrefix, true
+	case errors.Is(err, domain.ErrInvalidContentType):
+		return http.StatusBadRequest, errInvalidContentType, true
+	case errors.Is(err, domain.ErrInvalidProxyURL):
+		return http.StatusBadRequest, errInvalidProxyURL, true
+	case errors.Is(err, domain.ErrClientCertNotFound):
+		return http.StatusBadRequest, errUnknownClientCert, true
+	case errors.Is(err, domain.ErrInvalidTLSOptions):
+		return http.StatusBadRequest, errInvalidTLSOptions, true
+	case errors.Is(err, domain.ErrScheduleNameConflict):
+		return http.StatusConflict, errScheduleNameConflict, true
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return http.StatusTooManyRequests, errQuotaExceeded, true
+	default:
+		return http.StatusInternalServerError, errInternalServer, false
 	}
 }
 
 func (h *ScheduleHandler) Create(ctx *gin.Context) {
 	var req createScheduleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondInvalid(ctx, err)
 		return
 	}
 
-	method := req.Method
-	if method == "" {
-		method = "POST"
-	}
-
-	s, err := h.uc.CreateSchedule(ctx.Request.Context(), usecase.CreateScheduleInput{
-		UserID:         ctx.GetString("userID"),
-		Name:           req.Name,
-		CronExpr:       req.CronExpr,
-		URL:            req.URL,
-		Method:         method,
-		Headers:        req.Headers,
-		Body:           req.Body,
-		TimeoutSeconds: req.TimeoutSeconds,
-		MaxRetries:     req.MaxRetries,
-		Backoff:        req.Backoff,
-	})
+	s, err := h.uc.CreateSchedule(ctx.Request.Context(), req.toInput(ctx.GetString("userID")))
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrInvalidCronExpr):
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": errInvalidCronExpr})
-		case errors.Is(err, domain.ErrScheduleNameConflict):
-			ctx.JSON(http.StatusConflict, gin.H{"error": errScheduleNameConflict})
-		default:
+		status, e, ok := createScheduleError(err)
+		if !ok {
 			h.logger.Error("create schedule", "error", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
 		}
+		apierror.Respond(ctx, status, e)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, toScheduleResponse(s))
 }
 
+// updateScheduleRequest is a partial update: omitted fields are left unchanged. Mode is
+// fixed at creation. headers, when present, replaces the whole header map.
+type updateScheduleRequest struct {
+	Name             *string               `json:"name"            binding:"omitempty,min=1,max=256"`
+	CronExpr         *string               `json:"cron_expr"       binding:"omitempty,min=1"`
+	Every            *domain.Interval      `json:"every"`
+	Timezone         *string               `json:"timezone"        binding:"omitempty,min=1,max=64"`
+	URL              *string               `json:"url"             binding:"omitempty,url,max=2048"`
+	Method           *string               `json:"method"          binding:"omitempty,oneof=GET HEAD POST PUT PATCH DELETE"`
+	Topic            *string               `json:"topic"           binding:"omitempty,max=249"`
+	MessageKey       *string               `json:"message_key"     binding:"omitempty,max=1024"` // "" removes it
+	EmailTo          *string               `json:"email_to"        binding:"omitempty,email,max=254"`
+	EmailSubject     *string               `json:"email_subject"   binding:"omitempty,max=998"`
+	Headers          map[string]string     `json:"headers"`
+	Body             *string               `json:"body"`
+	TimeoutSeconds   *int                  `json:"timeout_seconds" binding:"omitempty,min=1,max=3600"`
+	MaxRetries       *int                  `json:"max_retries"     binding:"omitempty,min=0,max=20"`
+	Backoff          *domain.Backoff       `json:"backoff"         binding:"omitempty,oneof=exponential linear"`
+	RetryBaseSeconds *int                  `json:"retry_base_seconds" binding:"omitempty,min=1,max=86400"`
+	RetryMaxSeconds  *int                  `json:"retry_max_seconds"  binding:"omitempty,min=1,max=86400"`
+	RetryJitter      *domain.Jitter        `json:"retry_jitter"       binding:"omitempty,oneof=auto none full equal"`
+	OverlapPolicy    *domain.OverlapPolicy `json:"overlap_policy"   binding:"omitempty,oneof=queue skip replace"`
+	JitterSeconds    *int                  `json:"jitter_seconds"   binding:"omitempty,min=0,max=3600"`
+	SuccessCodes     *[]string             `json:"success_codes"   binding:"omitempty,max=20"`
+	Templated        *bool                 `json:"templated"`
+	SigningSecret    *string               `json:"signing_secret"   binding:"omitempty,max=256"` // "" removes the secret
+	Queue            *string               `json:"queue"            binding:"omitempty,max=63"`
+
+	PauseAfterFailures *int                 `json:"pause_after_failures" binding:"omitempty,min=0,max=100"`
+	BodyEncoding       *domain.BodyEncoding `json:"body_encoding"        binding:"omitempty,oneof=utf8 base64"`
+	ContentType        *string              `json:"content_type"         binding:"omitempty,max=255"`   // "" removes it
+	ProxyURL           *string              `json:"proxy_url"            binding:"omitempty,max=2048"`  // "" removes it
+	ClientCertID       *string              `json:"client_cert_id"       binding:"omitempty,max=64"`    // "" removes it
+	CABundle           *string              `json:"ca_bundle"            binding:"omitempty,max=65536"` // "" removes it
+	TLSSkipVerify      *bool                `json:"tls_skip_verify"`
+}
+
+func (h *ScheduleHandler) Update(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req updateScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	input := usecase.UpdateScheduleInput{
+		Name:             req.Name,
+		CronExpr:         req.CronExpr,
+		Every:            req.Every,
+		Timezone:         req.Timezone,
+		URL:              req.URL,
+		Method:           req.Method,
+		Topic:            req.Topic,
+		MessageKey:       req.MessageKey,
+		EmailTo:          req.EmailTo,
+		EmailSubject:     req.EmailSubject,
+		Headers:          req.Headers,
+		Body:             req.Body,
+		TimeoutSeconds:   req.TimeoutSeconds,
+		MaxRetries:       req.MaxRetries,
+		Backoff:          req.Backoff,
+		RetryBaseSeconds: req.RetryBaseSeconds,
+		RetryMaxSeconds:  req.RetryMaxSeconds,
+		RetryJitter:      req.RetryJitter,
+		OverlapPolicy:    req.OverlapPolicy,
+		JitterSeconds:    req.JitterSeconds,
+		Templated:        req.Templated,
+		SigningSecret:    req.SigningSecret,
+		Queue:            req.Queue,
+
+		PauseAfterFailures: req.PauseAfterFailures,
+		BodyEncoding:       req.BodyEncoding,
+		ContentType:        req.ContentType,
+		ProxyURL:           req.ProxyURL,
+		ClientCertID:       req.ClientCertID,
+		CABundle:           req.CABundle,
+		TLSSkipVerify:      req.TLSSkipVerify,
+	}
+	if req.SuccessCodes != nil {
+		codes := domain.SuccessCodes(*req.SuccessCodes)
+		input.SuccessCodes = &codes
+	}
+
+	s, err := h.uc.UpdateSchedule(ctx.Request.Context(), id, ctx.GetString("userID"), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrScheduleNotFound) {
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+			return
+		}
+		status, e, ok := createScheduleError(err)
+		if !ok {
+			h.logger.ErrorContext(ctx.Request.Context(), "update schedule", "schedule_id", id, "error", err)
+		}
+		apierror.Respond(ctx, status, e)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toScheduleResponse(s))
+}
+
+type listSchedulesResponse struct {
+	Schedules  []scheduleResponse `json:"schedules"`
+	NextCursor *string            `json:"next_cursor"`
+	HasMore    bool               `json:"has_more"`
+	TotalCount *int64             `json:"total_count,omitempty"` // with include_total=true
+}
+
 func (h *ScheduleHandler) List(ctx *gin.Context) {
-	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	page, err := pageQuery(ctx)
+	if err != nil {
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidPageSize)
+		return
+	}
 
 	result, err := h.uc.ListSchedules(ctx.Request.Context(), usecase.ListSchedulesInput{
 		UserID: ctx.GetString("userID"),
-		Cursor: ctx.Query("cursor"),
-		Limit:  limit,
+		Page:   page,
 	})
 	if err != nil {
+		if e, ok := pageError(err); ok {
+			apierror.Respond(ctx, http.StatusBadRequest, e)
+			return
+		}
 		h.logger.Error("list schedules", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		return
 	}
 
@@ -123,9 +427,11 @@ func (h *ScheduleHandler) List(ctx *gin.Context) {
 	for i, s := range result.Schedules {
 		items[i] = toScheduleResponse(s)
 	}
-	ctx.JSON(http.StatusOK, gin.H{
-		"schedules":   items,
-		"next_cursor": result.NextCursor,
+	ctx.JSON(http.StatusOK, listSchedulesResponse{
+		Schedules:  items,
+		NextCursor: result.NextCursor,
+		HasMore:    result.NextCursor != nil,
+		TotalCount: result.TotalCount,
 	})
 }
 
@@ -135,11 +441,15 @@ func (h *ScheduleHandler) GetByID(ctx *gin.Context) {
 	s, err := h.uc.GetSchedule(ctx.Request.Context(), id, ctx.GetString("userID"))
 	if err != nil {
 		if errors.Is(err, domain.ErrScheduleNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errScheduleNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
 			return
 		}
 		h.logger.Error("get schedule", "schedule_id", id, "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	// The failure-tracking trigger counts failures without moving updated_at.
+	if notModified(ctx, etag(s.UpdatedAt, s.ConsecutiveFailures)) {
 		return
 	}
 
@@ -153,12 +463,12 @@ func (h *ScheduleHandler) Pause(ctx *gin.Context) {
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrScheduleNotFound):
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errScheduleNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
 		case errors.Is(err, domain.ErrScheduleAlreadyPaused):
-			ctx.JSON(http.StatusConflict, gin.H{"error": errScheduleAlreadyPaused})
+			apierror.Respond(ctx, http.StatusConflict, errScheduleAlreadyPaused)
 		default:
 			h.logger.Error("pause schedule", "schedule_id", id, "error", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		}
 		return
 	}
@@ -173,12 +483,12 @@ func (h *ScheduleHandler) Resume(ctx *gin.Context) {
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrScheduleNotFound):
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errScheduleNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
 		case errors.Is(err, domain.ErrScheduleNotPaused):
-			ctx.JSON(http.StatusConflict, gin.H{"error": errScheduleNotPaused})
+			apierror.Respond(ctx, http.StatusConflict, errScheduleNotPaused)
 		default:
 			h.logger.Error("resume schedule", "schedule_id", id, "error", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		}
 		return
 	}
@@ -186,17 +496,98 @@ func (h *ScheduleHandler) Resume(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+type triggerScheduleResponse struct {
+	ID             string    `json:"id"`
+	ScheduleID     string    `json:"schedule_id"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	ScheduledAt    time.Time `json:"scheduled_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Trigger runs the schedule once now, outside its cron cadence.
+func (h *ScheduleHandler) Trigger(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	job, err := h.uc.TriggerSchedule(ctx.Request.Context(), id, ctx.GetString("userID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrScheduleNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+		case errors.Is(err, domain.ErrDuplicateJob):
+			// Two triggers within the same millisecond.
+			apierror.Respond(ctx, http.StatusConflict, errDuplicateJob)
+		case errors.Is(err, domain.ErrQuotaExceeded):
+			apierror.Respond(ctx, http.StatusTooManyRequests, errQuotaExceeded)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "trigger schedule", "schedule_id", id, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, triggerScheduleResponse{
+		ID:             job.ID,
+		ScheduleID:     id,
+		IdempotencyKey: job.IdempotencyKey,
+		ScheduledAt:    job.ScheduledAt,
+		CreatedAt:      job.CreatedAt,
+	})
+}
+
+// defaultPreviewRuns is how many fire times a preview returns without "count".
+const defaultPreviewRuns = 5
+
+type previewScheduleRequest struct {
+	CronExpr string          `json:"cron_expr" binding:"required_without=Every"`
+	Every    domain.Interval `json:"every,omitempty"`
+	Timezone string          `json:"timezone"  binding:"omitempty,max=64"` // default UTC
+	Count    int             `json:"count"     binding:"omitempty,min=1,max=10"`
+}
+
+type previewScheduleResponse struct {
+	Timezone string      `json:"timezone"`
+	Runs     []time.Time `json:"runs"` // with the timezone's offset; jitter not applied
+}
+
+// Preview validates a cadence and lists the next times a schedule with it would fire,
+// without creating anything.
+func (h *ScheduleHandler) Preview(ctx *gin.Context) {
+	var req previewScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+	if req.Timezone == "" {
+		req.Timezone = domain.DefaultTimezone
+	}
+	if req.Count == 0 {
+		req.Count = defaultPreviewRuns
+	}
+
+	runs, err := h.uc.PreviewRuns(req.CronExpr, req.Timezone, req.Every, req.Count)
+	if err != nil {
+		if status, e, ok := createScheduleError(err); ok {
+			apierror.Respond(ctx, status, e)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "preview schedule", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, previewScheduleResponse{Timezone: req.Timezone, Runs: runs})
+}
+
 func (h *ScheduleHandler) Delete(ctx *gin.Context) {
 	id := ctx.Param("id")
 
 	err := h.uc.DeleteSchedule(ctx.Request.Context(), id, ctx.GetString("userID"))
 	if err != nil {
 		if errors.Is(err, domain.ErrScheduleNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errScheduleNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
 			return
 		}
 		h.logger.Error("delete schedule", "schedule_id", id, "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		return
 	}
 
@@ -205,21 +596,28 @@ func (h *ScheduleHandler) Delete(ctx *gin.Context) {
 
 func (h *ScheduleHandler) ListJobs(ctx *gin.Context) {
 	id := ctx.Param("id")
-	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	page, err := pageQuery(ctx)
+	if err != nil {
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidPageSize)
+		return
+	}
 
 	result, err := h.uc.ListScheduleJobs(ctx.Request.Context(), usecase.ListScheduleJobsInput{
 		ScheduleID: id,
 		UserID:     ctx.GetString("userID"),
-		Cursor:     ctx.Query("cursor"),
-		Limit:      limit,
+		Page:       page,
 	})
 	if err != nil {
+		if e, ok := pageError(err); ok {
+			apierror.Respond(ctx, http.StatusBadRequest, e)
+			return
+		}
 		if errors.Is(err, domain.ErrScheduleNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": errScheduleNotFound})
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
 			return
 		}
 		h.logger.Error("list schedule jobs", "schedule_id", id, "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": errInternalServer})
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
 		return
 	}
 
@@ -237,8 +635,161 @@ func (h *ScheduleHandler) ListJobs(ctx *gin.Context) {
 			ScheduleID:  j.ScheduleID,
 		}
 	}
-	ctx.JSON(http.StatusOK, gin.H{
-		"jobs":        items,
-		"next_cursor": result.NextCursor,
+	ctx.JSON(http.StatusOK, listJobsResponse{
+		Jobs:       items,
+		NextCursor: result.NextCursor,
+		HasMore:    result.NextCursor != nil,
+		TotalCount: result.TotalCount,
 	})
 }
+
+type pingIncidentResponse struct {
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	LastError *string    `json:"last_error,omitempty"`
+}
+
+type uptimeResponse struct {
+	ScheduleID      string                 `json:"schedule_id"`
+	Since           time.Time              `json:"since"`
+	Checks          int                    `json:"checks"`
+	Failures        int                    `json:"failures"`
+	SuccessRatio    *float64               `json:"success_ratio"` // nil when no checks ran
+	UptimeRatio     float64                `json:"uptime_ratio"`
+	DowntimeSeconds float64                `json:"downtime_seconds"`
+	AvgDurationMS   int64                  `json:"avg_duration_ms"`
+	Incidents       []pingIncidentResponse `json:"incidents"`
+}
+
+const maxUptimeWindow = 30 * 24 * time.Hour
+
+func (h *ScheduleHandler) Uptime(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	window := 24 * time.Hour
+	if raw := ctx.Query("window"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 || d > maxUptimeWindow {
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidUptimeWindow)
+			return
+		}
+		window = d
+	}
+
+	u, err := h.uc.Uptime(ctx.Request.Context(), id, ctx.GetString("userID"), window)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrScheduleNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+		case errors.Is(err, domain.ErrNotPingSchedule):
+			apierror.Respond(ctx, http.StatusConflict, errNotPingSchedule)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "get schedule uptime", "schedule_id", id, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	resp := uptimeResponse{
+		ScheduleID:      u.ScheduleID,
+		Since:           u.Since,
+		Checks:          u.Checks,
+		Failures:        u.Failures,
+		UptimeRatio:     max(0, 1-u.DowntimeSeconds/window.Seconds()),
+		DowntimeSeconds: u.DowntimeSeconds,
+		AvgDurationMS:   u.AvgDurationMS,
+		Incidents:       make([]pingIncidentResponse, len(u.Incidents)),
+	}
+	if u.Checks > 0 {
+		ratio := float64(u.Checks-u.Failures) / float64(u.Checks)
+		resp.SuccessRatio = &ratio
+	}
+	for i, inc := range u.Incidents {
+		resp.Incidents[i] = pingIncidentResponse{
+			StartedAt: inc.StartedAt,
+			EndedAt:   inc.EndedAt,
+			LastError: inc.LastError,
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type revisionResponse struct {
+	Revision  int                   `json:"revision"`
+	Action    domain.RevisionAction `json:"action"`
+	ActorID   string                `json:"actor_id"`
+	Changed   []string              `json:"changed,omitempty"`
+	Before    *domain.ScheduleSpec  `json:"before"`
+	After     domain.ScheduleSpec   `json:"after"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+// ListRevisions returns the schedule's change history, newest first.
+func (h *ScheduleHandler) ListRevisions(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	revisions, err := h.uc.ListRevisions(ctx.Request.Context(), id, ctx.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrScheduleNotFound) {
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "list schedule revisions", "schedule_id", id, "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := make([]revisionResponse, len(revisions))
+	for i, rev := range revisions {
+		resp[i] = revisionResponse{
+			Revision:  rev.Revision,
+			Action:    rev.Action,
+			ActorID:   rev.ActorID,
+			Changed:   rev.Changed(),
+			After:     redactSpec(rev.After),
+			CreatedAt: rev.CreatedAt,
+		}
+		if rev.Before != nil {
+			before := redactSpec(*rev.Before)
+			resp[i].Before = &before
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"schedule_id": id, "revisions": resp})
+}
+
+// redactSpec hides secret header values; a change to one still shows up in Changed.
+func redactSpec(spec domain.ScheduleSpec) domain.ScheduleSpec {
+	spec.Headers = domain.RedactHeaders(spec.Headers)
+	return spec
+}
+
+// Revert restores the schedule's configuration as of the given revision.
+func (h *ScheduleHandler) Revert(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	revision, err := strconv.Atoi(ctx.Param("revision"))
+	if err != nil || revision < 1 {
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidRevision)
+		return
+	}
+
+	s, err := h.uc.RevertSchedule(ctx.Request.Context(), id, ctx.GetString("userID"), revision)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrScheduleNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+		case errors.Is(err, domain.ErrRevisionNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errRevisionNotFound)
+		case errors.Is(err, domain.ErrScheduleNameConflict):
+			apierror.Respond(ctx, http.StatusConflict, errScheduleNameConflict)
+		case errors.Is(err, domain.ErrInvalidTimezone):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidTimezone)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "revert schedule", "schedule_id", id, "revision", revision, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toScheduleResponse(s))
+}
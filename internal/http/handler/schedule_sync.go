@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// syncSchedulesRequest is an export document naming every schedule the user should have.
+// An empty list deletes them all, so it must be given explicitly.
+type syncSchedulesRequest struct {
+	Version   int                `json:"version"   binding:"required"`
+	Schedules []exportedSchedule `json:"schedules" binding:"required,max=500,dive"`
+}
+
+type syncScheduleAction struct {
+	Name    string             `json:"name"`
+	Action  usecase.SyncAction `json:"action,omitempty"` // create, update, replace, delete, unchanged; empty when invalid
+	ID      *string            `json:"id,omitempty"`     // the existing schedule, or the one saved when applied
+	Changed []string           `json:"changed,omitempty"`
+	Error   *apierror.Error    `json:"error,omitempty"`
+}
+
+type syncSchedulesResponse struct {
+	Applied   bool                 `json:"applied"`
+	Created   int                  `json:"created"`
+	Updated   int                  `json:"updated"`
+	Replaced  int                  `json:"replaced"`
+	Deleted   int                  `json:"deleted"`
+	Unchanged int                  `json:"unchanged"`
+	Failed    int                  `json:"failed"`
+	Actions   []syncScheduleAction `json:"actions"`
+}
+
+// Sync makes the user's schedules match the document: names not yet used are created,
+// changed ones updated (or replaced, when mode or job_type changes) and unlisted ones
+// deleted. ?dry_run=true returns the plan without applying it. A document with an invalid
+// entry is rejected with the plan and nothing applied.
+func (h *ScheduleHandler) Sync(ctx *gin.Context) {
+	dryRun := ctx.Query("dry_run") == "true"
+
+	var req syncSchedulesRequest
+	if err := bindDocument(ctx, &req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+	if req.Version != scheduleExportVersion {
+		apierror.Respond(ctx, http.StatusBadRequest, errUnsupportedExport)
+		return
+	}
+
+	userID := ctx.GetString("userID")
+	inputs := make([]usecase.CreateScheduleInput, len(req.Schedules))
+	for i, s := range req.Schedules {
+		inputs[i] = s.toInput(userID)
+		inputs[i].Paused = s.Paused
+	}
+
+	result, err := h.uc.SyncSchedules(ctx.Request.Context(), userID, inputs, dryRun)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "sync schedules", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := syncSchedulesResponse{Applied: result.Applied, Actions: make([]syncScheduleAction, len(result.Entries))}
+	for i, e := range result.Entries {
+		a := syncScheduleAction{Name: e.Name, Action: e.Action, Changed: e.Changed}
+		switch {
+		case e.Schedule != nil:
+			a.ID = &e.Schedule.ID
+		case e.Existing != nil:
+			a.ID = &e.Existing.ID
+		}
+		if e.Err != nil {
+			_, itemErr, ok := createScheduleError(e.Err)
+			if !ok {
+				h.logger.ErrorContext(ctx.Request.Context(), "sync schedule", "name", e.Name, "action", e.Action, "error", e.Err)
+			}
+			a.Error = &itemErr
+			resp.Failed++
+		} else {
+			switch e.Action {
+			case usecase.SyncCreate:
+				resp.Created++
+			case usecase.SyncUpdate:
+				resp.Updated++
+			case usecase.SyncReplace:
+				resp.Replaced++
+			case usecase.SyncDelete:
+				resp.Deleted++
+			case usecase.SyncUnchanged:
+				resp.Unchanged++
+			}
+		}
+		resp.Actions[i] = a
+	}
+
+	status := http.StatusOK
+	if !result.Applied && !dryRun {
+		status = http.StatusBadRequest
+	}
+	ctx.JSON(status, resp)
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/goccy/go-yaml"
+)
+
+// scheduleExportVersion is bumped whenever the document shape changes incompatibly.
+const scheduleExportVersion = 1
+
+// exportedSchedule carries everything needed to recreate a schedule on another deployment.
+// IDs and run times are deployment-local and deliberately left out of the settings.
+type exportedSchedule struct {
+	createScheduleRequest
+	Paused    bool                `json:"paused"`
+	LastRunAt *time.Time          `json:"last_run_at,omitempty"`
+	History   *scheduleRunSummary `json:"history,omitempty"`
+}
+
+// scheduleRunSummary is informational: it is exported for reference and ignored on import.
+type scheduleRunSummary struct {
+	TotalRuns      int            `json:"total_runs"`
+	CompletedRuns  int            `json:"completed_runs"`
+	FailedRuns     int            `json:"failed_runs"`
+	LastStatus     *domain.Status `json:"last_status,omitempty"`
+	LastRunAt      *time.Time     `json:"last_run_at,omitempty"`
+	LastFinishedAt *time.Time     `json:"last_finished_at,omitempty"`
+	LastError      *string        `json:"last_error,omitempty"`
+}
+
+type scheduleExportDocument struct {
+	Version    int                `json:"version"`
+	ExportedAt time.Time          `json:"exported_at"`
+	Schedules  []exportedSchedule `json:"schedules"`
+}
+
+// importSchedulesRequest accepts an export document as-is. Imports are capped at 500
+// schedules per request; larger exports can be split client-side.
+type importSchedulesRequest struct {
+	Version   int                `json:"version"   binding:"required"`
+	Schedules []exportedSchedule `json:"schedules" binding:"required,min=1,max=500,dive"`
+}
+
+// importScheduleResult reports one entry. In dry_run and diff modes the status is what
+// apply would do, and created entries have no ID.
+type importScheduleResult struct {
+	Name    string          `json:"name"`
+	Status  string          `json:"status"` // created, skipped (name already exists), failed
+	ID      *string         `json:"id,omitempty"`
+	Error   *apierror.Error `json:"error,omitempty"`
+	Changed []string        `json:"changed,omitempty"` // diff mode: fields that differ from the existing schedule
+}
+
+type importSchedulesResponse struct {
+	Mode    usecase.ImportMode     `json:"mode"`
+	Created int                    `json:"created"`
+	Skipped int                    `json:"skipped"`
+	Failed  int                    `json:"failed"`
+	Results []importScheduleResult `json:"results"`
+}
+
+// Export returns the document as JSON, or as YAML with ?format=yaml or an Accept header
+// asking for it. Headers, signing secrets and proxy URLs are in the clear so the document
+// can be imported elsewhere, which is why the route is registered as Privileged.
+func (h *ScheduleHandler) Export(ctx *gin.Context) {
+	includeHistory := ctx.Query("include_history") == "true"
+
+	exported, err := h.uc.ExportSchedules(ctx.Request.Context(), ctx.GetString("userID"), includeHistory)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "export schedules", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	doc := scheduleExportDocument{
+		Version:    scheduleExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Schedules:  make([]exportedSchedule, len(exported)),
+	}
+	for i, e := range exported {
+		s := e.Schedule
+		doc.Schedules[i] = exportedSchedule{
+			createScheduleRequest: createScheduleRequest{
+				Name:             s.Name,
+				CronExpr:         s.CronExpr,
+				Every:            s.Every,
+				Timezone:         s.Timezone,
+				URL:              s.URL,
+				Method:           s.Method,
+				Topic:            s.Topic,
+				MessageKey:       s.MessageKey,
+				EmailTo:          s.EmailTo,
+				EmailSubject:     s.EmailSubject,
+				Headers:          s.Headers,
+				Body:             s.Body,
+				TimeoutSeconds:   s.TimeoutSeconds,
+				MaxRetries:       s.MaxRetries,
+				Backoff:          s.Backoff,
+				RetryBaseSeconds: s.RetryBaseSeconds,
+				RetryMaxSeconds:  s.RetryMaxSeconds,
+				RetryJitter:      s.RetryJitter,
+				OverlapPolicy:    s.OverlapPolicy,
+				JitterSeconds:    s.JitterSeconds,
+				Mode:             s.Mode,
+				JobType:          s.JobType,
+				SuccessCodes:     s.SuccessCodes,
+				Templated:        s.Templated,
+				SigningSecret:    s.SigningSecret, // like header credentials, needed to recreate the schedule
+				Queue:            s.Queue,
+
+				PauseAfterFailures: s.PauseAfterFailures,
+				BodyEncoding:       s.BodyEncoding,
+				ContentType:        s.ContentType,
+				ProxyURL:           s.ProxyURL, // unredacted, like the signing secret
+				ClientCertID:       s.ClientCertID,
+				CABundle:           s.CABundle,
+				TLSSkipVerify:      s.TLSSkipVerify,
+			},
+			Paused:    s.Paused,
+			LastRunAt: s.LastRunAt,
+		}
+		if sum := e.Summary; sum != nil {
+			doc.Schedules[i].History = &scheduleRunSummary{
+				TotalRuns:      sum.TotalRuns,
+				CompletedRuns:  sum.CompletedRuns,
+				FailedRuns:     sum.FailedRuns,
+				LastStatus:     sum.LastStatus,
+				LastRunAt:      sum.LastRunAt,
+				LastFinishedAt: sum.LastFinishedAt,
+				LastError:      sum.LastError,
+			}
+		}
+	}
+	if ctx.Query("format") != "yaml" && !strings.Contains(ctx.GetHeader("Accept"), "yaml") {
+		ctx.JSON(http.StatusOK, doc)
+		return
+	}
+	// Through JSON, so the YAML keys and omissions follow the json tags.
+	out, err := json.Marshal(doc)
+	if err == nil {
+		out, err = yaml.JSONToYAML(out)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "encode export as yaml", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/yaml", out)
+}
+
+// Import accepts an export document as JSON, or as YAML with a YAML Content-Type.
+// ?mode=dry_run reports what would be created without writing, and ?mode=diff also lists
+// the fields in which entries differ from the existing schedules they would skip.
+func (h *ScheduleHandler) Import(ctx *gin.Context) {
+	mode := usecase.ImportMode(ctx.DefaultQuery("mode", string(usecase.ImportApply)))
+	if mode != usecase.ImportApply && mode != usecase.ImportDryRun && mode != usecase.ImportDiff {
+		apierror.Respond(ctx, http.StatusBadRequest, errInvalidImportMode)
+		return
+	}
+
+	var req importSchedulesRequest
+	if err := bindDocument(ctx, &req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+	if req.Version != scheduleExportVersion {
+		apierror.Respond(ctx, http.StatusBadRequest, errUnsupportedExport)
+		return
+	}
+
+	userID := ctx.GetString("userID")
+	inputs := make([]usecase.CreateScheduleInput, len(req.Schedules))
+	for i, s := range req.Schedules {
+		inputs[i] = s.toInput(userID)
+		inputs[i].Paused = s.Paused
+	}
+
+	results, err := h.uc.ImportSchedules(ctx.Request.Context(), userID, inputs, mode)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "import schedules", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	resp := importSchedulesResponse{Mode: mode, Results: make([]importScheduleResult, len(results))}
+	for i, r := range results {
+		result := importScheduleResult{Name: r.Name, Changed: r.Changed}
+		switch {
+		case r.Schedule != nil:
+			result.Status = "created"
+			if r.Schedule.ID != "" {
+				result.ID = &r.Schedule.ID
+			}
+			resp.Created++
+		case r.Existing != nil || errors.Is(r.Err, domain.ErrScheduleNameConflict):
+			result.Status = "skipped"
+			if r.Existing != nil {
+				result.ID = &r.Existing.ID
+			}
+			resp.Skipped++
+		default:
+			_, itemErr, ok := createScheduleError(r.Err)
+			if !ok {
+				h.logger.ErrorContext(ctx.Request.Context(), "import schedule", "name", r.Name, "error", r.Err)
+			}
+			result.Status = "failed"
+			result.Error = &itemErr
+			resp.Failed++
+		}
+		resp.Results[i] = result
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// bindDocument binds a JSON body, or a YAML one when the Content-Type says so, into obj
+// and validates it.
+func bindDocument(ctx *gin.Context, obj any) error {
+	if !strings.Contains(ctx.ContentType(), "yaml") {
+		return ctx.ShouldBindJSON(obj)
+	}
+	body, err := ctx.GetRawData()
+	if err != nil {
+		return err
+	}
+	// Through JSON, so the json tags and binding rules apply as they do to JSON bodies.
+	doc, err := yaml.YAMLToJSON(body)
+	if err != nil {
+		return err
+	}
+	return binding.JSON.BindBody(doc, obj)
+}
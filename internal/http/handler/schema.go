@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// SchemaHandler publishes the JSON Schemas of outgoing event payloads so integrators can
+// generate consumers. Schemas are derived from the Go payload types at startup.
+type SchemaHandler struct{}
+
+func NewSchemaHandler() *SchemaHandler {
+	return &SchemaHandler{}
+}
+
+// Routes mounts the schema endpoints on rg.
+func (h *SchemaHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("/events", h.ListEvents)
+	rg.GET("/events/:file", h.GetEvent)
+}
+
+type eventSchemaLink struct {
+	Event  domain.CallbackEvent `json:"event"`
+	Schema string               `json:"schema"`
+}
+
+func (h *SchemaHandler) ListEvents(ctx *gin.Context) {
+	events := eventschema.Events()
+	resp := make([]eventSchemaLink, len(events))
+	for i, event := range events {
+		resp[i] = eventSchemaLink{Event: event, Schema: eventschema.Path(event)}
+	}
+	ctx.Header("Cache-Control", "public, max-age=3600")
+	ctx.JSON(http.StatusOK, gin.H{"events": resp})
+}
+
+// GetEvent serves /schemas/events/<event>.json, e.g. job.completed.json.
+func (h *SchemaHandler) GetEvent(ctx *gin.Context) {
+	name, ok := strings.CutSuffix(ctx.Param("file"), ".json")
+	s := eventschema.ForEvent(domain.CallbackEvent(name))
+	if !ok || s == nil {
+		apierror.Respond(ctx, http.StatusNotFound, errSchemaNotFound)
+		return
+	}
+	ctx.Header("Cache-Control", "public, max-age=3600")
+	ctx.Header("Content-Type", "application/schema+json")
+	ctx.JSON(http.StatusOK, s)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	uc     *usecase.SearchUsecase
+	logger *slog.Logger
+}
+
+func NewSearchHandler(uc *usecase.SearchUsecase, logger *slog.Logger) *SearchHandler {
+	return &SearchHandler{uc: uc, logger: logger.With("component", "search_handler")}
+}
+
+// Routes mounts the search endpoint on rg.
+func (h *SearchHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("", h.Search)
+}
+
+const (
+	searchKindSchedule = "schedule"
+	searchKindJob      = "job"
+)
+
+// searchResultItem is one hit in a mixed result list. Title and Subtitle are ready to
+// display; MatchedOn names the field the query matched.
+type searchResultItem struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Subtitle  string    `json:"subtitle"`
+	Status    string    `json:"status"`
+	MatchedOn string    `json:"matched_on"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Search returns the caller's schedules and jobs matching ?q=, schedules first.
+func (h *SearchHandler) Search(ctx *gin.Context) {
+	q := ctx.Query("q")
+
+	result, err := h.uc.Search(ctx.Request.Context(), ctx.GetString("userID"), q)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSearchQuery) {
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidSearchQuery)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "search", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+
+	q = strings.TrimSpace(q)
+	items := make([]searchResultItem, 0, len(result.Schedules)+len(result.Jobs))
+	for _, s := range result.Schedules {
+		status := "active"
+		if s.Paused {
+			status = "paused"
+		}
+		cadence := s.CronExpr
+		if s.Every != 0 {
+			cadence = "every " + time.Duration(s.Every).String()
+		}
+		items = append(items, searchResultItem{
+			Kind:      searchKindSchedule,
+			ID:        s.ID,
+			Title:     s.Name,
+			Subtitle:  cadence + " " + s.Method + " " + s.URL,
+			Status:    status,
+			MatchedOn: "name",
+			CreatedAt: s.CreatedAt,
+		})
+	}
+	for _, j := range result.Jobs {
+		items = append(items, searchResultItem{
+			Kind:      searchKindJob,
+			ID:        j.ID,
+			Title:     j.Method + " " + j.URL,
+			Subtitle:  j.IdempotencyKey,
+			Status:    string(j.Status),
+			MatchedOn: jobMatchedOn(j, q),
+			CreatedAt: j.CreatedAt,
+		})
+	}
+	ctx.JSON(http.StatusOK, gin.H{"query": q, "results": items})
+}
+
+// jobMatchedOn mirrors the repository's match order: ID prefix, idempotency key prefix, URL.
+func jobMatchedOn(j *domain.Job, q string) string {
+	switch {
+	case strings.HasPrefix(j.ID, q):
+		return "id"
+	case strings.HasPrefix(j.IdempotencyKey, q):
+		return "idempotency_key"
+	default:
+		return "url"
+	}
+}
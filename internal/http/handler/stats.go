@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// StatsHandler serves job aggregates for dashboards.
+type StatsHandler struct {
+	uc     *usecase.StatsUsecase
+	logger *slog.Logger
+}
+
+func NewStatsHandler(uc *usecase.StatsUsecase, logger *slog.Logger) *StatsHandler {
+	return &StatsHandler{uc: uc, logger: logger.With("component", "stats_handler")}
+}
+
+// Routes mounts the account-wide stats endpoint on rg.
+func (h *StatsHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("", h.UserStats)
+}
+
+// ScheduleRoutes mounts the per-schedule stats endpoint on the schedules group.
+func (h *StatsHandler) ScheduleRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:id/stats", h.ScheduleStats)
+}
+
+// statsStatuses are the statuses every stats response lists, with zero counts included.
+var statsStatuses = []domain.Status{
+	domain.StatusAwaitingApproval, domain.StatusPending, domain.StatusBlocked, domain.StatusPaused,
+	domain.StatusRunning, domain.StatusCompleted, domain.StatusFailed, domain.StatusCancelled, domain.StatusExpired,
+}
+
+type statsResponse struct {
+	ScheduleID *string                 `json:"schedule_id,omitempty"`
+	Since      time.Time               `json:"since"`
+	Jobs       int64                   `json:"jobs"` // scheduled since "since", up to now
+	Counts     map[domain.Status]int64 `json:"counts"`
+	// SuccessRate is completed / (completed + failed); null until a job has finished.
+	SuccessRate    *float64 `json:"success_rate"`
+	Attempts       int64    `json:"attempts"`
+	FailedAttempts int64    `json:"failed_attempts"`
+	P50DurationMS  *int64   `json:"p50_duration_ms"`
+	P95DurationMS  *int64   `json:"p95_duration_ms"`
+}
+
+func toStatsResponse(s *domain.JobStats, scheduleID *string) statsResponse {
+	resp := statsResponse{
+		ScheduleID:     scheduleID,
+		Since:          s.Since,
+		Counts:         make(map[domain.Status]int64, len(statsStatuses)),
+		Attempts:       s.Attempts,
+		FailedAttempts: s.FailedAttempts,
+		P50DurationMS:  s.P50DurationMS,
+		P95DurationMS:  s.P95DurationMS,
+	}
+	for _, status := range statsStatuses {
+		resp.Counts[status] = s.Counts[status]
+		resp.Jobs += s.Counts[status]
+	}
+	if finished := s.Counts[domain.StatusCompleted] + s.Counts[domain.StatusFailed]; finished > 0 {
+		rate := float64(s.Counts[domain.StatusCompleted]) / float64(finished)
+		resp.SuccessRate = &rate
+	}
+	return resp
+}
+
+// statsWindow parses ?window= (default 24h). ok is false once a 400 has been written.
+func statsWindow(ctx *gin.Context) (window time.Duration, ok bool) {
+	window = 24 * time.Hour
+	if raw := ctx.Query("window"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 || d > usecase.MaxStatsWindow {
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidStatsWindow)
+			return 0, false
+		}
+		window = d
+	}
+	return window, true
+}
+
+// UserStats reports counts by status, success rate and attempt durations for the
+// caller's jobs scheduled over ?window=.
+func (h *StatsHandler) UserStats(ctx *gin.Context) {
+	window, ok := statsWindow(ctx)
+	if !ok {
+		return
+	}
+
+	stats, err := h.uc.UserStats(ctx.Request.Context(), ctx.GetString("userID"), window)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "get stats", "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, toStatsResponse(stats, nil))
+}
+
+// ScheduleStats is UserStats for the jobs one schedule fired.
+func (h *StatsHandler) ScheduleStats(ctx *gin.Context) {
+	id := ctx.Param("id")
+	window, ok := statsWindow(ctx)
+	if !ok {
+		return
+	}
+
+	stats, err := h.uc.ScheduleStats(ctx.Request.Context(), id, ctx.GetString("userID"), window)
+	if err != nil {
+		if errors.Is(err, domain.ErrScheduleNotFound) {
+			apierror.Respond(ctx, http.StatusNotFound, errScheduleNotFound)
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "get schedule stats", "schedule_id", id, "error", err)
+		apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		return
+	}
+	ctx.JSON(http.StatusOK, toStatsResponse(stats, &id))
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateHandler struct {
+	uc     *usecase.TemplateUsecase
+	logger *slog.Logger
+}
+
+func NewTemplateHandler(uc *usecase.TemplateUsecase, logger *slog.Logger) *TemplateHandler {
+	return &TemplateHandler{uc: uc, logger: logger.With("component", "template_handler")}
+}
+
+// Routes mounts the template endpoints on rg.
+func (h *TemplateHandler) Routes(rg *gin.RouterGroup) {
+	rg.GET("/catalog", h.Catalog)
+	rg.POST("/:id/instantiate", h.Instantiate)
+}
+
+type templateParamResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Default     string `json:"default,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+}
+
+type templateResponse struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Kind        domain.TemplateKind     `json:"kind"`
+	Method      string                  `json:"method"`
+	CronExpr    string                  `json:"cron_expr,omitempty"`
+	Params      []templateParamResponse `json:"params"`
+}
+
+type instantiateTemplateRequest struct {
+	Params map[string]string `json:"params"`
+
+	// Job templates
+	IdempotencyKey string    `json:"idempotency_key" binding:"max=256"`
+	ScheduledAt    time.Time `json:"scheduled_at"`
+
+	// Schedule templates
+	Name     string `json:"name"      binding:"max=256"`
+	CronExpr string `json:"cron_expr"`
+	Timezone string `json:"timezone"  binding:"max=64"`
+}
+
+func (h *TemplateHandler) Catalog(ctx *gin.Context) {
+	catalog := h.uc.Catalog()
+
+	items := make([]templateResponse, len(catalog))
+	for i, t := range catalog {
+		params := make([]templateParamResponse, len(t.Params))
+		for j, p := range t.Params {
+			params[j] = templateParamResponse{
+				Name:        p.Name,
+				Description: p.Description,
+				Required:    p.Required,
+				Default:     p.Default,
+				Pattern:     patternString(p.Pattern),
+			}
+		}
+		items[i] = templateResponse{
+			ID:          t.ID,
+			Name:        t.Name,
+			Description: t.Description,
+			Kind:        t.Kind,
+			Method:      t.Method,
+			CronExpr:    t.CronExpr,
+			Params:      params,
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"templates": items})
+}
+
+func (h *TemplateHandler) Instantiate(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req instantiateTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apierror.RespondInvalid(ctx, err)
+		return
+	}
+
+	result, err := h.uc.Instantiate(ctx.Request.Context(), usecase.InstantiateTemplateInput{
+		UserID:         ctx.GetString("userID"),
+		TemplateID:     id,
+		Params:         req.Params,
+		IdempotencyKey: req.IdempotencyKey,
+		ScheduledAt:    req.ScheduledAt,
+		Name:           req.Name,
+		CronExpr:       req.CronExpr,
+		Timezone:       req.Timezone,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTemplateNotFound):
+			apierror.Respond(ctx, http.StatusNotFound, errTemplateNotFound)
+		case errors.Is(err, domain.ErrInvalidTemplateParams):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidTemplateParams)
+		case errors.Is(err, domain.ErrInvalidCronExpr):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidCronExpr)
+		case errors.Is(err, domain.ErrInvalidTimezone):
+			apierror.Respond(ctx, http.StatusBadRequest, errInvalidTimezone)
+		case errors.Is(err, domain.ErrDuplicateJob):
+			apierror.Respond(ctx, http.StatusBadRequest, errDuplicateJob)
+		case errors.Is(err, domain.ErrScheduleNameConflict):
+			apierror.Respond(ctx, http.StatusConflict, errScheduleNameConflict)
+		case errors.Is(err, domain.ErrQuotaExceeded):
+			apierror.Respond(ctx, http.StatusTooManyRequests, errQuotaExceeded)
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "instantiate template", "template_id", id, "error", err)
+			apierror.Respond(ctx, http.StatusInternalServerError, errInternalServer)
+		}
+		return
+	}
+
+	if result.Schedule != nil {
+		ctx.JSON(http.StatusCreated, gin.H{
+			"kind":     domain.TemplateKindSchedule,
+			"schedule": toScheduleResponse(result.Schedule),
+		})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{
+		"kind": domain.TemplateKindJob,
+		"job": createJobResponse{
+			ID:        result.Job.ID,
+			CreatedAt: result.Job.CreatedAt,
+		},
+	})
+}
+
+func patternString(re *regexp.Regexp) string {
+	if re == nil {
+		return ""
+	}
+	return re.String()
+}
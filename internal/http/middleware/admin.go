@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+var errForbidden = apierror.New("forbidden", "Forbidden")
+
+// RequireAdmin runs after Auth and rejects callers whose user ID is not in adminIDs.
+// An empty list means no one is an admin.
+func RequireAdmin(adminIDs []string) gin.HandlerFunc {
+	admins := make(map[string]struct{}, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if _, ok := admins[c.GetString("userID")]; !ok {
+			apierror.Abort(c, http.StatusForbidden, errForbidden)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// newAdminEngine stubs Auth by setting userID directly, then applies RequireAdmin.
+func newAdminEngine(userID string, admins []string) *gin.Engine {
+	r := gin.New()
+	r.GET("/admin",
+		func(c *gin.Context) { c.Set("userID", userID); c.Next() },
+		middleware.RequireAdmin(admins),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	return r
+}
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		admins []string
+		want   int
+	}{
+		{"admin allowed", "user_admin", []string{"user_other", "user_admin"}, http.StatusOK},
+		{"non-admin forbidden", "user_1", []string{"user_admin"}, http.StatusForbidden},
+		{"no admins configured", "user_admin", nil, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newAdminEngine(tt.userID, tt.admins).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	sloggin "github.com/samber/slog-gin"
+)
+
+// UsageRecorder receives one call per authenticated request.
+type UsageRecorder interface {
+	Record(userID, method, route string, status int, duration time.Duration)
+}
+
+// APIUsage runs after Auth. It tags the access log line with the authenticated user
+// and records the request against that user's per-endpoint usage.
+func APIUsage(recorder UsageRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		userID := c.GetString("userID")
+		sloggin.AddCustomAttributes(c, slog.String("user_id", userID))
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unknown"
+		}
+		recorder.Record(userID, c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
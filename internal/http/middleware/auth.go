@@ -6,15 +6,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
 	"github.com/gin-gonic/gin"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 )
 
-const errUnauthorized = "Unauthorized"
+var errUnauthorized = apierror.New("unauthorized", "Unauthorized")
 
-// Auth validates a Bearer JWT and sets "userID" in the gin context.
+// roleClaim is the JWT claim that restricts a token to a role, e.g. "viewer" for a
+// read-only dashboard token.
+const roleClaim = "role"
+
+// Auth validates a Bearer JWT and sets "userID" in the gin context. A "role" claim, if
+// the token carries one, is set as "tokenRole" for RBAC.
 //
 // When jwksURL is non-empty the token is verified against the JWKS endpoint
 // (RS256 — Clerk). The key set is auto-cached and refreshed every 15 minutes.
@@ -34,7 +41,7 @@ func Auth(jwksURL string, hmacKey []byte) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.GetHeader("Authorization")
 		if !strings.HasPrefix(header, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errUnauthorized})
+			apierror.Abort(c, http.StatusUnauthorized, errUnauthorized)
 			return
 		}
 
@@ -48,7 +55,7 @@ func Auth(jwksURL string, hmacKey []byte) gin.HandlerFunc {
 		if cache != nil {
 			keySet, fetchErr := cache.Get(c.Request.Context(), jwksURL)
 			if fetchErr != nil {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errUnauthorized})
+				apierror.Abort(c, http.StatusUnauthorized, errUnauthorized)
 				return
 			}
 			tok, err = jwt.Parse([]byte(rawToken), jwt.WithKeySet(keySet), jwt.WithValidate(true))
@@ -57,17 +64,21 @@ func Auth(jwksURL string, hmacKey []byte) gin.HandlerFunc {
 		}
 
 		if err != nil || tok == nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errUnauthorized})
+			apierror.Abort(c, http.StatusUnauthorized, errUnauthorized)
 			return
 		}
 
 		userID := tok.Subject()
 		if userID == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errUnauthorized})
+			apierror.Abort(c, http.StatusUnauthorized, errUnauthorized)
 			return
 		}
 
 		c.Set("userID", userID)
+		if v, ok := tok.Get(roleClaim); ok {
+			role, _ := v.(string) // a non-string role matches no role, so RBAC denies writes
+			c.Set("tokenRole", domain.OrgRole(role))
+		}
 		c.Next()
 	}
 }
@@ -124,3 +124,26 @@ func TestAuth_ValidToken_PassesAndSetsUserID(t *testing.T) {
 		t.Errorf("body = %q, want %q", got, userID)
 	}
 }
+
+func TestAuth_RoleClaim_SetsTokenRole(t *testing.T) {
+	tok := makeJWT(t, []byte(testKey), jwt.MapClaims{
+		"sub":  "user-abc",
+		"role": "viewer",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	})
+
+	r := gin.New()
+	r.GET("/protected", middleware.Auth("", []byte(testKey)), func(c *gin.Context) {
+		role, _ := c.Get("tokenRole")
+		c.String(http.StatusOK, "%v", role)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+tok)
+	r.ServeHTTP(w, req)
+
+	if got := w.Body.String(); got != "viewer" {
+		t.Errorf("tokenRole = %q, want viewer", got)
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+var errUnsupportedEncoding = apierror.New("unsupported_content_encoding", "Unsupported Content-Encoding: use gzip or send the body uncompressed")
+
+// BodyLimit caps request bodies at maxBytes and decompresses gzip bodies
+// (Content-Encoding: gzip), capping the decompressed size so a small upload can't
+// expand without bound. A body declared larger than the cap is rejected with 413
+// before it is read; one that turns out larger fails to bind, which
+// apierror.RespondInvalid also reports as 413.
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding")))
+		switch encoding {
+		case "", "identity":
+			if c.Request.ContentLength > maxBytes {
+				e := apierror.TooLarge
+				e.Message = fmt.Sprintf("Request body is larger than %d bytes", maxBytes)
+				apierror.Abort(c, http.StatusRequestEntityTooLarge, e)
+				return
+			}
+		case "gzip", "x-gzip":
+			zr, err := gzip.NewReader(c.Request.Body)
+			if err != nil {
+				e := apierror.InvalidRequest
+				e.Message = "Invalid request: body is not valid gzip"
+				apierror.Abort(c, http.StatusBadRequest, e)
+				return
+			}
+			c.Request.Body = gzipBody{Reader: zr, body: c.Request.Body}
+			c.Request.Header.Del("Content-Encoding")
+			c.Request.ContentLength = -1
+		default:
+			apierror.Abort(c, http.StatusUnsupportedMediaType, errUnsupportedEncoding)
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
+
+// gzipBody reads the decompressed body and closes the original.
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (b gzipBody) Close() error {
+	_ = b.Reader.Close()
+	return b.body.Close()
+}
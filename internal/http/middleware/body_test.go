@@ -0,0 +1,87 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestBodyLimit(t *testing.T) {
+	small := `{"name":"` + strings.Repeat("a", 10) + `"}`
+	large := `{"name":"` + strings.Repeat("a", 200) + `"}`
+	tests := []struct {
+		name     string
+		body     []byte
+		encoding string
+		chunked  bool // hide Content-Length so the limit trips while reading
+		want     int
+		wantName string
+	}{
+		{"small", []byte(small), "", false, http.StatusOK, strings.Repeat("a", 10)},
+		{"declared too large", []byte(large), "", false, http.StatusRequestEntityTooLarge, ""},
+		{"read too large", []byte(large), "", true, http.StatusRequestEntityTooLarge, ""},
+		{"gzip", gzipped(t, small), "gzip", false, http.StatusOK, strings.Repeat("a", 10)},
+		{"gzip expands past limit", gzipped(t, large), "gzip", false, http.StatusRequestEntityTooLarge, ""},
+		{"corrupt gzip", []byte("not gzip"), "gzip", false, http.StatusBadRequest, ""},
+		{"unsupported encoding", []byte(small), "br", false, http.StatusUnsupportedMediaType, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.BodyLimit(100))
+			r.POST("/jobs", func(c *gin.Context) {
+				var req struct {
+					Name string `json:"name"`
+				}
+				if err := c.ShouldBindJSON(&req); err != nil {
+					apierror.RespondInvalid(c, err)
+					return
+				}
+				c.String(http.StatusOK, req.Name)
+			})
+
+			var body io.Reader = bytes.NewReader(tt.body)
+			if tt.chunked {
+				body = io.MultiReader(body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/jobs", body)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want == http.StatusOK && w.Body.String() != tt.wantName {
+				t.Errorf("name = %q, want %q", w.Body, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat("x", 4096)
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		wantGzip       bool
+	}{
+		{"large json", "gzip, deflate", "application/json", large, true},
+		{"small json", "gzip", "application/json", "ok", false},
+		{"not accepted", "", "application/json", large, false},
+		{"refused", "gzip;q=0", "application/json", large, false},
+		{"event stream", "gzip", "text/event-stream", large, false},
+		{"binary", "gzip", "application/octet-stream", large, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.Compress())
+			r.GET("/jobs", func(c *gin.Context) {
+				c.Header("Content-Type", tt.contentType)
+				c.Status(http.StatusOK)
+				// Two writes, so the size threshold is crossed mid-body.
+				half := len(tt.body) / 2
+				_, _ = io.WriteString(c.Writer, tt.body[:half])
+				_, _ = io.WriteString(c.Writer, tt.body[half:])
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/jobs", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Encoding") == "gzip"; got != tt.wantGzip {
+				t.Fatalf("gzipped = %v, want %v", got, tt.wantGzip)
+			}
+			if w.Header().Get("Vary") != "Accept-Encoding" {
+				t.Errorf("Vary = %q", w.Header().Get("Vary"))
+			}
+			body := w.Body.String()
+			if tt.wantGzip {
+				zr, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := io.ReadAll(zr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = string(b)
+			}
+			if body != tt.body {
+				t.Errorf("body has %d bytes, want %d", len(body), len(tt.body))
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORS(t *testing.T) {
+	cfg := middleware.CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.preview.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		MaxAgeSec:      600,
+	}
+	tests := []struct {
+		name        string
+		cfg         middleware.CORSConfig
+		method      string
+		origin      string // "" = same-origin request
+		preflight   bool
+		wantStatus  int
+		wantAllowed bool
+	}{
+		{"same origin", cfg, http.MethodGet, "", false, http.StatusUnauthorized, false},
+		{"allowed origin", cfg, http.MethodGet, "https://app.example.com", false, http.StatusUnauthorized, true},
+		{"wildcard subdomain", cfg, http.MethodGet, "https://pr-12.preview.example.com", false, http.StatusUnauthorized, true},
+		{"wildcard needs a subdomain", cfg, http.MethodGet, "https://.preview.example.com", false, http.StatusUnauthorized, false},
+		{"other origin", cfg, http.MethodGet, "https://evil.example.net", false, http.StatusUnauthorized, false},
+		{"preflight skips auth", cfg, http.MethodOptions, "https://app.example.com", true, http.StatusNoContent, true},
+		{"preflight from other origin", cfg, http.MethodOptions, "https://evil.example.net", true, http.StatusNotFound, false},
+		{"any origin", middleware.CORSConfig{AllowedOrigins: []string{"*"}}, http.MethodGet, "https://evil.example.net", false, http.StatusUnauthorized, true},
+		{"disabled", middleware.CORSConfig{}, http.MethodOptions, "https://app.example.com", true, http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.CORS(tt.cfg))
+			r.GET("/jobs", func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) })
+
+			req := httptest.NewRequest(tt.method, "/jobs", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			got := w.Header().Get("Access-Control-Allow-Origin")
+			if tt.wantAllowed && got != tt.origin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.origin)
+			}
+			if !tt.wantAllowed && got != "" {
+				t.Errorf("Access-Control-Allow-Origin = %q, want none", got)
+			}
+			if tt.wantAllowed && tt.preflight {
+				if m := w.Header().Get("Access-Control-Allow-Methods"); m != "GET, POST" {
+					t.Errorf("Access-Control-Allow-Methods = %q", m)
+				}
+				if h := w.Header().Get("Access-Control-Allow-Headers"); h != "Authorization, Content-Type" {
+					t.Errorf("Access-Control-Allow-Headers = %q", h)
+				}
+				if a := w.Header().Get("Access-Control-Max-Age"); a != "600" {
+					t.Errorf("Access-Control-Max-Age = %q", a)
+				}
+			}
+			if tt.wantAllowed && !tt.preflight && w.Header().Get("Access-Control-Expose-Headers") != "ETag, X-Request-ID" {
+				t.Errorf("Access-Control-Expose-Headers = %q", w.Header().Get("Access-Control-Expose-Headers"))
+			}
+		})
+	}
+}
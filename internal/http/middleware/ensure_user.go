@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
 	"github.com/gin-gonic/gin"
 )
@@ -15,8 +16,7 @@ func EnsureUser(repo repository.UserRepository, logger *slog.Logger) gin.Handler
 		userID := c.GetString("userID")
 		if err := repo.Upsert(c.Request.Context(), userID); err != nil {
 			logger.ErrorContext(c.Request.Context(), "ensure user upsert", "error", err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError,
-				gin.H{"error": "Internal server error"})
+			apierror.Abort(c, http.StatusInternalServerError, apierror.Internal)
 			return
 		}
 		c.Next()
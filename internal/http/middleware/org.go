@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// OrgHeader names the organization a request acts in.
+const OrgHeader = "X-Org-ID"
+
+var errOrgNotFound = apierror.New("org_not_found", "Organization not found")
+
+// OrgRoles looks up a user's role in an organization, returning domain.ErrOrgNotFound
+// for non-members.
+type OrgRoles interface {
+	Role(ctx context.Context, orgID, userID string) (domain.OrgRole, error)
+}
+
+// OrgScope runs after EnsureUser. It keeps the authenticated user in "actorID". When the
+// request names an organization in X-Org-ID, the caller's role goes in "orgRole" and
+// "userID" becomes the organization's account, so every handler scopes its reads and
+// writes to the organization without knowing about it.
+func OrgScope(roles OrgRoles, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userID")
+		c.Set("actorID", userID)
+
+		orgID := c.GetHeader(OrgHeader)
+		if orgID == "" {
+			c.Next()
+			return
+		}
+
+		role, err := roles.Role(c.Request.Context(), orgID, userID)
+		if errors.Is(err, domain.ErrOrgNotFound) {
+			apierror.Abort(c, http.StatusNotFound, errOrgNotFound)
+			return
+		}
+		if err != nil {
+			logger.ErrorContext(c.Request.Context(), "org scope role lookup", "org_id", orgID, "error", err)
+			apierror.Abort(c, http.StatusInternalServerError, apierror.Internal)
+			return
+		}
+
+		c.Set("userID", orgID)
+		c.Set("orgRole", role)
+		c.Next()
+	}
+}
@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeOrgRoles maps "orgID/userID" to a role.
+type fakeOrgRoles map[string]domain.OrgRole
+
+func (f fakeOrgRoles) Role(_ context.Context, orgID, userID string) (domain.OrgRole, error) {
+	role, ok := f[orgID+"/"+userID]
+	if !ok {
+		return "", domain.ErrOrgNotFound
+	}
+	return role, nil
+}
+
+func TestOrgScope(t *testing.T) {
+	roles := fakeOrgRoles{"org_1/user_1": domain.OrgRoleViewer}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name      string
+		org       string
+		want      int
+		wantUser  string
+		wantActor string
+	}{
+		{"no header acts as the user", "", http.StatusOK, "user_1", "user_1"},
+		{"member acts as the org", "org_1", http.StatusOK, "org_1", "user_1"},
+		{"non-member rejected", "org_2", http.StatusNotFound, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser, gotActor string
+			r := gin.New()
+			r.GET("/jobs",
+				func(c *gin.Context) { c.Set("userID", "user_1"); c.Next() },
+				middleware.OrgScope(roles, logger),
+				func(c *gin.Context) {
+					gotUser, gotActor = c.GetString("userID"), c.GetString("actorID")
+					c.Status(http.StatusOK)
+				},
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/jobs", nil)
+			if tt.org != "" {
+				req.Header.Set(middleware.OrgHeader, tt.org)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if gotUser != tt.wantUser || gotActor != tt.wantActor {
+				t.Errorf("userID, actorID = %q, %q; want %q, %q", gotUser, gotActor, tt.wantUser, tt.wantActor)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+var errReadOnlyRole = apierror.New("read_only_role", "Forbidden: your role can only read")
+
+// RBAC runs after OrgScope. Reads (GET, HEAD) are open to every role; any other method
+// needs a role that can write in both places a role can come from: the token's "role"
+// claim and the caller's membership of the organization named in X-Org-ID. A token
+// without the claim, acting outside an organization, is not restricted. readOnly lists
+// route paths (as in gin's FullPath) that change nothing despite their method, such as
+// previews taking a request body; they are open to every role too. privileged lists
+// reads that return secrets in the clear, such as schedule exports; they need a role
+// that can write like any other method.
+func RBAC(readOnly, privileged []string) gin.HandlerFunc {
+	readOnlyPaths := make(map[string]bool, len(readOnly))
+	for _, path := range readOnly {
+		readOnlyPaths[path] = true
+	}
+	privilegedPaths := make(map[string]bool, len(privileged))
+	for _, path := range privileged {
+		privilegedPaths[path] = true
+	}
+	return func(c *gin.Context) {
+		read := c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead
+		if (read && !privilegedPaths[c.FullPath()]) || readOnlyPaths[c.FullPath()] {
+			c.Next()
+			return
+		}
+		for _, key := range []string{"tokenRole", "orgRole"} {
+			if v, ok := c.Get(key); ok {
+				if role, _ := v.(domain.OrgRole); !role.CanWrite() {
+					apierror.Abort(c, http.StatusForbidden, errReadOnlyRole)
+					return
+				}
+			}
+		}
+		c.Next()
+	}
+}
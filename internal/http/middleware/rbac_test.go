@@ -0,0 +1,58 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRBAC(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		tokenRole domain.OrgRole // "" = no claim
+		orgRole   domain.OrgRole // "" = not acting in an org
+		want      int
+	}{
+		{"unrestricted write", http.MethodPost, "/jobs", "", "", http.StatusOK},
+		{"viewer token reads", http.MethodGet, "/jobs", domain.OrgRoleViewer, "", http.StatusOK},
+		{"viewer token cannot create", http.MethodPost, "/jobs", domain.OrgRoleViewer, "", http.StatusForbidden},
+		{"viewer member cannot delete", http.MethodDelete, "/jobs", "", domain.OrgRoleViewer, http.StatusForbidden},
+		{"editor member writes", http.MethodPost, "/jobs", "", domain.OrgRoleEditor, http.StatusOK},
+		{"viewer token overrides owner membership", http.MethodPatch, "/jobs", domain.OrgRoleViewer, domain.OrgRoleOwner, http.StatusForbidden},
+		{"unknown token role cannot write", http.MethodPost, "/jobs", "admin", "", http.StatusForbidden},
+		{"viewer member previews", http.MethodPost, "/schedules/preview", "", domain.OrgRoleViewer, http.StatusOK},
+		{"viewer token cannot export", http.MethodGet, "/schedules/export", domain.OrgRoleViewer, "", http.StatusForbidden},
+		{"viewer member cannot export", http.MethodGet, "/schedules/export", "", domain.OrgRoleViewer, http.StatusForbidden},
+		{"editor member exports", http.MethodGet, "/schedules/export", "", domain.OrgRoleEditor, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Handle(tt.method, tt.path,
+				func(c *gin.Context) {
+					if tt.tokenRole != "" {
+						c.Set("tokenRole", tt.tokenRole)
+					}
+					if tt.orgRole != "" {
+						c.Set("orgRole", tt.orgRole)
+					}
+					c.Next()
+				},
+				middleware.RBAC([]string{"/schedules/preview"}, []string{"/schedules/export"}),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
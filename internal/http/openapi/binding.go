@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+)
+
+// RequestSchema describes a request body type. Unlike a response schema, a field is
+// required only when its binding tag says so, and unknown properties are allowed
+// because gin ignores them.
+func RequestSchema(t reflect.Type) *eventschema.Schema {
+	s := eventschema.FromType(t)
+	applyBinding(s, t)
+	return s
+}
+
+func applyBinding(s *eventschema.Schema, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if s.Items != nil {
+			applyBinding(s.Items, t.Elem())
+		}
+		if sub, ok := s.AdditionalProperties.(*eventschema.Schema); ok {
+			applyBinding(sub, t.Elem())
+		}
+	case reflect.Struct:
+		if s.Properties == nil { // time.Time and text-marshaled structs
+			return
+		}
+		s.Required = nil
+		s.AdditionalProperties = nil
+		for _, f := range jsonFields(t) {
+			prop := s.Properties[f.name]
+			if prop == nil {
+				continue
+			}
+			if applyRules(prop, f.Tag.Get("binding")) {
+				s.Required = append(s.Required, f.name)
+			}
+			applyBinding(prop, f.Type)
+		}
+	}
+}
+
+// applyRules maps the validator rules the API uses onto schema keywords and reports
+// whether the field is required. Conditional rules (required_without, required_if) are
+// left to the field descriptions.
+func applyRules(s *eventschema.Schema, binding string) (required bool) {
+	for _, rule := range strings.Split(binding, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "url":
+			s.Format = "uri"
+		case "oneof":
+			for _, v := range strings.Fields(arg) {
+				s.Enum = append(s.Enum, v)
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				continue
+			}
+			setBound(s, name == "min", n)
+		}
+	}
+	return required
+}
+
+// setBound applies a min or max rule, which validator reads as a length for strings and
+// slices and as a value for numbers.
+func setBound(s *eventschema.Schema, lower bool, n int) {
+	if len(s.Type) == 0 {
+		return
+	}
+	switch s.Type[0] {
+	case "string":
+		if lower {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "array":
+		if lower {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	case "integer", "number":
+		f := float64(n)
+		if lower {
+			s.Minimum = &f
+		} else {
+			s.Maximum = &f
+		}
+	}
+}
+
+type jsonField struct {
+	reflect.StructField
+	name string
+}
+
+// jsonFields lists t's encoding/json fields, promoting those of untagged embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{StructField: f, name: name})
+	}
+	return fields
+}
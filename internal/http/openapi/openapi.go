@@ -0,0 +1,200 @@
+// Package openapi builds the API's OpenAPI 3.1 document from a table of operations and
+// the Go types their handlers bind and render, so the contract can't drift from the
+// code. Schemas come from eventschema; request bodies additionally pick up their gin
+// binding rules (required, min/max, oneof, url).
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
+)
+
+const (
+	version    = "3.1.0"
+	bearerAuth = "bearerAuth"
+)
+
+// Operation describes one route. Request and response bodies are given as zero values
+// of the Go types the handler binds and renders.
+type Operation struct {
+	Method    string
+	Path      string // gin syntax, e.g. /jobs/:id
+	Summary   string
+	Tag       string
+	Public    bool // callable without a bearer token
+	Query     []Param
+	Request   any // JSON body; nil for none
+	Responses []Response
+}
+
+type Param struct {
+	Name        string
+	Type        string // "string", "integer" or "boolean"
+	Description string
+}
+
+type Response struct {
+	Status      int
+	Body        any    // nil for no body
+	ContentType string // default application/json
+	Description string // default http.StatusText(Status)
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components components                      `json:"components"`
+	Security   []map[string][]string           `json:"security"`
+}
+
+type components struct {
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat"`
+}
+
+type operation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []parameter            `json:"parameters,omitempty"`
+	RequestBody *requestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]response    `json:"responses"`
+	Security    *[]map[string][]string `json:"security,omitempty"` // points at an empty list for public routes
+}
+
+type parameter struct {
+	Name        string              `json:"name"`
+	In          string              `json:"in"`
+	Required    bool                `json:"required,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Schema      *eventschema.Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *eventschema.Schema `json:"schema"`
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// Build assembles the document. It panics on a duplicate route or a body type that
+// eventschema can't describe; both are programming errors caught by the handler tests.
+func Build(info Info, ops []Operation) *Document {
+	doc := &Document{
+		OpenAPI: version,
+		Info:    info,
+		Paths:   map[string]map[string]operation{},
+		Components: components{SecuritySchemes: map[string]securityScheme{
+			bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		}},
+		Security: []map[string][]string{{bearerAuth: {}}},
+	}
+
+	for _, op := range ops {
+		path := pathParam.ReplaceAllString(op.Path, "{$1}")
+		method := strings.ToLower(op.Method)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]operation{}
+		}
+		if _, ok := doc.Paths[path][method]; ok {
+			panic(fmt.Sprintf("openapi: duplicate operation %s %s", op.Method, op.Path))
+		}
+		doc.Paths[path][method] = buildOperation(op)
+	}
+	return doc
+}
+
+func buildOperation(op Operation) operation {
+	out := operation{
+		OperationID: operationID(op.Method, op.Path),
+		Summary:     op.Summary,
+		Responses:   map[string]response{},
+	}
+	if op.Tag != "" {
+		out.Tags = []string{op.Tag}
+	}
+	if op.Public {
+		out.Security = &[]map[string][]string{}
+	}
+
+	for _, m := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+		out.Parameters = append(out.Parameters, parameter{
+			Name: m[1], In: "path", Required: true,
+			Schema: &eventschema.Schema{Type: []string{"string"}},
+		})
+	}
+	for _, q := range op.Query {
+		out.Parameters = append(out.Parameters, parameter{
+			Name: q.Name, In: "query", Description: q.Description,
+			Schema: &eventschema.Schema{Type: []string{q.Type}},
+		})
+	}
+
+	if op.Request != nil {
+		out.RequestBody = &requestBody{
+			Required: true,
+			Content:  map[string]mediaType{"application/json": {Schema: RequestSchema(reflect.TypeOf(op.Request))}},
+		}
+	}
+
+	for _, r := range op.Responses {
+		desc := r.Description
+		if desc == "" {
+			desc = http.StatusText(r.Status)
+		}
+		resp := response{Description: desc}
+		if r.Body != nil {
+			contentType := r.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			resp.Content = map[string]mediaType{contentType: {Schema: eventschema.FromType(reflect.TypeOf(r.Body))}}
+		}
+		out.Responses[strconv.Itoa(r.Status)] = resp
+	}
+	out.Responses["default"] = response{
+		Description: "Error",
+		Content:     map[string]mediaType{"application/json": {Schema: eventschema.FromType(reflect.TypeFor[apierror.Response]())}},
+	}
+	return out
+}
+
+// operationID derives a stable ID from the route, e.g. POST /jobs/:id/pause → post_jobs_id_pause.
+func operationID(method, path string) string {
+	parts := []string{strings.ToLower(method)}
+	for _, seg := range strings.Split(path, "/") {
+		seg = strings.TrimPrefix(seg, ":")
+		if seg != "" {
+			parts = append(parts, strings.ReplaceAll(seg, "-", "_"))
+		}
+	}
+	return strings.Join(parts, "_")
+}
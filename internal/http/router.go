@@ -2,43 +2,104 @@ package httptransport
 
 import (
 	"log/slog"
+	"net/http"
 
-	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
-	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/handler"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/apierror"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/middleware"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	sloggin "github.com/samber/slog-gin"
 )
 
-func NewRouter(logger *slog.Logger, jobHandler *handler.JobHandler, scheduleHandler *handler.ScheduleHandler, userRepo repository.UserRepository, jwksURL string, hmacKey []byte) *gin.Engine {
+// tracingService names the API's server spans.
+const tracingService = "dist-job-scheduler-api"
+
+// RegisterFunc mounts a feature's endpoints on the group it is given.
+type RegisterFunc func(rg *gin.RouterGroup)
+
+type module struct {
+	prefix     string
+	public     bool
+	register   RegisterFunc
+	middleware []gin.HandlerFunc
+}
+
+// Registry collects feature route modules so new features can mount their endpoints
+// from the composition root without editing NewRouter. Modules are mounted in the
+// order they were added.
+type Registry struct {
+	modules    []module
+	readOnly   []string
+	privileged []string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Protected mounts register under prefix behind authentication and user provisioning.
+// Requests sent with X-Org-ID act as the organization (see middleware.OrgScope) and are
+// attributed to it in access logs and per-user API usage stats; otherwise to the user.
+// Viewers, by organization role or token claim, are limited to GET and routes marked
+// ReadOnly (middleware.RBAC), and cannot call routes marked Privileged.
+// Extra middleware (e.g. middleware.RequireAdmin) runs after authentication.
+func (r *Registry) Protected(prefix string, register RegisterFunc, mw ...gin.HandlerFunc) {
+	r.modules = append(r.modules, module{prefix: prefix, register: register, middleware: mw})
+}
+
+// ReadOnly marks protected routes, by full path, that change nothing although their
+// method isn't GET, so viewers may call them too.
+func (r *Registry) ReadOnly(paths ...string) {
+	r.readOnly = append(r.readOnly, paths...)
+}
+
+// Privileged marks protected GET routes, by full path, that return secrets in the
+// clear, so only roles that can write may call them.
+func (r *Registry) Privileged(paths ...string) {
+	r.privileged = append(r.privileged, paths...)
+}
+
+// Public mounts register under prefix without authentication. Handlers mounted here
+// must verify their callers themselves (e.g. signed inbound webhooks).
+func (r *Registry) Public(prefix string, register RegisterFunc, mw ...gin.HandlerFunc) {
+	r.modules = append(r.modules, module{prefix: prefix, public: true, register: register, middleware: mw})
+}
+
+func NewRouter(logger *slog.Logger, registry *Registry, userRepo repository.UserRepository, orgs middleware.OrgRoles, usage middleware.UsageRecorder, jwksURL string, hmacKey []byte, cors middleware.CORSConfig, maxBodyBytes int64) *gin.Engine {
+	apierror.UseJSONFieldNames()
+
 	r := gin.New()
-	r.Use(gin.Recovery())
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		apierror.Abort(c, http.StatusInternalServerError, apierror.Internal)
+	}))
 	r.Use(middleware.RequestID())
+	r.Use(otelgin.Middleware(tracingService))
 	r.Use(middleware.Security())
+	r.Use(middleware.CORS(cors))
 	r.Use(sloggin.New(logger))
 	r.Use(middleware.Metrics())
+	r.Use(middleware.Compress())
+	r.Use(middleware.BodyLimit(maxBodyBytes))
 
 	authMW := middleware.Auth(jwksURL, hmacKey)
 	ensureUser := middleware.EnsureUser(userRepo, logger)
+	orgScope := middleware.OrgScope(orgs, logger)
+	apiUsage := middleware.APIUsage(usage)
+	rbac := middleware.RBAC(registry.readOnly, registry.privileged)
+
+	for _, m := range registry.modules {
+		chain := []gin.HandlerFunc{authMW, ensureUser, orgScope, apiUsage, rbac}
+		if m.public {
+			chain = nil
+		}
+		m.register(r.Group(m.prefix, append(chain, m.middleware...)...))
+	}
 
-	// Protected job routes
-	jobs := r.Group("/jobs", authMW, ensureUser)
-	jobs.GET("", jobHandler.List)
-	jobs.POST("", jobHandler.Create)
-	jobs.GET("/:id", jobHandler.GetByID)
-	jobs.DELETE("/:id", jobHandler.Cancel)
-	jobs.GET("/:id/attempts", jobHandler.ListAttempts)
-
-	// Protected schedule routes
-	schedules := r.Group("/schedules", authMW, ensureUser)
-	schedules.POST("", scheduleHandler.Create)
-	schedules.GET("", scheduleHandler.List)
-	schedules.GET("/:id", scheduleHandler.GetByID)
-	schedules.POST("/:id/pause", scheduleHandler.Pause)
-	schedules.POST("/:id/resume", scheduleHandler.Resume)
-	schedules.DELETE("/:id", scheduleHandler.Delete)
-	schedules.GET("/:id/jobs", scheduleHandler.ListJobs)
+	r.NoRoute(func(c *gin.Context) {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound)
+	})
 
 	return r
 }
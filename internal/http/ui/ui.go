@@ -0,0 +1,25 @@
+// Package ui serves the embedded operator dashboard. The page is static and talks to
+// the regular JSON API with the operator's bearer token, so it needs no auth of its own.
+package ui
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed static/index.html
+var indexHTML []byte
+
+// Routes mounts the dashboard on rg.
+func Routes(rg *gin.RouterGroup) {
+	rg.GET("", index)
+	rg.GET("/", index)
+}
+
+func index(ctx *gin.Context) {
+	// The token lives in sessionStorage; keep the page itself out of shared caches.
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", indexHTML)
+}
@@ -0,0 +1,65 @@
+// Package kafka connects to the Kafka cluster kafka jobs are published to.
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+// Options configures the connection to the cluster.
+type Options struct {
+	Brokers       []string // seed brokers, host:port
+	TLS           bool
+	SASLMechanism string // "", "plain", "scram-sha-256" or "scram-sha-512"
+	Username      string
+	Password      string
+}
+
+// NewClient connects a producer to the cluster and pings it, so a misconfigured broker
+// list fails at startup rather than on the first job. The producer is idempotent and
+// waits for all in-sync replicas, franz-go's defaults; topics are never auto-created.
+func NewClient(ctx context.Context, opts Options) (*kgo.Client, error) {
+	kopts := []kgo.Opt{
+		kgo.SeedBrokers(opts.Brokers...),
+		kgo.ClientID("dist-job-scheduler"),
+	}
+	if opts.TLS {
+		kopts = append(kopts, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	if opts.SASLMechanism != "" {
+		mechanism, err := saslMechanism(opts)
+		if err != nil {
+			return nil, err
+		}
+		kopts = append(kopts, kgo.SASL(mechanism))
+	}
+
+	client, err := kgo.NewClient(kopts...)
+	if err != nil {
+		return nil, fmt.Errorf("create kafka client: %w", err)
+	}
+	if err := client.Ping(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("ping kafka: %w", err)
+	}
+	return client, nil
+}
+
+func saslMechanism(opts Options) (sasl.Mechanism, error) {
+	switch opts.SASLMechanism {
+	case "plain":
+		return plain.Auth{User: opts.Username, Pass: opts.Password}.AsMechanism(), nil
+	case "scram-sha-256":
+		return scram.Auth{User: opts.Username, Pass: opts.Password}.AsSha256Mechanism(), nil
+	case "scram-sha-512":
+		return scram.Auth{User: opts.Username, Pass: opts.Password}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unsupported kafka sasl mechanism %q", opts.SASLMechanism)
+	}
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type APIUsageRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIUsageRepository(pool *pgxpool.Pool) *APIUsageRepository {
+	return &APIUsageRepository{pool: pool}
+}
+
+func (r *APIUsageRepository) AddBatch(ctx context.Context, entries []domain.APIUsage) error {
+	batch := &pgx.Batch{}
+	for _, e := range entries {
+		batch.Queue(`
+			INSERT INTO api_usage_hourly (
+				user_id, bucket_start, method, route,
+				requests, client_errors, server_errors, total_duration_ms
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (user_id, bucket_start, method, route) DO UPDATE
+			SET requests          = api_usage_hourly.requests + EXCLUDED.requests,
+			    client_errors     = api_usage_hourly.client_errors + EXCLUDED.client_errors,
+			    server_errors     = api_usage_hourly.server_errors + EXCLUDED.server_errors,
+			    total_duration_ms = api_usage_hourly.total_duration_ms + EXCLUDED.total_duration_ms`,
+			e.UserID, e.BucketStart, e.Method, e.Route,
+			e.Requests, e.ClientErrors, e.ServerErrors, e.TotalDurationMS,
+		)
+	}
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("add api usage: %w", err)
+	}
+	return nil
+}
+
+func (r *APIUsageRepository) ListByUser(ctx context.Context, userID string, since time.Time) ([]domain.APIUsage, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT method, route,
+		       SUM(requests), SUM(client_errors), SUM(server_errors), SUM(total_duration_ms)
+		FROM   api_usage_hourly
+		WHERE  user_id = $1 AND bucket_start >= date_trunc('hour', $2::timestamptz)
+		GROUP BY method, route
+		ORDER BY SUM(requests) DESC, route, method`, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("list api usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []domain.APIUsage
+	for rows.Next() {
+		u := domain.APIUsage{UserID: userID}
+		if err := rows.Scan(&u.Method, &u.Route,
+			&u.Requests, &u.ClientErrors, &u.ServerErrors, &u.TotalDurationMS); err != nil {
+			return nil, fmt.Errorf("scan api usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api usage: %w", err)
+	}
+	return usage, nil
+}
+
+func (r *APIUsageRepository) DeleteBefore(ctx context.Context, cutoff time.Time) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM api_usage_hourly WHERE bucket_start < $1`, cutoff); err != nil {
+		return fmt.Errorf("prune api usage: %w", err)
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,22 +23,34 @@ func (r *AttemptRepository) CreateAttempt(ctx context.Context, a *domain.JobAtte
 	query := `
 		INSERT INTO job_attempts (job_id, attempt_num, worker_id, started_at)
 		VALUES ($1, $2, $3, $4)
-		RETURNING id, job_id, attempt_num, worker_id, started_at,
-		          completed_at, status_code, error, duration_ms`
+		RETURNING ` + attemptColumns
 
 	row := r.pool.QueryRow(ctx, query, a.JobID, a.AttemptNum, a.WorkerID, a.StartedAt)
 	return scanAttempt(row)
 }
 
-func (r *AttemptRepository) CompleteAttempt(ctx context.Context, id string, statusCode *int, errMsg *string, durationMS int64) error {
+func (r *AttemptRepository) CompleteAttempt(ctx context.Context, a *domain.JobAttempt) error {
 	_, err := r.pool.Exec(ctx, `
 		UPDATE job_attempts
-		SET completed_at = NOW(),
-		    status_code  = $2,
-		    error        = $3,
-		    duration_ms  = $4
+		SET completed_at     = NOW(),
+		    status_code      = $2,
+		    error            = $3,
+		    duration_ms      = $4,
+		    response_bytes   = $5,
+		    remote_addr      = $6,
+		    request_bytes    = $7,
+		    response_headers = $8,
+		    response_body    = $9,
+		    cancelled        = $10,
+		    request_method   = $11,
+		    request_url      = $12,
+		    request_headers  = $13,
+		    request_body       = $14,
+		    response_truncated = $15
 		WHERE id = $1`,
-		id, statusCode, errMsg, durationMS,
+		a.ID, a.StatusCode, a.Error, a.DurationMS, a.ResponseBytes, a.RemoteAddr, a.RequestBytes,
+		a.ResponseHeaders, a.ResponseBody, a.Cancelled,
+		a.RequestMethod, a.RequestURL, a.RequestHeaders, a.RequestBody, a.ResponseTruncated,
 	)
 	if err != nil {
 		return fmt.Errorf("complete attempt: %w", err)
@@ -43,10 +58,16 @@ func (r *AttemptRepository) CompleteAttempt(ctx context.Context, id string, stat
 	return nil
 }
 
+func (r *AttemptRepository) DeleteAttempt(ctx context.Context, id string) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM job_attempts WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete attempt: %w", err)
+	}
+	return nil
+}
+
 func (r *AttemptRepository) ListByJobID(ctx context.Context, jobID string) ([]*domain.JobAttempt, error) {
 	query := `
-		SELECT id, job_id, attempt_num, worker_id, started_at,
-		       completed_at, status_code, error, duration_ms
+		SELECT ` + attemptColumns + `
 		FROM job_attempts
 		WHERE job_id = $1
 		ORDER BY started_at ASC`
@@ -68,11 +89,108 @@ func (r *AttemptRepository) ListByJobID(ctx context.Context, jobID string) ([]*d
 	return attempts, nil
 }
 
+func (r *AttemptRepository) ListPageByJobID(ctx context.Context, jobID string, order domain.SortOrder, after *int, limit int) ([]*domain.JobAttempt, error) {
+	args := []any{jobID}
+	where := "job_id = $1"
+	cmp, dir := keysetOrder(order)
+
+	if after != nil {
+		args = append(args, *after)
+		where += fmt.Sprintf(" AND attempt_num %s $%d", cmp, len(args))
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT `+attemptColumns+`
+		FROM job_attempts
+		WHERE %s
+		ORDER BY attempt_num %s
+		LIMIT $%d`,
+		where, dir, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*domain.JobAttempt
+	for rows.Next() {
+		a, err := scanAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	return attempts, nil
+}
+
+func (r *AttemptRepository) CountByJobID(ctx context.Context, jobID string) (int64, error) {
+	var n int64
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM job_attempts WHERE job_id = $1`, jobID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count attempts: %w", err)
+	}
+	return n, nil
+}
+
+func (r *AttemptRepository) GetByID(ctx context.Context, jobID, attemptID string) (*domain.JobAttempt, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+attemptColumns+`
+		FROM job_attempts
+		WHERE id = $1 AND job_id = $2`,
+		attemptID, jobID)
+	a, err := scanAttempt(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrAttemptNotFound
+	}
+	return a, err
+}
+
+// SumEgressByUser totals attempt traffic per schedule for attempts started since since.
+// Ad-hoc jobs are grouped under a nil ScheduleID.
+func (r *AttemptRepository) SumEgressByUser(ctx context.Context, userID string, since time.Time) ([]domain.EgressUsage, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT j.schedule_id, s.name, COUNT(*),
+		       COALESCE(SUM(a.request_bytes), 0), COALESCE(SUM(a.response_bytes), 0)
+		FROM job_attempts a
+		JOIN jobs j           ON j.id = a.job_id
+		LEFT JOIN schedules s ON s.id = j.schedule_id
+		WHERE j.user_id = $1 AND a.started_at >= $2
+		GROUP BY j.schedule_id, s.name
+		ORDER BY COALESCE(SUM(a.request_bytes), 0) + COALESCE(SUM(a.response_bytes), 0) DESC`,
+		userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("sum egress: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []domain.EgressUsage
+	for rows.Next() {
+		var u domain.EgressUsage
+		if err := rows.Scan(&u.ScheduleID, &u.ScheduleName, &u.Attempts, &u.RequestBytes, &u.ResponseBytes); err != nil {
+			return nil, fmt.Errorf("scan egress: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate egress: %w", err)
+	}
+	return usage, nil
+}
+
+// attemptColumns is the column list every attempt query selects/returns — keep in sync with scanAttempt.
+const attemptColumns = `id, job_id, attempt_num, worker_id, started_at,
+		completed_at, status_code, error, duration_ms, response_bytes, remote_addr, request_bytes,
+		response_headers, response_body, cancelled, request_method, request_url, request_headers,
+		request_body, response_truncated`
+
 func scanAttempt(row rowScanner) (*domain.JobAttempt, error) {
 	var a domain.JobAttempt
 	err := row.Scan(
 		&a.ID, &a.JobID, &a.AttemptNum, &a.WorkerID, &a.StartedAt,
-		&a.CompletedAt, &a.StatusCode, &a.Error, &a.DurationMS,
+		&a.CompletedAt, &a.StatusCode, &a.Error, &a.DurationMS, &a.ResponseBytes, &a.RemoteAddr,
+		&a.RequestBytes, &a.ResponseHeaders, &a.ResponseBody, &a.Cancelled,
+		&a.RequestMethod, &a.RequestURL, &a.RequestHeaders, &a.RequestBody, &a.ResponseTruncated,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("scan attempt: %w", err)
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CallbackRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCallbackRepository(pool *pgxpool.Pool) *CallbackRepository {
+	return &CallbackRepository{pool: pool}
+}
+
+func (r *CallbackRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]repository.DueCallback, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH due AS (
+			SELECT id FROM job_callbacks
+			WHERE  status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE job_callbacks c
+		SET    next_attempt_at = NOW() + make_interval(secs => $2),
+		       updated_at      = NOW()
+		FROM due
+		WHERE c.id = due.id
+		RETURNING `+qualifiedCallbackColumns, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim callbacks: %w", err)
+	}
+	callbacks, err := collectCallbacks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(callbacks) == 0 {
+		return nil, nil
+	}
+
+	jobIDs := make([]string, len(callbacks))
+	for i, cb := range callbacks {
+		jobIDs[i] = cb.JobID
+	}
+	jobRows, err := r.pool.Query(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = ANY($1)`, jobIDs)
+	if err != nil {
+		return nil, fmt.Errorf("load callback jobs: %w", err)
+	}
+	defer jobRows.Close()
+
+	jobs := make(map[string]*domain.Job, len(callbacks))
+	for jobRows.Next() {
+		// Callback payloads never carry the job's headers or body, so sealed columns are
+		// left as stored.
+		j, err := scanJob(jobRows)
+		if err != nil {
+			return nil, err
+		}
+		jobs[j.ID] = j
+	}
+	if err := jobRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate callback jobs: %w", err)
+	}
+
+	due := make([]repository.DueCallback, 0, len(callbacks))
+	for _, cb := range callbacks {
+		// The job may have been deleted since; its callback row cascades with it.
+		if j, ok := jobs[cb.JobID]; ok {
+			due = append(due, repository.DueCallback{Callback: cb, Job: j})
+		}
+	}
+	return due, nil
+}
+
+func (r *CallbackRepository) RecordAttempt(ctx context.Context, a *domain.CallbackAttempt, status domain.CallbackStatus, nextAttemptAt time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO job_callback_attempts (callback_id, attempt_num, status_code, error, response_snippet, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		a.CallbackID, a.AttemptNum, a.StatusCode, a.Error, a.ResponseSnippet, a.DurationMS,
+	); err != nil {
+		return fmt.Errorf("insert callback attempt: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE job_callbacks
+		SET    status          = $2,
+		       attempts        = $3,
+		       next_attempt_at = $4,
+		       last_error      = $5,
+		       delivered_at    = CASE WHEN $2 = 'delivered' THEN NOW() ELSE delivered_at END,
+		       updated_at      = NOW()
+		WHERE id = $1`,
+		a.CallbackID, status, a.AttemptNum, nextAttemptAt, a.Error,
+	); err != nil {
+		return fmt.Errorf("update callback: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *CallbackRepository) ListByJobID(ctx context.Context, jobID string) ([]*domain.Callback, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+callbackColumns+`
+		FROM job_callbacks
+		WHERE job_id = $1
+		ORDER BY created_at ASC`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list callbacks: %w", err)
+	}
+	callbacks, err := collectCallbacks(rows)
+	if err != nil || len(callbacks) == 0 {
+		return callbacks, err
+	}
+
+	byID := make(map[string]*domain.Callback, len(callbacks))
+	for _, cb := range callbacks {
+		byID[cb.ID] = cb
+	}
+
+	attemptRows, err := r.pool.Query(ctx, `
+		SELECT a.id, a.callback_id, a.attempt_num, a.status_code, a.error,
+		       a.response_snippet, a.duration_ms, a.created_at
+		FROM job_callback_attempts a
+		JOIN job_callbacks c ON c.id = a.callback_id
+		WHERE c.job_id = $1
+		ORDER BY a.attempt_num ASC`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list callback attempts: %w", err)
+	}
+	defer attemptRows.Close()
+
+	for attemptRows.Next() {
+		var a domain.CallbackAttempt
+		if err := attemptRows.Scan(
+			&a.ID, &a.CallbackID, &a.AttemptNum, &a.StatusCode, &a.Error,
+			&a.ResponseSnippet, &a.DurationMS, &a.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan callback attempt: %w", err)
+		}
+		if cb, ok := byID[a.CallbackID]; ok {
+			cb.History = append(cb.History, &a)
+		}
+	}
+	if err := attemptRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate callback attempts: %w", err)
+	}
+	return callbacks, nil
+}
+
+func (r *CallbackRepository) Redeliver(ctx context.Context, jobID string) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE job_callbacks
+		SET    status = 'pending', next_attempt_at = NOW(), updated_at = NOW()
+		WHERE  job_id = $1`, jobID)
+	if err != nil {
+		return 0, fmt.Errorf("redeliver callbacks: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// callbackColumns is the column list every callback query selects/returns — keep in sync with collectCallbacks.
+const callbackColumns = `id, job_id, event, url, status, attempts,
+		next_attempt_at, last_error, delivered_at, created_at`
+
+// qualifiedCallbackColumns is callbackColumns for UPDATE ... FROM, where bare names are ambiguous.
+const qualifiedCallbackColumns = `c.id, c.job_id, c.event, c.url, c.status, c.attempts,
+		c.next_attempt_at, c.last_error, c.delivered_at, c.created_at`
+
+func collectCallbacks(rows pgx.Rows) ([]*domain.Callback, error) {
+	defer rows.Close()
+	var callbacks []*domain.Callback
+	for rows.Next() {
+		var cb domain.Callback
+		if err := rows.Scan(
+			&cb.ID, &cb.JobID, &cb.Event, &cb.URL, &cb.Status, &cb.Attempts,
+			&cb.NextAttemptAt, &cb.LastError, &cb.DeliveredAt, &cb.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan callback: %w", err)
+		}
+		callbacks = append(callbacks, &cb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate callbacks: %w", err)
+	}
+	return callbacks, nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ClientCertRepository struct {
+	pool *pgxpool.Pool
+	box  *secrets.Box
+}
+
+func NewClientCertRepository(pool *pgxpool.Pool, box *secrets.Box) *ClientCertRepository {
+	return &ClientCertRepository{pool: pool, box: box}
+}
+
+func (r *ClientCertRepository) Create(ctx context.Context, c *domain.ClientCertificate) (*domain.ClientCertificate, error) {
+	key, err := r.box.Seal(secrets.WithOwner(ctx, c.UserID), c.KeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("seal client key: %w", err)
+	}
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO client_certificates (user_id, name, cert_pem, key_pem, subject, fingerprint, not_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+clientCertColumns,
+		c.UserID, c.Name, c.CertPEM, key, c.Subject, c.Fingerprint, c.NotAfter,
+	)
+	created, err := r.scan(ctx, row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrClientCertNameConflict
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *ClientCertRepository) GetByID(ctx context.Context, id, userID string) (*domain.ClientCertificate, error) {
+	return r.scan(ctx, r.pool.QueryRow(ctx, `
+		SELECT `+clientCertColumns+`
+		FROM client_certificates
+		WHERE id = $1 AND user_id = $2`, id, userID))
+}
+
+func (r *ClientCertRepository) ListByUser(ctx context.Context, userID string) ([]*domain.ClientCertificate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, name, subject, fingerprint, not_after, created_at
+		FROM client_certificates
+		WHERE user_id = $1
+		ORDER BY name ASC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list client certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var certs []*domain.ClientCertificate
+	for rows.Next() {
+		var c domain.ClientCertificate
+		if err := rows.Scan(&c.ID, &c.UserID, &c.Name, &c.Subject, &c.Fingerprint, &c.NotAfter, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan client certificate: %w", err)
+		}
+		certs = append(certs, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate client certificates: %w", err)
+	}
+	return certs, nil
+}
+
+func (r *ClientCertRepository) Delete(ctx context.Context, id, userID string) error {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM client_certificates c
+		WHERE c.id = $1 AND c.user_id = $2
+		  AND NOT EXISTS (
+		      SELECT 1 FROM jobs
+		      WHERE client_cert_id = c.id AND status IN ('pending', 'running', 'blocked', 'paused', 'awaiting_approval'))
+		  AND NOT EXISTS (SELECT 1 FROM schedules WHERE client_cert_id = c.id)`, id, userID)
+	if err != nil {
+		// A schedule took the certificate after the NOT EXISTS check.
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrClientCertInUse
+		}
+		return fmt.Errorf("delete client certificate: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+	if _, err := r.GetByID(ctx, id, userID); err != nil {
+		return err
+	}
+	return domain.ErrClientCertInUse
+}
+
+// isUnknownClientCert reports whether a job or schedule write failed because its
+// client_cert_id isn't one of the owner's certificates.
+func isUnknownClientCert(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && strings.Contains(pgErr.ConstraintName, "client_cert_id")
+}
+
+// clientCertColumns is the column list every full certificate query selects/returns — keep in sync with scan.
+const clientCertColumns = `id, user_id, name, cert_pem, key_pem, subject, fingerprint, not_after, created_at`
+
+// scan reads a certificate row and opens its key.
+func (r *ClientCertRepository) scan(ctx context.Context, row rowScanner) (*domain.ClientCertificate, error) {
+	var c domain.ClientCertificate
+	err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.CertPEM, &c.KeyPEM, &c.Subject, &c.Fingerprint, &c.NotAfter, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrClientCertNotFound
+		}
+		return nil, fmt.Errorf("scan client certificate: %w", err)
+	}
+	if c.KeyPEM, err = r.box.Open(ctx, c.KeyPEM); err != nil {
+		return nil, fmt.Errorf("client certificate %s: open key: %w", c.ID, err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DefaultsRepository struct {
+	pool *pgxpool.Pool
+	box  *secrets.Box
+}
+
+func NewDefaultsRepository(pool *pgxpool.Pool, box *secrets.Box) *DefaultsRepository {
+	return &DefaultsRepository{pool: pool, box: box}
+}
+
+func (r *DefaultsRepository) Get(ctx context.Context, userID string) (*domain.JobDefaults, error) {
+	var d domain.JobDefaults
+	err := r.pool.QueryRow(ctx, `
+		SELECT timeout_seconds, max_retries, backoff, headers
+		FROM user_job_defaults
+		WHERE user_id = $1`, userID,
+	).Scan(&d.TimeoutSeconds, &d.MaxRetries, &d.Backoff, &d.Headers)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.JobDefaults{}, nil
+		}
+		return nil, fmt.Errorf("get job defaults: %w", err)
+	}
+	if d.Headers, err = r.box.OpenHeaders(ctx, d.Headers); err != nil {
+		return nil, fmt.Errorf("open job default headers: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *DefaultsRepository) Upsert(ctx context.Context, userID string, d *domain.JobDefaults) error {
+	headers, err := r.box.SealHeaders(secrets.WithOwner(ctx, userID), d.Headers)
+	if err != nil {
+		return fmt.Errorf("seal job default headers: %w", err)
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO user_job_defaults (user_id, timeout_seconds, max_retries, backoff, headers)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET timeout_seconds = EXCLUDED.timeout_seconds,
+		    max_retries     = EXCLUDED.max_retries,
+		    backoff         = EXCLUDED.backoff,
+		    headers         = EXCLUDED.headers,
+		    updated_at      = NOW()`,
+		userID, d.TimeoutSeconds, d.MaxRetries, d.Backoff, headers,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert job defaults: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NOTIFY channels written by the notify_job_status and notify_jobs_ready triggers.
+const (
+	jobStatusChannel = "job_status"
+	jobsReadyChannel = "jobs_ready"
+)
+
+// JobEventListener holds one pooled connection per active Listen call.
+type JobEventListener struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NewJobEventListener(pool *pgxpool.Pool, logger *slog.Logger) *JobEventListener {
+	return &JobEventListener{pool: pool, logger: logger}
+}
+
+// jobStatusPayload mirrors the json_build_object in notify_job_status, which sends a
+// JSON array of them per statement.
+type jobStatusPayload struct {
+	ID             string        `json:"id"`
+	UserID         string        `json:"user_id"`
+	ScheduleID     *string       `json:"schedule_id"`
+	Status         domain.Status `json:"status"`
+	PreviousStatus domain.Status `json:"previous_status"`
+	At             time.Time     `json:"at"`
+}
+
+func (l *JobEventListener) ListenStatusChanges(ctx context.Context, fn func(domain.JobStatusEvent)) error {
+	return l.listen(ctx, jobStatusChannel, func(payload string) {
+		var batch []jobStatusPayload
+		if err := json.Unmarshal([]byte(payload), &batch); err != nil {
+			l.logger.ErrorContext(ctx, "decode job status notification", "payload", payload, "error", err)
+			return
+		}
+		for _, p := range batch {
+			fn(domain.JobStatusEvent{
+				JobID:          p.ID,
+				UserID:         p.UserID,
+				ScheduleID:     p.ScheduleID,
+				Status:         p.Status,
+				PreviousStatus: p.PreviousStatus,
+				At:             p.At,
+			})
+		}
+	})
+}
+
+func (l *JobEventListener) ListenReady(ctx context.Context, fn func()) error {
+	return l.listen(ctx, jobsReadyChannel, func(string) { fn() })
+}
+
+func (l *JobEventListener) listen(ctx context.Context, channel string, fn func(payload string)) error {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire listen conn: %w", err)
+	}
+	// A LISTENing connection must not go back to the pool, where another query could
+	// inherit the subscription; Hijack takes it out and Close ends the session.
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.Background())
+
+	if _, err := pgConn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return fmt.Errorf("listen %s: %w", channel, err)
+	}
+
+	for {
+		n, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification on %s: %w", channel, err)
+		}
+		fn(n.Payload)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -16,63 +17,268 @@ import (
 
 type JobRepository struct {
 	pool *pgxpool.Pool
+	box  *secrets.Box
 }
 
-func NewJobRepository(pool *pgxpool.Pool) *JobRepository {
-	return &JobRepository{pool: pool}
+func NewJobRepository(pool *pgxpool.Pool, box *secrets.Box) *JobRepository {
+	return &JobRepository{pool: pool, box: box}
 }
 
 func (r *JobRepository) Create(ctx context.Context, job *domain.Job) (*domain.Job, error) {
+	// A dependent job is inserted in a transaction that holds its parent's row lock; see
+	// lockParent.
+	var q queryRower = r.pool
+	var tx pgx.Tx
+	if job.DependsOn != nil {
+		var err error
+		if tx, err = r.pool.Begin(ctx); err != nil {
+			return nil, fmt.Errorf("begin tx: %w", err)
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+		if err := lockParent(ctx, tx, job); err != nil {
+			return nil, err
+		}
+		q = tx
+	}
+
+	sealed, err := seal(ctx, r.box, job.UserID, job.Headers, job.Body, job.SigningSecret, job.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		INSERT INTO jobs (
 			user_id, idempotency_key, url, method, headers, body,
-			timeout_seconds, status, scheduled_at, max_retries, backoff, schedule_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, user_id, idempotency_key, url, method, headers, body,
-		          timeout_seconds, status, scheduled_at, retry_count,
-		          max_retries, backoff, claimed_at, claimed_by,
-		          heartbeat_at, completed_at, last_error, created_at, updated_at, schedule_id`
-
-	row := r.pool.QueryRow(ctx, query,
+			timeout_seconds, status, scheduled_at, max_retries, backoff, schedule_id,
+			request_id, priority, deadline, retry_delays_ms, callback_url, success_codes, templated,
+			retry_base_seconds, retry_max_seconds, retry_jitter, debug, expires_at, trace_parent,
+			depends_on, on_parent_failure, signing_secret, queue, body_encoding, content_type,
+			proxy_url, client_cert_id, ca_bundle, tls_skip_verify, job_type, topic, message_key,
+			email_to, email_subject, retried_from, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41, $42)
+		RETURNING ` + jobColumns
+
+	row := q.QueryRow(ctx, query,
 		job.UserID,
 		job.IdempotencyKey,
 		job.URL,
 		job.Method,
-		job.Headers,
-		job.Body,
+		sealed.headers,
+		sealed.body,
 		job.TimeoutSeconds,
 		job.Status,
 		job.ScheduledAt,
 		job.MaxRetries,
 		job.Backoff,
 		job.ScheduleID,
+		job.RequestID,
+		job.Priority,
+		job.Deadline,
+		durationsToMillis(job.RetryDelays),
+		job.CallbackURL,
+		[]string(job.SuccessCodes),
+		job.Templated,
+		job.RetryBaseSeconds,
+		job.RetryMaxSeconds,
+		job.RetryJitter,
+		job.Debug,
+		job.ExpiresAt,
+		job.TraceParent,
+		job.DependsOn,
+		job.OnParentFailure,
+		sealed.secret,
+		job.Queue,
+		job.BodyEncoding,
+		job.ContentType,
+		sealed.proxy,
+		job.ClientCertID,
+		job.CABundle,
+		job.TLSSkipVerify,
+		job.JobType,
+		job.Topic,
+		job.MessageKey,
+		job.EmailTo,
+		job.EmailSubject,
+		job.RetriedFrom,
+		job.CreatedBy,
 	)
 
-	created, err := scanJob(row)
+	created, err := r.scan(ctx, row)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return nil, domain.ErrDuplicateJob
 		}
+		if isUnknownClientCert(err) {
+			return nil, domain.ErrClientCertNotFound
+		}
 		return nil, err
 	}
+	if tx != nil {
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("commit tx: %w", err)
+		}
+	}
+	return created, nil
+}
+
+// lockParent share-locks the parent of a dependent job and sets the job's initial status
+// from the parent's. The lock makes a concurrent parent transition wait for this insert to
+// commit, so the release trigger it fires always sees the new child. A job awaiting
+// approval keeps that status; Review works its status out again when it is approved.
+func lockParent(ctx context.Context, tx pgx.Tx, job *domain.Job) error {
+	var parentStatus domain.Status
+	err := tx.QueryRow(ctx,
+		`SELECT status FROM jobs WHERE id = $1 AND user_id = $2 FOR SHARE`,
+		*job.DependsOn, job.UserID).Scan(&parentStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrInvalidDependency
+	}
+	if err != nil {
+		return fmt.Errorf("lock parent job: %w", err)
+	}
+	status, err := domain.StatusAfterParent(parentStatus, job.OnParentFailure)
+	if err != nil {
+		return err
+	}
+	if job.Status != domain.StatusAwaitingApproval {
+		job.Status = status
+	}
+	return nil
+}
+
+// CreateBatch inserts jobs in a single transaction. The result is index-aligned with jobs;
+// an entry is nil when its idempotency key already exists, either from an earlier request
+// or from an earlier item in the same batch.
+func (r *JobRepository) CreateBatch(ctx context.Context, jobs []*domain.Job) ([]*domain.Job, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		INSERT INTO jobs (
+			user_id, idempotency_key, url, method, headers, body,
+			timeout_seconds, status, scheduled_at, max_retries, backoff, schedule_id,
+			request_id, priority, deadline, retry_delays_ms, callback_url, success_codes, templated,
+			retry_base_seconds, retry_max_seconds, retry_jitter, debug, expires_at, trace_parent,
+			depends_on, on_parent_failure, signing_secret, queue, body_encoding, content_type,
+			proxy_url, client_cert_id, ca_bundle, tls_skip_verify, job_type, topic, message_key,
+			email_to, email_subject, retried_from, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41, $42)
+		ON CONFLICT (user_id, idempotency_key) DO NOTHING
+		RETURNING ` + jobColumns
+
+	created := make([]*domain.Job, len(jobs))
+	for i, job := range jobs {
+		if job.DependsOn != nil {
+			if err := lockParent(ctx, tx, job); err != nil {
+				return nil, err
+			}
+		}
+		sealed, err := seal(ctx, r.box, job.UserID, job.Headers, job.Body, job.SigningSecret, job.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		row := tx.QueryRow(ctx, query,
+			job.UserID,
+			job.IdempotencyKey,
+			job.URL,
+			job.Method,
+			sealed.headers,
+			sealed.body,
+			job.TimeoutSeconds,
+			job.Status,
+			job.ScheduledAt,
+			job.MaxRetries,
+			job.Backoff,
+			job.ScheduleID,
+			job.RequestID,
+			job.Priority,
+			job.Deadline,
+			durationsToMillis(job.RetryDelays),
+			job.CallbackURL,
+			[]string(job.SuccessCodes),
+			job.Templated,
+			job.RetryBaseSeconds,
+			job.RetryMaxSeconds,
+			job.RetryJitter,
+			job.Debug,
+			job.ExpiresAt,
+			job.TraceParent,
+			job.DependsOn,
+			job.OnParentFailure,
+			sealed.secret,
+			job.Queue,
+			job.BodyEncoding,
+			job.ContentType,
+			sealed.proxy,
+			job.ClientCertID,
+			job.CABundle,
+			job.TLSSkipVerify,
+			job.JobType,
+			job.Topic,
+			job.MessageKey,
+			job.EmailTo,
+			job.EmailSubject,
+			job.RetriedFrom,
+			job.CreatedBy,
+		)
+		j, err := r.scan(ctx, row)
+		if err != nil {
+			// DO NOTHING returns no row on conflict.
+			if errors.Is(err, domain.ErrJobNotFound) {
+				continue
+			}
+			if isUnknownClientCert(err) {
+				return nil, domain.ErrClientCertNotFound
+			}
+			return nil, err
+		}
+		created[i] = j
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
 	return created, nil
 }
 
 func (r *JobRepository) GetByID(ctx context.Context, id, userID string) (*domain.Job, error) {
 	query := `
-		SELECT id, user_id, idempotency_key, url, method, headers, body,
-		       timeout_seconds, status, scheduled_at, retry_count,
-		       max_retries, backoff, claimed_at, claimed_by,
-		       heartbeat_at, completed_at, last_error, created_at, updated_at, schedule_id
+		SELECT ` + jobColumns + `
 		FROM jobs
 		WHERE id = $1 AND user_id = $2`
 
 	row := r.pool.QueryRow(ctx, query, id, userID)
-	return scanJob(row)
+	return r.scan(ctx, row)
 }
 
-func (r *JobRepository) Claim(ctx context.Context, workerID string, limit int) ([]*domain.Job, error) {
+// claimOrder maps each claim policy to its ORDER BY; each has a matching partial index.
+var claimOrder = map[domain.ClaimPolicy]string{
+	domain.ClaimPolicyFIFO:    "priority DESC, scheduled_at ASC",
+	domain.ClaimPolicyOverdue: "priority DESC, COALESCE(first_due_at, scheduled_at) ASC",
+}
+
+// targetHostExpr extracts the lower-cased host from a job's URL, for per-host limits. It is
+// NULL for jobs without a URL, which the per-host cap doesn't apply to.
+const targetHostExpr = `lower(substring(url from '^[^:]+://(?:[^@/?#]*@)?([^/?#:]+)'))`
+
+// claimScanFactor bounds how many due jobs a capped claim ranks per slot requested. Jobs
+// past the window wait for a later poll, so one tenant's backlog deeper than the window
+// can delay others; the floor keeps small claims from seeing only a single tenant.
+const (
+	claimScanFactor = 20
+	claimScanFloor  = 500
+)
+
+func (r *JobRepository) Claim(ctx context.Context, workerID string, queues []string, limit int, policy domain.ClaimPolicy, limits domain.ConcurrencyLimits) ([]*domain.Job, error) {
+	order, ok := claimOrder[policy]
+	if !ok {
+		return nil, fmt.Errorf("unknown claim policy %q", policy)
+	}
+
 	// FOR UPDATE SKIP LOCKED prevents double-execution across workers.
 	query := `
 		UPDATE jobs
@@ -84,61 +290,267 @@ func (r *JobRepository) Claim(ctx context.Context, workerID string, limit int) (
 		WHERE id IN (
 			SELECT id FROM jobs
 			WHERE  status       = 'pending'
+			  AND  queue        = ANY($3)
 			  AND  scheduled_at <= NOW()
-			ORDER BY scheduled_at ASC
+			  AND  (expires_at IS NULL OR expires_at > NOW())
+			ORDER BY ` + order + `
 			LIMIT $2
 			FOR UPDATE SKIP LOCKED
 		)
-		RETURNING id, user_id, idempotency_key, url, method, headers, body,
-		          timeout_seconds, status, scheduled_at, retry_count,
-		          max_retries, backoff, claimed_at, claimed_by,
-		          heartbeat_at, completed_at, last_error, created_at, updated_at, schedule_id`
+		RETURNING ` + jobColumns
+	args := []any{workerID, limit, queues}
 
-	rows, err := r.pool.Query(ctx, query, workerID, limit)
+	if limits.Enabled() {
+		// Rank the first due jobs within their user and host, offset by what is already
+		// running, and claim only those that fit under the caps. Slots are counted from a
+		// snapshot, so concurrent claims by other workers can overshoot a cap briefly.
+		query = `
+			WITH due AS (
+				SELECT id, user_id, ` + targetHostExpr + ` AS host,
+				       ROW_NUMBER() OVER (ORDER BY ` + order + `) AS pos
+				FROM   jobs
+				WHERE  status       = 'pending'
+				  AND  queue        = ANY($3)
+				  AND  scheduled_at <= NOW()
+				  AND  (expires_at IS NULL OR expires_at > NOW())
+				ORDER BY ` + order + `
+				LIMIT $6
+			),
+			running_users AS (
+				SELECT user_id, COUNT(*) AS n FROM jobs WHERE status = 'running' GROUP BY user_id
+			),
+			running_hosts AS (
+				SELECT ` + targetHostExpr + ` AS host, COUNT(*) AS n FROM jobs WHERE status = 'running' GROUP BY 1
+			),
+			ranked AS (
+				SELECT d.id, d.pos, d.host,
+				       COALESCE(ru.n, 0) + ROW_NUMBER() OVER (PARTITION BY d.user_id ORDER BY d.pos) AS user_slot,
+				       COALESCE(rh.n, 0) + ROW_NUMBER() OVER (PARTITION BY d.host ORDER BY d.pos)    AS host_slot,
+				       COALESCE(u.max_concurrent_jobs, $4) AS user_cap
+				FROM   due d
+				LEFT JOIN running_users ru ON ru.user_id = d.user_id
+				LEFT JOIN running_hosts rh ON rh.host = d.host
+				LEFT JOIN users u          ON u.id = d.user_id
+			)
+			UPDATE jobs
+			SET    status       = 'running',
+			       claimed_at   = NOW(),
+			       claimed_by   = $1,
+			       heartbeat_at = NOW(),
+			       updated_at   = NOW()
+			WHERE id IN (
+				SELECT j.id
+				FROM   jobs j
+				JOIN   ranked r ON r.id = j.id
+				WHERE  j.status = 'pending'
+				  AND  (r.user_cap = 0 OR r.user_slot <= r.user_cap)
+				  AND  ($5 = 0 OR r.host IS NULL OR r.host_slot <= $5)
+				ORDER BY r.pos
+				LIMIT $2
+				FOR UPDATE OF j SKIP LOCKED
+			)
+			RETURNING ` + jobColumns
+		args = append(args, limits.PerUser, limits.PerHost, max(limit*claimScanFactor, claimScanFloor))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("claim jobs: %w", err)
 	}
+	return r.scanClaimed(ctx, workerID, rows)
+}
+
+// unreadableRetryDelay is how long a claimed job whose sealed columns can't be opened
+// (e.g. ENCRYPTION_KEYS lacks the key that sealed it) waits before it is claimed again.
+const unreadableRetryDelay = 5 * time.Minute
+
+// scanClaimed reads the rows of a claim. The whole batch is already marked running, so a
+// job whose sealed columns can't be opened must not fail the claim and strand the rest:
+// it is handed back, deferred by unreadableRetryDelay with the error as its last_error.
+// If that fails too it stays running until the reaper rescues it.
+func (r *JobRepository) scanClaimed(ctx context.Context, workerID string, rows pgx.Rows) ([]*domain.Job, error) {
 	defer rows.Close()
 
-	var jobs []*domain.Job
+	var (
+		jobs       []*domain.Job
+		unreadable = make(map[string]error)
+	)
 	for rows.Next() {
 		j, err := scanJob(rows)
 		if err != nil {
 			return nil, err
 		}
+		if err := openJob(ctx, r.box, j); err != nil {
+			unreadable[j.ID] = err
+			continue
+		}
 		jobs = append(jobs, j)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for id, openErr := range unreadable {
+		_, _ = r.pool.Exec(ctx,
+			`UPDATE jobs
+			SET    status       = CASE WHEN cancel_requested_at IS NULL THEN 'pending' ELSE 'cancelled' END,
+			       scheduled_at = NOW() + make_interval(secs => $3),
+			       claimed_at   = NULL,
+			       claimed_by   = NULL,
+			       heartbeat_at = NULL,
+			       last_error   = $4,
+			       updated_at   = NOW()
+			WHERE  id = $1 AND status = 'running' AND claimed_by = $2`,
+			id, workerID, unreadableRetryDelay.Seconds(), "deferred: "+openErr.Error())
+	}
 	return jobs, nil
 }
 
-func (r *JobRepository) UpdateHeartbeat(ctx context.Context, jobID string) error {
+// EnqueueDue marks up to limit due pending jobs as pushed to the claim queue and returns
+// their IDs by job queue, each in claim order. A job is due for a push when it was never pushed, its last
+// push is older than redeliverAfter, or it changed since (e.g. was retried or resumed):
+// enqueued_at is set without touching updated_at, so any later transition re-arms it.
+func (r *JobRepository) EnqueueDue(ctx context.Context, limit int, policy domain.ClaimPolicy, redeliverAfter time.Duration) (map[string][]string, error) {
+	order, ok := claimOrder[policy]
+	if !ok {
+		return nil, fmt.Errorf("unknown claim policy %q", policy)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH due AS (
+			SELECT id, queue, priority, scheduled_at, first_due_at
+			FROM   jobs
+			WHERE  status       = 'pending'
+			  AND  scheduled_at <= NOW()
+			  AND  (expires_at IS NULL OR expires_at > NOW())
+			  AND  (enqueued_at IS NULL
+			        OR enqueued_at < updated_at
+			        OR enqueued_at < NOW() - make_interval(secs => $2))
+			ORDER BY `+order+`
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		),
+		marked AS (
+			UPDATE jobs j SET enqueued_at = NOW()
+			FROM   due
+			WHERE  j.id = due.id
+		)
+		SELECT id, queue FROM due ORDER BY `+order, limit, redeliverAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("enqueue due jobs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string][]string)
+	for rows.Next() {
+		var id, queue string
+		if err := rows.Scan(&id, &queue); err != nil {
+			return nil, err
+		}
+		ids[queue] = append(ids[queue], id)
+	}
+	return ids, rows.Err()
+}
+
+// ClaimByIDs claims those of ids that are still pending and due. IDs of jobs that were
+// claimed, cancelled or paused since they were queued are silently skipped.
+func (r *JobRepository) ClaimByIDs(ctx context.Context, workerID string, ids []string) ([]*domain.Job, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE jobs
+		SET    status       = 'running',
+		       claimed_at   = NOW(),
+		       claimed_by   = $1,
+		       heartbeat_at = NOW(),
+		       updated_at   = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE  id = ANY($2)
+			  AND  status       = 'pending'
+			  AND  scheduled_at <= NOW()
+			  AND  (expires_at IS NULL OR expires_at > NOW())
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns, workerID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("claim jobs by id: %w", err)
+	}
+	return r.scanClaimed(ctx, workerID, rows)
+}
+
+// Release honours a cancel requested while the job was held, like Reschedule.
+func (r *JobRepository) Release(ctx context.Context, workerID string, ids []string) error {
 	_, err := r.pool.Exec(ctx,
+		`UPDATE jobs
+		SET    status       = CASE WHEN cancel_requested_at IS NULL THEN 'pending' ELSE 'cancelled' END,
+		       claimed_at   = NULL,
+		       claimed_by   = NULL,
+		       heartbeat_at = NULL,
+		       updated_at   = NOW()
+		WHERE  id = ANY($1) AND status = 'running' AND claimed_by = $2`, ids, workerID)
+	if err != nil {
+		return fmt.Errorf("release jobs: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) UpdateHeartbeat(ctx context.Context, jobID string) (bool, error) {
+	var cancelRequested bool
+	err := r.pool.QueryRow(ctx,
 		`UPDATE jobs SET heartbeat_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'running'
+		RETURNING cancel_requested_at IS NOT NULL`, jobID).Scan(&cancelRequested)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil // no longer running, e.g. rescued by the reaper
+	}
+	return cancelRequested, err
+}
+
+// MarkCancelled finishes a running job whose execution was aborted by a cancel request.
+func (r *JobRepository) MarkCancelled(ctx context.Context, jobID string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE jobs SET status = 'cancelled', updated_at = NOW()
 		WHERE id = $1 AND status = 'running'`, jobID)
 	return err
 }
 
+// Complete and Fail enqueue the job's callback in the same statement, so a finished
+// job with a callback_url can never miss its notification.
 func (r *JobRepository) Complete(ctx context.Context, jobID string) error {
 	_, err := r.pool.Exec(ctx,
-		`UPDATE jobs SET status = 'completed', completed_at = NOW(), updated_at = NOW()
-		WHERE id = $1`, jobID)
+		`WITH done AS (
+			UPDATE jobs SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+			WHERE id = $1
+			RETURNING id, callback_url
+		)
+		INSERT INTO job_callbacks (job_id, event, url)
+		SELECT id, 'job.completed', callback_url FROM done WHERE callback_url IS NOT NULL
+		ON CONFLICT (job_id, event) DO NOTHING`, jobID)
 	return err
 }
 
 func (r *JobRepository) Fail(ctx context.Context, jobID string, lastError string) error {
 	_, err := r.pool.Exec(ctx,
-		`UPDATE jobs SET status = 'failed', last_error = $2, updated_at = NOW()
-		WHERE id = $1`, jobID, lastError)
+		`WITH done AS (
+			UPDATE jobs SET status = 'failed', last_error = $2, updated_at = NOW()
+			WHERE id = $1
+			RETURNING id, callback_url
+		)
+		INSERT INTO job_callbacks (job_id, event, url)
+		SELECT id, 'job.failed', callback_url FROM done WHERE callback_url IS NOT NULL
+		ON CONFLICT (job_id, event) DO NOTHING`, jobID, lastError)
 	return err
 }
 
 func (r *JobRepository) Reschedule(ctx context.Context, jobID string, lastError string, retryAt time.Time) error {
 	// make sure that retry_count is not over-incremented due to multiple workers trying to re-schedule same jobs
+	// A cancel requested after the worker's last heartbeat is honoured here instead of retrying.
 	_, err := r.pool.Exec(ctx,
 		`UPDATE jobs
-		SET    status       = 'pending',
+		SET    status       = CASE WHEN cancel_requested_at IS NULL THEN 'pending' ELSE 'cancelled' END,
 		       retry_count  = retry_count + 1,
 		       last_error   = $2,
+		       first_due_at = COALESCE(first_due_at, scheduled_at),
 		       scheduled_at = $3,
 		       claimed_at   = NULL,
 		       claimed_by   = NULL,
@@ -148,9 +560,36 @@ func (r *JobRepository) Reschedule(ctx context.Context, jobID string, lastError
 	return err
 }
 
-func (r *JobRepository) RescheduleStale(ctx context.Context, staleCutoff time.Time, limit int) (int, error) {
-	tag, err := r.pool.Exec(ctx, `
-		UPDATE jobs
+func (r *JobRepository) Defer(ctx context.Context, jobID string, reason string, retryAt time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE jobs
+		SET    status       = CASE WHEN cancel_requested_at IS NULL THEN 'pending' ELSE 'cancelled' END,
+		       last_error   = $2,
+		       first_due_at = COALESCE(first_due_at, scheduled_at),
+		       scheduled_at = $3,
+		       claimed_at   = NULL,
+		       claimed_by   = NULL,
+		       heartbeat_at = NULL,
+		       updated_at   = NOW()
+		WHERE id = $1 AND status = 'running'`, jobID, reason, retryAt)
+	return err
+}
+
+func (r *JobRepository) RescheduleStale(ctx context.Context, staleCutoff time.Time, limit int) ([]domain.ReapedJob, error) {
+	// The CTE captures the lease before it's cleared so the reaper can attribute the rescue.
+	rows, err := r.pool.Query(ctx, `
+		WITH stale AS (
+			SELECT id, claimed_by, claimed_at, heartbeat_at FROM jobs
+			WHERE  status       = 'running'
+			  AND  heartbeat_at < $1
+			  AND  retry_count  < max_retries
+			  AND  (deadline IS NULL OR deadline > NOW())
+			  AND  cancel_requested_at IS NULL
+			ORDER BY heartbeat_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE jobs j
 		SET    status       = 'pending',
 		       retry_count  = retry_count + 1,
 		       last_error   = 'worker timeout',
@@ -158,77 +597,278 @@ func (r *JobRepository) RescheduleStale(ctx context.Context, staleCutoff time.Ti
 		       claimed_by   = NULL,
 		       heartbeat_at = NULL,
 		       updated_at   = NOW()
-		WHERE id IN (
-			SELECT id FROM jobs
+		FROM stale
+		WHERE j.id = stale.id
+		RETURNING stale.id, j.user_id, j.schedule_id, j.url, j.last_error,
+		          stale.claimed_by, stale.claimed_at, stale.heartbeat_at`, staleCutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("reschedule stale jobs: %w", err)
+	}
+	return collectReapedJobs(rows)
+}
+
+func (r *JobRepository) FailStale(ctx context.Context, staleCutoff time.Time, limit int) ([]domain.ReapedJob, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH stale AS (
+			SELECT id, claimed_by, claimed_at, heartbeat_at FROM jobs
 			WHERE  status       = 'running'
 			  AND  heartbeat_at < $1
-			  AND  retry_count  < max_retries
+			  AND  (retry_count >= max_retries OR deadline <= NOW())
+			  AND  cancel_requested_at IS NULL
 			ORDER BY heartbeat_at ASC
 			LIMIT $2
 			FOR UPDATE SKIP LOCKED
-		)`, staleCutoff, limit)
-	return int(tag.RowsAffected()), err
+		),
+		failed AS (
+			UPDATE jobs j
+			SET    status      = 'failed',
+			       last_error  = CASE WHEN j.deadline <= NOW()
+			                          THEN 'worker timeout: deadline exceeded'
+			                          ELSE 'worker timeout: max retries exceeded' END,
+			       updated_at  = NOW()
+			FROM stale
+			WHERE j.id = stale.id
+			RETURNING j.callback_url, stale.id, j.user_id, j.schedule_id, j.url, j.last_error,
+			          stale.claimed_by, stale.claimed_at, stale.heartbeat_at
+		),
+		callbacks AS (
+			INSERT INTO job_callbacks (job_id, event, url)
+			SELECT id, 'job.failed', callback_url FROM failed WHERE callback_url IS NOT NULL
+			ON CONFLICT (job_id, event) DO NOTHING
+		)
+		SELECT id, user_id, schedule_id, url, last_error, claimed_by, claimed_at, heartbeat_at
+		FROM failed`, staleCutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fail stale jobs: %w", err)
+	}
+	return collectReapedJobs(rows)
 }
 
-func (r *JobRepository) FailStale(ctx context.Context, staleCutoff time.Time, limit int) (int, error) {
-	tag, err := r.pool.Exec(ctx, `
-		UPDATE jobs
-		SET    status      = 'failed',
-		       last_error  = 'worker timeout: max retries exceeded',
-		       updated_at  = NOW()
-		WHERE id IN (
-			SELECT id FROM jobs
+// CancelStale finishes stale jobs whose cancellation was requested: the worker that would
+// have honoured the request is gone, so the job is cancelled rather than retried or failed.
+func (r *JobRepository) CancelStale(ctx context.Context, staleCutoff time.Time, limit int) ([]domain.ReapedJob, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH stale AS (
+			SELECT id, claimed_by, claimed_at, heartbeat_at FROM jobs
 			WHERE  status       = 'running'
 			  AND  heartbeat_at < $1
-			  AND  retry_count  >= max_retries
+			  AND  cancel_requested_at IS NOT NULL
 			ORDER BY heartbeat_at ASC
 			LIMIT $2
 			FOR UPDATE SKIP LOCKED
-		)`, staleCutoff, limit)
-	return int(tag.RowsAffected()), err
+		)
+		UPDATE jobs j
+		SET    status     = 'cancelled',
+		       updated_at = NOW()
+		FROM stale
+		WHERE j.id = stale.id
+		RETURNING stale.id, j.user_id, j.schedule_id, j.url, j.last_error,
+		          stale.claimed_by, stale.claimed_at, stale.heartbeat_at`, staleCutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("cancel stale jobs: %w", err)
+	}
+	return collectReapedJobs(rows)
+}
+
+// ExpirePending moves up to limit pending jobs whose expires_at has passed to expired.
+// Claim already skips them; this only settles their status.
+func (r *JobRepository) ExpirePending(ctx context.Context, limit int) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE jobs
+		SET    status     = 'expired',
+		       updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE  status     = 'pending'
+			  AND  expires_at <= NOW()
+			ORDER BY expires_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("expire pending jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *JobRepository) QueueStats(ctx context.Context, queues []string) (domain.QueueStats, error) {
+	var s domain.QueueStats
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE scheduled_at <= NOW()),
+		       MIN(scheduled_at) FILTER (WHERE scheduled_at <= NOW())
+		FROM   jobs
+		WHERE  status = 'pending'
+		  AND  ($1::text[] IS NULL OR queue = ANY($1))`, queues).Scan(&s.Pending, &s.Overdue, &s.OldestDueAt)
+	if err != nil {
+		return domain.QueueStats{}, fmt.Errorf("queue stats: %w", err)
+	}
+	return s, nil
+}
+
+func (r *JobRepository) StuckPending(ctx context.Context, cutoff time.Time, queues []string) ([]domain.StuckQueue, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT   queue, COUNT(*), MIN(scheduled_at)
+		FROM     jobs
+		WHERE    status = 'pending'
+		  AND    scheduled_at < $1
+		  AND    queue = ANY($2)
+		GROUP BY queue`, cutoff, queues)
+	if err != nil {
+		return nil, fmt.Errorf("stuck pending jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var stuck []domain.StuckQueue
+	for rows.Next() {
+		var q domain.StuckQueue
+		if err := rows.Scan(&q.Queue, &q.Count, &q.OldestDueAt); err != nil {
+			return nil, fmt.Errorf("scan stuck queue: %w", err)
+		}
+		stuck = append(stuck, q)
+	}
+	return stuck, rows.Err()
 }
 
-func (r *JobRepository) Cancel(ctx context.Context, jobID, userID string) error {
+func collectReapedJobs(rows pgx.Rows) ([]domain.ReapedJob, error) {
+	defer rows.Close()
+
+	var reaped []domain.ReapedJob
+	for rows.Next() {
+		var j domain.ReapedJob
+		if err := rows.Scan(&j.ID, &j.UserID, &j.ScheduleID, &j.URL, &j.LastError, &j.ClaimedBy, &j.ClaimedAt, &j.HeartbeatAt); err != nil {
+			return nil, fmt.Errorf("scan reaped job: %w", err)
+		}
+		reaped = append(reaped, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reaped jobs: %w", err)
+	}
+	return reaped, nil
+}
+
+// Cancel cancels a pending or paused job outright. A running job can't be stopped from
+// here, so only the request is recorded and requested is true; its worker finishes it.
+func (r *JobRepository) Cancel(ctx context.Context, jobID, userID string) (bool, error) {
+	var status domain.Status
+	err := r.pool.QueryRow(ctx,
+		`UPDATE jobs
+		SET    status              = CASE WHEN status = 'running' THEN status ELSE 'cancelled' END,
+		       cancel_requested_at = CASE WHEN status = 'running' THEN COALESCE(cancel_requested_at, NOW()) END,
+		       updated_at          = NOW()
+		WHERE id = $1 AND user_id = $2 AND status IN ('pending', 'paused', 'blocked', 'awaiting_approval', 'running')
+		RETURNING status`,
+		jobID, userID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := r.GetByID(ctx, jobID, userID); err != nil {
+			return false, err // ErrJobNotFound
+		}
+		return false, domain.ErrJobNotCancellable
+	}
+	if err != nil {
+		return false, fmt.Errorf("cancel job: %w", err)
+	}
+	return status == domain.StatusRunning, nil
+}
+
+func (r *JobRepository) Review(ctx context.Context, jobID, userID, reviewerID string, approve bool) (*domain.Job, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	job, err := r.scan(ctx, tx.QueryRow(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE`, jobID, userID))
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != domain.StatusAwaitingApproval {
+		return nil, domain.ErrJobNotAwaitingApproval
+	}
+
+	status, lastError := domain.StatusCancelled, domain.RejectedError
+	if approve {
+		// Released as if just created: blocked behind an unfinished parent, and cancelled
+		// under the skip policy if the parent failed while the job waited.
+		status, lastError = domain.StatusPending, ""
+		if job.DependsOn != nil {
+			job.Status = domain.StatusPending
+			err := lockParent(ctx, tx, job)
+			switch {
+			case errors.Is(err, domain.ErrParentJobFailed):
+				status, lastError = domain.StatusCancelled, err.Error()
+			case err != nil:
+				return nil, err
+			default:
+				status = job.Status
+			}
+		}
+	}
+
+	reviewed, err := r.scan(ctx, tx.QueryRow(ctx, `
+		UPDATE jobs
+		SET    status      = $2,
+		       last_error  = NULLIF($3, ''),
+		       reviewed_by = $4,
+		       reviewed_at = NOW(),
+		       updated_at  = NOW()
+		WHERE id = $1
+		RETURNING `+jobColumns,
+		jobID, status, lastError, reviewerID))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return reviewed, nil
+}
+
+func (r *JobRepository) SetPaused(ctx context.Context, jobID, userID string, paused bool) error {
+	from, to := domain.StatusPaused, domain.StatusPending
+	if paused {
+		from, to = domain.StatusPending, domain.StatusPaused
+	}
 	tag, err := r.pool.Exec(ctx,
-		`UPDATE jobs SET status = 'cancelled', updated_at = NOW()
-		WHERE id = $1 AND user_id = $2 AND status = 'pending'`,
-		jobID, userID)
+		`UPDATE jobs SET status = $4, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND status = $3`,
+		jobID, userID, from, to)
 	if err != nil {
-		return fmt.Errorf("cancel job: %w", err)
+		return fmt.Errorf("set job paused: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
 		if _, err := r.GetByID(ctx, jobID, userID); err != nil {
 			return err // ErrJobNotFound
 		}
-		return domain.ErrJobNotCancellable
+		if paused {
+			return domain.ErrJobNotPausable
+		}
+		return domain.ErrJobNotPaused
 	}
 	return nil
 }
 
 func (r *JobRepository) ListJobs(ctx context.Context, input repository.ListJobsInput) ([]*domain.Job, error) {
-	args := []any{input.UserID}
-	where := []string{"user_id = $1"}
+	where, args := jobFilters(input)
+	cmp, dir := keysetOrder(input.Order)
 
-	if input.Status != "" {
-		args = append(args, input.Status)
-		where = append(where, fmt.Sprintf("status = $%d", len(args)))
-	}
 	if input.CursorTime != nil {
 		args = append(args, *input.CursorTime, input.CursorID)
-		where = append(where, fmt.Sprintf("(scheduled_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+		where = append(where, fmt.Sprintf("(scheduled_at, id) %s ($%d, $%d)", cmp, len(args)-1, len(args)))
 	}
 	args = append(args, input.Limit)
 
 	query := fmt.Sprintf(`
-		SELECT id, user_id, idempotency_key, url, method, headers, body,
-		       timeout_seconds, status, scheduled_at, retry_count,
-		       max_retries, backoff, claimed_at, claimed_by,
-		       heartbeat_at, completed_at, last_error, created_at, updated_at, schedule_id
+		SELECT `+jobColumns+`
 		FROM jobs
 		WHERE %s
-		ORDER BY scheduled_at DESC, id DESC
+		ORDER BY scheduled_at %s, id %s
 		LIMIT $%d`,
-		strings.Join(where, " AND "), len(args))
+		strings.Join(where, " AND "), dir, dir, len(args))
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -238,7 +878,7 @@ func (r *JobRepository) ListJobs(ctx context.Context, input repository.ListJobsI
 
 	var jobs []*domain.Job
 	for rows.Next() {
-		j, err := scanJob(rows)
+		j, err := r.scan(ctx, rows)
 		if err != nil {
 			return nil, err
 		}
@@ -247,20 +887,116 @@ func (r *JobRepository) ListJobs(ctx context.Context, input repository.ListJobsI
 	return jobs, nil
 }
 
+func (r *JobRepository) CountJobs(ctx context.Context, input repository.ListJobsInput) (int64, error) {
+	where, args := jobFilters(input)
+	var n int64
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM jobs WHERE `+strings.Join(where, " AND "), args...).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count jobs: %w", err)
+	}
+	return n, nil
+}
+
+// jobFilters builds the WHERE conditions and arguments for input's filters, without its
+// cursor.
+func jobFilters(input repository.ListJobsInput) ([]string, []any) {
+	args := []any{input.UserID}
+	where := []string{"user_id = $1"}
+
+	if input.Status != "" {
+		args = append(args, input.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if input.RequestID != "" {
+		args = append(args, input.RequestID)
+		where = append(where, fmt.Sprintf("request_id = $%d", len(args)))
+	}
+	if input.URLContains != "" {
+		args = append(args, "%"+escapeLike(input.URLContains)+"%")
+		where = append(where, fmt.Sprintf("url ILIKE $%d", len(args)))
+	}
+	if input.IdempotencyKey != "" {
+		args = append(args, input.IdempotencyKey)
+		where = append(where, fmt.Sprintf("idempotency_key = $%d", len(args)))
+	}
+	if input.ScheduleID != "" {
+		args = append(args, input.ScheduleID)
+		where = append(where, fmt.Sprintf("schedule_id = $%d", len(args)))
+	}
+	if input.ScheduledAfter != nil {
+		args = append(args, *input.ScheduledAfter)
+		where = append(where, fmt.Sprintf("scheduled_at >= $%d", len(args)))
+	}
+	if input.ScheduledBefore != nil {
+		args = append(args, *input.ScheduledBefore)
+		where = append(where, fmt.Sprintf("scheduled_at < $%d", len(args)))
+	}
+	return where, args
+}
+
+// keysetOrder returns the comparison that continues a keyset-paginated list after the
+// cursor row, and the direction the list is sorted in.
+func keysetOrder(order domain.SortOrder) (cmp, dir string) {
+	if order == domain.SortAsc {
+		return ">", "ASC"
+	}
+	return "<", "DESC"
+}
+
 // pgx.Row and pgx.Rows both implement this.
 type rowScanner interface {
 	Scan(dest ...any) error
 }
 
+// queryRower is satisfied by both the pool and a transaction.
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// jobColumns is the column list every job query selects/returns — keep in sync with scanJob.
+const jobColumns = `id, user_id, idempotency_key, url, method, headers, body,
+		timeout_seconds, status, scheduled_at, retry_count,
+		max_retries, backoff, claimed_at, claimed_by,
+		heartbeat_at, completed_at, last_error, created_at, updated_at, schedule_id,
+		request_id, ping, priority, deadline, retry_delays_ms, callback_url, success_codes,
+		cancel_requested_at, first_due_at, templated, retry_base_seconds, retry_max_seconds,
+		retry_jitter, debug, expires_at, trace_parent, depends_on, on_parent_failure,
+		signing_secret, queue, body_encoding, content_type, proxy_url, client_cert_id,
+		ca_bundle, tls_skip_verify, job_type, topic, message_key, email_to, email_subject,
+		retried_from, created_by, reviewed_by, reviewed_at`
+
+// scan reads a job row and opens its sealed columns.
+func (r *JobRepository) scan(ctx context.Context, row rowScanner) (*domain.Job, error) {
+	j, err := scanJob(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := openJob(ctx, r.box, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
 // scanJob is a private helper — avoids repeating Scan calls across multiple queries.
 func scanJob(row rowScanner) (*domain.Job, error) {
-	var j domain.Job
+	var (
+		j             domain.Job
+		retryDelaysMS []int64
+		successCodes  []string
+	)
 	err := row.Scan(
 		&j.ID, &j.UserID, &j.IdempotencyKey, &j.URL, &j.Method, &j.Headers, &j.Body,
 		&j.TimeoutSeconds, &j.Status, &j.ScheduledAt, &j.RetryCount,
 		&j.MaxRetries, &j.Backoff, &j.ClaimedAt, &j.ClaimedBy,
 		&j.HeartbeatAt, &j.CompletedAt, &j.LastError, &j.CreatedAt, &j.UpdatedAt,
-		&j.ScheduleID,
+		&j.ScheduleID, &j.RequestID, &j.Ping, &j.Priority, &j.Deadline, &retryDelaysMS,
+		&j.CallbackURL, &successCodes, &j.CancelRequestedAt, &j.FirstDueAt, &j.Templated,
+		&j.RetryBaseSeconds, &j.RetryMaxSeconds, &j.RetryJitter, &j.Debug, &j.ExpiresAt,
+		&j.TraceParent, &j.DependsOn, &j.OnParentFailure, &j.SigningSecret,
+		&j.Queue, &j.BodyEncoding, &j.ContentType, &j.ProxyURL, &j.ClientCertID,
+		&j.CABundle, &j.TLSSkipVerify, &j.JobType, &j.Topic, &j.MessageKey,
+		&j.EmailTo, &j.EmailSubject, &j.RetriedFrom, &j.CreatedBy, &j.ReviewedBy, &j.ReviewedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -268,29 +1004,88 @@ func scanJob(row rowScanner) (*domain.Job, error) {
 		}
 		return nil, fmt.Errorf("scan job: %w", err)
 	}
+	j.RetryDelays = millisToDurations(retryDelaysMS)
+	j.SuccessCodes = successCodes
 	return &j, nil
 }
 
-func (r *JobRepository) ListByScheduleID(ctx context.Context, scheduleID string, limit int, cursorTime *time.Time, cursorID string) ([]*domain.Job, error) {
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes LIKE wildcards in s so user input matches literally.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+func (r *JobRepository) Search(ctx context.Context, userID, q string, limit int) ([]*domain.Job, error) {
+	escaped := escapeLike(q)
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE user_id = $1
+		  AND (id LIKE $2 OR idempotency_key LIKE $2 OR url ILIKE $3)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4`,
+		userID, escaped+"%", "%"+escaped+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("search jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*domain.Job
+	for rows.Next() {
+		j, err := r.scan(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// durationsToMillis maps an empty list to NULL so "no custom delays" has one representation.
+func durationsToMillis(ds []time.Duration) []int64 {
+	if len(ds) == 0 {
+		return nil
+	}
+	ms := make([]int64, len(ds))
+	for i, d := range ds {
+		ms[i] = d.Milliseconds()
+	}
+	return ms
+}
+
+func millisToDurations(ms []int64) []time.Duration {
+	if len(ms) == 0 {
+		return nil
+	}
+	ds := make([]time.Duration, len(ms))
+	for i, m := range ms {
+		ds[i] = time.Duration(m) * time.Millisecond
+	}
+	return ds
+}
+
+func (r *JobRepository) ListByScheduleID(ctx context.Context, scheduleID string, order domain.SortOrder, limit int, cursorTime *time.Time, cursorID string) ([]*domain.Job, error) {
 	args := []any{scheduleID}
 	where := []string{"schedule_id = $1"}
+	cmp, dir := keysetOrder(order)
 
 	if cursorTime != nil {
 		args = append(args, *cursorTime, cursorID)
-		where = append(where, fmt.Sprintf("(scheduled_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+		where = append(where, fmt.Sprintf("(scheduled_at, id) %s ($%d, $%d)", cmp, len(args)-1, len(args)))
 	}
 	args = append(args, limit)
 
 	query := fmt.Sprintf(`
-		SELECT id, user_id, idempotency_key, url, method, headers, body,
-		       timeout_seconds, status, scheduled_at, retry_count,
-		       max_retries, backoff, claimed_at, claimed_by,
-		       heartbeat_at, completed_at, last_error, created_at, updated_at, schedule_id
+		SELECT `+jobColumns+`
 		FROM jobs
 		WHERE %s
-		ORDER BY scheduled_at DESC, id DESC
+		ORDER BY scheduled_at %s, id %s
 		LIMIT $%d`,
-		strings.Join(where, " AND "), len(args))
+		strings.Join(where, " AND "), dir, dir, len(args))
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -300,7 +1095,7 @@ func (r *JobRepository) ListByScheduleID(ctx context.Context, scheduleID string,
 
 	var jobs []*domain.Job
 	for rows.Next() {
-		j, err := scanJob(rows)
+		j, err := r.scan(ctx, rows)
 		if err != nil {
 			return nil, err
 		}
@@ -308,3 +1103,73 @@ func (r *JobRepository) ListByScheduleID(ctx context.Context, scheduleID string,
 	}
 	return jobs, nil
 }
+
+func (r *JobRepository) CountByScheduleID(ctx context.Context, scheduleID string) (int64, error) {
+	var n int64
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE schedule_id = $1`, scheduleID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count jobs by schedule id: %w", err)
+	}
+	return n, nil
+}
+
+// ConsecutiveFailures orders runs by scheduled_at, like SummarizeBySchedule, so a late
+// retry of an old run doesn't break or extend the streak.
+func (r *JobRepository) ConsecutiveFailures(ctx context.Context, scheduleID string) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM   jobs
+		WHERE  schedule_id = $1
+		  AND  status      = 'failed'
+		  AND  scheduled_at > COALESCE(
+		           (SELECT MAX(scheduled_at) FROM jobs WHERE schedule_id = $1 AND status = 'completed'),
+		           '-infinity')`, scheduleID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count consecutive failures: %w", err)
+	}
+	return n, nil
+}
+
+func (r *JobRepository) SummarizeBySchedule(ctx context.Context, userID string) (map[string]*domain.ScheduleRunSummary, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH counts AS (
+			SELECT schedule_id,
+			       COUNT(*)                                    AS total,
+			       COUNT(*) FILTER (WHERE status = 'completed') AS completed,
+			       COUNT(*) FILTER (WHERE status = 'failed')    AS failed
+			FROM   jobs
+			WHERE  user_id = $1 AND schedule_id IS NOT NULL
+			GROUP BY schedule_id
+		), last AS (
+			SELECT DISTINCT ON (schedule_id)
+			       schedule_id, status, scheduled_at, COALESCE(completed_at, updated_at) AS finished_at, last_error
+			FROM   jobs
+			WHERE  user_id = $1 AND schedule_id IS NOT NULL AND status IN ('completed', 'failed')
+			ORDER BY schedule_id, scheduled_at DESC
+		)
+		SELECT c.schedule_id, c.total, c.completed, c.failed,
+		       l.status, l.scheduled_at, l.finished_at, l.last_error
+		FROM   counts c
+		LEFT JOIN last l USING (schedule_id)`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("summarize schedule runs: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make(map[string]*domain.ScheduleRunSummary)
+	for rows.Next() {
+		var (
+			scheduleID string
+			s          domain.ScheduleRunSummary
+		)
+		if err := rows.Scan(&scheduleID, &s.TotalRuns, &s.CompletedRuns, &s.FailedRuns,
+			&s.LastStatus, &s.LastRunAt, &s.LastFinishedAt, &s.LastError); err != nil {
+			return nil, fmt.Errorf("scan schedule run summary: %w", err)
+		}
+		summaries[scheduleID] = &s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate schedule run summaries: %w", err)
+	}
+	return summaries, nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// errLeadershipLost is returned by Lead when the session holding the lock fails.
+var errLeadershipLost = errors.New("leader lock session lost")
+
+// AdvisoryLeaderLock elects a leader with a session-level Postgres advisory lock. The
+// lock lives as long as the session that took it, so a crashed leader's lock is freed
+// as soon as Postgres notices the connection is gone.
+type AdvisoryLeaderLock struct {
+	pool     *pgxpool.Pool
+	key      int64
+	interval time.Duration // between lock attempts, and between liveness checks while held
+}
+
+func NewAdvisoryLeaderLock(pool *pgxpool.Pool, key int64, interval time.Duration) *AdvisoryLeaderLock {
+	return &AdvisoryLeaderLock{pool: pool, key: key, interval: interval}
+}
+
+func (l *AdvisoryLeaderLock) Lead(ctx context.Context, fn func(ctx context.Context)) error {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire leader lock conn: %w", err)
+	}
+	// The lock belongs to the session, so the connection must not go back to the pool
+	// while held; Hijack takes it out and Close ends the session, releasing the lock.
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.Background())
+
+	if err := l.waitForLock(ctx, pgConn); err != nil {
+		return err
+	}
+
+	leadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(leadCtx)
+	}()
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			unlockCtx, cancelUnlock := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelUnlock()
+			if _, err := pgConn.Exec(unlockCtx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+				return fmt.Errorf("release leader lock: %w", err)
+			}
+			return nil
+		case <-ticker.C:
+			if err := pgConn.Ping(leadCtx); err != nil && leadCtx.Err() == nil {
+				cancel()
+				<-done
+				return fmt.Errorf("%w: %w", errLeadershipLost, err)
+			}
+		}
+	}
+}
+
+func (l *AdvisoryLeaderLock) waitForLock(ctx context.Context, conn *pgx.Conn) error {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		var locked bool
+		if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&locked); err != nil {
+			return fmt.Errorf("try leader lock: %w", err)
+		}
+		if locked {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
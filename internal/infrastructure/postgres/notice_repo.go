@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NoticeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNoticeRepository(pool *pgxpool.Pool) *NoticeRepository {
+	return &NoticeRepository{pool: pool}
+}
+
+func (r *NoticeRepository) Create(ctx context.Context, n *domain.Notice) (*domain.Notice, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO system_notices (title, body, severity, starts_at, ends_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+noticeColumns,
+		n.Title, n.Body, n.Severity, n.StartsAt, n.EndsAt, n.CreatedBy,
+	)
+	created, err := scanNotice(row)
+	if err != nil {
+		return nil, fmt.Errorf("create notice: %w", err)
+	}
+	return created, nil
+}
+
+func (r *NoticeRepository) ListCurrent(ctx context.Context, now time.Time) ([]*domain.Notice, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+noticeColumns+`
+		FROM system_notices
+		WHERE ends_at IS NULL OR ends_at > $1
+		ORDER BY starts_at ASC, id ASC`, now)
+	if err != nil {
+		return nil, fmt.Errorf("list current notices: %w", err)
+	}
+	return collectNotices(rows)
+}
+
+func (r *NoticeRepository) ListAll(ctx context.Context) ([]*domain.Notice, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+noticeColumns+`
+		FROM system_notices
+		ORDER BY created_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list notices: %w", err)
+	}
+	return collectNotices(rows)
+}
+
+func (r *NoticeRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM system_notices WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete notice: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNoticeNotFound
+	}
+	return nil
+}
+
+// noticeColumns is the column list every notice query selects/returns — keep in sync with scanNotice.
+const noticeColumns = `id, title, body, severity, starts_at, ends_at, created_by, created_at`
+
+func scanNotice(row rowScanner) (*domain.Notice, error) {
+	var n domain.Notice
+	if err := row.Scan(&n.ID, &n.Title, &n.Body, &n.Severity, &n.StartsAt, &n.EndsAt, &n.CreatedBy, &n.CreatedAt); err != nil {
+		return nil, fmt.Errorf("scan notice: %w", err)
+	}
+	return &n, nil
+}
+
+func collectNotices(rows pgx.Rows) ([]*domain.Notice, error) {
+	defer rows.Close()
+
+	var notices []*domain.Notice
+	for rows.Next() {
+		n, err := scanNotice(rows)
+		if err != nil {
+			return nil, err
+		}
+		notices = append(notices, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate notices: %w", err)
+	}
+	return notices, nil
+}
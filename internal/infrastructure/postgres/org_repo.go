@@ -0,0 +1,330 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OrgRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOrgRepository(pool *pgxpool.Pool) *OrgRepository {
+	return &OrgRepository{pool: pool}
+}
+
+func (r *OrgRepository) Create(ctx context.Context, name, ownerID string) (*domain.Org, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// The org_ prefix keeps organization accounts apart from Clerk user IDs (user_...).
+	var org domain.Org
+	err = tx.QueryRow(ctx, `
+		WITH account AS (
+			INSERT INTO users (id) VALUES ('org_' || gen_random_uuid()::text)
+			RETURNING id
+		)
+		INSERT INTO organizations (id, name)
+		SELECT id, $1 FROM account
+		RETURNING id, name, created_at`, name).Scan(&org.ID, &org.Name, &org.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO org_members (org_id, user_id, role) VALUES ($1, $2, 'owner')`,
+		org.ID, ownerID); err != nil {
+		return nil, fmt.Errorf("add owner: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return &org, nil
+}
+
+func (r *OrgRepository) ListByMember(ctx context.Context, userID string) ([]domain.OrgMembership, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT o.id, o.name, o.kms_key_ref, o.require_approval, o.created_at, m.role
+		FROM org_members m
+		JOIN organizations o ON o.id = m.org_id
+		WHERE m.user_id = $1
+		ORDER BY o.created_at ASC, o.id ASC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []domain.OrgMembership
+	for rows.Next() {
+		var (
+			o    domain.Org
+			role domain.OrgRole
+		)
+		if err := rows.Scan(&o.ID, &o.Name, &o.KMSKeyRef, &o.RequireApproval, &o.CreatedAt, &role); err != nil {
+			return nil, fmt.Errorf("scan organization: %w", err)
+		}
+		orgs = append(orgs, domain.OrgMembership{Org: &o, Role: role})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+func (r *OrgRepository) SetKeyRef(ctx context.Context, orgID string, keyRef *string) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE organizations SET kms_key_ref = $2 WHERE id = $1`, orgID, keyRef)
+	if err != nil {
+		return fmt.Errorf("set encryption key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrOrgNotFound
+	}
+	return nil
+}
+
+func (r *OrgRepository) SetRequireApproval(ctx context.Context, orgID string, on bool) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE organizations SET require_approval = $2 WHERE id = $1`, orgID, on)
+	if err != nil {
+		return fmt.Errorf("set approval mode: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrOrgNotFound
+	}
+	return nil
+}
+
+func (r *OrgRepository) ApprovalRequired(ctx context.Context, orgID, userID string) (bool, error) {
+	var required bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT o.require_approval AND m.role <> 'owner'
+		FROM organizations o
+		JOIN org_members m ON m.org_id = o.id AND m.user_id = $2
+		WHERE o.id = $1`, orgID, userID).Scan(&required)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, domain.ErrOrgNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("get approval mode: %w", err)
+	}
+	return required, nil
+}
+
+// KeyRef returns ownerID's KMS key reference, or "" for organizations without one and
+// for individual users. It is the secrets.KeyRefLookup of the repositories' Box.
+func (r *OrgRepository) KeyRef(ctx context.Context, ownerID string) (string, error) {
+	var ref *string
+	err := r.pool.QueryRow(ctx,
+		`SELECT kms_key_ref FROM organizations WHERE id = $1`, ownerID).Scan(&ref)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get encryption key: %w", err)
+	}
+	if ref == nil {
+		return "", nil
+	}
+	return *ref, nil
+}
+
+func (r *OrgRepository) Role(ctx context.Context, orgID, userID string) (domain.OrgRole, error) {
+	var role domain.OrgRole
+	err := r.pool.QueryRow(ctx,
+		`SELECT role FROM org_members WHERE org_id = $1 AND user_id = $2`,
+		orgID, userID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrOrgNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("get organization role: %w", err)
+	}
+	return role, nil
+}
+
+func (r *OrgRepository) ListMembers(ctx context.Context, orgID string) ([]*domain.OrgMember, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT m.org_id, m.user_id, u.email, m.role, m.created_at
+		FROM org_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY m.created_at ASC, m.user_id ASC`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("list members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*domain.OrgMember
+	for rows.Next() {
+		var m domain.OrgMember
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.Email, &m.Role, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan member: %w", err)
+		}
+		members = append(members, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *OrgRepository) SetRole(ctx context.Context, orgID, userID string, role domain.OrgRole) error {
+	return r.changeMember(ctx, orgID, userID, role != domain.OrgRoleOwner, func(tx pgx.Tx) (int64, error) {
+		tag, err := tx.Exec(ctx,
+			`UPDATE org_members SET role = $3 WHERE org_id = $1 AND user_id = $2`,
+			orgID, userID, role)
+		return tag.RowsAffected(), err
+	})
+}
+
+func (r *OrgRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	return r.changeMember(ctx, orgID, userID, true, func(tx pgx.Tx) (int64, error) {
+		tag, err := tx.Exec(ctx,
+			`DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+		return tag.RowsAffected(), err
+	})
+}
+
+// changeMember runs change in a transaction that holds the organization's owner rows, so
+// two owners demoting each other concurrently can't both succeed. demotes says whether
+// change takes the owner role away from userID.
+func (r *OrgRepository) changeMember(ctx context.Context, orgID, userID string, demotes bool, change func(pgx.Tx) (int64, error)) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	rows, err := tx.Query(ctx,
+		`SELECT user_id FROM org_members WHERE org_id = $1 AND role = 'owner' FOR UPDATE`, orgID)
+	if err != nil {
+		return fmt.Errorf("lock owners: %w", err)
+	}
+	owners, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("lock owners: %w", err)
+	}
+	if demotes && len(owners) == 1 && owners[0] == userID {
+		return domain.ErrLastOrgOwner
+	}
+
+	affected, err := change(tx)
+	if err != nil {
+		return fmt.Errorf("change member: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrOrgMemberNotFound
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+func (r *OrgRepository) CreateInvitation(ctx context.Context, inv *domain.OrgInvitation, tokenHash string) (*domain.OrgInvitation, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO org_invitations (org_id, email, role, token_hash, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+invitationColumns,
+		inv.OrgID, inv.Email, inv.Role, tokenHash, inv.InvitedBy, inv.ExpiresAt,
+	)
+	created, err := scanInvitation(row)
+	if err != nil {
+		return nil, fmt.Errorf("create invitation: %w", err)
+	}
+	return created, nil
+}
+
+func (r *OrgRepository) ListInvitations(ctx context.Context, orgID string) ([]*domain.OrgInvitation, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+invitationColumns+`
+		FROM org_invitations
+		WHERE org_id = $1 AND accepted_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC, id DESC`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*domain.OrgInvitation
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (r *OrgRepository) DeleteInvitation(ctx context.Context, orgID, id string) error {
+	tag, err := r.pool.Exec(ctx,
+		`DELETE FROM org_invitations WHERE id = $1 AND org_id = $2 AND accepted_at IS NULL`, id, orgID)
+	if err != nil {
+		return fmt.Errorf("delete invitation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (r *OrgRepository) AcceptInvitation(ctx context.Context, tokenHash, userID string) (*domain.OrgMember, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	m := domain.OrgMember{UserID: userID}
+	err = tx.QueryRow(ctx, `
+		UPDATE org_invitations SET accepted_at = NOW()
+		WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > NOW()
+		RETURNING org_id, role`, tokenHash).Scan(&m.OrgID, &m.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("use invitation: %w", err)
+	}
+
+	// An existing member keeps their role, and the invitation stays usable by someone else.
+	err = tx.QueryRow(ctx, `
+		INSERT INTO org_members (org_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (org_id, user_id) DO NOTHING
+		RETURNING created_at`, m.OrgID, userID, m.Role).Scan(&m.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrAlreadyOrgMember
+	}
+	if err != nil {
+		return nil, fmt.Errorf("add member: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return &m, nil
+}
+
+// invitationColumns is the column list every invitation query selects/returns — keep in sync with scanInvitation.
+const invitationColumns = `id, org_id, email, role, invited_by, expires_at, accepted_at, created_at`
+
+func scanInvitation(row rowScanner) (*domain.OrgInvitation, error) {
+	var inv domain.OrgInvitation
+	if err := row.Scan(&inv.ID, &inv.OrgID, &inv.Email, &inv.Role, &inv.InvitedBy,
+		&inv.ExpiresAt, &inv.AcceptedAt, &inv.CreatedAt); err != nil {
+		return nil, fmt.Errorf("scan invitation: %w", err)
+	}
+	return &inv, nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PingRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPingRepository(pool *pgxpool.Pool) *PingRepository {
+	return &PingRepository{pool: pool}
+}
+
+func (r *PingRepository) RecordCheck(ctx context.Context, c repository.PingCheck) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	failures := 0
+	if !c.OK {
+		failures = 1
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO schedule_ping_rollups (schedule_id, bucket_start, checks, failures, total_duration_ms)
+		VALUES ($1, date_trunc('hour', $2::timestamptz), 1, $3, $4)
+		ON CONFLICT (schedule_id, bucket_start) DO UPDATE
+		SET checks            = schedule_ping_rollups.checks + 1,
+		    failures          = schedule_ping_rollups.failures + EXCLUDED.failures,
+		    total_duration_ms = schedule_ping_rollups.total_duration_ms + EXCLUDED.total_duration_ms`,
+		c.ScheduleID, c.At, failures, c.DurationMS,
+	); err != nil {
+		return fmt.Errorf("upsert ping rollup: %w", err)
+	}
+
+	if c.OK {
+		_, err = tx.Exec(ctx,
+			`UPDATE schedule_ping_incidents SET ended_at = $2
+			 WHERE schedule_id = $1 AND ended_at IS NULL`,
+			c.ScheduleID, c.At)
+	} else {
+		// The partial unique index keeps a single open incident; repeat failures just refresh the error.
+		_, err = tx.Exec(ctx, `
+			INSERT INTO schedule_ping_incidents (schedule_id, started_at, last_error)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (schedule_id) WHERE ended_at IS NULL
+			DO UPDATE SET last_error = EXCLUDED.last_error`,
+			c.ScheduleID, c.At, c.Error)
+	}
+	if err != nil {
+		return fmt.Errorf("update ping incident: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM jobs WHERE id = $1`, c.JobID); err != nil {
+		return fmt.Errorf("delete ping job: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *PingRepository) GetUptime(ctx context.Context, scheduleID string, since time.Time) (*domain.ScheduleUptime, error) {
+	u := domain.ScheduleUptime{ScheduleID: scheduleID, Since: since}
+
+	var totalDurationMS int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(checks), 0), COALESCE(SUM(failures), 0), COALESCE(SUM(total_duration_ms), 0)
+		FROM schedule_ping_rollups
+		WHERE schedule_id = $1 AND bucket_start >= date_trunc('hour', $2::timestamptz)`,
+		scheduleID, since,
+	).Scan(&u.Checks, &u.Failures, &totalDurationMS)
+	if err != nil {
+		return nil, fmt.Errorf("sum ping rollups: %w", err)
+	}
+	if u.Checks > 0 {
+		u.AvgDurationMS = totalDurationMS / int64(u.Checks)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT started_at, ended_at, last_error
+		FROM schedule_ping_incidents
+		WHERE schedule_id = $1 AND (ended_at IS NULL OR ended_at > $2)
+		ORDER BY started_at DESC`,
+		scheduleID, since)
+	if err != nil {
+		return nil, fmt.Errorf("list ping incidents: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	for rows.Next() {
+		var inc domain.PingIncident
+		if err := rows.Scan(&inc.StartedAt, &inc.EndedAt, &inc.LastError); err != nil {
+			return nil, fmt.Errorf("scan ping incident: %w", err)
+		}
+		// Clip each incident to the requested window before summing downtime.
+		start, end := inc.StartedAt, now
+		if start.Before(since) {
+			start = since
+		}
+		if inc.EndedAt != nil {
+			end = *inc.EndedAt
+		}
+		u.DowntimeSeconds += end.Sub(start).Seconds()
+		u.Incidents = append(u.Incidents, inc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate ping incidents: %w", err)
+	}
+	return &u, nil
+}
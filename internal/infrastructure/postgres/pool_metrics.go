@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector reads pool.Stat() on every scrape, so the values are never older than
+// the scrape itself.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquired, idle, constructing, total, max  *prometheus.Desc
+	acquires, emptyAcquires, canceledAcquires *prometheus.Desc
+	acquireSeconds, emptyAcquireWaitSeconds   *prometheus.Desc
+	newConns, lifetimeDestroys, idleDestroys  *prometheus.Desc
+}
+
+// RegisterPoolMetrics exports pool's connection statistics as scheduler_db_pool_*
+// metrics. Alert on scheduler_db_pool_acquired_conns nearing scheduler_db_pool_max_conns,
+// and on a rising rate of scheduler_db_pool_empty_acquire_wait_seconds_total: both mean
+// queries are queueing for a connection before they time out.
+func RegisterPoolMetrics(pool *pgxpool.Pool, reg prometheus.Registerer) {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("scheduler", "db_pool", name), help, nil, nil)
+	}
+	reg.MustRegister(&poolCollector{
+		pool:                    pool,
+		acquired:                desc("acquired_conns", "Connections currently checked out by queries."),
+		idle:                    desc("idle_conns", "Open connections waiting to be acquired."),
+		constructing:            desc("constructing_conns", "Connections being opened."),
+		total:                   desc("total_conns", "Open connections: acquired, idle and constructing."),
+		max:                     desc("max_conns", "Most connections the pool will open."),
+		acquires:                desc("acquires_total", "Successful connection acquires."),
+		emptyAcquires:           desc("empty_acquires_total", "Successful acquires that had to wait for a connection to be opened or released."),
+		canceledAcquires:        desc("canceled_acquires_total", "Acquires abandoned because their context ended first, e.g. a query timing out while waiting."),
+		acquireSeconds:          desc("acquire_seconds_total", "Time spent in successful acquires."),
+		emptyAcquireWaitSeconds: desc("empty_acquire_wait_seconds_total", "Time successful acquires spent waiting because no connection was idle."),
+		newConns:                desc("new_conns_total", "Connections opened."),
+		lifetimeDestroys:        desc("max_lifetime_destroys_total", "Connections closed for reaching their maximum lifetime."),
+		idleDestroys:            desc("max_idle_destroys_total", "Connections closed for being idle too long."),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.acquired, c.idle, c.constructing, c.total, c.max,
+		c.acquires, c.emptyAcquires, c.canceledAcquires,
+		c.acquireSeconds, c.emptyAcquireWaitSeconds,
+		c.newConns, c.lifetimeDestroys, c.idleDestroys,
+	} {
+		ch <- d
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+	gauge := func(d *prometheus.Desc, v int32) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, float64(v))
+	}
+	counter := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v)
+	}
+	gauge(c.acquired, s.AcquiredConns())
+	gauge(c.idle, s.IdleConns())
+	gauge(c.constructing, s.ConstructingConns())
+	gauge(c.total, s.TotalConns())
+	gauge(c.max, s.MaxConns())
+	counter(c.acquires, float64(s.AcquireCount()))
+	counter(c.emptyAcquires, float64(s.EmptyAcquireCount()))
+	counter(c.canceledAcquires, float64(s.CanceledAcquireCount()))
+	counter(c.acquireSeconds, s.AcquireDuration().Seconds())
+	counter(c.emptyAcquireWaitSeconds, s.EmptyAcquireWaitTime().Seconds())
+	counter(c.newConns, float64(s.NewConnsCount()))
+	counter(c.lifetimeDestroys, float64(s.MaxLifetimeDestroyCount()))
+	counter(c.idleDestroys, float64(s.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RetentionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRetentionRepository(pool *pgxpool.Pool) *RetentionRepository {
+	return &RetentionRepository{pool: pool}
+}
+
+// Both purges pick their batch with SKIP LOCKED, so janitors on several replicas split
+// the work. The archive CTEs read the rows from the statement's snapshot, before the
+// delete (and the job delete's cascade) takes effect.
+
+func (r *RetentionRepository) PurgeAttempts(ctx context.Context, cutoff time.Time, limit int, archive bool) (int64, error) {
+	var n int64
+	err := r.pool.QueryRow(ctx, `
+		WITH doomed AS (
+			SELECT id FROM job_attempts
+			WHERE  completed_at < $1
+			ORDER BY completed_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		),
+		archived AS (
+			INSERT INTO job_attempts_archive (id, job_id, user_id, attempt)
+			SELECT a.id, a.job_id, j.user_id, to_jsonb(a)
+			FROM   job_attempts a
+			JOIN   doomed d ON d.id = a.id
+			JOIN   jobs j   ON j.id = a.job_id
+			WHERE  $3
+			ON CONFLICT (id) DO NOTHING
+		),
+		deleted AS (
+			DELETE FROM job_attempts a USING doomed d WHERE a.id = d.id
+			RETURNING a.id
+		)
+		SELECT COUNT(*) FROM deleted`, cutoff, limit, archive).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("purge attempts: %w", err)
+	}
+	return n, nil
+}
+
+func (r *RetentionRepository) PurgeJobs(ctx context.Context, cutoff time.Time, limit int, archive bool) (int64, error) {
+	var n int64
+	err := r.pool.QueryRow(ctx, `
+		WITH doomed AS (
+			SELECT id FROM jobs
+			WHERE  status IN ('completed', 'failed', 'cancelled', 'expired')
+			  AND  updated_at < $1
+			ORDER BY updated_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		),
+		archived_attempts AS (
+			INSERT INTO job_attempts_archive (id, job_id, user_id, attempt)
+			SELECT a.id, a.job_id, j.user_id, to_jsonb(a)
+			FROM   job_attempts a
+			JOIN   doomed d ON d.id = a.job_id
+			JOIN   jobs j   ON j.id = a.job_id
+			WHERE  $3
+			ON CONFLICT (id) DO NOTHING
+		),
+		archived_jobs AS (
+			INSERT INTO jobs_archive (id, user_id, job)
+			SELECT j.id, j.user_id, to_jsonb(j)
+			FROM   jobs j
+			JOIN   doomed d ON d.id = j.id
+			WHERE  $3
+			ON CONFLICT (id) DO NOTHING
+		),
+		deleted AS (
+			DELETE FROM jobs j USING doomed d WHERE j.id = d.id
+			RETURNING j.id
+		)
+		SELECT COUNT(*) FROM deleted`, cutoff, limit, archive).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("purge jobs: %w", err)
+	}
+	return n, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,69 +19,95 @@ import (
 type ScheduleRepository struct {
 	pool   *pgxpool.Pool
 	logger *slog.Logger
+	box    *secrets.Box
 }
 
-func NewScheduleRepository(pool *pgxpool.Pool, logger *slog.Logger) *ScheduleRepository {
-	return &ScheduleRepository{pool: pool, logger: logger.With("component", "schedule_repo")}
+func NewScheduleRepository(pool *pgxpool.Pool, logger *slog.Logger, box *secrets.Box) *ScheduleRepository {
+	return &ScheduleRepository{pool: pool, logger: logger.With("component", "schedule_repo"), box: box}
 }
 
 func (r *ScheduleRepository) Create(ctx context.Context, s *domain.Schedule) (*domain.Schedule, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sealed, err := seal(ctx, r.box, s.UserID, s.Headers, s.Body, s.SigningSecret, s.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		INSERT INTO schedules (
 			user_id, name, cron_expr, url, method, headers, body,
-			timeout_seconds, max_retries, backoff, paused, next_run_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, user_id, name, cron_expr, url, method, headers, body,
-		          timeout_seconds, max_retries, backoff, paused,
-		          next_run_at, last_run_at, created_at, updated_at`
-
-	row := r.pool.QueryRow(ctx, query,
-		s.UserID, s.Name, s.CronExpr, s.URL, s.Method, s.Headers, s.Body,
-		s.TimeoutSeconds, s.MaxRetries, s.Backoff, s.Paused, s.NextRunAt,
+			timeout_seconds, max_retries, backoff, paused, next_run_at, mode, success_codes,
+			timezone, every_ms, templated, retry_base_seconds, retry_max_seconds, retry_jitter,
+			overlap_policy, jitter_seconds, signing_secret, queue, pause_after_failures, body_encoding,
+			content_type, proxy_url, client_cert_id, ca_bundle, tls_skip_verify, job_type, topic, message_key,
+			email_to, email_subject
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36)
+		RETURNING ` + scheduleColumns
+
+	row := tx.QueryRow(ctx, query,
+		s.UserID, s.Name, s.CronExpr, s.URL, s.Method, sealed.headers, sealed.body,
+		s.TimeoutSeconds, s.MaxRetries, s.Backoff, s.Paused, s.NextRunAt, s.Mode,
+		[]string(s.SuccessCodes), s.Timezone, intervalToMillis(s.Every), s.Templated,
+		s.RetryBaseSeconds, s.RetryMaxSeconds, s.RetryJitter, s.OverlapPolicy, s.JitterSeconds,
+		sealed.secret, s.Queue, s.PauseAfterFailures, s.BodyEncoding, s.ContentType, sealed.proxy,
+		s.ClientCertID, s.CABundle, s.TLSSkipVerify, s.JobType, s.Topic, s.MessageKey,
+		s.EmailTo, s.EmailSubject,
 	)
 
-	created, err := scanSchedule(row)
+	created, err := r.scan(ctx, row)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return nil, domain.ErrScheduleNameConflict
 		}
+		if isUnknownClientCert(err) {
+			return nil, domain.ErrClientCertNotFound
+		}
+		return nil, err
+	}
+
+	if err := r.insertRevision(ctx, tx, created.ID, created.UserID, domain.RevisionActionCreate, nil, created.Spec()); err != nil {
 		return nil, err
 	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
 	return created, nil
 }
 
 func (r *ScheduleRepository) GetByID(ctx context.Context, id, userID string) (*domain.Schedule, error) {
 	query := `
-		SELECT id, user_id, name, cron_expr, url, method, headers, body,
-		       timeout_seconds, max_retries, backoff, paused,
-		       next_run_at, last_run_at, created_at, updated_at
+		SELECT ` + scheduleColumns + `
 		FROM schedules
 		WHERE id = $1 AND user_id = $2`
 
 	row := r.pool.QueryRow(ctx, query, id, userID)
-	return scanSchedule(row)
+	return r.scan(ctx, row)
 }
 
 func (r *ScheduleRepository) List(ctx context.Context, input repository.ListSchedulesInput) ([]*domain.Schedule, error) {
 	args := []any{input.UserID}
 	where := []string{"user_id = $1"}
+	cmp, dir := keysetOrder(input.Order)
 
 	if input.CursorTime != nil {
 		args = append(args, *input.CursorTime, input.CursorID)
-		where = append(where, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+		where = append(where, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", cmp, len(args)-1, len(args)))
 	}
 	args = append(args, input.Limit)
 
 	query := fmt.Sprintf(`
-		SELECT id, user_id, name, cron_expr, url, method, headers, body,
-		       timeout_seconds, max_retries, backoff, paused,
-		       next_run_at, last_run_at, created_at, updated_at
+		SELECT `+scheduleColumns+`
 		FROM schedules
 		WHERE %s
-		ORDER BY created_at DESC, id DESC
+		ORDER BY created_at %s, id %s
 		LIMIT $%d`,
-		strings.Join(where, " AND "), len(args))
+		strings.Join(where, " AND "), dir, dir, len(args))
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -90,7 +117,7 @@ func (r *ScheduleRepository) List(ctx context.Context, input repository.ListSche
 
 	var schedules []*domain.Schedule
 	for rows.Next() {
-		s, err := scanSchedule(rows)
+		s, err := r.scan(ctx, rows)
 		if err != nil {
 			return nil, err
 		}
@@ -99,15 +126,69 @@ func (r *ScheduleRepository) List(ctx context.Context, input repository.ListSche
 	return schedules, nil
 }
 
+func (r *ScheduleRepository) Count(ctx context.Context, userID string) (int64, error) {
+	var n int64
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM schedules WHERE user_id = $1`, userID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count schedules: %w", err)
+	}
+	return n, nil
+}
+
+func (r *ScheduleRepository) CountOverdue(ctx context.Context, cutoff time.Time) (int64, error) {
+	var n int64
+	if err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM schedules WHERE next_run_at < $1 AND NOT paused`, cutoff,
+	).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count overdue schedules: %w", err)
+	}
+	return n, nil
+}
+
+func (r *ScheduleRepository) SearchByName(ctx context.Context, userID, q string, limit int) ([]*domain.Schedule, error) {
+	escaped := escapeLike(q)
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM schedules
+		WHERE user_id = $1 AND name ILIKE $2
+		ORDER BY name ILIKE $3 DESC, name ASC
+		LIMIT $4`,
+		userID, "%"+escaped+"%", escaped+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("search schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []*domain.Schedule
+	for rows.Next() {
+		s, err := r.scan(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate schedules: %w", err)
+	}
+	return schedules, nil
+}
+
 func (r *ScheduleRepository) SetPaused(ctx context.Context, id, userID string, paused bool) error {
-	tag, err := r.pool.Exec(ctx,
-		`UPDATE schedules SET paused = $3, updated_at = NOW()
-		 WHERE id = $1 AND user_id = $2 AND paused = $4`,
-		id, userID, paused, !paused)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("set paused: %w", err)
+		return fmt.Errorf("begin tx: %w", err)
 	}
-	if tag.RowsAffected() == 0 {
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	row := tx.QueryRow(ctx,
+		`UPDATE schedules
+		 SET    paused = $3, updated_at = NOW(),
+		        -- A resumed schedule starts a fresh failure streak.
+		        consecutive_failures = CASE WHEN $3 THEN consecutive_failures ELSE 0 END
+		 WHERE id = $1 AND user_id = $2 AND paused = $4
+		 RETURNING `+scheduleColumns,
+		id, userID, paused, !paused)
+	updated, err := r.scan(ctx, row)
+	if errors.Is(err, domain.ErrScheduleNotFound) {
 		// Distinguish not-found vs already-in-desired-state
 		if _, err := r.GetByID(ctx, id, userID); err != nil {
 			return err // ErrScheduleNotFound
@@ -117,11 +198,194 @@ func (r *ScheduleRepository) SetPaused(ctx context.Context, id, userID string, p
 		}
 		return domain.ErrScheduleNotPaused
 	}
+	if err != nil {
+		return fmt.Errorf("set paused: %w", err)
+	}
+
+	action := domain.RevisionActionResume
+	if paused {
+		action = domain.RevisionActionPause
+	}
+	before := updated.Spec()
+	before.Paused = !paused
+	if err := r.insertRevision(ctx, tx, id, userID, action, &before, updated.Spec()); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) Update(ctx context.Context, s *domain.Schedule, action domain.RevisionAction) (*domain.Schedule, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the row so the recorded "before" is exactly what this update replaced.
+	current, err := r.scan(ctx, tx.QueryRow(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM schedules
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE`, s.ID, s.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := seal(ctx, r.box, s.UserID, s.Headers, s.Body, s.SigningSecret, s.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.scan(ctx, tx.QueryRow(ctx, `
+		UPDATE schedules
+		SET    name                 = $3,
+		       cron_expr            = $4,
+		       url                  = $5,
+		       method               = $6,
+		       headers              = $7,
+		       body                 = $8,
+		       timeout_seconds      = $9,
+		       max_retries          = $10,
+		       backoff              = $11,
+		       paused               = $12,
+		       next_run_at          = $13,
+		       success_codes        = $14,
+		       timezone             = $15,
+		       every_ms             = $16,
+		       templated            = $17,
+		       retry_base_seconds   = $18,
+		       retry_max_seconds    = $19,
+		       retry_jitter         = $20,
+		       overlap_policy       = $21,
+		       jitter_seconds       = $22,
+		       signing_secret       = $23,
+		       queue                = $24,
+		       pause_after_failures = $25,
+		       body_encoding        = $26,
+		       content_type         = $27,
+		       proxy_url            = $28,
+		       client_cert_id       = $29,
+		       ca_bundle            = $30,
+		       tls_skip_verify      = $31,
+		       topic                = $32,
+		       message_key          = $33,
+		       email_to             = $34,
+		       email_subject        = $35,
+		       updated_at           = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+scheduleColumns,
+		s.ID, s.UserID, s.Name, s.CronExpr, s.URL, s.Method, sealed.headers, sealed.body,
+		s.TimeoutSeconds, s.MaxRetries, s.Backoff, s.Paused, s.NextRunAt,
+		[]string(s.SuccessCodes), s.Timezone, intervalToMillis(s.Every), s.Templated,
+		s.RetryBaseSeconds, s.RetryMaxSeconds, s.RetryJitter, s.OverlapPolicy, s.JitterSeconds,
+		sealed.secret, s.Queue, s.PauseAfterFailures, s.BodyEncoding, s.ContentType, sealed.proxy,
+		s.ClientCertID, s.CABundle, s.TLSSkipVerify, s.Topic, s.MessageKey, s.EmailTo, s.EmailSubject,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrScheduleNameConflict
+		}
+		if isUnknownClientCert(err) {
+			return nil, domain.ErrClientCertNotFound
+		}
+		return nil, err
+	}
+
+	// A no-op update changes nothing worth a revision.
+	before := current.Spec()
+	if len(domain.ChangedFields(before, updated.Spec())) > 0 {
+		if err := r.insertRevision(ctx, tx, s.ID, s.UserID, action, &before, updated.Spec()); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return updated, nil
+}
+
+// insertRevision appends the next revision for a schedule. Callers hold the schedule's
+// row lock (by inserting or updating it in tx), which serialises revision numbering.
+func (r *ScheduleRepository) insertRevision(ctx context.Context, tx pgx.Tx, scheduleID, actorID string, action domain.RevisionAction, before *domain.ScheduleSpec, after domain.ScheduleSpec) error {
+	var err error
+	if before != nil {
+		sealedBefore, err := sealSpec(ctx, r.box, actorID, *before)
+		if err != nil {
+			return err
+		}
+		before = &sealedBefore
+	}
+	if after, err = sealSpec(ctx, r.box, actorID, after); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO schedule_revisions (schedule_id, revision, action, actor_id, before, after)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5
+		FROM schedule_revisions
+		WHERE schedule_id = $1`,
+		scheduleID, action, actorID, before, after)
+	if err != nil {
+		return fmt.Errorf("insert schedule revision: %w", err)
+	}
 	return nil
 }
 
+func (r *ScheduleRepository) ListRevisions(ctx context.Context, scheduleID string, limit int) ([]*domain.ScheduleRevision, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+revisionColumns+`
+		FROM schedule_revisions
+		WHERE schedule_id = $1
+		ORDER BY revision DESC
+		LIMIT $2`, scheduleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list schedule revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.ScheduleRevision
+	for rows.Next() {
+		rev, err := r.scanRevision(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate schedule revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (r *ScheduleRepository) GetRevision(ctx context.Context, scheduleID string, revision int) (*domain.ScheduleRevision, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+revisionColumns+`
+		FROM schedule_revisions
+		WHERE schedule_id = $1 AND revision = $2`, scheduleID, revision)
+	return r.scanRevision(ctx, row)
+}
+
+// Delete removes a schedule. Its still-pending jobs are cancelled in the same
+// transaction so a deleted schedule never executes again; running and terminal
+// jobs keep their history and have schedule_id set to NULL by the FK.
 func (r *ScheduleRepository) Delete(ctx context.Context, id, userID string) error {
-	tag, err := r.pool.Exec(ctx,
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE jobs SET status = 'cancelled', updated_at = NOW()
+		 WHERE schedule_id = $1 AND user_id = $2 AND status IN ('pending', 'paused')`,
+		id, userID); err != nil {
+		return fmt.Errorf("cancel pending schedule jobs: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
 		`DELETE FROM schedules WHERE id = $1 AND user_id = $2`,
 		id, userID)
 	if err != nil {
@@ -130,12 +394,16 @@ func (r *ScheduleRepository) Delete(ctx context.Context, id, userID string) erro
 	if tag.RowsAffected() == 0 {
 		return domain.ErrScheduleNotFound
 	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
 	return nil
 }
 
 // ClaimAndFire atomically claims due schedules, inserts a job for each, and advances next_run_at.
 // All operations happen in a single transaction — no partial state on crash.
-func (r *ScheduleRepository) ClaimAndFire(ctx context.Context, limit int, computeNext func(*domain.Schedule) time.Time) ([]*domain.Job, error) {
+func (r *ScheduleRepository) ClaimAndFire(ctx context.Context, limit int, plan func(*domain.Schedule) repository.FirePlan) ([]*domain.Job, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("begin tx: %w", err)
@@ -148,9 +416,7 @@ func (r *ScheduleRepository) ClaimAndFire(ctx context.Context, limit int, comput
 
 	// Claim due schedules — FOR UPDATE SKIP LOCKED prevents double-firing across replicas.
 	rows, err := tx.Query(ctx, `
-		SELECT id, user_id, name, cron_expr, url, method, headers, body,
-		       timeout_seconds, max_retries, backoff, paused,
-		       next_run_at, last_run_at, created_at, updated_at
+		SELECT `+scheduleColumns+`
 		FROM schedules
 		WHERE next_run_at <= NOW() AND NOT paused
 		ORDER BY next_run_at ASC
@@ -160,6 +426,8 @@ func (r *ScheduleRepository) ClaimAndFire(ctx context.Context, limit int, comput
 		return nil, fmt.Errorf("claim schedules: %w", err)
 	}
 
+	// The schedules stay sealed: their headers, body, secret and proxy URL are copied into
+	// the fired jobs as stored.
 	var schedules []*domain.Schedule
 	for rows.Next() {
 		s, scanErr := scanSchedule(rows)
@@ -177,29 +445,47 @@ func (r *ScheduleRepository) ClaimAndFire(ctx context.Context, limit int, comput
 	var firedJobs []*domain.Job
 
 	for _, s := range schedules {
-		next := computeNext(s)
+		fp := plan(s)
+		next := fp.NextRunAt
 		idempotencyKey := fmt.Sprintf("sched:%s:%d", s.ID, s.NextRunAt.Unix())
 
+		fire, overlapErr := r.resolveOverlap(ctx, tx, s)
+		if overlapErr != nil {
+			return nil, overlapErr
+		}
+		if !fire {
+			if _, updateErr := tx.Exec(ctx,
+				`UPDATE schedules SET next_run_at = $2, updated_at = NOW() WHERE id = $1`,
+				s.ID, next,
+			); updateErr != nil {
+				return nil, fmt.Errorf("advance schedule %s: %w", s.ID, updateErr)
+			}
+			continue
+		}
+
 		// Insert the job — idempotency key guards against any edge-case duplicate fire.
-		var j domain.Job
-		scanErr := tx.QueryRow(ctx, `
+		// first_due_at is the nominal fire time, which a late dispatch doesn't change; the
+		// plan's jitter delay only moves scheduled_at.
+		row := tx.QueryRow(ctx, `
 			INSERT INTO jobs (
 				user_id, idempotency_key, url, method, headers, body,
-				timeout_seconds, status, scheduled_at, max_retries, backoff, schedule_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, 'pending', NOW(), $8, $9, $10)
-			RETURNING id, user_id, idempotency_key, url, method, headers, body,
-			          timeout_seconds, status, scheduled_at, retry_count,
-			          max_retries, backoff, claimed_at, claimed_by,
-			          heartbeat_at, completed_at, last_error, created_at, updated_at, schedule_id`,
+				timeout_seconds, status, scheduled_at, max_retries, backoff, schedule_id, ping,
+				success_codes, templated, first_due_at, retry_base_seconds, retry_max_seconds, retry_jitter,
+				signing_secret, queue, body_encoding, content_type, proxy_url, client_cert_id,
+				ca_bundle, tls_skip_verify, job_type, topic, message_key, email_to, email_subject
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, 'pending', NOW() + make_interval(secs => $18), $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
+			RETURNING `+jobColumns,
 			s.UserID, idempotencyKey, s.URL, s.Method, s.Headers, s.Body,
-			s.TimeoutSeconds, s.MaxRetries, s.Backoff, s.ID,
-		).Scan(
-			&j.ID, &j.UserID, &j.IdempotencyKey, &j.URL, &j.Method, &j.Headers, &j.Body,
-			&j.TimeoutSeconds, &j.Status, &j.ScheduledAt, &j.RetryCount,
-			&j.MaxRetries, &j.Backoff, &j.ClaimedAt, &j.ClaimedBy,
-			&j.HeartbeatAt, &j.CompletedAt, &j.LastError, &j.CreatedAt, &j.UpdatedAt,
-			&j.ScheduleID,
+			s.TimeoutSeconds, s.MaxRetries, s.Backoff, s.ID, s.Mode == domain.ScheduleModePing,
+			[]string(s.SuccessCodes), s.Templated, s.NextRunAt,
+			s.RetryBaseSeconds, s.RetryMaxSeconds, s.RetryJitter, fp.Delay.Seconds(), s.SigningSecret, s.Queue,
+			s.BodyEncoding, s.ContentType, s.ProxyURL, s.ClientCertID,
+			s.CABundle, s.TLSSkipVerify, s.JobType, s.Topic, s.MessageKey, s.EmailTo, s.EmailSubject,
 		)
+		j, scanErr := scanJob(row)
+		if scanErr == nil {
+			scanErr = openJob(ctx, r.box, j)
+		}
 		if scanErr != nil {
 			var pgErr *pgconn.PgError
 			if errors.As(scanErr, &pgErr) && pgErr.Code == "23505" {
@@ -213,7 +499,7 @@ func (r *ScheduleRepository) ClaimAndFire(ctx context.Context, limit int, comput
 				return nil, fmt.Errorf("insert job for schedule %s: %w", s.ID, scanErr)
 			}
 		} else {
-			firedJobs = append(firedJobs, &j)
+			firedJobs = append(firedJobs, j)
 		}
 
 		// Advance next_run_at and record last_run_at.
@@ -231,12 +517,112 @@ func (r *ScheduleRepository) ClaimAndFire(ctx context.Context, limit int, comput
 	return firedJobs, nil
 }
 
+// resolveOverlap applies the schedule's overlap policy to the jobs of its earlier fires
+// that are still pending or running, and reports whether this fire should go ahead.
+// Paused jobs are parked by their owner and never count as overlapping.
+func (r *ScheduleRepository) resolveOverlap(ctx context.Context, tx pgx.Tx, s *domain.Schedule) (bool, error) {
+	switch s.OverlapPolicy {
+	case domain.OverlapSkip:
+		var active bool
+		if err := tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM jobs WHERE schedule_id = $1 AND status IN ('pending', 'running')
+			)`, s.ID).Scan(&active); err != nil {
+			return false, fmt.Errorf("check overlap for schedule %s: %w", s.ID, err)
+		}
+		if active {
+			r.logger.InfoContext(ctx, "previous run still active, skipping fire", "schedule_id", s.ID)
+		}
+		return !active, nil
+	case domain.OverlapReplace:
+		// Same transition as a user cancel: pending jobs end now, running ones are
+		// flagged and aborted by their worker.
+		tag, err := tx.Exec(ctx, `
+			UPDATE jobs
+			SET    status              = CASE WHEN status = 'running' THEN status ELSE 'cancelled' END,
+			       cancel_requested_at = CASE WHEN status = 'running' THEN COALESCE(cancel_requested_at, NOW()) END,
+			       updated_at          = NOW()
+			WHERE schedule_id = $1 AND status IN ('pending', 'running')`, s.ID)
+		if err != nil {
+			return false, fmt.Errorf("replace previous run of schedule %s: %w", s.ID, err)
+		}
+		if tag.RowsAffected() > 0 {
+			r.logger.InfoContext(ctx, "cancelled previous run to replace it", "schedule_id", s.ID, "jobs", tag.RowsAffected())
+		}
+		return true, nil
+	default:
+		return true, nil
+	}
+}
+
+// revisionColumns is the column list every revision query selects — keep in sync with scanRevision.
+const revisionColumns = `id, schedule_id, revision, action, actor_id, before, after, created_at`
+
+// scanRevision reads a revision row and opens the sealed fields of its specs.
+func (r *ScheduleRepository) scanRevision(ctx context.Context, row rowScanner) (*domain.ScheduleRevision, error) {
+	rev, err := scanRevision(row)
+	if err != nil {
+		return nil, err
+	}
+	if rev.Before != nil {
+		if err := openSpec(ctx, r.box, rev.Before); err != nil {
+			return nil, fmt.Errorf("revision %d: %w", rev.Revision, err)
+		}
+	}
+	if err := openSpec(ctx, r.box, &rev.After); err != nil {
+		return nil, fmt.Errorf("revision %d: %w", rev.Revision, err)
+	}
+	return rev, nil
+}
+
+func scanRevision(row rowScanner) (*domain.ScheduleRevision, error) {
+	var rev domain.ScheduleRevision
+	err := row.Scan(&rev.ID, &rev.ScheduleID, &rev.Revision, &rev.Action, &rev.ActorID, &rev.Before, &rev.After, &rev.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("scan schedule revision: %w", err)
+	}
+	return &rev, nil
+}
+
+// scheduleColumns is the column list every schedule query selects/returns — keep in sync with scanSchedule.
+const scheduleColumns = `id, user_id, name, cron_expr, url, method, headers, body,
+		timeout_seconds, max_retries, backoff, paused,
+		next_run_at, last_run_at, created_at, updated_at, mode, success_codes, timezone, every_ms,
+		templated, retry_base_seconds, retry_max_seconds, retry_jitter, overlap_policy,
+		jitter_seconds, signing_secret, queue, pause_after_failures, consecutive_failures,
+		body_encoding, content_type, proxy_url, client_cert_id, ca_bundle, tls_skip_verify,
+		job_type, topic, message_key, email_to, email_subject`
+
+// scan reads a schedule row and opens its sealed columns.
+func (r *ScheduleRepository) scan(ctx context.Context, row rowScanner) (*domain.Schedule, error) {
+	s, err := scanSchedule(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := openSchedule(ctx, r.box, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
 func scanSchedule(row rowScanner) (*domain.Schedule, error) {
-	var s domain.Schedule
+	var (
+		s            domain.Schedule
+		successCodes []string
+		everyMS      *int64
+	)
 	err := row.Scan(
 		&s.ID, &s.UserID, &s.Name, &s.CronExpr, &s.URL, &s.Method, &s.Headers, &s.Body,
 		&s.TimeoutSeconds, &s.MaxRetries, &s.Backoff, &s.Paused,
-		&s.NextRunAt, &s.LastRunAt, &s.CreatedAt, &s.UpdatedAt,
+		&s.NextRunAt, &s.LastRunAt, &s.CreatedAt, &s.UpdatedAt, &s.Mode, &successCodes,
+		&s.Timezone, &everyMS, &s.Templated, &s.RetryBaseSeconds, &s.RetryMaxSeconds, &s.RetryJitter,
+		&s.OverlapPolicy, &s.JitterSeconds, &s.SigningSecret, &s.Queue,
+		&s.PauseAfterFailures, &s.ConsecutiveFailures, &s.BodyEncoding, &s.ContentType,
+		&s.ProxyURL, &s.ClientCertID, &s.CABundle, &s.TLSSkipVerify, &s.JobType,
+		&s.Topic, &s.MessageKey, &s.EmailTo, &s.EmailSubject,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -244,5 +630,18 @@ func scanSchedule(row rowScanner) (*domain.Schedule, error) {
 		}
 		return nil, fmt.Errorf("scan schedule: %w", err)
 	}
+	s.SuccessCodes = successCodes
+	if everyMS != nil {
+		s.Every = domain.Interval(time.Duration(*everyMS) * time.Millisecond)
+	}
 	return &s, nil
 }
+
+// intervalToMillis maps the zero interval (a cron schedule) to NULL.
+func intervalToMillis(i domain.Interval) *int64 {
+	if i == 0 {
+		return nil
+	}
+	ms := time.Duration(i).Milliseconds()
+	return &ms
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
+)
+
+// Header values, bodies, signing secrets and proxy URLs of jobs, schedules, schedule revisions and
+// job defaults are sealed by the repositories on write and opened on read, so everything
+// above this package sees plaintext. Rows written before encryption was enabled stay
+// plaintext until rewritten; Box.Open passes them through. Values are sealed on behalf
+// of the row's owner (secrets.WithOwner), so an organization with its own KMS key gets
+// its rows sealed under it.
+
+// sealedFields is the at-rest form of a row's sensitive columns.
+type sealedFields struct {
+	headers map[string]string
+	body    *string
+	secret  *string
+	proxy   *string
+}
+
+func seal(ctx context.Context, box *secrets.Box, ownerID string, headers map[string]string, body, secret, proxy *string) (sealedFields, error) {
+	var (
+		s   sealedFields
+		err error
+	)
+	ctx = secrets.WithOwner(ctx, ownerID)
+	if s.headers, err = box.SealHeaders(ctx, headers); err != nil {
+		return sealedFields{}, fmt.Errorf("seal headers: %w", err)
+	}
+	if s.body, err = box.SealPtr(ctx, body); err != nil {
+		return sealedFields{}, fmt.Errorf("seal body: %w", err)
+	}
+	if s.secret, err = box.SealPtr(ctx, secret); err != nil {
+		return sealedFields{}, fmt.Errorf("seal signing secret: %w", err)
+	}
+	if s.proxy, err = box.SealPtr(ctx, proxy); err != nil {
+		return sealedFields{}, fmt.Errorf("seal proxy url: %w", err)
+	}
+	return s, nil
+}
+
+// open replaces sealed values in place. secret and proxy may be nil for rows without
+// those columns.
+func open(ctx context.Context, box *secrets.Box, headers *map[string]string, body, secret, proxy **string) error {
+	var err error
+	if *headers, err = box.OpenHeaders(ctx, *headers); err != nil {
+		return fmt.Errorf("open headers: %w", err)
+	}
+	if *body, err = box.OpenPtr(ctx, *body); err != nil {
+		return fmt.Errorf("open body: %w", err)
+	}
+	if secret != nil {
+		if *secret, err = box.OpenPtr(ctx, *secret); err != nil {
+			return fmt.Errorf("open signing secret: %w", err)
+		}
+	}
+	if proxy != nil {
+		if *proxy, err = box.OpenPtr(ctx, *proxy); err != nil {
+			return fmt.Errorf("open proxy url: %w", err)
+		}
+	}
+	return nil
+}
+
+func openJob(ctx context.Context, box *secrets.Box, j *domain.Job) error {
+	if err := open(ctx, box, &j.Headers, &j.Body, &j.SigningSecret, &j.ProxyURL); err != nil {
+		return fmt.Errorf("job %s: %w", j.ID, err)
+	}
+	return nil
+}
+
+func openSchedule(ctx context.Context, box *secrets.Box, s *domain.Schedule) error {
+	if err := open(ctx, box, &s.Headers, &s.Body, &s.SigningSecret, &s.ProxyURL); err != nil {
+		return fmt.Errorf("schedule %s: %w", s.ID, err)
+	}
+	return nil
+}
+
+func sealSpec(ctx context.Context, box *secrets.Box, ownerID string, spec domain.ScheduleSpec) (domain.ScheduleSpec, error) {
+	s, err := seal(ctx, box, ownerID, spec.Headers, spec.Body, nil, nil)
+	if err != nil {
+		return domain.ScheduleSpec{}, err
+	}
+	spec.Headers, spec.Body = s.headers, s.body
+	return spec, nil
+}
+
+func openSpec(ctx context.Context, box *secrets.Box, spec *domain.ScheduleSpec) error {
+	return open(ctx, box, &spec.Headers, &spec.Body, nil, nil)
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStatsRepository(pool *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{pool: pool}
+}
+
+func (r *StatsRepository) JobStats(ctx context.Context, userID string, scheduleID *string, since time.Time) (*domain.JobStats, error) {
+	stats := &domain.JobStats{Since: since, Counts: make(map[domain.Status]int64)}
+
+	// The jobs in the window; idx_jobs_user_scheduled or idx_jobs_schedule_scheduled
+	// serves it.
+	args := []any{userID, since}
+	windowJobs := `SELECT id, status FROM jobs
+		WHERE user_id = $1 AND scheduled_at >= $2 AND scheduled_at <= now()`
+	if scheduleID != nil {
+		args = append(args, *scheduleID)
+		windowJobs += ` AND schedule_id = $3`
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH w AS (`+windowJobs+`)
+		SELECT status, COUNT(*) FROM w GROUP BY status`,
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("count jobs by status: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status domain.Status
+		var n int64
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("scan status count: %w", err)
+		}
+		stats.Counts[status] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate status counts: %w", err)
+	}
+
+	var p50, p95 *float64
+	err = r.pool.QueryRow(ctx, `
+		WITH w AS (`+windowJobs+`)
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE a.error IS NOT NULL),
+		       percentile_cont(0.5)  WITHIN GROUP (ORDER BY a.duration_ms),
+		       percentile_cont(0.95) WITHIN GROUP (ORDER BY a.duration_ms)
+		FROM w
+		JOIN job_attempts a ON a.job_id = w.id
+		WHERE a.completed_at IS NOT NULL AND NOT a.cancelled`,
+		args...,
+	).Scan(&stats.Attempts, &stats.FailedAttempts, &p50, &p95)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate attempts: %w", err)
+	}
+	stats.P50DurationMS = roundMS(p50)
+	stats.P95DurationMS = roundMS(p95)
+	return stats, nil
+}
+
+func (r *StatsRepository) ScheduleDigests(ctx context.Context, userID string, scheduleID *string, from, to time.Time) ([]domain.ScheduleDigest, error) {
+	// The window spans the period and the one before it, for the latency trend.
+	args := []any{userID, from.Add(-to.Sub(from)), from, to}
+	windowJobs := `SELECT id, schedule_id, status, scheduled_at >= $3 AS current FROM jobs
+		WHERE user_id = $1 AND schedule_id IS NOT NULL AND scheduled_at >= $2 AND scheduled_at < $4`
+	if scheduleID != nil {
+		args = append(args, *scheduleID)
+		windowJobs += ` AND schedule_id = $5`
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH w AS (`+windowJobs+`),
+		runs AS (
+			SELECT schedule_id,
+			       COUNT(*) FILTER (WHERE status = 'completed')             AS succeeded,
+			       COUNT(*) FILTER (WHERE status IN ('failed', 'expired')) AS failed
+			FROM w WHERE current GROUP BY schedule_id
+		),
+		latency AS (
+			SELECT w.schedule_id,
+			       percentile_cont(0.95) WITHIN GROUP (ORDER BY a.duration_ms) FILTER (WHERE w.current)     AS p95,
+			       percentile_cont(0.95) WITHIN GROUP (ORDER BY a.duration_ms) FILTER (WHERE NOT w.current) AS prev_p95
+			FROM w
+			JOIN job_attempts a ON a.job_id = w.id
+			WHERE a.completed_at IS NOT NULL AND NOT a.cancelled
+			GROUP BY w.schedule_id
+		)
+		SELECT s.id, s.name, runs.succeeded, runs.failed, latency.p95, latency.prev_p95
+		FROM runs
+		JOIN schedules s ON s.id = runs.schedule_id
+		LEFT JOIN latency ON latency.schedule_id = runs.schedule_id
+		WHERE runs.succeeded + runs.failed > 0
+		ORDER BY s.name, s.id`,
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("summarise schedule runs: %w", err)
+	}
+	defer rows.Close()
+
+	var digests []domain.ScheduleDigest
+	for rows.Next() {
+		var d domain.ScheduleDigest
+		var p95, prevP95 *float64
+		if err := rows.Scan(&d.ScheduleID, &d.Name, &d.Succeeded, &d.Failed, &p95, &prevP95); err != nil {
+			return nil, fmt.Errorf("scan schedule digest: %w", err)
+		}
+		d.Runs = d.Succeeded + d.Failed
+		d.P95DurationMS = roundMS(p95)
+		d.PrevP95DurationMS = roundMS(prevP95)
+		digests = append(digests, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate schedule digests: %w", err)
+	}
+	return digests, nil
+}
+
+func roundMS(ms *float64) *int64 {
+	if ms == nil {
+		return nil
+	}
+	n := int64(math.Round(*ms))
+	return &n
+}
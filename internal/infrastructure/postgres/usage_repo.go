@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UsageRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewUsageRepository(pool *pgxpool.Pool) *UsageRepository {
+	return &UsageRepository{pool: pool}
+}
+
+// GetUsage runs on every create; the daily-executions count walks the user's jobs by
+// idx_jobs_user_scheduled and their attempts since since by idx_attempts_job_started.
+func (r *UsageRepository) GetUsage(ctx context.Context, userID string, since time.Time) (*domain.Usage, error) {
+	var u domain.Usage
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM jobs WHERE user_id = $1 AND status IN ('pending', 'paused', 'blocked', 'awaiting_approval')),
+			(SELECT COUNT(*) FROM schedules WHERE user_id = $1),
+			(SELECT COUNT(*)
+			   FROM job_attempts a
+			   JOIN jobs j ON j.id = a.job_id
+			  WHERE j.user_id = $1 AND a.started_at >= $2)`,
+		userID, since,
+	).Scan(&u.PendingJobs, &u.Schedules, &u.DailyExecutions)
+	if err != nil {
+		return nil, fmt.Errorf("get usage: %w", err)
+	}
+	return &u, nil
+}
@@ -0,0 +1,88 @@
+// Package redis implements the optional Redis claim queue used in high-throughput mode.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ClaimQueue keeps a Redis list of due job IDs per job queue: the mover LPUSHes, workers
+// BRPOP, so IDs are handed out oldest first. The default queue's list is the base key, as
+// before queues existed; other queues append ":<queue>".
+type ClaimQueue struct {
+	client *goredis.Client
+	key    string
+}
+
+// NewClient connects to the Redis server at url (redis://[user:pass@]host:port/db) and
+// pings it, so a misconfigured URL fails at startup rather than on the first pop.
+func NewClient(ctx context.Context, url string) (*goredis.Client, error) {
+	opts, err := goredis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	client := goredis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("ping redis: %w", err)
+	}
+	return client, nil
+}
+
+func NewClaimQueue(client *goredis.Client, key string) *ClaimQueue {
+	return &ClaimQueue{client: client, key: key}
+}
+
+func (q *ClaimQueue) listKey(queue string) string {
+	if queue == domain.DefaultQueue {
+		return q.key
+	}
+	return q.key + ":" + queue
+}
+
+func (q *ClaimQueue) Push(ctx context.Context, queue string, jobIDs []string) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+	ids := make([]any, len(jobIDs))
+	for i, id := range jobIDs {
+		ids[i] = id
+	}
+	if err := q.client.LPush(ctx, q.listKey(queue), ids...).Err(); err != nil {
+		return fmt.Errorf("push job ids: %w", err)
+	}
+	return nil
+}
+
+func (q *ClaimQueue) Pop(ctx context.Context, queues []string, max int, timeout time.Duration) (string, []string, error) {
+	keys := make([]string, len(queues))
+	byKey := make(map[string]string, len(queues))
+	for i, queue := range queues {
+		keys[i] = q.listKey(queue)
+		byKey[keys[i]] = queue
+	}
+
+	res, err := q.client.BRPop(ctx, timeout, keys...).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("pop job id: %w", err)
+	}
+	key, ids := res[0], []string{res[1]}
+
+	if max > 1 {
+		more, err := q.client.RPopCount(ctx, key, max-1).Result()
+		if err != nil && !errors.Is(err, goredis.Nil) {
+			// The first ID is already off the list; hand it out rather than lose it.
+			return byKey[key], ids, nil
+		}
+		ids = append(ids, more...)
+	}
+	return byKey[key], ids, nil
+}
@@ -38,6 +38,224 @@ var (
 		Help:      "Total jobs finished, by outcome.",
 	}, []string{"outcome"})
 
+	// Retry metrics, for tuning retry policy. Retries after a worker shutdown or a reaper
+	// rescue aren't backoff decisions and are not counted.
+
+	RetriesScheduledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "retries_scheduled_total",
+		Help:      "Failed attempts rescheduled for a retry, by retry strategy (delays, exponential, linear, fixed) and the attempt that failed (1-9, then 10+).",
+	}, []string{"strategy", "attempt"})
+
+	RetryDelay = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "retry_delay_seconds",
+		Help:      "Delay before a scheduled retry, after jitter, by retry strategy.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200, 21600, 86400},
+	}, []string{"strategy"})
+
+	RetriedJobsFinishedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "retried_jobs_finished_total",
+		Help:      "Jobs finished by the worker after at least one retry, by outcome (success, failed); success / total is how often retrying pays off.",
+	}, []string{"outcome"})
+
+	JobSuccessAttempt = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "job_success_attempt",
+		Help:      "The attempt number on which jobs succeeded; 1 is a first-try success.",
+		Buckets:   []float64{1, 2, 3, 4, 5, 7, 10, 15, 20},
+	})
+
+	PingChecksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "ping_checks_total",
+		Help:      "Ping-mode schedule checks executed, by outcome.",
+	}, []string{"outcome"})
+
+	CallbackDeliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "callback_deliveries_total",
+		Help:      "Job callback delivery attempts, by resulting callback status.",
+	}, []string{"status"})
+
+	NotificationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "notifications_total",
+		Help:      "Failure notifications delivered, by channel and outcome.",
+	}, []string{"channel", "outcome"})
+
+	DigestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "digests_total",
+		Help:      "Schedule digests, by channel and outcome (sent, failed, empty).",
+	}, []string{"channel", "outcome"})
+
+	NotificationsDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "notifications_dropped_total",
+		Help:      "Job failures not evaluated for notification because the queue was full.",
+	})
+
+	WorkerClaimsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "worker_claims_total",
+		Help:      "Postgres claim requests, by whether they returned all (full), some (partial) or none (empty) of the jobs asked for.",
+	}, []string{"result"})
+
+	WorkerJobsClaimedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "worker_jobs_claimed_total",
+		Help:      "Jobs claimed by the worker, from Postgres or the Redis claim queue.",
+	})
+
+	WorkerSlots = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "worker_slots",
+		Help:      "Jobs the worker can run at once (WORKER_COUNT); saturation is worker_jobs_in_flight / worker_slots.",
+	})
+
+	WorkerClaimBatchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "worker_claim_batch_size",
+		Help:      "Jobs returned per Postgres claim request.",
+		Buckets:   []float64{0, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+	})
+
+	WorkerPrefetchTarget = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "worker_prefetch_target",
+		Help:      "Jobs the worker currently aims to claim beyond its free slots.",
+	})
+
+	WorkerPrefetchedJobs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "worker_prefetched_jobs",
+		Help:      "Claimed jobs held by the worker waiting for a free slot.",
+	})
+
+	WorkerPrefetchReleasedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "worker_prefetch_released_total",
+		Help:      "Prefetched jobs handed back unrun, because no slot freed up in time or the worker drained.",
+	})
+
+	ExecutorRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "executor_requests_total",
+		Help:      "HTTP job requests sent, by target host:port (\"other\" past EXECUTOR_METRICS_HOSTS) and status class (2xx-5xx, timeout, error).",
+	}, []string{"host", "status_class"})
+
+	ExecutorRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "executor_request_duration_seconds",
+		Help:      "Duration of HTTP job requests, response body included, by target host:port and status class.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"host", "status_class"})
+
+	ExecutorResponseBytesDrained = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "executor_response_bytes_drained_total",
+		Help:      "Response body bytes read and discarded by the executor.",
+	})
+
+	ExecutorResponsesTruncatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "executor_responses_truncated_total",
+		Help:      "Responses whose body exceeded the worker's size limit and was not read to the end.",
+	})
+
+	ExecutorCircuitTransitionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "executor_circuit_transitions_total",
+		Help:      "Per-host circuit breaker state changes, by new state (open, half_open, closed).",
+	}, []string{"state"})
+
+	ExecutorTLSUnverifiedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "executor_tls_unverified_requests_total",
+		Help:      "Requests sent with TLS certificate verification turned off by the job's tls_skip_verify.",
+	})
+
+	ExecutorCircuitsOpen = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "executor_circuits_open",
+		Help:      "Target hosts this worker currently has an open or half-open circuit for.",
+	})
+
+	JobsDeferredTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "jobs_deferred_total",
+		Help:      "Claimed jobs put back unrun because their target host's circuit was open.",
+	})
+
+	// Queue metrics, sampled by the stats collector. Every scheduler replica reports the
+	// same global values, so aggregate with max() rather than sum().
+
+	JobsPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "jobs_pending_total",
+		Help:      "Pending jobs across all users, due or not.",
+	})
+
+	JobsOverdue = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "jobs_overdue_total",
+		Help:      "Pending jobs whose scheduled_at has passed but that no worker has claimed yet.",
+	})
+
+	SchedulingLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "scheduling_lag_seconds",
+		Help:      "Now minus the oldest scheduled_at among overdue jobs; 0 when nothing is overdue.",
+	})
+
+	JobsEnqueuedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "jobs_enqueued_total",
+		Help:      "Job IDs pushed to the Redis claim queue by the mover, including redeliveries.",
+	})
+
+	RetentionPurgedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "retention_purged_rows_total",
+		Help:      "Rows removed by the retention janitor, by table (job_attempts, jobs). Attempts removed by a job's cascade are not counted.",
+	}, []string{"table"})
+
+	SchedulesOverdue = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "schedules_overdue",
+		Help:      "Active schedules whose next_run_at is more than SCHEDULE_OVERDUE_SEC in the past, i.e. the dispatcher is behind or not running.",
+	})
+
+	// Dispatcher metrics, reported by the replica running the dispatcher.
+
+	DispatcherCycleDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "dispatcher_cycle_duration_seconds",
+		Help:      "Time taken for one dispatcher cycle, by result (ok, error).",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	DispatcherFiredPerCycle = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "dispatcher_schedules_fired_per_cycle",
+		Help:      "Schedules fired per successful dispatcher cycle; cycles at the 100 cap mean more were due.",
+		Buckets:   []float64{0, 1, 2, 5, 10, 25, 50, 75, 100},
+	})
+
+	DispatcherFiredTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "dispatcher_schedules_fired_total",
+		Help:      "Jobs created by the dispatcher from due schedules.",
+	})
+
+	ScheduleLastFireTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "schedule_last_fire_timestamp_seconds",
+		Help:      "Unix time this replica's dispatcher last fired each schedule. Only set with SCHEDULE_FIRE_METRICS=true: one series per schedule.",
+	}, []string{"schedule_id"})
+
 	// Reaper metrics
 
 	ReaperRescuedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
@@ -53,6 +271,38 @@ var (
 		Buckets:   prometheus.DefBuckets,
 	})
 
+	ReaperTimeToRescue = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "reaper_time_to_rescue_seconds",
+		Help:      "Time between a stale job's last heartbeat and the reaper recovering it.",
+		Buckets:   []float64{30, 45, 60, 90, 120, 300, 600, 1800, 3600},
+	}, []string{"action"})
+
+	ReaperStaleClaimAge = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "scheduler",
+		Name:      "reaper_stale_claim_age_seconds",
+		Help:      "Time between a stale job being claimed and the reaper recovering it.",
+		Buckets:   []float64{30, 60, 120, 300, 600, 1800, 3600, 7200, 21600},
+	}, []string{"action"})
+
+	ReaperRescuedByWorkerTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "reaper_rescued_by_worker_total",
+		Help:      "Stale jobs recovered by the reaper, by the worker that held the lease.",
+	}, []string{"worker", "action"})
+
+	ReaperStuckPending = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "reaper_stuck_pending_jobs",
+		Help:      "Pending jobs unclaimed for longer than STUCK_PENDING_SEC past scheduled_at, by queue this replica serves.",
+	}, []string{"queue"})
+
+	ReaperStuckPendingAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "reaper_stuck_pending_oldest_seconds",
+		Help:      "Now minus the oldest scheduled_at among stuck pending jobs, by queue; 0 when none are stuck.",
+	}, []string{"queue"})
+
 	// Worker lifecycle
 
 	WorkerStartTime = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -67,6 +317,20 @@ var (
 		Help:      "Number of times the worker has shut down.",
 	})
 
+	SchedulerLeader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "scheduler",
+		Name:      "leader",
+		Help:      "1 while this replica holds the leader lock and runs the dispatcher and reaper; 0 otherwise.",
+	})
+
+	// Quota metrics
+
+	QuotaWarningsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "scheduler",
+		Name:      "quota_warnings_total",
+		Help:      "Soft-limit warnings raised when a user crosses the warning ratio of a quota.",
+	}, []string{"quota"})
+
 	// HTTP metrics
 
 	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -89,18 +353,64 @@ func Register() {
 		JobExecutionDuration,
 		JobsInFlight,
 		JobsCompletedTotal,
+		RetriesScheduledTotal,
+		RetryDelay,
+		RetriedJobsFinishedTotal,
+		JobSuccessAttempt,
+		PingChecksTotal,
+		CallbackDeliveriesTotal,
+		NotificationsTotal,
+		DigestsTotal,
+		NotificationsDroppedTotal,
+		ExecutorRequestsTotal,
+		ExecutorRequestDuration,
+		ExecutorResponseBytesDrained,
+		ExecutorResponsesTruncatedTotal,
+		ExecutorCircuitTransitionsTotal,
+		ExecutorCircuitsOpen,
+		ExecutorTLSUnverifiedTotal,
+		JobsDeferredTotal,
+		WorkerClaimsTotal,
+		WorkerJobsClaimedTotal,
+		WorkerSlots,
+		WorkerClaimBatchSize,
+		WorkerPrefetchTarget,
+		WorkerPrefetchedJobs,
+		WorkerPrefetchReleasedTotal,
+		JobsPending,
+		JobsOverdue,
+		SchedulingLag,
+		JobsEnqueuedTotal,
+		RetentionPurgedTotal,
+		SchedulesOverdue,
+		DispatcherCycleDuration,
+		DispatcherFiredPerCycle,
+		DispatcherFiredTotal,
+		ScheduleLastFireTime,
 		ReaperRescuedTotal,
 		ReaperCycleDuration,
+		ReaperTimeToRescue,
+		ReaperStaleClaimAge,
+		ReaperRescuedByWorkerTotal,
+		ReaperStuckPending,
+		ReaperStuckPendingAge,
 		WorkerStartTime,
 		WorkerShutdownsTotal,
+		SchedulerLeader,
+		QuotaWarningsTotal,
 		HTTPRequestDuration,
 		HTTPRequestsTotal,
 	)
 }
 
-func NewServer(addr string, checker *health.Checker) *http.Server {
+// NewServer serves metrics and health probes. A non-nil admin handler is mounted under
+// /admin/ for replica-local operations.
+func NewServer(addr string, checker *health.Checker, admin http.Handler) *http.Server {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
+	if admin != nil {
+		mux.Handle("/admin/", admin)
+	}
 
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		writeHealth(w, checker.Liveness(r.Context()))
@@ -114,7 +424,7 @@ func NewServer(addr string, checker *health.Checker) *http.Server {
 
 func writeHealth(w http.ResponseWriter, result health.HealthResult) {
 	w.Header().Set("Content-Type", "application/json")
-	if result.Status != "up" {
+	if !result.Ready() {
 		w.WriteHeader(http.StatusServiceUnavailable)
 	}
 	_ = json.NewEncoder(w).Encode(result)
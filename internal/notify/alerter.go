@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// Alerter delivers account alerts raised by the API — quota warnings and approval
+// requests — to the rules for their event. Like Notifier it is best-effort: alerts are
+// queued in memory, dropped when the queue is full and lost on shutdown.
+type Alerter struct {
+	rules    repository.NotificationRuleRepository
+	channels map[domain.NotificationChannel]Channel
+	queue    chan alert
+	logger   *slog.Logger
+}
+
+// alert is a rendered message for the userID's rules for event.
+type alert struct {
+	userID string
+	event  domain.NotificationEvent
+	msg    Message
+}
+
+func NewAlerter(
+	rules repository.NotificationRuleRepository,
+	channels map[domain.NotificationChannel]Channel,
+	logger *slog.Logger,
+) *Alerter {
+	return &Alerter{
+		rules:    rules,
+		channels: channels,
+		queue:    make(chan alert, queueSize),
+		logger:   logger.With("component", "alerter"),
+	}
+}
+
+// QuotaWarning counts the warning and queues it for delivery. It never blocks, so a slow
+// channel can't hold up the request that crossed the threshold.
+func (a *Alerter) QuotaWarning(ctx context.Context, w domain.QuotaWarning) {
+	metrics.QuotaWarningsTotal.WithLabelValues(string(w.Kind)).Inc()
+	a.enqueue(ctx, alert{userID: w.UserID, event: domain.NotifyQuotaWarning, msg: quotaWarningMessage(w)})
+}
+
+// ApprovalRequested queues an alert to the organization's approval.requested rules.
+func (a *Alerter) ApprovalRequested(ctx context.Context, r domain.ApprovalRequest) {
+	a.enqueue(ctx, alert{userID: r.OrgID, event: domain.NotifyApprovalRequested, msg: approvalRequestMessage(r)})
+}
+
+func (a *Alerter) enqueue(ctx context.Context, al alert) {
+	select {
+	case a.queue <- al:
+	default:
+		metrics.NotificationsDroppedTotal.Inc()
+		a.logger.WarnContext(ctx, "notification queue full, dropping alert", "user_id", al.userID, "event", al.event)
+	}
+}
+
+func (a *Alerter) Start(ctx context.Context) {
+	a.logger.InfoContext(ctx, "alerter started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.logger.InfoContext(ctx, "alerter shut down", "unsent", len(a.queue))
+			return
+		case al := <-a.queue:
+			a.handle(ctx, al)
+		}
+	}
+}
+
+func (a *Alerter) handle(ctx context.Context, al alert) {
+	rules, err := a.rules.ListByUser(ctx, al.userID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "list notification rules", "user_id", al.userID, "error", err)
+		return
+	}
+	for _, rule := range rules {
+		if rule.Event == al.event {
+			a.send(ctx, rule, al.msg)
+		}
+	}
+}
+
+func (a *Alerter) send(ctx context.Context, rule *domain.NotificationRule, msg Message) {
+	ch, ok := a.channels[rule.Channel]
+	if !ok {
+		a.logger.ErrorContext(ctx, "notification channel not configured", "rule_id", rule.ID, "channel", rule.Channel)
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := ch.Send(sendCtx, rule.Target, msg); err != nil {
+		metrics.NotificationsTotal.WithLabelValues(string(rule.Channel), "failed").Inc()
+		a.logger.WarnContext(ctx, "send alert", "rule_id", rule.ID, "event", rule.Event, "channel", rule.Channel, "error", err)
+		return
+	}
+	metrics.NotificationsTotal.WithLabelValues(string(rule.Channel), "sent").Inc()
+}
+
+func quotaWarningMessage(w domain.QuotaWarning) Message {
+	return Message{
+		Subject: fmt.Sprintf("Quota %s at %d of %d", w.Kind, w.Used, w.Limit),
+		Text: fmt.Sprintf("Your %s quota reached %d of %d at %s. Creates are rejected with 429 once it is exhausted; check GET /account/usage.\n",
+			w.Kind, w.Used, w.Limit, w.RaisedAt.UTC().Format(time.RFC3339)),
+	}
+}
+
+// maxListedApprovals caps the job IDs an approval request message lists.
+const maxListedApprovals = 10
+
+func approvalRequestMessage(r domain.ApprovalRequest) Message {
+	ids := r.JobIDs
+	more := ""
+	if len(ids) > maxListedApprovals {
+		more = fmt.Sprintf("\n…and %d more", len(ids)-maxListedApprovals)
+		ids = ids[:maxListedApprovals]
+	}
+	return Message{
+		Subject: fmt.Sprintf("%d job(s) await approval", len(r.JobIDs)),
+		Text: fmt.Sprintf("Member %s created %d job(s) in organization %s at %s that wait for an owner's approval:\n%s%s\nReview them with GET /orgs/%s/approvals.\n",
+			r.RequestedBy, len(r.JobIDs), r.OrgID, r.RequestedAt.UTC().Format(time.RFC3339),
+			strings.Join(ids, "\n"), more, r.OrgID),
+	}
+}
@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// digestBatchSize bounds the rules claimed at once, so a replica that dies mid-batch
+// loses few digests.
+const digestBatchSize = 100
+
+// Digester sends digest rules their summary once each period ends. A rule is claimed
+// before its digest is built, so like failure alerts a digest is sent at most once: one
+// that fails to send is lost. Digests with no runs are skipped.
+type Digester struct {
+	rules    repository.NotificationRuleRepository
+	stats    repository.StatsRepository
+	channels map[domain.NotificationChannel]Channel
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewDigester(
+	rules repository.NotificationRuleRepository,
+	stats repository.StatsRepository,
+	channels map[domain.NotificationChannel]Channel,
+	interval time.Duration,
+	logger *slog.Logger,
+) *Digester {
+	return &Digester{
+		rules:    rules,
+		stats:    stats,
+		channels: channels,
+		interval: interval,
+		logger:   logger.With("component", "digester"),
+	}
+}
+
+func (d *Digester) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.logger.InfoContext(ctx, "digester started", "interval", d.interval)
+
+	d.sweep(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.InfoContext(ctx, "digester shut down")
+			return
+		case <-ticker.C:
+			d.sweep(ctx)
+		}
+	}
+}
+
+// sweep sends the digests of every rule whose period has ended since its last one.
+func (d *Digester) sweep(ctx context.Context) {
+	now := time.Now()
+	for _, event := range []domain.NotificationEvent{domain.NotifyDigestDaily, domain.NotifyDigestWeekly} {
+		from, to := domain.DigestPeriod(event, now)
+		for ctx.Err() == nil {
+			rules, err := d.rules.ClaimDigests(ctx, event, to, digestBatchSize)
+			if err != nil {
+				d.logger.ErrorContext(ctx, "claim digest rules", "event", event, "error", err)
+				break
+			}
+			for _, rule := range rules {
+				d.send(ctx, &domain.Digest{Rule: rule, From: from, To: to})
+			}
+			if len(rules) < digestBatchSize {
+				break
+			}
+		}
+	}
+}
+
+func (d *Digester) send(ctx context.Context, digest *domain.Digest) {
+	rule := digest.Rule
+	ch, ok := d.channels[rule.Channel]
+	if !ok {
+		d.logger.ErrorContext(ctx, "notification channel not configured", "rule_id", rule.ID, "channel", rule.Channel)
+		return
+	}
+
+	schedules, err := d.stats.ScheduleDigests(ctx, rule.UserID, rule.ScheduleID, digest.From, digest.To)
+	if err != nil {
+		metrics.DigestsTotal.WithLabelValues(string(rule.Channel), "failed").Inc()
+		d.logger.ErrorContext(ctx, "summarise schedule runs", "rule_id", rule.ID, "error", err)
+		return
+	}
+	if len(schedules) == 0 {
+		metrics.DigestsTotal.WithLabelValues(string(rule.Channel), "empty").Inc()
+		return
+	}
+	digest.Schedules = schedules
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := ch.Send(sendCtx, rule.Target, digestMessage(digest)); err != nil {
+		metrics.DigestsTotal.WithLabelValues(string(rule.Channel), "failed").Inc()
+		d.logger.WarnContext(ctx, "send digest", "rule_id", rule.ID, "channel", rule.Channel, "error", err)
+		return
+	}
+	metrics.DigestsTotal.WithLabelValues(string(rule.Channel), "sent").Inc()
+}
+
+func digestMessage(d *domain.Digest) Message {
+	period := "Daily"
+	dates := d.From.Format("Jan 2")
+	if d.Rule.Event == domain.NotifyDigestWeekly {
+		period = "Weekly"
+		dates += " – " + d.To.AddDate(0, 0, -1).Format("Jan 2")
+	}
+	runs, _, failed := d.Totals()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Schedule runs from %s to %s (UTC).\n", d.From.Format(time.RFC3339), d.To.Format(time.RFC3339))
+	for _, s := range d.Schedules {
+		fmt.Fprintf(&b, "\n%s (%s)\n  Runs: %d, succeeded: %d, failed: %d\n", s.Name, s.ScheduleID, s.Runs, s.Succeeded, s.Failed)
+		if s.P95DurationMS != nil {
+			fmt.Fprintf(&b, "  p95 latency: %dms%s\n", *s.P95DurationMS, latencyTrend(*s.P95DurationMS, s.PrevP95DurationMS))
+		}
+	}
+	return Message{
+		Subject: fmt.Sprintf("%s digest for %s: %d runs, %d failed", period, dates, runs, failed),
+		Text:    b.String(),
+	}
+}
+
+// latencyTrend compares a p95 with the previous period's, if there was one.
+func latencyTrend(p95 int64, prev *int64) string {
+	if prev == nil || *prev == 0 {
+		return ""
+	}
+	change := float64(p95-*prev) / float64(*prev) * 100
+	return fmt.Sprintf(" (%+.0f%% vs %dms the period before)", change, *prev)
+}
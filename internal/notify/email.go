@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/resend/resend-go/v2"
+)
+
+// EmailChannel sends notifications through Resend. With no API key (ENV=local) it only
+// logs the message, so local dev never sends real email.
+type EmailChannel struct {
+	client *resend.Client
+	from   string
+	logger *slog.Logger
+}
+
+func NewEmailChannel(apiKey, from string, logger *slog.Logger) *EmailChannel {
+	c := &EmailChannel{from: from, logger: logger.With("component", "email_channel")}
+	if apiKey != "" {
+		c.client = resend.NewClient(apiKey)
+	}
+	return c
+}
+
+func (c *EmailChannel) Send(ctx context.Context, target string, msg Message) error {
+	if c.client == nil {
+		c.logger.InfoContext(ctx, "notification email (local dev)", "to", target, "subject", msg.Subject, "body", msg.Text)
+		return nil
+	}
+	_, err := c.client.Emails.SendWithContext(ctx, &resend.SendEmailRequest{
+		From:    c.from,
+		To:      []string{target},
+		Subject: msg.Subject,
+		Text:    msg.Text,
+	})
+	if err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// SendHTML sends an HTML message to one address on behalf of an email job. Resend drops
+// a repeat of idempotencyKey, so an attempt that is delivered twice sends one message.
+func (c *EmailChannel) SendHTML(ctx context.Context, to, subject, html, idempotencyKey string) error {
+	if c.client == nil {
+		c.logger.InfoContext(ctx, "job email (local dev)", "to", to, "subject", subject, "body", html)
+		return nil
+	}
+	_, err := c.client.Emails.SendWithOptions(ctx, &resend.SendEmailRequest{
+		From:    c.from,
+		To:      []string{to},
+		Subject: subject,
+		Html:    html,
+	}, &resend.SendEmailOptions{IdempotencyKey: idempotencyKey})
+	if err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// Check reports whether the Resend API is reachable, for the readiness probe. It makes
+// an unauthenticated request, so it costs no quota; in local dev there is nothing to reach.
+func (c *EmailChannel) Check(ctx context.Context) error {
+	if c.client == nil {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.client.BaseURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("reach resend: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("resend status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package notify alerts users about job failures over the channels their notification
+// rules name (email, Slack).
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+const (
+	queueSize   = 256
+	sendTimeout = 10 * time.Second
+)
+
+// Message is a rendered notification, independent of the channel that delivers it.
+type Message struct {
+	Subject string
+	Text    string
+}
+
+// Channel delivers a message to one target: an email address, a webhook URL.
+type Channel interface {
+	Send(ctx context.Context, target string, msg Message) error
+}
+
+// Notifier matches published job failures against their owners' notification rules and
+// delivers the resulting alerts. Delivery is best-effort and at-most-once: failures are
+// queued in memory, dropped when the queue is full and lost on shutdown.
+type Notifier struct {
+	rules    repository.NotificationRuleRepository
+	jobs     repository.JobRepository
+	channels map[domain.NotificationChannel]Channel
+	queue    chan domain.JobFailure
+	logger   *slog.Logger
+}
+
+func New(
+	rules repository.NotificationRuleRepository,
+	jobs repository.JobRepository,
+	channels map[domain.NotificationChannel]Channel,
+	logger *slog.Logger,
+) *Notifier {
+	return &Notifier{
+		rules:    rules,
+		jobs:     jobs,
+		channels: channels,
+		queue:    make(chan domain.JobFailure, queueSize),
+		logger:   logger.With("component", "notifier"),
+	}
+}
+
+// Publish queues a failure for evaluation. It never blocks, so a slow channel can't hold
+// up the worker or reaper that reported the failure.
+func (n *Notifier) Publish(ctx context.Context, f domain.JobFailure) {
+	select {
+	case n.queue <- f:
+	default:
+		metrics.NotificationsDroppedTotal.Inc()
+		n.logger.WarnContext(ctx, "notification queue full, dropping failure", "job_id", f.JobID)
+	}
+}
+
+func (n *Notifier) Start(ctx context.Context) {
+	n.logger.InfoContext(ctx, "notifier started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			n.logger.InfoContext(ctx, "notifier shut down", "unsent", len(n.queue))
+			return
+		case f := <-n.queue:
+			n.handle(ctx, f)
+		}
+	}
+}
+
+func (n *Notifier) handle(ctx context.Context, f domain.JobFailure) {
+	rules, err := n.rules.ListByUser(ctx, f.UserID)
+	if err != nil {
+		n.logger.ErrorContext(ctx, "list notification rules", "user_id", f.UserID, "error", err)
+		return
+	}
+
+	streak := -1 // loaded on first use; most failures only meet job.failed rules
+	for _, rule := range rules {
+		if !rule.Matches(f.ScheduleID) {
+			continue
+		}
+		var msg Message
+		switch rule.Event {
+		case domain.NotifyJobFailed:
+			msg = jobFailedMessage(f)
+		case domain.NotifyScheduleFailing:
+			if streak < 0 {
+				if streak, err = n.jobs.ConsecutiveFailures(ctx, *f.ScheduleID); err != nil {
+					n.logger.ErrorContext(ctx, "count consecutive failures", "schedule_id", *f.ScheduleID, "error", err)
+					streak = 0
+				}
+			}
+			// Fire once per streak, on the run that reaches the threshold.
+			if streak != rule.Threshold {
+				continue
+			}
+			msg = scheduleFailingMessage(f, streak)
+		default:
+			continue
+		}
+		n.send(ctx, rule, msg)
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, rule *domain.NotificationRule, msg Message) {
+	ch, ok := n.channels[rule.Channel]
+	if !ok {
+		n.logger.ErrorContext(ctx, "notification channel not configured", "rule_id", rule.ID, "channel", rule.Channel)
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := ch.Send(sendCtx, rule.Target, msg); err != nil {
+		metrics.NotificationsTotal.WithLabelValues(string(rule.Channel), "failed").Inc()
+		n.logger.WarnContext(ctx, "send notification", "rule_id", rule.ID, "channel", rule.Channel, "error", err)
+		return
+	}
+	metrics.NotificationsTotal.WithLabelValues(string(rule.Channel), "sent").Inc()
+}
+
+func jobFailedMessage(f domain.JobFailure) Message {
+	return Message{
+		Subject: fmt.Sprintf("Job %s failed", f.JobID),
+		Text:    fmt.Sprintf("Job %s failed permanently.\n%s", f.JobID, failureDetails(f)),
+	}
+}
+
+func scheduleFailingMessage(f domain.JobFailure, streak int) Message {
+	return Message{
+		Subject: fmt.Sprintf("Schedule %s failed %d runs in a row", *f.ScheduleID, streak),
+		Text: fmt.Sprintf("The last %d runs of schedule %s failed. Latest run: job %s.\n%s",
+			streak, *f.ScheduleID, f.JobID, failureDetails(f)),
+	}
+}
+
+func failureDetails(f domain.JobFailure) string {
+	return fmt.Sprintf("\nURL:    %s\nError:  %s\nFailed: %s\n", f.URL, f.Error, f.FailedAt.UTC().Format(time.RFC3339))
+}
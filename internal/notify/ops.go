@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+)
+
+// opsChannel labels operator alerts in the notification metrics.
+const opsChannel = "ops"
+
+// OpsAlerter sends operator alerts about the scheduler itself, rather than about a
+// user's jobs, to one fixed target configured on the deployment.
+type OpsAlerter struct {
+	channel Channel
+	target  string
+	logger  *slog.Logger
+}
+
+func NewOpsAlerter(channel Channel, target string, logger *slog.Logger) *OpsAlerter {
+	return &OpsAlerter{
+		channel: channel,
+		target:  target,
+		logger:  logger.With("component", "ops_alerter"),
+	}
+}
+
+// StuckPending reports a queue whose pending jobs stopped being claimed, or that
+// recovered when q.Count is 0.
+func (a *OpsAlerter) StuckPending(ctx context.Context, q domain.StuckQueue) {
+	a.send(ctx, stuckPendingMessage(q, time.Now()))
+}
+
+func (a *OpsAlerter) send(ctx context.Context, msg Message) {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := a.channel.Send(sendCtx, a.target, msg); err != nil {
+		metrics.NotificationsTotal.WithLabelValues(opsChannel, "failed").Inc()
+		a.logger.WarnContext(ctx, "send ops alert", "subject", msg.Subject, "error", err)
+		return
+	}
+	metrics.NotificationsTotal.WithLabelValues(opsChannel, "sent").Inc()
+}
+
+func stuckPendingMessage(q domain.StuckQueue, now time.Time) Message {
+	if q.Count == 0 {
+		return Message{
+			Subject: fmt.Sprintf("Queue %q recovered", q.Queue),
+			Text:    fmt.Sprintf("No pending jobs in queue %q are stuck past their scheduled time anymore.", q.Queue),
+		}
+	}
+	text := fmt.Sprintf("%d pending jobs in queue %q have not been claimed long after their scheduled time. Check that workers serving the queue are running.", q.Count, q.Queue)
+	if q.OldestDueAt != nil {
+		text += fmt.Sprintf("\nOldest was due: %s (%s ago)", q.OldestDueAt.UTC().Format(time.RFC3339), now.Sub(*q.OldestDueAt).Round(time.Second))
+	}
+	return Message{
+		Subject: fmt.Sprintf("Jobs stuck in queue %q", q.Queue),
+		Text:    text,
+	}
+}
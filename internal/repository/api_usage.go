@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type APIUsageRepository interface {
+	// AddBatch adds each entry's counters to its hourly bucket.
+	AddBatch(ctx context.Context, entries []domain.APIUsage) error
+	// ListByUser sums the user's counters per endpoint since the given time.
+	ListByUser(ctx context.Context, userID string, since time.Time) ([]domain.APIUsage, error)
+	// DeleteBefore drops buckets that started before cutoff.
+	DeleteBefore(ctx context.Context, cutoff time.Time) error
+}
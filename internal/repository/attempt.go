@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 )
@@ -12,12 +13,34 @@ type AttemptRepository interface {
 	// can close it with CompleteAttempt once the job finishes.
 	CreateAttempt(ctx context.Context, attempt *domain.JobAttempt) (*domain.JobAttempt, error)
 
-	// CompleteAttempt closes an open attempt record with the execution outcome.
-	// statusCode is nil when the HTTP request never received a response.
-	// errMsg is nil on success.
-	CompleteAttempt(ctx context.Context, id string, statusCode *int, errMsg *string, durationMS int64) error
+	// CompleteAttempt closes an open attempt record with the execution outcome
+	// carried on attempt (StatusCode, Error, DurationMS, RequestBytes,
+	// ResponseBytes, RemoteAddr).
+	// StatusCode is nil when the HTTP request never received a response.
+	// Error is nil on success.
+	CompleteAttempt(ctx context.Context, attempt *domain.JobAttempt) error
+
+	// DeleteAttempt removes an attempt a worker shutdown interrupted, so the job's rerun
+	// takes over its number and idempotency key.
+	DeleteAttempt(ctx context.Context, id string) error
 
 	// ListByJobID returns all attempts for a job, ordered by started_at ASC.
 	// Ownership is assumed to have been verified by the caller.
 	ListByJobID(ctx context.Context, jobID string) ([]*domain.JobAttempt, error)
+
+	// ListPageByJobID returns up to limit of a job's attempts ordered by attempt_num in
+	// order, continuing after attempt number after (nil = from the first in order).
+	// Ownership is assumed to have been verified by the caller.
+	ListPageByJobID(ctx context.Context, jobID string, order domain.SortOrder, after *int, limit int) ([]*domain.JobAttempt, error)
+
+	// CountByJobID counts a job's attempts.
+	CountByJobID(ctx context.Context, jobID string) (int64, error)
+
+	// GetByID returns one attempt of a job, or domain.ErrAttemptNotFound.
+	// Ownership is assumed to have been verified by the caller.
+	GetByID(ctx context.Context, jobID, attemptID string) (*domain.JobAttempt, error)
+
+	// SumEgressByUser totals request/response bytes of the user's attempts started at or
+	// after since, grouped by schedule and ordered by total bytes, largest first.
+	SumEgressByUser(ctx context.Context, userID string, since time.Time) ([]domain.EgressUsage, error)
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// DueCallback is a claimed callback together with the job it reports on.
+type DueCallback struct {
+	Callback *domain.Callback
+	Job      *domain.Job
+}
+
+type CallbackRepository interface {
+	// ClaimDue leases up to limit pending callbacks whose next attempt is due by pushing
+	// next_attempt_at forward by lease. A dispatcher that crashes mid-delivery leaves the
+	// callback to be picked up again once the lease expires.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]DueCallback, error)
+
+	// RecordAttempt appends attempt to the delivery log and moves the callback to status.
+	// nextAttemptAt is only used when status is pending.
+	RecordAttempt(ctx context.Context, attempt *domain.CallbackAttempt, status domain.CallbackStatus, nextAttemptAt time.Time) error
+
+	// ListByJobID returns a job's callbacks with their delivery logs.
+	// Ownership is assumed to have been verified by the caller.
+	ListByJobID(ctx context.Context, jobID string) ([]*domain.Callback, error)
+
+	// Redeliver resets every callback of a job to pending and due now, returning how
+	// many were reset. Attempt numbering continues from the existing log.
+	Redeliver(ctx context.Context, jobID string) (int64, error)
+}
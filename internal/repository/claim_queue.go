@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ClaimQueue hands due job IDs from the mover to workers in high-throughput mode. It only
+// carries hints: an ID may be delivered twice or lost, and the job row in Postgres decides
+// whether it is still claimable. Each job queue has its own list.
+type ClaimQueue interface {
+	Push(ctx context.Context, queue string, jobIDs []string) error
+	// Pop blocks up to timeout for the first ID in any of queues, checked in order, then
+	// returns up to max IDs from that same queue without waiting further. It returns no
+	// IDs and no error when the timeout passes.
+	Pop(ctx context.Context, queues []string, max int, timeout time.Duration) (queue string, jobIDs []string, err error)
+}
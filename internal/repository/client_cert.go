@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type ClientCertRepository interface {
+	// Create returns domain.ErrClientCertNameConflict if the user already has a
+	// certificate with that name.
+	Create(ctx context.Context, c *domain.ClientCertificate) (*domain.ClientCertificate, error)
+	GetByID(ctx context.Context, id, userID string) (*domain.ClientCertificate, error)
+	// ListByUser returns the user's certificates by name, without their PEM.
+	ListByUser(ctx context.Context, userID string) ([]*domain.ClientCertificate, error)
+	// Delete returns domain.ErrClientCertInUse while pending, running, blocked or paused
+	// jobs, or any schedule, reference the certificate.
+	Delete(ctx context.Context, id, userID string) error
+}
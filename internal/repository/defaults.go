@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type DefaultsRepository interface {
+	// Get returns the user's stored defaults; a user who never set any gets empty defaults.
+	Get(ctx context.Context, userID string) (*domain.JobDefaults, error)
+	Upsert(ctx context.Context, userID string, d *domain.JobDefaults) error
+}
@@ -8,32 +8,93 @@ import (
 )
 
 type ListJobsInput struct {
-	UserID     string
-	Status     domain.Status // empty = all statuses
-	CursorTime *time.Time    // nil = first page
-	CursorID   string        // used only when CursorTime is non-nil
-	Limit      int
+	UserID          string
+	Status          domain.Status    // empty = all statuses
+	RequestID       string           // empty = no filter
+	URLContains     string           // case-insensitive substring; empty = no filter
+	IdempotencyKey  string           // empty = no filter
+	ScheduleID      string           // empty = no filter
+	ScheduledAfter  *time.Time       // inclusive; nil = no bound
+	ScheduledBefore *time.Time       // exclusive; nil = no bound
+	Order           domain.SortOrder // by (scheduled_at, id); "" = SortDesc
+	CursorTime      *time.Time       // nil = first page
+	CursorID        string           // used only when CursorTime is non-nil
+	Limit           int
 }
 
 // UseCase depends on interface, not concrete implementation.
 // This way we get: 1) can swap DB later without touching usecase 2) We can pass a mock implementation of interface in tests
 type JobRepository interface {
 	Create(ctx context.Context, job *domain.Job) (*domain.Job, error)
+	// CreateBatch inserts jobs atomically. The result is index-aligned with jobs, with nil
+	// for each job whose idempotency key already exists.
+	CreateBatch(ctx context.Context, jobs []*domain.Job) ([]*domain.Job, error)
 	GetByID(ctx context.Context, jobID, userID string) (*domain.Job, error)
 	ListJobs(ctx context.Context, input ListJobsInput) ([]*domain.Job, error)
-	Cancel(ctx context.Context, jobID, userID string) error
+	// CountJobs counts the jobs matching input's filters, ignoring its cursor and limit.
+	CountJobs(ctx context.Context, input ListJobsInput) (int64, error)
+	// Cancel cancels a pending or paused job. For a running job it only records the
+	// request and returns requested = true; the worker aborts the execution.
+	Cancel(ctx context.Context, jobID, userID string) (requested bool, err error)
+	// SetPaused moves a job between pending and paused. Returns ErrJobNotPausable or
+	// ErrJobNotPaused when the job is not in the expected state.
+	SetPaused(ctx context.Context, jobID, userID string, paused bool) error
+	// Review approves or rejects a job awaiting approval, recording reviewerID. Approval
+	// releases it as if it had just been created; rejection cancels it. Returns
+	// ErrJobNotAwaitingApproval for a job in any other status.
+	Review(ctx context.Context, jobID, userID, reviewerID string, approve bool) (*domain.Job, error)
 
 	// what does the scheduler worker need? Worker to poll, then claim and process the batch
 	// Reaper process to find all failed jobs and re-schedule them for another attempt if a retry is possible
-	Claim(ctx context.Context, workerID string, limit int) ([]*domain.Job, error)
-	UpdateHeartbeat(ctx context.Context, jobID string) error
+	// Claim always takes higher priority bands first; policy orders jobs within a band.
+	// Only jobs in one of queues are claimed, and jobs whose user or target host is at its
+	// concurrency limit are skipped.
+	Claim(ctx context.Context, workerID string, queues []string, limit int, policy domain.ClaimPolicy, limits domain.ConcurrencyLimits) ([]*domain.Job, error)
+	// EnqueueDue and ClaimByIDs back the Redis claim queue: the mover marks due jobs as
+	// queued and pushes their IDs to the list of each job's queue, and workers claim the
+	// IDs they pop from the lists of the queues they serve.
+	EnqueueDue(ctx context.Context, limit int, policy domain.ClaimPolicy, redeliverAfter time.Duration) (map[string][]string, error)
+	ClaimByIDs(ctx context.Context, workerID string, ids []string) ([]*domain.Job, error)
+	// Release returns jobs the worker claimed but never started to pending, without
+	// counting an attempt. Jobs no longer running under workerID are left alone.
+	Release(ctx context.Context, workerID string, ids []string) error
+	// UpdateHeartbeat extends the lease of a running job and reports whether the user has
+	// asked for it to be cancelled.
+	UpdateHeartbeat(ctx context.Context, jobID string) (cancelRequested bool, err error)
+	MarkCancelled(ctx context.Context, jobID string) error
 	Complete(ctx context.Context, jobID string) error
 	Fail(ctx context.Context, jobID string, lastError string) error
 	Reschedule(ctx context.Context, jobID string, lastError string, retryAt time.Time) error
+	// Defer puts a running job back to pending at retryAt without counting an attempt,
+	// for a job the worker chose not to run or whose run a shutdown aborted.
+	Defer(ctx context.Context, jobID string, reason string, retryAt time.Time) error
 
-	// Reaper methods — recover jobs from crashed workers
-	RescheduleStale(ctx context.Context, staleCutoff time.Time, limit int) (int, error)
-	FailStale(ctx context.Context, staleCutoff time.Time, limit int) (int, error)
+	// Reaper methods — recover jobs from crashed workers.
+	// Both return the recovered jobs with the lease state they had before recovery.
+	RescheduleStale(ctx context.Context, staleCutoff time.Time, limit int) ([]domain.ReapedJob, error)
+	FailStale(ctx context.Context, staleCutoff time.Time, limit int) ([]domain.ReapedJob, error)
+	CancelStale(ctx context.Context, staleCutoff time.Time, limit int) ([]domain.ReapedJob, error)
 
-	ListByScheduleID(ctx context.Context, scheduleID string, limit int, cursorTime *time.Time, cursorID string) ([]*domain.Job, error)
+	// ExpirePending moves pending jobs past their expires_at to expired and returns how many.
+	ExpirePending(ctx context.Context, limit int) (int64, error)
+
+	// QueueStats reports the current backlog of pending jobs in queues, or in every queue
+	// when queues is nil — for the stats collector and the autoscaling signals.
+	QueueStats(ctx context.Context, queues []string) (domain.QueueStats, error)
+	// StuckPending counts pending jobs due before cutoff in each of queues. Queues with
+	// none are absent from the result.
+	StuckPending(ctx context.Context, cutoff time.Time, queues []string) ([]domain.StuckQueue, error)
+
+	// SummarizeBySchedule returns run summaries for the user's schedules, keyed by schedule ID.
+	// Schedules that have never fired are absent from the map.
+	SummarizeBySchedule(ctx context.Context, userID string) (map[string]*domain.ScheduleRunSummary, error)
+	// ConsecutiveFailures counts the schedule's failed jobs since its most recent completed one.
+	ConsecutiveFailures(ctx context.Context, scheduleID string) (int, error)
+
+	// Search returns the user's jobs whose ID or idempotency key starts with q, or whose
+	// URL contains q (case-insensitive), newest first.
+	Search(ctx context.Context, userID, q string, limit int) ([]*domain.Job, error)
+
+	ListByScheduleID(ctx context.Context, scheduleID string, order domain.SortOrder, limit int, cursorTime *time.Time, cursorID string) ([]*domain.Job, error)
+	CountByScheduleID(ctx context.Context, scheduleID string) (int64, error)
 }
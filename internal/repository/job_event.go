@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// JobEventListener streams job status transitions as they are committed.
+type JobEventListener interface {
+	// ListenStatusChanges blocks, calling fn for every transition, until ctx is done or the
+	// underlying connection fails. Transitions committed while not listening are not replayed.
+	ListenStatusChanges(ctx context.Context, fn func(domain.JobStatusEvent)) error
+	// ListenReady blocks like ListenStatusChanges, calling fn whenever a job may have become
+	// claimable. Calls are hints: several jobs can share one call, and fn may find no work.
+	ListenReady(ctx context.Context, fn func()) error
+}
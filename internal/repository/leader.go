@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// LeaderLock elects one leader among the replicas sharing a lock.
+type LeaderLock interface {
+	// Lead waits until this replica holds the lock, then calls fn with a context that is
+	// cancelled if the lock is lost, and releases the lock once fn returns. It returns an
+	// error when ctx is done before the lock is taken or when leadership is lost.
+	Lead(ctx context.Context, fn func(ctx context.Context)) error
+}
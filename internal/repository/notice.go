@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type NoticeRepository interface {
+	Create(ctx context.Context, n *domain.Notice) (*domain.Notice, error)
+	// ListCurrent returns notices that have not ended by now — active and upcoming —
+	// ordered by start time.
+	ListCurrent(ctx context.Context, now time.Time) ([]*domain.Notice, error)
+	// ListAll returns every notice, newest first.
+	ListAll(ctx context.Context) ([]*domain.Notice, error)
+	Delete(ctx context.Context, id string) error
+}
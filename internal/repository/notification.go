@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type NotificationRuleRepository interface {
+	Create(ctx context.Context, rule *domain.NotificationRule) (*domain.NotificationRule, error)
+	// ListByUser returns the user's rules, oldest first.
+	ListByUser(ctx context.Context, userID string) ([]*domain.NotificationRule, error)
+	Delete(ctx context.Context, id, userID string) error
+	// ClaimDigests moves up to limit rules of a digest event whose last digest ended before
+	// periodEnd to periodEnd and returns them, so each period is claimed by one caller.
+	ClaimDigests(ctx context.Context, event domain.NotificationEvent, periodEnd time.Time, limit int) ([]*domain.NotificationRule, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type OrgRepository interface {
+	// Create creates the organization and its account, with ownerID as its first owner.
+	Create(ctx context.Context, name, ownerID string) (*domain.Org, error)
+	// ListByMember returns the organizations userID belongs to, oldest first.
+	ListByMember(ctx context.Context, userID string) ([]domain.OrgMembership, error)
+	// SetKeyRef sets or, with nil, clears the organization's KMS key reference.
+	SetKeyRef(ctx context.Context, orgID string, keyRef *string) error
+	SetRequireApproval(ctx context.Context, orgID string, on bool) error
+	// ApprovalRequired reports whether jobs userID creates in orgID must await approval:
+	// the organization requires it and userID is not one of its owners.
+	ApprovalRequired(ctx context.Context, orgID, userID string) (bool, error)
+	// Role returns userID's role in orgID, or ErrOrgNotFound if they are not a member.
+	Role(ctx context.Context, orgID, userID string) (domain.OrgRole, error)
+	ListMembers(ctx context.Context, orgID string) ([]*domain.OrgMember, error)
+	// SetRole and RemoveMember return ErrLastOrgOwner rather than leave the organization
+	// without an owner.
+	SetRole(ctx context.Context, orgID, userID string, role domain.OrgRole) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+
+	CreateInvitation(ctx context.Context, inv *domain.OrgInvitation, tokenHash string) (*domain.OrgInvitation, error)
+	// ListInvitations returns the organization's invitations that are neither accepted
+	// nor expired, newest first.
+	ListInvitations(ctx context.Context, orgID string) ([]*domain.OrgInvitation, error)
+	DeleteInvitation(ctx context.Context, orgID, id string) error
+	// AcceptInvitation adds userID to the organization with the invitation's role and
+	// uses the invitation up. Returns ErrInvitationNotFound for an unknown, accepted or
+	// expired token.
+	AcceptInvitation(ctx context.Context, tokenHash, userID string) (*domain.OrgMember, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// PingCheck is the outcome of one ping-mode job execution.
+type PingCheck struct {
+	JobID      string
+	ScheduleID string
+	At         time.Time
+	OK         bool
+	DurationMS int64
+	Error      *string
+}
+
+type PingRepository interface {
+	// RecordCheck atomically folds a check into the schedule's hourly rollup, opens or
+	// closes its downtime incident, and deletes the ping job row.
+	RecordCheck(ctx context.Context, check PingCheck) error
+
+	// GetUptime aggregates rollups and incidents since `since`.
+	// Ownership is assumed to have been verified by the caller.
+	GetUptime(ctx context.Context, scheduleID string, since time.Time) (*domain.ScheduleUptime, error)
+}
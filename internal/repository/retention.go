@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RetentionRepository purges execution history past its retention window. Each call
+// removes at most limit rows and returns how many it removed; with archive set the rows
+// are copied to the archive tables in the same statement.
+type RetentionRepository interface {
+	// PurgeAttempts removes finished attempts that completed before cutoff.
+	PurgeAttempts(ctx context.Context, cutoff time.Time, limit int, archive bool) (int64, error)
+	// PurgeJobs removes terminal jobs last updated before cutoff, with their attempts.
+	PurgeJobs(ctx context.Context, cutoff time.Time, limit int, archive bool) (int64, error)
+}
@@ -9,17 +9,41 @@ import (
 
 type ListSchedulesInput struct {
 	UserID     string
-	CursorTime *time.Time // cursor on (created_at DESC, id DESC)
+	Order      domain.SortOrder // by (created_at, id); "" = SortDesc
+	CursorTime *time.Time       // cursor on (created_at, id) in Order
 	CursorID   string
 	Limit      int
 }
 
+// FirePlan is the dispatcher's decision for one due schedule.
+type FirePlan struct {
+	NextRunAt time.Time     // the schedule's next nominal fire time
+	Delay     time.Duration // added to now for the fired job's scheduled_at (jitter)
+}
+
 type ScheduleRepository interface {
 	Create(ctx context.Context, s *domain.Schedule) (*domain.Schedule, error)
 	GetByID(ctx context.Context, id, userID string) (*domain.Schedule, error)
 	List(ctx context.Context, input ListSchedulesInput) ([]*domain.Schedule, error)
+	Count(ctx context.Context, userID string) (int64, error)
+	// SearchByName returns the user's schedules whose name contains q (case-insensitive),
+	// prefix matches first.
+	SearchByName(ctx context.Context, userID, q string, limit int) ([]*domain.Schedule, error)
 	SetPaused(ctx context.Context, id, userID string, paused bool) error
+	// Update overwrites the schedule's editable fields and next_run_at from s, recording a
+	// revision with the given action. Returns ErrScheduleNotFound if s is not the user's.
+	Update(ctx context.Context, s *domain.Schedule, action domain.RevisionAction) (*domain.Schedule, error)
+	// Delete removes the schedule and cancels its pending jobs; job history is kept.
 	Delete(ctx context.Context, id, userID string) error
-	// Atomic: claim due schedules, create jobs, advance next_run_at — all in one tx
-	ClaimAndFire(ctx context.Context, limit int, computeNext func(*domain.Schedule) time.Time) ([]*domain.Job, error)
+	// Atomic: claim due schedules, create jobs, advance next_run_at — all in one tx.
+	// plan decides each due schedule's next run time and its job's start delay.
+	ClaimAndFire(ctx context.Context, limit int, plan func(*domain.Schedule) FirePlan) ([]*domain.Job, error)
+	// CountOverdue counts active schedules whose next_run_at is before cutoff, across all
+	// users — for the stats collector.
+	CountOverdue(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// Create, SetPaused and Update each record a revision in the same transaction as the
+	// mutation. Ownership is assumed to have been verified by the caller.
+	ListRevisions(ctx context.Context, scheduleID string, limit int) ([]*domain.ScheduleRevision, error)
+	GetRevision(ctx context.Context, scheduleID string, revision int) (*domain.ScheduleRevision, error)
 }
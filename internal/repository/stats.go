@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type StatsRepository interface {
+	// JobStats aggregates the user's jobs scheduled between since and now, and their
+	// attempts. A non-nil scheduleID restricts it to the jobs that schedule fired.
+	JobStats(ctx context.Context, userID string, scheduleID *string, since time.Time) (*domain.JobStats, error)
+	// ScheduleDigests summarises the runs the user's schedules fired between from and to,
+	// with their latency over the equally long period before. A non-nil scheduleID
+	// restricts it to that schedule. Schedules without finished runs are left out.
+	ScheduleDigests(ctx context.Context, userID string, scheduleID *string, from, to time.Time) ([]domain.ScheduleDigest, error)
+}
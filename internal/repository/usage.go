@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type UsageRepository interface {
+	// GetUsage counts the user's pending jobs, schedules, and attempts started since `since`.
+	GetUsage(ctx context.Context, userID string, since time.Time) (*domain.Usage, error)
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// NewAdminHandler serves the replica-local admin endpoints used by deployment tooling:
+//
+//	POST /admin/drain        stop claiming new jobs; responds with the drain status
+//	GET  /admin/drain        drain status — tooling polls until in_flight reaches 0
+//	GET  /admin/autoscaling  AutoscalingSignals, for KEDA's metrics-api scaler
+//	POST /admin/reload       re-read the configuration through reload, as SIGHUP does;
+//	                         responds with the worker settings now in effect
+//
+// It is mounted on the metrics port, which is cluster-internal and never routed publicly
+// but reachable by monitoring and the autoscaler. The POST routes change the replica, so
+// they also need "Authorization: Bearer <token>"; with no token configured they answer 403.
+func NewAdminHandler(worker *Worker, reload func(context.Context) error, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/drain", requireAdminToken(token, func(w http.ResponseWriter, r *http.Request) {
+		writeDrainStatus(w, worker.Drain(r.Context()))
+	}))
+	mux.HandleFunc("GET /admin/drain", func(w http.ResponseWriter, _ *http.Request) {
+		writeDrainStatus(w, worker.DrainStatus())
+	})
+	mux.HandleFunc("GET /admin/autoscaling", func(w http.ResponseWriter, r *http.Request) {
+		signals, err := worker.AutoscalingSignals(r.Context())
+		if err != nil {
+			worker.logger.ErrorContext(r.Context(), "read autoscaling signals", "error", err)
+			http.Error(w, "backlog unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(signals)
+	})
+	mux.HandleFunc("POST /admin/reload", requireAdminToken(token, func(w http.ResponseWriter, r *http.Request) {
+		if err := reload(r.Context()); err != nil {
+			// The previous settings stay in effect.
+			http.Error(w, "reload failed: "+err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		s := worker.Settings()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Concurrency           int   `json:"concurrency"`
+			PollIntervalMS        int64 `json:"poll_interval_ms"`
+			UserMaxConcurrentJobs int   `json:"user_max_concurrent_jobs"`
+			HostMaxConcurrentJobs int   `json:"host_max_concurrent_jobs"`
+		}{s.Concurrency, s.PollInterval.Milliseconds(), s.Limits.PerUser, s.Limits.PerHost})
+	}))
+	return mux
+}
+
+// requireAdminToken lets a request through to next only if it carries token as a bearer
+// token. An empty token refuses every request.
+func requireAdminToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.Error(w, "admin endpoints disabled: SCHEDULER_ADMIN_TOKEN is not set", http.StatusForbidden)
+			return
+		}
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func writeDrainStatus(w http.ResponseWriter, status DrainStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireAdminToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{"no token configured", "", "Bearer anything", http.StatusForbidden},
+		{"missing header", "s3cret-admin-token", "", http.StatusUnauthorized},
+		{"wrong token", "s3cret-admin-token", "Bearer nope", http.StatusUnauthorized},
+		{"not a bearer token", "s3cret-admin-token", "s3cret-admin-token", http.StatusUnauthorized},
+		{"right token", "s3cret-admin-token", "Bearer s3cret-admin-token", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := requireAdminToken(tt.token, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodPost, "/admin/drain", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			h(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
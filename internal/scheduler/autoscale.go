@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// claimRateWindow is how far back the claim rate reported to autoscalers looks.
+const claimRateWindow = time.Minute
+
+// AutoscalingSignals is what an autoscaler (KEDA's metrics-api scaler, an HPA through a
+// metrics adapter) needs to size the scheduler deployment. Backlog figures cover the
+// queues this replica serves and are the same on every replica serving them; the rest
+// are this replica's own.
+type AutoscalingSignals struct {
+	Queues           []string `json:"queues"`
+	DueJobs          int64    `json:"due_jobs"`           // pending and due, not yet claimed
+	OldestDueSeconds float64  `json:"oldest_due_seconds"` // 0 when nothing is due
+	ClaimRate        float64  `json:"claim_rate"`         // jobs claimed per second over the last minute
+	InFlight         int      `json:"in_flight"`
+	Slots            int      `json:"slots"`      // WORKER_COUNT
+	Saturation       float64  `json:"saturation"` // in_flight / slots
+	Draining         bool     `json:"draining"`
+}
+
+// AutoscalingSignals reads the due backlog of the worker's queues and reports it with
+// the worker's own claim rate and slot usage.
+func (w *Worker) AutoscalingSignals(ctx context.Context) (AutoscalingSignals, error) {
+	stats, err := w.repo.QueueStats(ctx, w.queues)
+	if err != nil {
+		return AutoscalingSignals{}, err
+	}
+	now := time.Now()
+	drain := w.DrainStatus()
+	slots := w.Settings().Concurrency
+
+	s := AutoscalingSignals{
+		Queues:     w.queues,
+		DueJobs:    stats.Overdue,
+		ClaimRate:  w.claims.rate(now),
+		InFlight:   drain.InFlight,
+		Slots:      slots,
+		Saturation: float64(drain.InFlight) / float64(slots),
+		Draining:   drain.Draining,
+	}
+	if stats.OldestDueAt != nil {
+		s.OldestDueSeconds = max(now.Sub(*stats.OldestDueAt).Seconds(), 0)
+	}
+	return s, nil
+}
+
+// claimRate counts claimed jobs in one-second buckets over claimRateWindow.
+type claimRate struct {
+	mu      sync.Mutex
+	buckets [int(claimRateWindow / time.Second)]struct {
+		sec   int64
+		count int
+	}
+}
+
+func (r *claimRate) add(n int, now time.Time) {
+	if n == 0 {
+		return
+	}
+	sec := now.Unix()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := &r.buckets[sec%int64(len(r.buckets))]
+	if b.sec != sec {
+		b.sec, b.count = sec, 0
+	}
+	b.count += n
+}
+
+// rate is the per-second average over the window, ignoring buckets left over from
+// earlier laps of the ring.
+func (r *claimRate) rate(now time.Time) float64 {
+	oldest := now.Unix() - int64(len(r.buckets)) + 1
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var total int
+	for _, b := range r.buckets {
+		if b.sec >= oldest {
+			total += b.count
+		}
+	}
+	return float64(total) / claimRateWindow.Seconds()
+}
@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+)
+
+const (
+	// probeWait is how long jobs to a half-open host are deferred while its probe runs.
+	probeWait = 5 * time.Second
+	// probeTimeout frees a probe slot whose outcome was never recorded, e.g. because the
+	// attempt record couldn't be created. It matches the HTTP client's safety-net timeout.
+	probeTimeout = 5 * time.Minute
+)
+
+// CircuitBreaker configures the executor's per-host circuit breaker. After Threshold
+// consecutive failed requests to a host, jobs to it are deferred for Cooldown instead of
+// tying up a slot until they time out; then a single probe request decides whether the
+// circuit closes or stays open for another Cooldown. Threshold 0 disables the breaker.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+// circuits tracks the breaker state of each host this process has seen failing. Hosts
+// whose requests succeed are forgotten.
+type circuits struct {
+	cfg CircuitBreaker
+
+	mu    sync.Mutex
+	hosts map[string]*circuit
+}
+
+type circuit struct {
+	failures  int       // consecutive
+	openUntil time.Time // zero while closed; half-open once passed
+	probeAt   time.Time // zero unless a half-open probe is in flight
+}
+
+func newCircuits(cfg CircuitBreaker) *circuits {
+	return &circuits{cfg: cfg, hosts: make(map[string]*circuit)}
+}
+
+// allow reports whether a request to host may be sent now, and if not, when to try again.
+// Once a circuit's cooldown has passed the first caller becomes its probe.
+func (c *circuits) allow(host string, now time.Time) (retryAt time.Time, ok bool) {
+	if c.cfg.Threshold == 0 {
+		return time.Time{}, true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := c.hosts[host]
+	switch {
+	case h == nil || h.openUntil.IsZero():
+		return time.Time{}, true
+	case now.Before(h.openUntil):
+		return h.openUntil, false
+	case !h.probeAt.IsZero() && now.Sub(h.probeAt) < probeTimeout:
+		return now.Add(min(probeWait, c.cfg.Cooldown)), false
+	}
+	h.probeAt = now
+	metrics.ExecutorCircuitTransitionsTotal.WithLabelValues("half_open").Inc()
+	return time.Time{}, true
+}
+
+// record updates host's circuit with the outcome of a request and reports whether that
+// opened it. A failure while half-open reopens the circuit; any success closes it.
+func (c *circuits) record(host string, failed bool, now time.Time) (opened bool) {
+	if c.cfg.Threshold == 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := c.hosts[host]
+	if !failed {
+		if h != nil && !h.openUntil.IsZero() {
+			metrics.ExecutorCircuitTransitionsTotal.WithLabelValues("closed").Inc()
+			metrics.ExecutorCircuitsOpen.Dec()
+		}
+		delete(c.hosts, host)
+		return false
+	}
+
+	if h == nil {
+		h = &circuit{}
+		c.hosts[host] = h
+	}
+	h.failures++
+	probe := !h.probeAt.IsZero()
+	if !probe && (!h.openUntil.IsZero() || h.failures < c.cfg.Threshold) {
+		return false
+	}
+	if h.openUntil.IsZero() {
+		metrics.ExecutorCircuitsOpen.Inc()
+	}
+	h.openUntil = now.Add(c.cfg.Cooldown)
+	h.probeAt = time.Time{}
+	metrics.ExecutorCircuitTransitionsTotal.WithLabelValues("open").Inc()
+	return true
+}
+
+// abandon frees host's probe slot without an outcome, for a request cut short by its
+// caller rather than by the target.
+func (c *circuits) abandon(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if h := c.hosts[host]; h != nil {
+		h.probeAt = time.Time{}
+	}
+}
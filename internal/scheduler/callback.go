@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+const (
+	callbackTimeout = 10 * time.Second
+	// callbackLease must outlast a delivery so a live dispatcher never loses its claim.
+	callbackLease      = time.Minute
+	callbackBatchSize  = 50
+	callbackRetryBase  = 30 * time.Second
+	callbackRetryLimit = time.Hour
+)
+
+// errInvalidPayload marks a payload that failed schema validation. Retrying cannot fix
+// it, so the callback is failed immediately.
+var errInvalidPayload = errors.New("payload does not match event schema")
+
+// CallbackDispatcher delivers job completion callbacks from the job_callbacks outbox.
+// Delivery is at-least-once; receivers deduplicate on the X-Callback-ID header.
+type CallbackDispatcher struct {
+	repo     repository.CallbackRepository
+	client   *http.Client
+	logger   *slog.Logger
+	interval time.Duration
+}
+
+func NewCallbackDispatcher(repo repository.CallbackRepository, logger *slog.Logger, interval time.Duration) *CallbackDispatcher {
+	return &CallbackDispatcher{
+		repo:     repo,
+		client:   &http.Client{Timeout: callbackTimeout},
+		logger:   logger.With("component", "callback_dispatcher"),
+		interval: interval,
+	}
+}
+
+func (d *CallbackDispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.logger.InfoContext(ctx, "callback dispatcher started", "interval", d.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.InfoContext(ctx, "callback dispatcher shut down")
+			return
+		case <-ticker.C:
+			d.dispatch(ctx)
+		}
+	}
+}
+
+func (d *CallbackDispatcher) dispatch(ctx context.Context) {
+	due, err := d.repo.ClaimDue(ctx, callbackBatchSize, callbackLease)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "claim callbacks", "error", err)
+		return
+	}
+	for _, dc := range due {
+		d.deliver(ctx, dc.Callback, dc.Job)
+	}
+}
+
+func (d *CallbackDispatcher) deliver(ctx context.Context, cb *domain.Callback, job *domain.Job) {
+	attempt := &domain.CallbackAttempt{
+		CallbackID: cb.ID,
+		AttemptNum: cb.Attempts + 1,
+	}
+
+	start := time.Now()
+	statusCode, snippet, err := d.post(ctx, cb, job)
+	attempt.DurationMS = time.Since(start).Milliseconds()
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+		attempt.ResponseSnippet = &snippet
+	}
+	if err == nil && (statusCode < 200 || statusCode > 299) {
+		err = fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+
+	status := domain.CallbackStatusDelivered
+	var nextAttemptAt time.Time
+	switch {
+	case err == nil:
+		nextAttemptAt = time.Now()
+	case errors.Is(err, errInvalidPayload):
+		d.logger.ErrorContext(ctx, "callback payload failed schema validation", "callback_id", cb.ID, "event", cb.Event, "error", err)
+		status = domain.CallbackStatusFailed
+		nextAttemptAt = time.Now()
+	case attempt.AttemptNum >= domain.MaxCallbackAttempts:
+		status = domain.CallbackStatusFailed
+		nextAttemptAt = time.Now()
+	default:
+		status = domain.CallbackStatusPending
+		nextAttemptAt = time.Now().Add(callbackRetryDelay(attempt.AttemptNum))
+	}
+	if err != nil {
+		errMsg := err.Error()
+		attempt.Error = &errMsg
+	}
+	metrics.CallbackDeliveriesTotal.WithLabelValues(string(status)).Inc()
+
+	if err := d.repo.RecordAttempt(ctx, attempt, status, nextAttemptAt); err != nil {
+		// The lease expires and the callback is retried; receivers dedupe on X-Callback-ID.
+		d.logger.ErrorContext(ctx, "record callback attempt", "callback_id", cb.ID, "job_id", cb.JobID, "error", err)
+		return
+	}
+	if status != domain.CallbackStatusDelivered {
+		d.logger.WarnContext(ctx, "callback delivery failed",
+			"callback_id", cb.ID,
+			"job_id", cb.JobID,
+			"attempt", attempt.AttemptNum,
+			"status", status,
+			"error", err,
+		)
+	}
+}
+
+// post sends the callback and returns the response status code and the start of its body.
+func (d *CallbackDispatcher) post(ctx context.Context, cb *domain.Callback, job *domain.Job) (int, string, error) {
+	body, err := json.Marshal(domain.NewCallbackPayload(cb, job))
+	if err != nil {
+		return 0, "", fmt.Errorf("marshal payload: %w", err)
+	}
+	// Never send a payload that breaks the published contract.
+	if err := eventschema.ValidatePayload(cb.Event, body); err != nil {
+		return 0, "", fmt.Errorf("%w: %w", errInvalidPayload, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cb.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Callback-ID", cb.ID)
+	req.Header.Set("X-Callback-Event", string(cb.Event))
+	if job.RequestID != nil {
+		req.Header.Set("X-Origin-Request-ID", *job.RequestID)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("do request: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body) // drain so the connection can be reused
+		_ = resp.Body.Close()
+	}()
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, domain.CallbackResponseSnippetBytes))
+	return resp.StatusCode, string(snippet), nil
+}
+
+// callbackRetryDelay doubles from callbackRetryBase per attempt, capped at callbackRetryLimit.
+func callbackRetryDelay(attemptNum int) time.Duration {
+	delay := callbackRetryBase << (attemptNum - 1)
+	return min(delay, callbackRetryLimit)
+}
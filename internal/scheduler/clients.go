@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// maxRoutedClients bounds how many proxied or mTLS clients, each with its own connection
+// pool, an executor keeps.
+const maxRoutedClients = 64
+
+// route is what sets a job's client apart from the shared direct one. A transport picks
+// its proxy and TLS settings per connection, not per request, so jobs that differ in any
+// of them need their own client.
+type route struct {
+	proxy    string
+	certID   string
+	caSum    string // hex SHA-256 of the CA bundle
+	insecure bool
+}
+
+// routedClients keeps one client per route, so jobs sharing a proxy or certificate reuse
+// its connections.
+type routedClients struct {
+	mu      sync.Mutex
+	clients map[route]*http.Client
+}
+
+func newRoutedClients() *routedClients {
+	return &routedClients{clients: make(map[route]*http.Client)}
+}
+
+// get returns the client for r, building it with build the first time.
+func (p *routedClients) get(r route, build func() (*http.Client, error)) (*http.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, ok := p.clients[r]; ok {
+		return c, nil
+	}
+	c, err := build()
+	if err != nil {
+		return nil, err
+	}
+	// Past the bound, drop an arbitrary client. Requests in flight on it finish; its idle
+	// connections are closed.
+	if len(p.clients) >= maxRoutedClients {
+		for k, old := range p.clients {
+			old.CloseIdleConnections()
+			delete(p.clients, k)
+			break
+		}
+	}
+	p.clients[r] = c
+	return c, nil
+}
+
+// clientFor picks the client a job's request is sent with. The certificate is looked up
+// on every attempt, even when its client is cached, so a deleted certificate stops being
+// presented and a job can only use its owner's.
+func (e *HTTPExecutor) clientFor(ctx context.Context, job *domain.Job) (*http.Client, error) {
+	if job.ProxyURL == nil && job.ClientCertID == nil && job.CABundle == nil && !job.TLSSkipVerify {
+		return e.client, nil
+	}
+	var (
+		r    route
+		cert *domain.ClientCertificate
+	)
+	if job.ProxyURL != nil {
+		r.proxy = *job.ProxyURL
+	}
+	if job.CABundle != nil {
+		sum := sha256.Sum256([]byte(*job.CABundle))
+		r.caSum = hex.EncodeToString(sum[:])
+	}
+	r.insecure = job.TLSSkipVerify
+	if job.ClientCertID != nil {
+		r.certID = *job.ClientCertID
+		var err error
+		if cert, err = e.certs.GetByID(ctx, *job.ClientCertID, job.UserID); err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+	}
+	return e.clients.get(r, func() (*http.Client, error) {
+		var (
+			proxy *url.URL
+			err   error
+		)
+		if r.proxy != "" {
+			if proxy, err = domain.ParseProxyURL(r.proxy); err != nil {
+				return nil, fmt.Errorf("proxy: %w", err)
+			}
+		}
+		tlsConfig := defaultTLSConfig()
+		if cert != nil {
+			var pair tls.Certificate
+			if pair, err = cert.KeyPair(); err != nil {
+				return nil, fmt.Errorf("client certificate %s: %w", cert.ID, err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if job.CABundle != nil {
+			if tlsConfig.RootCAs, err = domain.ParseCABundle(*job.CABundle); err != nil {
+				return nil, fmt.Errorf("ca bundle: %w", err)
+			}
+		}
+		tlsConfig.InsecureSkipVerify = r.insecure
+		return newHTTPClient(proxy, tlsConfig, e.timeout), nil
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
 	"github.com/robfig/cron/v3"
 )
@@ -14,13 +15,15 @@ type Dispatcher struct {
 	scheduleRepo repository.ScheduleRepository
 	logger       *slog.Logger
 	interval     time.Duration
+	fireMetrics  bool // set ScheduleLastFireTime, one series per schedule
 }
 
-func NewDispatcher(repo repository.ScheduleRepository, logger *slog.Logger, interval time.Duration) *Dispatcher {
+func NewDispatcher(repo repository.ScheduleRepository, logger *slog.Logger, interval time.Duration, fireMetrics bool) *Dispatcher {
 	return &Dispatcher{
 		scheduleRepo: repo,
 		logger:       logger.With("component", "dispatcher"),
 		interval:     interval,
+		fireMetrics:  fireMetrics,
 	}
 }
 
@@ -42,27 +45,63 @@ func (d *Dispatcher) Start(ctx context.Context) {
 }
 
 func (d *Dispatcher) dispatch(ctx context.Context) {
-	jobs, err := d.scheduleRepo.ClaimAndFire(ctx, 100, d.computeNext)
+	start := time.Now()
+	jobs, err := d.scheduleRepo.ClaimAndFire(ctx, 100, d.plan)
 	if err != nil {
+		metrics.DispatcherCycleDuration.WithLabelValues("error").Observe(time.Since(start).Seconds())
 		d.logger.Error("dispatcher claim and fire", "error", err)
 		return
 	}
+	metrics.DispatcherCycleDuration.WithLabelValues("ok").Observe(time.Since(start).Seconds())
+	metrics.DispatcherFiredPerCycle.Observe(float64(len(jobs)))
+	metrics.DispatcherFiredTotal.Add(float64(len(jobs)))
+	if d.fireMetrics {
+		for _, j := range jobs {
+			if j.ScheduleID != nil {
+				metrics.ScheduleLastFireTime.WithLabelValues(*j.ScheduleID).Set(float64(j.CreatedAt.Unix()))
+			}
+		}
+	}
 	if len(jobs) > 0 {
 		d.logger.Info("dispatcher fired jobs", "count", len(jobs))
 	}
 }
 
+// plan keeps the schedule on its nominal cadence and spreads only the fired job.
+func (d *Dispatcher) plan(s *domain.Schedule) repository.FirePlan {
+	return repository.FirePlan{NextRunAt: d.computeNext(s), Delay: s.FireOffset()}
+}
+
 // computeNext returns the next future run time for the schedule, skipping any missed runs.
+// Interval schedules step from the previous run time. Cron expressions are evaluated in the
+// schedule's timezone, so wall-clock times hold across DST changes: a skipped local time
+// is not run, a repeated one runs once.
 func (d *Dispatcher) computeNext(s *domain.Schedule) time.Time {
+	now := time.Now()
+	if s.Every > 0 {
+		every := time.Duration(s.Every)
+		next := s.NextRunAt.Add(every)
+		if next.Before(now) {
+			// Skip the missed runs in one step, keeping the original phase.
+			next = next.Add(now.Sub(next).Truncate(every) + every)
+		}
+		return next
+	}
+
 	sched, err := cron.ParseStandard(s.CronExpr)
 	if err != nil {
 		// Expression was validated on create; this should never happen.
 		d.logger.Error("invalid cron expression in schedule", "schedule_id", s.ID, "cron_expr", s.CronExpr, "error", err)
 		return time.Now().Add(time.Hour) // safe fallback
 	}
+	loc, err := domain.LoadTimezone(s.Timezone)
+	if err != nil {
+		// Validated on write too; fall back to UTC rather than stalling the schedule.
+		d.logger.Error("invalid timezone in schedule", "schedule_id", s.ID, "timezone", s.Timezone, "error", err)
+		loc = time.UTC
+	}
 
-	next := sched.Next(s.NextRunAt)
-	now := time.Now()
+	next := sched.Next(s.NextRunAt.In(loc))
 	for next.Before(now) {
 		next = sched.Next(next)
 	}
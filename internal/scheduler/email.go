@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EmailSender delivers an email job's message. notify.EmailChannel implements it with the
+// same sender and credentials as notification email.
+type EmailSender interface {
+	SendHTML(ctx context.Context, to, subject, html, idempotencyKey string) error
+}
+
+// EmailExecutor runs email jobs: the body (decoded, after templating) is sent as the HTML
+// of a message to EmailTo with subject EmailSubject, which is templated with the body.
+// Headers and signing secrets don't apply to email and are ignored.
+type EmailExecutor struct {
+	sender EmailSender
+	logger *slog.Logger
+}
+
+func NewEmailExecutor(sender EmailSender, logger *slog.Logger) *EmailExecutor {
+	return &EmailExecutor{sender: sender, logger: logger.With("component", "email_executor")}
+}
+
+// Run sends one attempt of job. The attempt's idempotency key goes to the sender, so an
+// attempt delivered twice sends one message; a retry after a failed attempt may not.
+func (e *EmailExecutor) Run(ctx context.Context, job *domain.Job, idempotencyKey string) ExecutionResult {
+	ctx, span := tracing.Start(ctx, "EmailExecutor.Run", trace.WithAttributes(
+		attribute.String("job.id", job.ID),
+	))
+	defer span.End()
+
+	result := e.run(ctx, job, idempotencyKey)
+	if result.Err != nil {
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+	return result
+}
+
+func (e *EmailExecutor) run(ctx context.Context, job *domain.Job, idempotencyKey string) ExecutionResult {
+	start := time.Now()
+
+	ctx, cancel := attemptContext(ctx, job)
+	defer cancel()
+
+	subject, body := deref(job.EmailSubject), job.Body
+	if job.Templated {
+		var err error
+		if _, _, body, err = renderPayload(job); err != nil {
+			return ExecutionResult{Err: err, Duration: time.Since(start)}
+		}
+		if subject, err = domain.RenderPayloadTemplate(subject, job.TemplateVars()); err != nil {
+			return ExecutionResult{Err: fmt.Errorf("render subject: %w", err), Duration: time.Since(start)}
+		}
+	}
+	payload, err := domain.DecodeBody(body, job.BodyEncoding)
+	if err != nil {
+		return ExecutionResult{Err: fmt.Errorf("decode body: %w", err), Duration: time.Since(start)}
+	}
+	if !utf8.Valid(payload) {
+		return ExecutionResult{Err: errors.New("email body is not valid UTF-8"), Duration: time.Since(start)}
+	}
+
+	to := deref(job.EmailTo)
+	e.logger.InfoContext(ctx, "sending email", "job_id", job.ID, "to", to)
+	if err := e.sender.SendHTML(ctx, to, subject, string(payload), idempotencyKey); err != nil {
+		e.logger.ErrorContext(ctx, "email failed", "job_id", job.ID, "error", err, "duration", time.Since(start))
+		return ExecutionResult{Err: err, Duration: time.Since(start), RequestBytes: int64(len(payload))}
+	}
+
+	duration := time.Since(start)
+	e.logger.InfoContext(ctx, "email sent", "job_id", job.ID, "duration", duration)
+	return ExecutionResult{Duration: duration, RequestBytes: int64(len(payload))}
+}
+
+// Allow always lets email jobs run.
+func (e *EmailExecutor) Allow(*domain.Job) (time.Time, bool) {
+	return time.Time{}, true
+}
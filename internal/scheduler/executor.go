@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
@@ -8,100 +9,472 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/requestid"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-type Executor struct {
-	client *http.Client
-	logger *slog.Logger
-}
-
-func NewExecutor(logger *slog.Logger) *Executor {
-	return &Executor{
-		client: &http.Client{
-			// Per-job timeouts are set via context; this is a safety net.
-			Timeout: 5 * time.Minute,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					MinVersion: tls.VersionTLS12,
-				},
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-				DialContext: (&net.Dialer{
-					Timeout:   10 * time.Second,
-					KeepAlive: 30 * time.Second,
-				}).DialContext,
-			},
-			CheckRedirect: func(_ *http.Request, via []*http.Request) error {
-				if len(via) >= 10 {
-					return fmt.Errorf("stopped after 10 redirects")
-				}
-				return nil
-			},
+// debugCaptureBytes is how much of the request and response bodies a debug job records,
+// or the worker's capture limit if that is higher.
+const debugCaptureBytes = 64 << 10 // 64 KiB
+
+type HTTPExecutor struct {
+	client       *http.Client
+	clients      *routedClients
+	certs        repository.ClientCertRepository
+	logger       *slog.Logger
+	captureBytes int64
+	maxBytes     int64
+	timeout      time.Duration
+	circuits     *circuits
+	hosts        *hostLabels
+}
+
+// NewHTTPExecutor returns the executor of http jobs. It keeps the response headers and up
+// to captureBytes of each response body in the result; captureBytes 0 disables capture.
+// At most maxBytes of a body is read: past it the body is closed un-drained, which makes
+// the transport drop the connection — cheaper than streaming an unbounded body through a
+// worker slot. Callers consult Allow before running a job so hosts the breaker has cut off
+// are not called. certs supplies the client certificates jobs reference for mutual TLS.
+// Request metrics are labelled by target host for the first metricsHosts hosts seen.
+// clientTimeout bounds every attempt, whatever the job's own timeout.
+func NewHTTPExecutor(logger *slog.Logger, captureBytes, maxBytes int, clientTimeout time.Duration, breaker CircuitBreaker, certs repository.ClientCertRepository, metricsHosts int) *HTTPExecutor {
+	return &HTTPExecutor{
+		client:       newHTTPClient(nil, defaultTLSConfig(), clientTimeout),
+		clients:      newRoutedClients(),
+		certs:        certs,
+		logger:       logger.With("component", "executor"),
+		captureBytes: int64(captureBytes),
+		maxBytes:     int64(maxBytes),
+		timeout:      clientTimeout,
+		circuits:     newCircuits(breaker),
+		hosts:        newHostLabels(metricsHosts),
+	}
+}
+
+func defaultTLSConfig() *tls.Config {
+	return &tls.Config{MinVersion: tls.VersionTLS12}
+}
+
+// newHTTPClient builds the client jobs are sent with; proxy nil connects directly.
+func newHTTPClient(proxy *url.URL, tlsConfig *tls.Config, timeout time.Duration) *http.Client {
+	transport := &http.Transport{
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+	}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	return &http.Client{
+		// Per-job timeouts are set via context; this is a safety net.
+		Timeout:   timeout,
+		Transport: otelhttp.NewTransport(transport),
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			return nil
 		},
-		logger: logger.With("component", "executor"),
 	}
 }
 
+// ExecutionResult is the outcome of one attempt. The HTTP fields are left zero by
+// executors of other job types; see Succeeded.
 type ExecutionResult struct {
-	StatusCode int
-	Err        error
-	Duration   time.Duration
+	StatusCode    int
+	Err           error
+	Duration      time.Duration
+	RequestBytes  int64  // request body size; sent only if a connection was established
+	ResponseBytes int64  // lower bound (or Content-Length) when ResponseTruncated
+	RemoteAddr    string // empty if no connection was established
+
+	// ResponseTruncated is set when the body exceeded the size limit and was not read to
+	// the end.
+	ResponseTruncated bool
+
+	// Set only when capture is enabled (or the job is a debug job) and a response arrived.
+	ResponseHeaders map[string]string
+	ResponseBody    *string
+
+	// Set only for debug jobs whose request could be built.
+	Request *RequestSnapshot
+
+	sent bool // the request was handed to the client, so the outcome reflects the host
+}
+
+// Succeeded reports whether the attempt completes job: it ran without error and, if it
+// got a status code, job's SuccessCodes accept it.
+func (r ExecutionResult) Succeeded(job *domain.Job) bool {
+	return r.Err == nil && (r.StatusCode == 0 || job.SuccessCodes.Matches(r.StatusCode))
+}
+
+// RequestSnapshot is a debug job's request as sent, with secret header values redacted
+// and the body truncated to the capture limit.
+type RequestSnapshot struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    *string
+}
+
+// Run executes one attempt of job inside a span; the outbound request carries the span's
+// traceparent so the target can join the trace, and idempotencyKey as X-Idempotency-Key
+// unless it is empty.
+func (e *HTTPExecutor) Run(ctx context.Context, job *domain.Job, idempotencyKey string) ExecutionResult {
+	ctx, span := tracing.Start(ctx, "HTTPExecutor.Run", trace.WithAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.String("http.request.method", job.Method),
+	))
+	defer span.End()
+
+	result := e.run(ctx, job, idempotencyKey)
+	e.recordOutcome(ctx, job, result)
+	e.recordMetrics(ctx, job, result)
+	if result.StatusCode != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", result.StatusCode))
+	}
+	if result.Err != nil {
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+	return result
 }
 
-func (e *Executor) Run(ctx context.Context, job *domain.Job) ExecutionResult {
+func (e *HTTPExecutor) run(ctx context.Context, job *domain.Job, idempotencyKey string) ExecutionResult {
 	start := time.Now()
 
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(job.TimeoutSeconds)*time.Second)
+	ctx, cancel := attemptContext(ctx, job)
 	defer cancel()
 
-	var bodyReader io.Reader
-	if job.Body != nil {
-		bodyReader = strings.NewReader(*job.Body)
+	captureBytes := e.captureBytes
+	if job.Debug {
+		captureBytes = max(captureBytes, debugCaptureBytes)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, job.Method, job.URL, bodyReader)
+	url, headers, body := job.URL, job.Headers, job.Body
+	if job.Templated {
+		var err error
+		if url, headers, body, err = renderPayload(job); err != nil {
+			return ExecutionResult{Err: err, Duration: time.Since(start)}
+		}
+	}
+
+	payload, err := domain.DecodeBody(body, job.BodyEncoding)
+	if err != nil {
+		return ExecutionResult{Err: fmt.Errorf("decode body: %w", err), Duration: time.Since(start)}
+	}
+	var (
+		bodyReader   io.Reader
+		requestBytes int64
+	)
+	if body != nil {
+		bodyReader = bytes.NewReader(payload)
+		requestBytes = int64(len(payload))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, job.Method, url, bodyReader)
 	if err != nil {
 		return ExecutionResult{Err: fmt.Errorf("build request: %w", err), Duration: time.Since(start)}
 	}
 
-	for k, v := range job.Headers {
+	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
+	if job.ContentType != nil {
+		req.Header.Set("Content-Type", *job.ContentType)
+	}
+	// Signed over the body as sent, after templating and decoding; a retry is signed
+	// afresh with its own timestamp.
+	if job.SigningSecret != nil {
+		req.Header.Set(domain.SignatureHeader, domain.SignRequest(*job.SigningSecret, time.Now(), payload))
+	}
+	// Replaces any header of the same name the job set, so the key always matches the
+	// attempt record.
+	if idempotencyKey != "" {
+		req.Header.Set(domain.IdempotencyKeyHeader, idempotencyKey)
+	}
+
+	// Record the address of the last connection used — after redirects, the one that
+	// served the final response.
+	var remoteAddr string
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			remoteAddr = info.Conn.RemoteAddr().String()
+		},
+	}))
 
 	reqID := requestid.New()
 	req.Header.Set("X-Request-ID", reqID)
 	ctx = requestid.WithRequestID(ctx, reqID)
 
-	e.logger.InfoContext(ctx, "sending request",
+	// Chain back to the API call that created the job so a target's logs can be
+	// correlated with the server's.
+	logger := e.logger
+	if job.RequestID != nil {
+		req.Header.Set("X-Origin-Request-ID", *job.RequestID)
+		logger = logger.With("origin_request_id", *job.RequestID)
+	}
+
+	var snapshot *RequestSnapshot
+	if job.Debug {
+		snapshot = snapshotRequest(req, body, captureBytes)
+	}
+
+	logger.InfoContext(ctx, "sending request",
 		"job_id", job.ID,
 		"method", job.Method,
-		"url", job.URL,
+		"url", url,
 	)
+	// Every unverified request is logged and counted, so turning verification off is
+	// never silent.
+	if job.TLSSkipVerify {
+		metrics.ExecutorTLSUnverifiedTotal.Inc()
+		logger.WarnContext(ctx, "sending request without TLS verification",
+			"job_id", job.ID,
+			"user_id", job.UserID,
+			"url", url,
+		)
+	}
+
+	client, err := e.clientFor(ctx, job)
+	if err != nil {
+		return ExecutionResult{Err: err, Duration: time.Since(start)}
+	}
 
-	resp, err := e.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		e.logger.ErrorContext(ctx, "request failed",
+		logger.ErrorContext(ctx, "request failed",
 			"job_id", job.ID,
 			"error", err,
 			"duration", time.Since(start),
 		)
-		return ExecutionResult{Err: fmt.Errorf("do request: %w", err), Duration: time.Since(start)}
+		return ExecutionResult{
+			Err:          fmt.Errorf("do request: %w", err),
+			Duration:     time.Since(start),
+			RequestBytes: requestBytes,
+			RemoteAddr:   remoteAddr,
+			Request:      snapshot,
+			sent:         true,
+		}
 	}
 	defer func() { _ = resp.Body.Close() }()
-	_, _ = io.Copy(io.Discard, resp.Body) // drain so the connection can be reused by the pool
+
+	var (
+		captured        []byte
+		responseHeaders map[string]string
+	)
+	if captureBytes > 0 {
+		captured, _ = io.ReadAll(io.LimitReader(resp.Body, min(captureBytes, e.maxBytes)))
+		responseHeaders = captureHeaders(resp.Header)
+	}
+
+	// Drain so the connection can be reused by the pool — but only up to maxBytes, and not
+	// at all when the declared length already exceeds it.
+	read := int64(len(captured))
+	if resp.ContentLength <= e.maxBytes {
+		rest, _ := io.Copy(io.Discard, io.LimitReader(resp.Body, e.maxBytes+1-read))
+		read += rest
+	}
+	metrics.ExecutorResponseBytesDrained.Add(float64(read))
+	responseBytes := max(read, resp.ContentLength)
+	truncated := responseBytes > e.maxBytes
+	if truncated {
+		metrics.ExecutorResponsesTruncatedTotal.Inc()
+		logger.WarnContext(ctx, "response body exceeds size limit, closing connection",
+			"job_id", job.ID,
+			"bytes_read", read,
+			"content_length", resp.ContentLength,
+			"size_limit", e.maxBytes,
+		)
+	}
 
 	duration := time.Since(start)
-	e.logger.InfoContext(ctx, "received response",
+	logger.InfoContext(ctx, "received response",
 		"job_id", job.ID,
 		"status", resp.StatusCode,
 		"duration", duration,
+		"response_bytes", responseBytes,
 	)
 
-	return ExecutionResult{StatusCode: resp.StatusCode, Duration: duration}
+	result := ExecutionResult{
+		StatusCode:        resp.StatusCode,
+		Duration:          duration,
+		RequestBytes:      requestBytes,
+		ResponseBytes:     responseBytes,
+		RemoteAddr:        remoteAddr,
+		ResponseTruncated: truncated,
+		Request:           snapshot,
+		sent:              true,
+	}
+	if captureBytes > 0 {
+		body := captureBody(captured)
+		result.ResponseHeaders = responseHeaders
+		result.ResponseBody = &body
+	}
+	return result
+}
+
+// Allow reports whether job's host may be called now. If its circuit is open, retryAt is
+// when the job should be tried again.
+func (e *HTTPExecutor) Allow(job *domain.Job) (retryAt time.Time, ok bool) {
+	host := circuitHost(job)
+	if host == "" {
+		return time.Time{}, true
+	}
+	return e.circuits.allow(host, time.Now())
+}
+
+// recordOutcome feeds a sent request into the breaker. Transport errors, timeouts and 5xx
+// responses count against the host; a request aborted by ctx (a cancel, or the worker
+// shutting down) says nothing about it.
+func (e *HTTPExecutor) recordOutcome(ctx context.Context, job *domain.Job, result ExecutionResult) {
+	host := circuitHost(job)
+	if host == "" || !result.sent {
+		return
+	}
+	if ctx.Err() != nil {
+		e.circuits.abandon(host)
+		return
+	}
+	failed := result.Err != nil || result.StatusCode >= http.StatusInternalServerError
+	if e.circuits.record(host, failed, time.Now()) {
+		e.logger.WarnContext(ctx, "circuit opened, deferring jobs to host",
+			"host", host,
+			"job_id", job.ID,
+			"cooldown", e.circuits.cfg.Cooldown,
+		)
+	}
+}
+
+// recordMetrics counts a sent request by target host and status class. Like
+// recordOutcome it skips requests aborted by ctx, which say nothing about the host.
+func (e *HTTPExecutor) recordMetrics(ctx context.Context, job *domain.Job, result ExecutionResult) {
+	if !result.sent || ctx.Err() != nil {
+		return
+	}
+	host, class := e.hosts.label(circuitHost(job)), statusClass(result)
+	metrics.ExecutorRequestsTotal.WithLabelValues(host, class).Inc()
+	metrics.ExecutorRequestDuration.WithLabelValues(host, class).Observe(result.Duration.Seconds())
+}
+
+// circuitHost is the host:port the breaker tracks job under, or "" for a URL that doesn't
+// parse before templating.
+func circuitHost(job *domain.Job) string {
+	u, err := url.Parse(job.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// redactedHeaders are replaced with a placeholder when captured — they hold credentials
+// the target issued, which don't belong in attempt history.
+var redactedHeaders = map[string]bool{
+	"Set-Cookie":         true,
+	"Authorization":      true,
+	"Proxy-Authenticate": true,
+	"Www-Authenticate":   true,
+}
+
+func captureHeaders(h http.Header) map[string]string {
+	headers := make(map[string]string, len(h))
+	for k, v := range h {
+		if redactedHeaders[k] {
+			headers[k] = domain.RedactedValue
+			continue
+		}
+		headers[k] = strings.Join(v, ", ")
+	}
+	return headers
+}
+
+// snapshotRequest records req for a debug job. body is the request body as a string,
+// since req.Body has not been sent yet and must not be consumed.
+func snapshotRequest(req *http.Request, body *string, captureBytes int64) *RequestSnapshot {
+	headers := make(map[string]string, len(req.Header))
+	for k, v := range req.Header {
+		if domain.IsSecretHeader(k) {
+			headers[k] = domain.RedactedValue
+			continue
+		}
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	snapshot := &RequestSnapshot{Method: req.Method, URL: req.URL.String(), Headers: headers}
+	if body != nil {
+		b := *body
+		if int64(len(b)) > captureBytes {
+			b = b[:captureBytes]
+		}
+		captured := captureBody([]byte(b))
+		snapshot.Body = &captured
+	}
+	return snapshot
+}
+
+// captureBody makes a possibly binary or cut-off body storable as text: invalid UTF-8
+// (including a rune split by the capture limit) becomes U+FFFD and NUL bytes are dropped.
+func captureBody(b []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(b), "\uFFFD"), "\x00", "")
+}
+
+// renderPayload expands a templated job's URL, header values and body for its next attempt.
+func renderPayload(job *domain.Job) (url string, headers map[string]string, body *string, err error) {
+	vars := job.TemplateVars()
+	if url, err = domain.RenderPayloadTemplate(job.URL, vars); err != nil {
+		return "", nil, nil, fmt.Errorf("render url: %w", err)
+	}
+	headers = make(map[string]string, len(job.Headers))
+	for k, v := range job.Headers {
+		if headers[k], err = domain.RenderPayloadTemplate(v, vars); err != nil {
+			return "", nil, nil, fmt.Errorf("render header %s: %w", k, err)
+		}
+	}
+	if job.Body != nil {
+		rendered, err := domain.RenderPayloadTemplate(*job.Body, vars)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("render body: %w", err)
+		}
+		body = &rendered
+	}
+	return url, headers, body, nil
+}
+
+// DryRun sends job's request once and reports the outcome, for validating a job before
+// it is scheduled. There is no attempt, so no idempotency key is sent.
+func (e *HTTPExecutor) DryRun(ctx context.Context, job *domain.Job) domain.DryRunResult {
+	result := e.Run(ctx, job, "")
+	dry := domain.DryRunResult{
+		Succeeded: result.Succeeded(job),
+		Duration:  result.Duration,
+	}
+	if result.Err != nil {
+		msg := result.Err.Error()
+		dry.Error = &msg
+	}
+	if result.StatusCode != 0 {
+		dry.StatusCode = &result.StatusCode
+		dry.ResponseHeaders = result.ResponseHeaders
+		dry.ResponseBody = result.ResponseBody
+		dry.ResponseBytes = &result.ResponseBytes
+		dry.ResponseTruncated = result.ResponseTruncated
+	}
+	return dry
 }
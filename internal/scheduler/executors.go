@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// Executor runs the attempts of jobs of one domain.JobType. The worker owns everything
+// around an attempt — the attempt record, heartbeats, cancellation, retries — so an
+// executor only performs the work and reports how it went.
+type Executor interface {
+	// Run performs one attempt of job. ctx is cancelled when the job is cancelled or the
+	// worker shuts down; the job's own timeout and deadline are the executor's to apply.
+	// idempotencyKey identifies the attempt to targets that deduplicate.
+	Run(ctx context.Context, job *domain.Job, idempotencyKey string) ExecutionResult
+
+	// Allow reports whether job may run now. If not, the job is deferred to retryAt
+	// without using up a retry.
+	Allow(job *domain.Job) (retryAt time.Time, ok bool)
+}
+
+// RegisterExecutor makes the worker run jobs of type t with e, replacing any executor
+// registered for t before. Call it before Start.
+func (w *Worker) RegisterExecutor(t domain.JobType, e Executor) {
+	w.executors[t] = e
+}
+
+// executorFor returns the executor registered for job's type.
+func (w *Worker) executorFor(job *domain.Job) (Executor, bool) {
+	e, ok := w.executors[job.JobType]
+	return e, ok
+}
+
+// attemptContext bounds one attempt of job by its timeout and, so it never runs past
+// it, its deadline.
+func attemptContext(ctx context.Context, job *domain.Job) (context.Context, context.CancelFunc) {
+	timeout := time.Now().Add(time.Duration(job.TimeoutSeconds) * time.Second)
+	if job.Deadline != nil && job.Deadline.Before(timeout) {
+		return context.WithDeadline(ctx, *job.Deadline)
+	}
+	return context.WithDeadline(ctx, timeout)
+}
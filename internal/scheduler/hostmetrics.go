@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// otherHost labels requests to hosts past the executor's host limit.
+const otherHost = "other"
+
+// hostLabels bounds the host label of the executor's request metrics: the first max hosts
+// this process sends to get their own series, later ones share otherHost. Hosts are never
+// evicted, so a busy worker's tracked hosts are the ones it saw first after starting.
+type hostLabels struct {
+	max int
+
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+func newHostLabels(limit int) *hostLabels {
+	return &hostLabels{max: limit, seen: make(map[string]struct{})}
+}
+
+func (h *hostLabels) label(host string) string {
+	if host == "" {
+		return otherHost
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.seen[host]; ok {
+		return host
+	}
+	if len(h.seen) >= h.max {
+		return otherHost
+	}
+	h.seen[host] = struct{}{}
+	return host
+}
+
+// statusClass buckets a sent request's outcome: 2xx to 5xx by status code, timeout when
+// the attempt's timeout cut it off and error for any other transport failure.
+func statusClass(result ExecutionResult) string {
+	if result.Err != nil {
+		var netErr net.Error
+		if errors.Is(result.Err, context.DeadlineExceeded) || errors.As(result.Err, &netErr) && netErr.Timeout() {
+			return "timeout"
+		}
+		return "error"
+	}
+	return strconv.Itoa(result.StatusCode/100) + "xx"
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// janitorBatchSize bounds each purge statement, so one sweep never holds locks on a large
+// slice of the table; a sweep keeps going while batches come back full.
+const janitorBatchSize = 1000
+
+// RetentionPolicy sets how long execution history is kept. A zero window keeps rows forever.
+type RetentionPolicy struct {
+	Attempts time.Duration // finished attempts, by completed_at
+	Jobs     time.Duration // terminal jobs, by updated_at; their attempts go with them
+	Archive  bool          // copy rows to the archive tables before deleting
+}
+
+// Janitor enforces the retention policy, so job_attempts and jobs don't grow without bound.
+type Janitor struct {
+	repo     repository.RetentionRepository
+	logger   *slog.Logger
+	interval time.Duration
+	policy   RetentionPolicy
+}
+
+func NewJanitor(repo repository.RetentionRepository, logger *slog.Logger, interval time.Duration, policy RetentionPolicy) *Janitor {
+	return &Janitor{
+		repo:     repo,
+		logger:   logger.With("component", "janitor"),
+		interval: interval,
+		policy:   policy,
+	}
+}
+
+func (j *Janitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.logger.InfoContext(ctx, "janitor started",
+		"interval", j.interval,
+		"attempt_retention", j.policy.Attempts,
+		"job_retention", j.policy.Jobs,
+		"archive", j.policy.Archive,
+	)
+
+	j.sweep(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.InfoContext(ctx, "janitor shut down")
+			return
+		case <-ticker.C:
+			j.sweep(ctx)
+		}
+	}
+}
+
+func (j *Janitor) sweep(ctx context.Context) {
+	now := time.Now()
+	if j.policy.Attempts > 0 {
+		j.purge(ctx, "job_attempts", now.Add(-j.policy.Attempts), j.repo.PurgeAttempts)
+	}
+	if j.policy.Jobs > 0 {
+		j.purge(ctx, "jobs", now.Add(-j.policy.Jobs), j.repo.PurgeJobs)
+	}
+}
+
+func (j *Janitor) purge(ctx context.Context, table string, cutoff time.Time, fn func(context.Context, time.Time, int, bool) (int64, error)) {
+	var total int64
+	for ctx.Err() == nil {
+		n, err := fn(ctx, cutoff, janitorBatchSize, j.policy.Archive)
+		if err != nil {
+			j.logger.ErrorContext(ctx, "purge expired history", "table", table, "error", err)
+			break
+		}
+		total += n
+		metrics.RetentionPurgedTotal.WithLabelValues(table).Add(float64(n))
+		if n < janitorBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		j.logger.InfoContext(ctx, "purged expired history", "table", table, "rows", total, "cutoff", cutoff, "archived", j.policy.Archive)
+	}
+}
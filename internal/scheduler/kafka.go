@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// KafkaExecutor publishes kafka jobs: the body (decoded, after templating) becomes the
+// record value, MessageKey its key and the headers its record headers, alongside the
+// Content-Type, X-Signature, X-Idempotency-Key and X-Origin-Request-ID an http job would
+// send. A job without a body publishes a tombstone.
+type KafkaExecutor struct {
+	client *kgo.Client
+	logger *slog.Logger
+}
+
+func NewKafkaExecutor(client *kgo.Client, logger *slog.Logger) *KafkaExecutor {
+	return &KafkaExecutor{client: client, logger: logger.With("component", "kafka_executor")}
+}
+
+// Run publishes one attempt of job and waits for the brokers to acknowledge it. An
+// attempt aborted while the record is in flight may still be written, so consumers that
+// must not see duplicates should dedupe on X-Idempotency-Key.
+func (e *KafkaExecutor) Run(ctx context.Context, job *domain.Job, idempotencyKey string) ExecutionResult {
+	ctx, span := tracing.Start(ctx, "KafkaExecutor.Run", trace.WithAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.String("messaging.destination.name", deref(job.Topic)),
+	))
+	defer span.End()
+
+	result := e.run(ctx, job, idempotencyKey)
+	if result.Err != nil {
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+	return result
+}
+
+func (e *KafkaExecutor) run(ctx context.Context, job *domain.Job, idempotencyKey string) ExecutionResult {
+	start := time.Now()
+
+	ctx, cancel := attemptContext(ctx, job)
+	defer cancel()
+
+	headers, body := job.Headers, job.Body
+	if job.Templated {
+		var err error
+		if _, headers, body, err = renderPayload(job); err != nil {
+			return ExecutionResult{Err: err, Duration: time.Since(start)}
+		}
+	}
+	payload, err := domain.DecodeBody(body, job.BodyEncoding)
+	if err != nil {
+		return ExecutionResult{Err: fmt.Errorf("decode body: %w", err), Duration: time.Since(start)}
+	}
+
+	headers = maps.Clone(headers)
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	if job.ContentType != nil {
+		setHeader(headers, "Content-Type", *job.ContentType)
+	}
+	if job.SigningSecret != nil {
+		setHeader(headers, domain.SignatureHeader, domain.SignRequest(*job.SigningSecret, time.Now(), payload))
+	}
+	if idempotencyKey != "" {
+		setHeader(headers, domain.IdempotencyKeyHeader, idempotencyKey)
+	}
+	if job.RequestID != nil {
+		setHeader(headers, "X-Origin-Request-ID", *job.RequestID)
+	}
+
+	record := &kgo.Record{Topic: deref(job.Topic), Value: payload}
+	if job.MessageKey != nil {
+		record.Key = []byte(*job.MessageKey)
+	}
+	for _, k := range slices.Sorted(maps.Keys(headers)) {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: k, Value: []byte(headers[k])})
+	}
+
+	e.logger.InfoContext(ctx, "publishing record", "job_id", job.ID, "topic", record.Topic)
+	if err := e.client.ProduceSync(ctx, record).FirstErr(); err != nil {
+		e.logger.ErrorContext(ctx, "publish failed", "job_id", job.ID, "topic", record.Topic, "error", err, "duration", time.Since(start))
+		return ExecutionResult{
+			Err:          fmt.Errorf("publish to %s: %w", record.Topic, err),
+			Duration:     time.Since(start),
+			RequestBytes: int64(len(payload)),
+		}
+	}
+
+	duration := time.Since(start)
+	e.logger.InfoContext(ctx, "record published",
+		"job_id", job.ID,
+		"topic", record.Topic,
+		"partition", record.Partition,
+		"offset", record.Offset,
+		"duration", duration,
+	)
+	return ExecutionResult{Duration: duration, RequestBytes: int64(len(payload))}
+}
+
+// Allow always lets kafka jobs run; the client retries unavailable brokers itself.
+func (e *KafkaExecutor) Allow(*domain.Job) (time.Time, bool) {
+	return time.Time{}, true
+}
+
+// setHeader sets key in h, replacing any header that differs from it only in case.
+func setHeader(h map[string]string, key, value string) {
+	for k := range h {
+		if strings.EqualFold(k, key) {
+			delete(h, k)
+		}
+	}
+	h[key] = value
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
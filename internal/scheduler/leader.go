@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// Leader runs cluster-wide loops — the dispatcher, the reaper — on one replica at a
+// time instead of on every one. The loops start when this replica wins the lock and are
+// stopped when it loses it; it then campaigns again, so a surviving replica takes over
+// when the leader dies. The loops stay safe to overlap, so a brief double leader during
+// failover costs only duplicate queries.
+type Leader struct {
+	lock   repository.LeaderLock
+	logger *slog.Logger
+	retry  time.Duration
+	loops  []func(ctx context.Context)
+}
+
+func NewLeader(lock repository.LeaderLock, logger *slog.Logger, retry time.Duration, loops ...func(ctx context.Context)) *Leader {
+	return &Leader{
+		lock:   lock,
+		logger: logger.With("component", "leader"),
+		retry:  retry,
+		loops:  loops,
+	}
+}
+
+func (l *Leader) Start(ctx context.Context) {
+	l.logger.InfoContext(ctx, "leader election started")
+
+	for {
+		err := l.lock.Lead(ctx, l.lead)
+		if ctx.Err() != nil {
+			l.logger.InfoContext(ctx, "leader election shut down")
+			return
+		}
+		if err != nil {
+			l.logger.WarnContext(ctx, "leadership ended", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			l.logger.InfoContext(ctx, "leader election shut down")
+			return
+		case <-time.After(l.retry):
+		}
+	}
+}
+
+// lead runs every loop until ctx is cancelled by shutdown or lost leadership.
+func (l *Leader) lead(ctx context.Context) {
+	l.logger.InfoContext(ctx, "elected leader")
+	metrics.SchedulerLeader.Set(1)
+	defer metrics.SchedulerLeader.Set(0)
+
+	var wg sync.WaitGroup
+	for _, loop := range l.loops {
+		wg.Go(func() { loop(ctx) })
+	}
+	wg.Wait()
+	l.logger.InfoContext(ctx, "stepped down as leader")
+}
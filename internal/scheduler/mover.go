@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// moverBatchSize caps how many jobs one EnqueueDue round marks; the mover keeps going
+// while rounds come back full.
+const moverBatchSize = 500
+
+// Mover feeds the Redis claim queue in high-throughput mode: it marks due jobs as queued
+// in Postgres and pushes their IDs for workers to pop. Postgres stays the source of truth,
+// so a push that is lost — Redis restarted, the mover crashed after marking — only delays
+// the job until it is pushed again after redeliverAfter. Running several movers is safe.
+type Mover struct {
+	repo           repository.JobRepository
+	queue          repository.ClaimQueue
+	logger         *slog.Logger
+	interval       time.Duration
+	policy         domain.ClaimPolicy
+	redeliverAfter time.Duration
+}
+
+func NewMover(
+	repo repository.JobRepository,
+	queue repository.ClaimQueue,
+	logger *slog.Logger,
+	interval time.Duration,
+	policy domain.ClaimPolicy,
+	redeliverAfter time.Duration,
+) *Mover {
+	return &Mover{
+		repo:           repo,
+		queue:          queue,
+		logger:         logger.With("component", "mover"),
+		interval:       interval,
+		policy:         policy,
+		redeliverAfter: redeliverAfter,
+	}
+}
+
+func (m *Mover) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.logger.InfoContext(ctx, "mover started", "interval", m.interval, "redeliver_after", m.redeliverAfter)
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.logger.InfoContext(ctx, "mover shut down")
+			return
+		case <-ticker.C:
+			m.move(ctx)
+		}
+	}
+}
+
+func (m *Mover) move(ctx context.Context) {
+	for {
+		byQueue, err := m.repo.EnqueueDue(ctx, moverBatchSize, m.policy, m.redeliverAfter)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "enqueue due jobs", "error", err)
+			return
+		}
+		if len(byQueue) == 0 {
+			return
+		}
+		total := 0
+		for queue, ids := range byQueue {
+			total += len(ids)
+			if err := m.queue.Push(ctx, queue, ids); err != nil {
+				m.logger.ErrorContext(ctx, "push due jobs, will redeliver", "queue", queue, "count", len(ids), "error", err)
+				return
+			}
+			metrics.JobsEnqueuedTotal.Add(float64(len(ids)))
+			m.logger.DebugContext(ctx, "pushed due jobs", "queue", queue, "count", len(ids))
+		}
+
+		if total < moverBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// prefetchMaxAge is how long a prefetched job may wait for a free slot before it is
+// released. Prefetched jobs are claimed but not heartbeated, so this stays well below the
+// reaper's stale cutoff.
+const prefetchMaxAge = 10 * time.Second
+
+// durationSmoothing weights each finished job in the moving average of execution time.
+const durationSmoothing = 0.2
+
+// prefetcher sizes the worker's claims. Beyond its free slots, a worker claims up to limit
+// extra jobs and holds them until slots free up, so a deep backlog is worked through
+// without a database round trip per finished job. The prefetch target grows while claims
+// come back full (the queue is deep) and halves when they come back short; it drops to
+// zero while jobs take longer than slowAfter on average, since slow targets keep
+// prefetched jobs waiting and out of reach of idle workers. Apart from ran, callers hold
+// the worker's claimMu.
+type prefetcher struct {
+	limit     int
+	slowAfter time.Duration
+
+	target int
+	held   []heldJob
+
+	// Finished jobs report their duration without claimMu, which is held across claims.
+	durationMu  sync.Mutex
+	avgDuration time.Duration
+}
+
+type heldJob struct {
+	job       *domain.Job
+	claimedAt time.Time
+}
+
+func newPrefetcher(limit int, slowAfter time.Duration) *prefetcher {
+	return &prefetcher{limit: limit, slowAfter: slowAfter}
+}
+
+// want returns how many jobs to claim for free slots.
+func (p *prefetcher) want(free int) int {
+	extra := p.target
+	if p.slow() {
+		extra = 0
+	}
+	return max(free+extra-len(p.held), 0)
+}
+
+// claimed records the outcome of a claim for requested jobs and holds the jobs.
+func (p *prefetcher) claimed(requested int, jobs []*domain.Job, now time.Time) {
+	switch {
+	case len(jobs) == requested:
+		p.target = min(max(p.target*2, 1), p.limit)
+	default:
+		p.target /= 2
+	}
+	for _, j := range jobs {
+		p.held = append(p.held, heldJob{job: j, claimedAt: now})
+	}
+}
+
+// take removes up to n held jobs, oldest first.
+func (p *prefetcher) take(n int) []*domain.Job {
+	n = min(n, len(p.held))
+	jobs := make([]*domain.Job, n)
+	for i := range n {
+		jobs[i] = p.held[i].job
+	}
+	p.held = p.held[n:]
+	return jobs
+}
+
+// expire removes and returns the IDs of held jobs claimed before now-prefetchMaxAge, or
+// of every held job when all is set.
+func (p *prefetcher) expire(now time.Time, all bool) []string {
+	var ids []string
+	kept := p.held[:0]
+	for _, h := range p.held {
+		if all || now.Sub(h.claimedAt) > prefetchMaxAge {
+			ids = append(ids, h.job.ID)
+			continue
+		}
+		kept = append(kept, h)
+	}
+	p.held = kept
+	return ids
+}
+
+// ran folds a finished execution into the moving average.
+func (p *prefetcher) ran(d time.Duration) {
+	p.durationMu.Lock()
+	defer p.durationMu.Unlock()
+	if p.avgDuration == 0 {
+		p.avgDuration = d
+		return
+	}
+	p.avgDuration += time.Duration(durationSmoothing * float64(d-p.avgDuration))
+}
+
+func (p *prefetcher) slow() bool {
+	p.durationMu.Lock()
+	defer p.durationMu.Unlock()
+	return p.avgDuration > p.slowAfter
+}
@@ -5,23 +5,46 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
 )
 
+// StuckPendingCheck configures detection of pending jobs no worker picks up, e.g.
+// during a worker outage. A job is stuck once it has been due for longer than After;
+// only Queues, the ones this replica serves, are checked. After 0 disables the check.
+type StuckPendingCheck struct {
+	After  time.Duration
+	Queues []string
+	Hook   StuckPendingHook // optional
+}
+
+// StuckPendingHook escalates stuck queues, e.g. by paging an operator. It is called when
+// a queue becomes stuck and again when it recovers (Count 0), not on every cycle, and
+// runs on the reaper's goroutine.
+type StuckPendingHook interface {
+	StuckPending(ctx context.Context, q domain.StuckQueue)
+}
+
 type Reaper struct {
 	repo             repository.JobRepository
 	logger           *slog.Logger
 	interval         time.Duration
 	heartbeatTimeout time.Duration
+	failures         FailurePublisher
+	stuck            StuckPendingCheck
+	stuckQueues      map[string]bool // queues currently reported stuck
 }
 
-func NewReaper(repo repository.JobRepository, logger *slog.Logger, interval time.Duration, heartbeatTimeout time.Duration) *Reaper {
+func NewReaper(repo repository.JobRepository, logger *slog.Logger, interval time.Duration, heartbeatTimeout time.Duration, failures FailurePublisher, stuck StuckPendingCheck) *Reaper {
 	return &Reaper{
 		repo:             repo,
+		failures:         failures,
 		logger:           logger,
 		interval:         interval,
 		heartbeatTimeout: heartbeatTimeout,
+		stuck:            stuck,
+		stuckQueues:      make(map[string]bool),
 	}
 }
 
@@ -29,7 +52,7 @@ func (r *Reaper) Start(ctx context.Context) {
 	ticker := time.NewTicker(r.interval)
 	defer ticker.Stop()
 
-	r.logger.InfoContext(ctx, "reaper started", "interval", r.interval, "heartbeat_timeout", r.heartbeatTimeout)
+	r.logger.InfoContext(ctx, "reaper started", "interval", r.interval, "heartbeat_timeout", r.heartbeatTimeout, "stuck_pending_after", r.stuck.After)
 
 	for {
 		select {
@@ -53,16 +76,116 @@ func (r *Reaper) reap(ctx context.Context) {
 	rescheduled, err := r.repo.RescheduleStale(ctx, staleCutoff, 100)
 	if err != nil {
 		r.logger.ErrorContext(ctx, "reschedule stale jobs", "error", err)
-	} else if rescheduled > 0 {
-		metrics.ReaperRescuedTotal.WithLabelValues("rescheduled").Add(float64(rescheduled))
-		r.logger.InfoContext(ctx, "rescheduled stale jobs", "count", rescheduled)
+	} else if len(rescheduled) > 0 {
+		r.observe("rescheduled", rescheduled)
+		r.logger.InfoContext(ctx, "rescheduled stale jobs", "count", len(rescheduled))
 	}
 
 	failed, err := r.repo.FailStale(ctx, staleCutoff, 100)
 	if err != nil {
 		r.logger.ErrorContext(ctx, "fail stale jobs", "error", err)
-	} else if failed > 0 {
-		metrics.ReaperRescuedTotal.WithLabelValues("failed").Add(float64(failed))
-		r.logger.InfoContext(ctx, "permanently failed stale jobs", "count", failed)
+	} else if len(failed) > 0 {
+		r.observe("failed", failed)
+		r.logger.InfoContext(ctx, "permanently failed stale jobs", "count", len(failed))
+		r.publish(ctx, failed)
+	}
+
+	cancelled, err := r.repo.CancelStale(ctx, staleCutoff, 100)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "cancel stale jobs", "error", err)
+	} else if len(cancelled) > 0 {
+		r.observe("cancelled", cancelled)
+		r.logger.InfoContext(ctx, "cancelled stale jobs", "count", len(cancelled))
+	}
+
+	expired, err := r.repo.ExpirePending(ctx, 100)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "expire pending jobs", "error", err)
+	} else if expired > 0 {
+		metrics.JobsCompletedTotal.WithLabelValues("expired").Add(float64(expired))
+		r.logger.InfoContext(ctx, "expired pending jobs", "count", expired)
+	}
+
+	if r.stuck.After > 0 {
+		r.checkStuck(ctx)
+	}
+}
+
+// checkStuck updates the stuck-pending gauges of every served queue, and logs and
+// escalates queues whose stuck state changed since the last cycle. On a query error the
+// gauges and states are left as they were.
+func (r *Reaper) checkStuck(ctx context.Context) {
+	now := time.Now()
+	found, err := r.repo.StuckPending(ctx, now.Add(-r.stuck.After), r.stuck.Queues)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "count stuck pending jobs", "error", err)
+		return
+	}
+	byQueue := make(map[string]domain.StuckQueue, len(found))
+	for _, q := range found {
+		byQueue[q.Queue] = q
+	}
+
+	for _, queue := range r.stuck.Queues {
+		q, ok := byQueue[queue]
+		if !ok {
+			q = domain.StuckQueue{Queue: queue}
+		}
+		var age float64
+		if q.OldestDueAt != nil {
+			age = max(now.Sub(*q.OldestDueAt).Seconds(), 0)
+		}
+		metrics.ReaperStuckPending.WithLabelValues(queue).Set(float64(q.Count))
+		metrics.ReaperStuckPendingAge.WithLabelValues(queue).Set(age)
+
+		stuck := q.Count > 0
+		if stuck == r.stuckQueues[queue] {
+			continue
+		}
+		r.stuckQueues[queue] = stuck
+		if stuck {
+			r.logger.WarnContext(ctx, "pending jobs stuck past scheduled_at", "queue", queue, "count", q.Count, "oldest_seconds", int(age))
+		} else {
+			r.logger.InfoContext(ctx, "stuck pending jobs cleared", "queue", queue)
+		}
+		if r.stuck.Hook != nil {
+			r.stuck.Hook.StuckPending(ctx, q)
+		}
+	}
+}
+
+func (r *Reaper) publish(ctx context.Context, jobs []domain.ReapedJob) {
+	now := time.Now()
+	for _, j := range jobs {
+		f := domain.JobFailure{
+			JobID:      j.ID,
+			UserID:     j.UserID,
+			ScheduleID: j.ScheduleID,
+			URL:        j.URL,
+			FailedAt:   now,
+		}
+		if j.LastError != nil {
+			f.Error = *j.LastError
+		}
+		r.failures.Publish(ctx, f)
+	}
+}
+
+// observe records how stale each recovered job was and which worker dropped it.
+func (r *Reaper) observe(action string, jobs []domain.ReapedJob) {
+	now := time.Now()
+	metrics.ReaperRescuedTotal.WithLabelValues(action).Add(float64(len(jobs)))
+	for _, j := range jobs {
+		if j.HeartbeatAt != nil {
+			metrics.ReaperTimeToRescue.WithLabelValues(action).Observe(now.Sub(*j.HeartbeatAt).Seconds())
+		}
+		if j.ClaimedAt != nil {
+			metrics.ReaperStaleClaimAge.WithLabelValues(action).Observe(now.Sub(*j.ClaimedAt).Seconds())
+		}
+		worker := "unknown"
+		if j.ClaimedBy != nil {
+			worker = *j.ClaimedBy
+		}
+		metrics.ReaperRescuedByWorkerTotal.WithLabelValues(worker, action).Inc()
 	}
 }
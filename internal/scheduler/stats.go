@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// StatsCollector periodically samples the pending-job backlog into the queue gauges, and
+// the overdue schedules into SchedulesOverdue, so a growing backlog, lagging workers or a
+// stalled dispatcher can be alerted on. It runs on every replica, so the overdue count is
+// still reported when the dispatcher's replica is the one in trouble.
+type StatsCollector struct {
+	repo         repository.JobRepository
+	schedules    repository.ScheduleRepository
+	logger       *slog.Logger
+	interval     time.Duration
+	overdueAfter time.Duration
+}
+
+func NewStatsCollector(repo repository.JobRepository, schedules repository.ScheduleRepository, logger *slog.Logger, interval, overdueAfter time.Duration) *StatsCollector {
+	return &StatsCollector{
+		repo:         repo,
+		schedules:    schedules,
+		logger:       logger.With("component", "stats"),
+		interval:     interval,
+		overdueAfter: overdueAfter,
+	}
+}
+
+func (c *StatsCollector) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.logger.InfoContext(ctx, "stats collector started", "interval", c.interval)
+
+	c.collect(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.InfoContext(ctx, "stats collector shut down")
+			return
+		case <-ticker.C:
+			c.collect(ctx)
+		}
+	}
+}
+
+// collect leaves the gauges at their last values when the query fails, rather than
+// reporting an empty queue.
+func (c *StatsCollector) collect(ctx context.Context) {
+	if overdue, err := c.schedules.CountOverdue(ctx, time.Now().Add(-c.overdueAfter)); err != nil {
+		c.logger.ErrorContext(ctx, "count overdue schedules", "error", err)
+	} else {
+		metrics.SchedulesOverdue.Set(float64(overdue))
+	}
+
+	stats, err := c.repo.QueueStats(ctx, nil)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "collect queue stats", "error", err)
+		return
+	}
+
+	metrics.JobsPending.Set(float64(stats.Pending))
+	metrics.JobsOverdue.Set(float64(stats.Overdue))
+	var lag float64
+	if stats.OldestDueAt != nil {
+		lag = max(time.Since(*stats.OldestDueAt).Seconds(), 0)
+	}
+	metrics.SchedulingLag.Set(lag)
+}
@@ -2,58 +2,258 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"math"
-	"math/rand"
-	"net/http"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/metrics"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Worker struct {
-	id           string
-	repo         repository.JobRepository
-	attempts     repository.AttemptRepository
-	executor     *Executor
-	logger       *slog.Logger
-	pollInterval time.Duration
-	concurrency  int
-	sem          chan struct{}
+	id          string
+	repo        repository.JobRepository
+	attempts    repository.AttemptRepository
+	pings       repository.PingRepository
+	executors   map[domain.JobType]Executor
+	breaker     CircuitBreaker // the http executor's
+	logger      *slog.Logger
+	settings    atomic.Pointer[WorkerSettings]
+	queues      []string // job queues this worker claims from
+	prefetch    *prefetcher
+	claimPolicy domain.ClaimPolicy
+	failures    FailurePublisher
+	wakeups     repository.JobEventListener
+	queue       repository.ClaimQueue // nil = claim from Postgres directly
+	wake        chan struct{}
+	sem         chan struct{} // sized MaxConcurrency; settings.Concurrency slots are used
+	popOffset   int           // rotates which queue Pop checks first; used by consumeQueue only
+	claims      claimRate
+
+	// claimMu serialises claiming with Drain, so once Drain returns no claim is in
+	// progress and every claimed job already holds a semaphore slot.
+	claimMu  sync.Mutex
+	draining bool
+
+	// Jobs outlive the context Start runs with: running tracks them so Shutdown can wait,
+	// and cancelling stopped aborts the executions still going when its grace period ends.
+	running sync.WaitGroup
+	stopped context.Context
+	stop    context.CancelFunc
+}
+
+// errCancelRequested is the cause attached to a job's execution context when the user
+// cancels it mid-run.
+var errCancelRequested = errors.New("job cancelled while running")
+
+// errCircuitOpen is recorded as a deferred job's last error.
+var errCircuitOpen = errors.New("deferred: target host circuit open after repeated failures")
+
+// errWorkerShutdown is the cause attached to executions still running when the worker's
+// shutdown grace period ends.
+var errWorkerShutdown = errors.New("aborted: worker shut down before the request finished")
+
+// shutdownAbortWait bounds how long Shutdown waits for aborted jobs to record their outcome.
+const shutdownAbortWait = 5 * time.Second
+
+// releaseTimeout bounds handing prefetched jobs back when the worker drains.
+const releaseTimeout = 5 * time.Second
+
+// listenRetryInterval paces reconnects of the jobs_ready listener.
+const listenRetryInterval = 5 * time.Second
+
+// MaxConcurrency is the most jobs a worker can be configured to run at once.
+const MaxConcurrency = 100
+
+// WorkerSettings are the worker's knobs that can change while it runs; see Reload.
+type WorkerSettings struct {
+	Concurrency  int // 1..MaxConcurrency
+	PollInterval time.Duration
+	Limits       domain.ConcurrencyLimits
+}
+
+// Reload swaps in new settings. Claims after it use them; lowering Concurrency below the
+// jobs in flight lets them finish and claims nothing until enough have. The poll ticker
+// picks up a new interval at once.
+func (w *Worker) Reload(ctx context.Context, s WorkerSettings) {
+	s.Concurrency = min(max(s.Concurrency, 1), MaxConcurrency)
+	w.settings.Store(&s)
+	metrics.WorkerSlots.Set(float64(s.Concurrency))
+	w.logger.InfoContext(ctx, "worker settings reloaded",
+		"concurrency", s.Concurrency,
+		"poll_interval", s.PollInterval,
+		"user_max_concurrent_jobs", s.Limits.PerUser,
+		"host_max_concurrent_jobs", s.Limits.PerHost,
+	)
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Settings returns the settings in effect.
+func (w *Worker) Settings() WorkerSettings {
+	return *w.settings.Load()
+}
+
+// freeSlots is how many more jobs may run under the current concurrency.
+func (w *Worker) freeSlots() int {
+	return max(w.settings.Load().Concurrency-len(w.sem), 0)
+}
+
+// FailurePublisher receives jobs that failed permanently, so their owners can be
+// notified. Publish must not block.
+type FailurePublisher interface {
+	Publish(ctx context.Context, f domain.JobFailure)
+}
+
+// DrainStatus reports a worker's progress towards a warm shutdown.
+type DrainStatus struct {
+	Draining bool `json:"draining"`
+	InFlight int  `json:"in_flight"`
+}
+
+// Drain stops the worker from claiming new jobs; in-flight jobs run to completion.
+// It is idempotent and cannot be undone — a drained replica is expected to be terminated.
+// Held claims are released even if ctx is cancelled meanwhile.
+func (w *Worker) Drain(ctx context.Context) DrainStatus {
+	w.claimMu.Lock()
+	if !w.draining {
+		w.draining = true
+		w.logger.InfoContext(ctx, "worker draining", "in_flight", len(w.sem))
+
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+		w.releaseHeld(releaseCtx, true)
+		cancel()
+	}
+	w.claimMu.Unlock()
+	return w.DrainStatus()
+}
+
+func (w *Worker) DrainStatus() DrainStatus {
+	w.claimMu.Lock()
+	defer w.claimMu.Unlock()
+	return DrainStatus{Draining: w.draining, InFlight: len(w.sem)}
+}
+
+// Shutdown drains the worker and waits for in-flight jobs to finish. If ctx ends first,
+// the remaining executions are aborted and put back to run again right away, without using
+// up a retry, and Shutdown returns ctx's error once they are recorded.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.Drain(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		w.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	w.logger.WarnContext(ctx, "shutdown grace period expired, aborting in-flight jobs", "in_flight", w.DrainStatus().InFlight)
+	w.stop()
+	select {
+	case <-done:
+	case <-time.After(shutdownAbortWait):
+		w.logger.ErrorContext(ctx, "aborted jobs did not finish; the reaper will reschedule them", "in_flight", w.DrainStatus().InFlight)
+	}
+	return ctx.Err()
 }
 
 func NewWorker(
 	repo repository.JobRepository,
 	attempts repository.AttemptRepository,
+	pings repository.PingRepository,
+	certs repository.ClientCertRepository,
 	logger *slog.Logger,
-	pollInterval time.Duration,
-	concurrency int,
+	settings WorkerSettings,
+	queues []string,
+	prefetch int,
+	claimPolicy domain.ClaimPolicy,
+	responseCaptureBytes int,
+	maxResponseBytes int,
+	executorTimeout time.Duration,
+	breaker CircuitBreaker,
+	metricsHosts int,
+	failures FailurePublisher,
+	wakeups repository.JobEventListener,
+	queue repository.ClaimQueue,
 ) *Worker {
 	hostname, _ := os.Hostname()
 	id := fmt.Sprintf("%s-%d", hostname, os.Getpid())
-	return &Worker{
-		id:           id,
-		repo:         repo,
-		attempts:     attempts,
-		executor:     NewExecutor(logger),
-		logger:       logger.With("worker_id", id),
-		pollInterval: pollInterval,
-		concurrency:  concurrency,
-		sem:          make(chan struct{}, concurrency),
+	stopped, stop := context.WithCancel(context.Background())
+	executors := map[domain.JobType]Executor{
+		domain.JobTypeHTTP: NewHTTPExecutor(logger, responseCaptureBytes, maxResponseBytes, executorTimeout, breaker, certs, metricsHosts),
+	}
+	settings.Concurrency = min(max(settings.Concurrency, 1), MaxConcurrency)
+	w := &Worker{
+		id:          id,
+		repo:        repo,
+		attempts:    attempts,
+		pings:       pings,
+		executors:   executors,
+		breaker:     breaker,
+		logger:      logger.With("worker_id", id),
+		queues:      queues,
+		prefetch:    newPrefetcher(prefetch, settings.PollInterval),
+		claimPolicy: claimPolicy,
+		failures:    failures,
+		wakeups:     wakeups,
+		queue:       queue,
+		wake:        make(chan struct{}, 1),
+		sem:         make(chan struct{}, MaxConcurrency),
+		stopped:     stopped,
+		stop:        stop,
 	}
+	w.settings.Store(&settings)
+	return w
 }
 
 func (w *Worker) Start(ctx context.Context) {
 	metrics.WorkerStartTime.SetToCurrentTime()
+	settings := w.Settings()
+	metrics.WorkerSlots.Set(float64(settings.Concurrency))
 
-	ticker := time.NewTicker(w.pollInterval)
+	w.logger.InfoContext(ctx, "worker started",
+		"concurrency", settings.Concurrency,
+		"poll_interval", settings.PollInterval,
+		"queues", w.queues,
+		"prefetch", w.prefetch.limit,
+		"claim_policy", w.claimPolicy,
+		"claim_queue", w.queue != nil,
+		"user_max_concurrent_jobs", settings.Limits.PerUser,
+		"host_max_concurrent_jobs", settings.Limits.PerHost,
+		"job_types", slices.Sorted(maps.Keys(w.executors)),
+		"circuit_breaker_threshold", w.breaker.Threshold,
+	)
+
+	if w.queue != nil {
+		w.consumeQueue(ctx)
+		metrics.WorkerShutdownsTotal.Inc()
+		w.logger.InfoContext(ctx, "worker shut down")
+		return
+	}
+
+	interval := w.tickInterval()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	w.logger.InfoContext(ctx, "worker started", "concurrency", w.concurrency)
+	go w.listenForWork(ctx)
 
 	for {
 		select {
@@ -63,35 +263,207 @@ func (w *Worker) Start(ctx context.Context) {
 			return
 		case <-ticker.C:
 			w.processBatch(ctx)
+		case <-w.wake:
+			w.processBatch(ctx)
 		}
+		if next := w.tickInterval(); next != interval {
+			interval = next
+			ticker.Reset(interval)
+		}
+	}
+}
+
+// tickInterval is how often the poll loop claims without a wakeup.
+func (w *Worker) tickInterval() time.Duration {
+	interval := w.settings.Load().PollInterval
+	if w.prefetch.limit > 0 {
+		// Held jobs are released by processBatch, so it must run before they go stale.
+		interval = min(interval, prefetchMaxAge/2)
 	}
+	return interval
 }
 
+// listenForWork turns jobs_ready notifications into immediate claim attempts, so pickup
+// latency isn't bounded by the poll interval. The ticker remains the fallback: while the
+// listener is down, or when a notification is lost, jobs are still claimed on the next tick.
+func (w *Worker) listenForWork(ctx context.Context) {
+	for {
+		err := w.wakeups.ListenReady(ctx, func() {
+			// Coalesce: one pending wakeup already covers every job that became ready.
+			select {
+			case w.wake <- struct{}{}:
+			default:
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		w.logger.WarnContext(ctx, "jobs_ready listener stopped, relying on polling", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// processBatch claims jobs for the free slots, plus the prefetch target on top, and
+// launches as many claimed jobs as there are free slots. The rest are held for the next
+// call, which a finishing job triggers.
 func (w *Worker) processBatch(ctx context.Context) {
-	available := cap(w.sem) - len(w.sem)
-	if available == 0 {
+	w.claimMu.Lock()
+	defer w.claimMu.Unlock()
+	if w.draining {
 		return
 	}
 
-	jobs, err := w.repo.Claim(ctx, w.id, available)
-	if err != nil {
-		w.logger.ErrorContext(ctx, "claim jobs", "error", err)
+	w.releaseHeld(ctx, false)
+
+	available := w.freeSlots()
+	if want := w.prefetch.want(available); want > 0 {
+		jobs, err := w.repo.Claim(ctx, w.id, w.queues, want, w.claimPolicy, w.settings.Load().Limits)
+		if err != nil {
+			w.logger.ErrorContext(ctx, "claim jobs", "error", err)
+		} else {
+			observeClaim(want, len(jobs))
+			w.claims.add(len(jobs), time.Now())
+			w.prefetch.claimed(want, jobs, time.Now())
+			metrics.WorkerPrefetchTarget.Set(float64(w.prefetch.target))
+			if len(jobs) > 0 {
+				w.logger.InfoContext(ctx, "claimed jobs", "count", len(jobs), "requested", want, "slots_used", len(w.sem), "slots_total", w.settings.Load().Concurrency)
+			}
+		}
+	}
+
+	w.launch(ctx, w.prefetch.take(available))
+	metrics.WorkerPrefetchedJobs.Set(float64(len(w.prefetch.held)))
+}
+
+// releaseHeld hands prefetched jobs back to the pending pool: those held too long for a
+// slot, or all of them. Callers hold claimMu. On error the jobs stay claimed until the
+// reaper reschedules them.
+func (w *Worker) releaseHeld(ctx context.Context, all bool) {
+	ids := w.prefetch.expire(time.Now(), all)
+	if len(ids) == 0 {
 		return
 	}
+	metrics.WorkerPrefetchedJobs.Set(float64(len(w.prefetch.held)))
+	if err := w.repo.Release(ctx, w.id, ids); err != nil {
+		w.logger.ErrorContext(ctx, "release prefetched jobs", "count", len(ids), "error", err)
+		return
+	}
+	metrics.WorkerPrefetchReleasedTotal.Add(float64(len(ids)))
+	w.logger.InfoContext(ctx, "released prefetched jobs", "count", len(ids))
+}
 
+// observeClaim records how much of a claim request was filled.
+func observeClaim(requested, claimed int) {
+	result := "partial"
+	switch claimed {
+	case 0:
+		result = "empty"
+	case requested:
+		result = "full"
+	}
+	metrics.WorkerClaimsTotal.WithLabelValues(result).Inc()
+	metrics.WorkerClaimBatchSize.Observe(float64(claimed))
+	metrics.WorkerJobsClaimedTotal.Add(float64(claimed))
+}
+
+// consumeQueue claims the job IDs the mover pushes to the claim queue, popping only as
+// many as there are free slots. Pop blocks for up to the poll interval, so an idle worker
+// waits on Redis instead of polling Postgres. Concurrency caps are not applied to queued
+// jobs; per-user and per-host limits need the Postgres claim.
+func (w *Worker) consumeQueue(ctx context.Context) {
+	for ctx.Err() == nil {
+		available := w.freeSlots()
+		pollInterval := w.settings.Load().PollInterval
+		if available == 0 || w.DrainStatus().Draining {
+			select {
+			case <-ctx.Done():
+			case <-w.wake: // a slot was freed
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		// Pop favours the first queue listed, so start from a different one each time to
+		// keep a busy queue from starving the others.
+		w.popOffset = (w.popOffset + 1) % len(w.queues)
+		queues := slices.Concat(w.queues[w.popOffset:], w.queues[:w.popOffset])
+
+		queue, ids, err := w.queue.Pop(ctx, queues, available, pollInterval)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			w.logger.ErrorContext(ctx, "pop claim queue", "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+		if len(ids) > 0 {
+			w.claimQueued(ctx, queue, ids)
+		}
+	}
+}
+
+func (w *Worker) claimQueued(ctx context.Context, queue string, ids []string) {
+	w.claimMu.Lock()
+	defer w.claimMu.Unlock()
+	if w.draining {
+		// Hand the IDs back so another replica claims them now rather than after redelivery.
+		if err := w.queue.Push(ctx, queue, ids); err != nil {
+			w.logger.WarnContext(ctx, "return job ids to claim queue", "count", len(ids), "error", err)
+		}
+		return
+	}
+
+	// On error the jobs stay pending and the mover pushes them again after redelivery.
+	jobs, err := w.repo.ClaimByIDs(ctx, w.id, ids)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "claim queued jobs", "error", err)
+		return
+	}
 	if len(jobs) == 0 {
 		return
 	}
+	metrics.WorkerJobsClaimedTotal.Add(float64(len(jobs)))
+	w.claims.add(len(jobs), time.Now())
 
-	w.logger.InfoContext(ctx, "claimed jobs", "count", len(jobs), "slots_used", len(w.sem)+len(jobs), "slots_total", cap(w.sem))
+	w.logger.InfoContext(ctx, "claimed jobs", "count", len(jobs), "slots_used", len(w.sem)+len(jobs), "slots_total", w.settings.Load().Concurrency)
+	w.launch(ctx, jobs)
+}
 
+// launch runs each claimed job in its own goroutine holding a semaphore slot. Callers
+// hold claimMu and claim no more jobs than there are free slots. Jobs don't stop with
+// ctx; Shutdown decides how long they may keep running.
+func (w *Worker) launch(ctx context.Context, jobs []*domain.Job) {
+	ctx = context.WithoutCancel(ctx)
 	for _, job := range jobs {
 		w.sem <- struct{}{}
+		w.running.Add(1)
 		go func(j *domain.Job) {
+			defer w.running.Done()
 			metrics.JobsInFlight.Inc()
 			defer metrics.JobsInFlight.Dec()
-			defer func() { <-w.sem }()
+			defer func() {
+				<-w.sem
+				if w.queue != nil || w.prefetch.limit > 0 {
+					// Let consumeQueue pop, or the poll loop launch a prefetched job, for
+					// the freed slot; without prefetch the poll loop keeps its ticker.
+					select {
+					case w.wake <- struct{}{}:
+					default:
+					}
+				}
+			}()
+			started := time.Now()
 			w.runJob(ctx, j)
+			w.prefetch.ran(time.Since(started))
 		}(job)
 	}
 }
@@ -99,6 +471,40 @@ func (w *Worker) processBatch(ctx context.Context) {
 func (w *Worker) runJob(ctx context.Context, job *domain.Job) {
 	metrics.JobPickupLatency.Observe(time.Since(job.CreatedAt).Seconds())
 
+	// Continue the trace of the API call that created the job, if it was traced.
+	if job.TraceParent != nil {
+		ctx = tracing.WithTraceParent(ctx, *job.TraceParent)
+	}
+	ctx, span := tracing.Start(ctx, "Worker.runJob", trace.WithAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.Int("job.attempt", job.RetryCount+1),
+	))
+	defer span.End()
+
+	// A job of a type this worker has no executor for — one created through a newer
+	// version, or left behind when an executor was turned off — fails without running.
+	executor, ok := w.executorFor(job)
+	if !ok {
+		w.failJob(ctx, job, fmt.Sprintf("no executor for job type %q", job.JobType))
+		return
+	}
+
+	if job.Ping {
+		w.runPing(ctx, job, executor)
+		return
+	}
+
+	// A job claimed after its deadline (e.g. from a backlog) is failed without executing.
+	if job.Deadline != nil && !time.Now().Before(*job.Deadline) {
+		w.failJob(ctx, job, domain.DeadlineExceededError)
+		return
+	}
+
+	if retryAt, ok := executor.Allow(job); !ok {
+		w.deferJob(ctx, job, retryAt)
+		return
+	}
+
 	startedAt := time.Now()
 
 	// Open the attempt record before executing so a worker crash leaves a
@@ -118,19 +524,45 @@ func (w *Worker) runJob(ctx context.Context, job *domain.Job) {
 		return
 	}
 
+	execCtx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+	stopAbort := context.AfterFunc(w.stopped, func() { abort(errWorkerShutdown) })
+	defer stopAbort()
 	heartbeatCtx, cancelHeartbeat := context.WithCancel(ctx)
 	defer cancelHeartbeat()
-	go w.heartbeat(heartbeatCtx, job.ID)
+	go w.heartbeat(heartbeatCtx, job.ID, abort)
 
-	w.logger.InfoContext(ctx, "executing job", "job_id", job.ID, "method", job.Method, "url", job.URL)
+	w.logger.InfoContext(ctx, "executing job", "job_id", job.ID, "job_type", job.JobType, "method", job.Method, "url", job.URL)
 
-	result := w.executor.Run(ctx, job)
+	result := executor.Run(execCtx, job, attempt.IdempotencyKey())
 	durationMS := time.Since(startedAt).Milliseconds()
+	attempt.DurationMS = &durationMS
+	if result.StatusCode != 0 {
+		attempt.StatusCode = &result.StatusCode
+		attempt.ResponseBytes = &result.ResponseBytes
+		attempt.ResponseTruncated = result.ResponseTruncated
+		attempt.ResponseHeaders = result.ResponseHeaders
+		attempt.ResponseBody = result.ResponseBody
+	}
+	if result.RemoteAddr != "" {
+		attempt.RemoteAddr = &result.RemoteAddr
+		attempt.RequestBytes = &result.RequestBytes
+	}
+	if req := result.Request; req != nil {
+		attempt.RequestMethod = &req.Method
+		attempt.RequestURL = &req.URL
+		attempt.RequestHeaders = req.Headers
+		attempt.RequestBody = req.Body
+	}
 
-	if result.Err == nil && result.StatusCode == http.StatusOK {
+	if result.Succeeded(job) {
 		metrics.JobExecutionDuration.WithLabelValues("success").Observe(result.Duration.Seconds())
 		metrics.JobsCompletedTotal.WithLabelValues("success").Inc()
-		w.closeAttempt(ctx, attempt, &result.StatusCode, nil, durationMS)
+		metrics.JobSuccessAttempt.Observe(float64(job.RetryCount + 1))
+		if job.RetryCount > 0 {
+			metrics.RetriedJobsFinishedTotal.WithLabelValues("success").Inc()
+		}
+		w.closeAttempt(ctx, attempt)
 		if err := w.repo.Complete(ctx, job.ID); err != nil {
 			w.logger.ErrorContext(ctx, "mark job complete", "job_id", job.ID, "error", err)
 		}
@@ -138,6 +570,18 @@ func (w *Worker) runJob(ctx context.Context, job *domain.Job) {
 		return
 	}
 
+	// A response that arrived before the abort still counts; only an aborted call is cancelled.
+	if result.Err != nil && errors.Is(context.Cause(execCtx), errCancelRequested) {
+		w.cancelJob(ctx, job, attempt)
+		return
+	}
+
+	// The target wasn't at fault, so a shutdown neither fails the job nor uses up a retry.
+	if result.Err != nil && errors.Is(context.Cause(execCtx), errWorkerShutdown) {
+		w.requeueAborted(ctx, job, attempt)
+		return
+	}
+
 	errMsg := ""
 	if result.Err != nil {
 		errMsg = result.Err.Error()
@@ -145,19 +589,26 @@ func (w *Worker) runJob(ctx context.Context, job *domain.Job) {
 		errMsg = fmt.Sprintf("unexpected status code: %d", result.StatusCode)
 	}
 
-	var statusCode *int
-	if result.StatusCode != 0 {
-		statusCode = &result.StatusCode
-	}
 	metrics.JobExecutionDuration.WithLabelValues("failure").Observe(result.Duration.Seconds())
-	w.closeAttempt(ctx, attempt, statusCode, &errMsg, durationMS)
+	attempt.Error = &errMsg
+	w.closeAttempt(ctx, attempt)
 
-	if job.RetryCount < job.MaxRetries {
-		retryAt := time.Now().Add(retryDelay(job.Backoff, job.RetryCount))
+	delay := job.RetryDelay(job.RetryCount)
+	retryAt := time.Now().Add(delay)
+	switch {
+	case job.RetryCount >= job.MaxRetries:
+		w.failJob(ctx, job, errMsg)
+	case job.Deadline != nil && retryAt.After(*job.Deadline):
+		// The retry would only start after the deadline — give up now.
+		w.failJob(ctx, job, domain.DeadlineExceededError+": "+errMsg)
+	default:
 		if err := w.repo.Reschedule(ctx, job.ID, errMsg, retryAt); err != nil {
 			w.logger.ErrorContext(ctx, "reschedule job", "job_id", job.ID, "error", err)
 		}
 		metrics.JobsCompletedTotal.WithLabelValues("retry").Inc()
+		strategy := job.RetryStrategy(job.RetryCount)
+		metrics.RetriesScheduledTotal.WithLabelValues(strategy, attemptLabel(job.RetryCount+1)).Inc()
+		metrics.RetryDelay.WithLabelValues(strategy).Observe(delay.Seconds())
 		w.logger.WarnContext(ctx, "job failed, will retry",
 			"job_id", job.ID,
 			"error", errMsg,
@@ -165,23 +616,153 @@ func (w *Worker) runJob(ctx context.Context, job *domain.Job) {
 			"max_retries", job.MaxRetries,
 			"retry_at", retryAt,
 		)
-	} else {
-		if err := w.repo.Fail(ctx, job.ID, errMsg); err != nil {
+	}
+}
+
+// attemptLabel bounds the attempt label of RetriesScheduledTotal.
+func attemptLabel(attempt int) string {
+	if attempt >= 10 {
+		return "10+"
+	}
+	return strconv.Itoa(attempt)
+}
+
+// failJob marks a job permanently failed.
+func (w *Worker) failJob(ctx context.Context, job *domain.Job, errMsg string) {
+	err := w.repo.Fail(ctx, job.ID, errMsg)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "mark job failed", "job_id", job.ID, "error", err)
+	}
+	metrics.JobsCompletedTotal.WithLabelValues("failed").Inc()
+	if job.RetryCount > 0 {
+		metrics.RetriedJobsFinishedTotal.WithLabelValues("failed").Inc()
+	}
+	w.logger.WarnContext(ctx, "job permanently failed", "job_id", job.ID, "error", errMsg)
+
+	// If the write failed the job is still running; the reaper will fail and report it.
+	if err == nil {
+		w.failures.Publish(ctx, domain.JobFailure{
+			JobID:      job.ID,
+			UserID:     job.UserID,
+			ScheduleID: job.ScheduleID,
+			URL:        job.URL,
+			Error:      errMsg,
+			FailedAt:   time.Now(),
+		})
+	}
+}
+
+// deferJob puts back a job whose host's circuit is open, to run once it may close. No
+// attempt is recorded and the job's retries are untouched: the target was not called.
+func (w *Worker) deferJob(ctx context.Context, job *domain.Job, retryAt time.Time) {
+	if job.Deadline != nil && retryAt.After(*job.Deadline) {
+		w.failJob(ctx, job, domain.DeadlineExceededError+": "+errCircuitOpen.Error())
+		return
+	}
+	if err := w.repo.Defer(ctx, job.ID, errCircuitOpen.Error(), retryAt); err != nil {
+		w.logger.ErrorContext(ctx, "defer job", "job_id", job.ID, "error", err)
+		return
+	}
+	metrics.JobsDeferredTotal.Inc()
+	w.logger.InfoContext(ctx, "target host circuit open, job deferred", "job_id", job.ID, "retry_at", retryAt)
+}
+
+// requeueAborted puts a job whose execution a worker shutdown aborted back to pending,
+// due now, without counting the attempt: its record is dropped so the rerun takes over
+// the attempt number and idempotency key, letting a target that saw the first request
+// deduplicate the second.
+func (w *Worker) requeueAborted(ctx context.Context, job *domain.Job, attempt *domain.JobAttempt) {
+	if err := w.attempts.DeleteAttempt(ctx, attempt.ID); err != nil {
+		// The rerun can't open its attempt record while this one exists; the reaper
+		// recovers it then.
+		w.logger.ErrorContext(ctx, "delete aborted attempt record", "job_id", job.ID, "error", err)
+	}
+	if err := w.repo.Defer(ctx, job.ID, errWorkerShutdown.Error(), time.Now()); err != nil {
+		w.logger.ErrorContext(ctx, "requeue aborted job", "job_id", job.ID, "error", err)
+		return
+	}
+	w.logger.WarnContext(ctx, "job aborted by shutdown, requeued", "job_id", job.ID)
+}
+
+func (w *Worker) cancelJob(ctx context.Context, job *domain.Job, attempt *domain.JobAttempt) {
+	errMsg := errCancelRequested.Error()
+	attempt.Error = &errMsg
+	attempt.Cancelled = true
+	w.closeAttempt(ctx, attempt)
+	if err := w.repo.MarkCancelled(ctx, job.ID); err != nil {
+		w.logger.ErrorContext(ctx, "mark job cancelled", "job_id", job.ID, "error", err)
+	}
+	metrics.JobsCompletedTotal.WithLabelValues("cancelled").Inc()
+	w.logger.InfoContext(ctx, "job cancelled while running", "job_id", job.ID)
+}
+
+// runPing executes a ping-mode job without attempt records. The outcome is folded into
+// the schedule's uptime rollup and the job row is deleted, so frequent checks stay cheap.
+// A crash mid-ping leaves the job running; the reaper fails it like any other stale job.
+func (w *Worker) runPing(ctx context.Context, job *domain.Job, executor Executor) {
+	if job.ScheduleID == nil {
+		w.logger.ErrorContext(ctx, "ping job without schedule", "job_id", job.ID)
+		if err := w.repo.Fail(ctx, job.ID, "ping job without schedule"); err != nil {
 			w.logger.ErrorContext(ctx, "mark job failed", "job_id", job.ID, "error", err)
 		}
-		metrics.JobsCompletedTotal.WithLabelValues("failed").Inc()
-		w.logger.WarnContext(ctx, "job permanently failed", "job_id", job.ID, "error", errMsg)
+		return
+	}
+
+	execCtx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+	stopAbort := context.AfterFunc(w.stopped, func() { abort(errWorkerShutdown) })
+	defer stopAbort()
+	heartbeatCtx, cancelHeartbeat := context.WithCancel(ctx)
+	go w.heartbeat(heartbeatCtx, job.ID, abort)
+	result := executor.Run(execCtx, job, domain.AttemptIdempotencyKey(job.ID, job.RetryCount+1))
+	cancelHeartbeat()
+
+	// An aborted check says nothing about the target, so it stays out of the uptime data.
+	cause := context.Cause(execCtx)
+	if result.Err != nil && (errors.Is(cause, errCancelRequested) || errors.Is(cause, errWorkerShutdown)) {
+		if err := w.repo.MarkCancelled(ctx, job.ID); err != nil {
+			w.logger.ErrorContext(ctx, "mark job cancelled", "job_id", job.ID, "error", err)
+		}
+		metrics.JobsCompletedTotal.WithLabelValues("cancelled").Inc()
+		return
+	}
+
+	ok := result.Succeeded(job)
+
+	check := repository.PingCheck{
+		JobID:      job.ID,
+		ScheduleID: *job.ScheduleID,
+		At:         time.Now(),
+		OK:         ok,
+		DurationMS: result.Duration.Milliseconds(),
+	}
+	outcome := "success"
+	if !ok {
+		outcome = "failure"
+		errMsg := fmt.Sprintf("unexpected status code: %d", result.StatusCode)
+		if result.Err != nil {
+			errMsg = result.Err.Error()
+		}
+		check.Error = &errMsg
+	}
+	metrics.JobExecutionDuration.WithLabelValues(outcome).Observe(result.Duration.Seconds())
+	metrics.PingChecksTotal.WithLabelValues(outcome).Inc()
+
+	if err := w.pings.RecordCheck(ctx, check); err != nil {
+		w.logger.ErrorContext(ctx, "record ping check", "job_id", job.ID, "schedule_id", *job.ScheduleID, "error", err)
 	}
 }
 
-// closeAttempt writes the execution outcome to the attempt record.
-func (w *Worker) closeAttempt(ctx context.Context, attempt *domain.JobAttempt, statusCode *int, errMsg *string, durationMS int64) {
-	if err := w.attempts.CompleteAttempt(ctx, attempt.ID, statusCode, errMsg, durationMS); err != nil {
+// closeAttempt writes the execution outcome carried on attempt to the attempt record.
+func (w *Worker) closeAttempt(ctx context.Context, attempt *domain.JobAttempt) {
+	if err := w.attempts.CompleteAttempt(ctx, attempt); err != nil {
 		w.logger.ErrorContext(ctx, "complete attempt record", "job_id", attempt.JobID, "error", err)
 	}
 }
 
-func (w *Worker) heartbeat(ctx context.Context, jobID string) {
+// heartbeat extends the job's lease until ctx is done. It doubles as the cancellation
+// check: once the user asks to cancel the job, abort is called and the heartbeat stops.
+func (w *Worker) heartbeat(ctx context.Context, jobID string, abort context.CancelCauseFunc) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 	for {
@@ -189,24 +770,16 @@ func (w *Worker) heartbeat(ctx context.Context, jobID string) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			if err := w.repo.UpdateHeartbeat(ctx, jobID); err != nil {
+			cancelRequested, err := w.repo.UpdateHeartbeat(ctx, jobID)
+			if err != nil {
 				w.logger.WarnContext(ctx, "heartbeat failed", "job_id", jobID, "error", err)
+				continue
+			}
+			if cancelRequested {
+				w.logger.InfoContext(ctx, "cancel requested, aborting job", "job_id", jobID)
+				abort(errCancelRequested)
+				return
 			}
 		}
 	}
 }
-
-func retryDelay(backoff domain.Backoff, retryCount int) time.Duration {
-	base := 30 * time.Second
-	switch backoff {
-	case domain.BackoffExponential:
-		delay := time.Duration(float64(base) * math.Pow(2, float64(retryCount)))
-		delay = min(delay, time.Hour)
-		jitter := time.Duration(rand.Int63n(int64(delay/2))) - delay/4
-		return delay + jitter
-	case domain.BackoffLinear:
-		return base * time.Duration(retryCount+1)
-	default:
-		return base
-	}
-}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// fakeJobRepo records how runJob left a job. Methods it doesn't override panic.
+type fakeJobRepo struct {
+	repository.JobRepository
+	deferred, failed, rescheduled []string
+}
+
+func (f *fakeJobRepo) Defer(_ context.Context, jobID, _ string, _ time.Time) error {
+	f.deferred = append(f.deferred, jobID)
+	return nil
+}
+
+func (f *fakeJobRepo) Fail(_ context.Context, jobID, _ string) error {
+	f.failed = append(f.failed, jobID)
+	return nil
+}
+
+func (f *fakeJobRepo) Reschedule(_ context.Context, jobID, _ string, _ time.Time) error {
+	f.rescheduled = append(f.rescheduled, jobID)
+	return nil
+}
+
+type fakeAttemptRepo struct {
+	repository.AttemptRepository
+	open map[string]bool
+}
+
+func (f *fakeAttemptRepo) CreateAttempt(_ context.Context, a *domain.JobAttempt) (*domain.JobAttempt, error) {
+	created := *a
+	created.ID = "attempt-1"
+	f.open[created.ID] = true
+	return &created, nil
+}
+
+func (f *fakeAttemptRepo) CompleteAttempt(_ context.Context, _ *domain.JobAttempt) error {
+	return nil
+}
+
+func (f *fakeAttemptRepo) DeleteAttempt(_ context.Context, id string) error {
+	delete(f.open, id)
+	return nil
+}
+
+// blockingExecutor runs until its context is cancelled, like a slow target.
+type blockingExecutor struct {
+	started chan struct{}
+}
+
+func (e *blockingExecutor) Run(ctx context.Context, _ *domain.Job, _ string) ExecutionResult {
+	close(e.started)
+	<-ctx.Done()
+	return ExecutionResult{Err: ctx.Err()}
+}
+
+func (e *blockingExecutor) Allow(_ *domain.Job) (time.Time, bool) {
+	return time.Time{}, true
+}
+
+type failurePublisherFunc func(domain.JobFailure)
+
+func (f failurePublisherFunc) Publish(_ context.Context, jf domain.JobFailure) { f(jf) }
+
+func TestRunJob_ShutdownAbortOnLastAttempt_Requeues(t *testing.T) {
+	jobs := &fakeJobRepo{}
+	attempts := &fakeAttemptRepo{open: map[string]bool{}}
+	executor := &blockingExecutor{started: make(chan struct{})}
+	stopped, stop := context.WithCancel(context.Background())
+	w := &Worker{
+		repo:      jobs,
+		attempts:  attempts,
+		executors: map[domain.JobType]Executor{domain.JobTypeHTTP: executor},
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		failures: failurePublisherFunc(func(f domain.JobFailure) {
+			t.Errorf("failure published for job %s", f.JobID)
+		}),
+		stopped: stopped,
+		stop:    stop,
+	}
+	job := &domain.Job{
+		ID:         "job-1",
+		JobType:    domain.JobTypeHTTP,
+		RetryCount: 3,
+		MaxRetries: 3,
+		CreatedAt:  time.Now(),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.runJob(context.Background(), job)
+		close(done)
+	}()
+	<-executor.started
+	w.stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runJob did not return after the shutdown abort")
+	}
+
+	if len(jobs.deferred) != 1 || len(jobs.failed) != 0 || len(jobs.rescheduled) != 0 {
+		t.Errorf("deferred=%v failed=%v rescheduled=%v, want the job deferred only",
+			jobs.deferred, jobs.failed, jobs.rescheduled)
+	}
+	if len(attempts.open) != 0 {
+		t.Errorf("aborted attempt record kept: %v", attempts.open)
+	}
+}
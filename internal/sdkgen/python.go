@@ -0,0 +1,320 @@
+package sdkgen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+)
+
+var pyIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// pyKeywords are the reserved words a parameter or TypedDict key could collide with.
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true, "finally": true,
+	"for": true, "from": true, "global": true, "if": true, "import": true, "in": true,
+	"is": true, "lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true,
+	"raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// pyName makes a parameter name usable in Python, e.g. from → from_.
+func pyName(s string) string {
+	s = nonIdent.ReplaceAllString(s, "_")
+	if pyKeywords[s] {
+		return s + "_"
+	}
+	return s
+}
+
+// pyTypes renders Python types. Objects with properties need a named TypedDict, so
+// nested ones are declared ahead of their parent, named after it and their key.
+type pyTypes struct {
+	decls []string
+	names map[string]bool
+}
+
+func python(a *api) []byte {
+	g := &pyTypes{names: map[string]bool{}}
+	g.typ(a.errors, "ErrorResponse")
+	var methods strings.Builder
+	for _, op := range a.ops {
+		methods.WriteString("\n")
+		g.method(&methods, op)
+	}
+
+	var b strings.Builder
+	b.WriteString("# Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.\n")
+	fmt.Fprintf(&b, "# %s, version %s.\n", a.title, a.version)
+	b.WriteString(pyImports)
+	for _, d := range g.decls {
+		b.WriteString("\n\n")
+		b.WriteString(d)
+	}
+	b.WriteString(pyRuntime)
+	b.WriteString(methods.String())
+	return []byte(b.String())
+}
+
+// declare reserves a unique type name derived from want.
+func (g *pyTypes) declare(want string) string {
+	name := want
+	for i := 2; g.names[name]; i++ {
+		name = want + strconv.Itoa(i)
+	}
+	g.names[name] = true
+	return name
+}
+
+// typ returns the type expression for s; name is the TypedDict name to use if s is an
+// object with properties, or the alias to declare for a top-level body.
+func (g *pyTypes) typ(s *eventschema.Schema, name string) string {
+	if s == nil {
+		return "Any"
+	}
+	ts, nullable := types(s)
+	var alts []string
+	if values := enumValues(s); len(values) > 0 {
+		var lits []string
+		for _, v := range values {
+			if v != nil {
+				lits = append(lits, pyLiteral(v))
+			}
+		}
+		alts = append(alts, "Literal["+strings.Join(lits, ", ")+"]")
+	} else {
+		for _, t := range ts {
+			switch t {
+			case "string":
+				alts = append(alts, "str")
+			case "integer":
+				alts = append(alts, "int")
+			case "number":
+				alts = append(alts, "float")
+			case "boolean":
+				alts = append(alts, "bool")
+			case "array":
+				alts = append(alts, "list["+g.typ(s.Items, name+"Item")+"]")
+			case "object":
+				switch {
+				case len(s.Properties) > 0:
+					alts = append(alts, g.typedDict(s, name))
+				case additional(s) != nil:
+					alts = append(alts, "dict[str, "+g.typ(additional(s), name+"Value")+"]")
+				default:
+					alts = append(alts, "dict[str, Any]")
+				}
+			}
+		}
+	}
+	if len(alts) == 0 {
+		return "Any"
+	}
+	if nullable {
+		alts = append(alts, "None")
+	}
+	return strings.Join(alts, " | ")
+}
+
+func (g *pyTypes) typedDict(s *eventschema.Schema, want string) string {
+	name := g.declare(want)
+	keys := sortedKeys(s)
+	fields := make([]string, len(keys))
+	classSyntax := true
+	for i, key := range keys {
+		typ := g.typ(s.Properties[key], name+pascal(key))
+		if !required(s, key) {
+			typ = "NotRequired[" + typ + "]"
+		}
+		fields[i] = typ
+		if !pyIdent.MatchString(key) || pyKeywords[key] {
+			classSyntax = false
+		}
+	}
+
+	var b strings.Builder
+	if classSyntax {
+		fmt.Fprintf(&b, "class %s(TypedDict):\n", name)
+		for i, key := range keys {
+			fmt.Fprintf(&b, "    %s: %s\n", key, fields[i])
+		}
+	} else {
+		fmt.Fprintf(&b, "%s = TypedDict(\n    %q,\n    {\n", name, name)
+		for i, key := range keys {
+			fmt.Fprintf(&b, "        %s: %s,\n", literal(key), fields[i])
+		}
+		b.WriteString("    },\n)\n")
+	}
+	g.decls = append(g.decls, b.String())
+	return name
+}
+
+// alias declares a top-level request or response body under name, unless it already is
+// a TypedDict of that name.
+func (g *pyTypes) alias(s *eventschema.Schema, name string) string {
+	typ := g.typ(s, name)
+	if typ != name {
+		g.declare(name)
+		g.decls = append(g.decls, fmt.Sprintf("%s = %s\n", name, typ))
+	}
+	return name
+}
+
+func (g *pyTypes) method(b *strings.Builder, op operation) {
+	params := []string{"self"}
+	path := op.path
+	for _, p := range op.pathParams {
+		params = append(params, pyName(p)+": str")
+		path = strings.ReplaceAll(path, "{"+p+"}", "{_quote("+pyName(p)+")}")
+	}
+	name := pascal(op.id)
+	body := "None"
+	if op.request != nil {
+		params = append(params, "body: "+g.alias(op.request, name+"Request"))
+		body = "body"
+	}
+	query := "None"
+	if len(op.query) > 0 {
+		params = append(params, "*")
+		entries := make([]string, len(op.query))
+		for i, q := range op.query {
+			typ := "str"
+			switch q.typ {
+			case "integer":
+				typ = "int"
+			case "boolean":
+				typ = "bool"
+			}
+			params = append(params, pyName(q.name)+": "+typ+" | None = None")
+			entries[i] = strconv.Quote(q.name) + ": " + pyName(q.name)
+		}
+		query = "{" + strings.Join(entries, ", ") + "}"
+	}
+
+	var result, call string
+	switch {
+	case op.raw:
+		result, call = "http.client.HTTPResponse", "_send"
+	case op.response != nil && op.optional:
+		result, call = g.alias(op.response, name+"Response")+" | None", "_json"
+	case op.response != nil:
+		result, call = g.alias(op.response, name+"Response"), "_json"
+	default:
+		result, call = "None", "_json"
+	}
+
+	fmt.Fprintf(b, "    def %s(%s) -> %s:\n", op.id, strings.Join(params, ", "), result)
+	if op.summary != "" {
+		doc := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(op.summary)
+		fmt.Fprintf(b, "        \"\"\"%s\"\"\"\n", doc)
+	}
+	if op.raw {
+		b.WriteString("        # The caller reads and closes the response.\n")
+	}
+	pathLit := strconv.Quote(path)
+	if len(op.pathParams) > 0 {
+		pathLit = "f" + pathLit
+	}
+	fmt.Fprintf(b, "        return self.%s(%q, %s, %s, %s)\n", call, op.method, pathLit, query, body)
+}
+
+func pyLiteral(v any) string {
+	switch v {
+	case true:
+		return "True"
+	case false:
+		return "False"
+	}
+	return literal(v)
+}
+
+const pyImports = `
+from __future__ import annotations
+
+import http.client
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from typing import Any, Literal, NotRequired, TypedDict
+`
+
+const pyRuntime = `
+
+class ApiError(Exception):
+    """Raised for every non-2xx response; body is None when it wasn't JSON."""
+
+    def __init__(self, status: int, body: ErrorResponse | None) -> None:
+        message = body["error"]["message"] if body else f"request failed with status {status}"
+        super().__init__(message)
+        self.status = status
+        self.body = body
+
+
+def _quote(value: str) -> str:
+    return urllib.parse.quote(value, safe="")
+
+
+def _query_value(value: str | int | bool) -> str:
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    return str(value)
+
+
+class Client:
+    """Calls the API as the token's user, or in the organization org_id names."""
+
+    def __init__(
+        self,
+        base_url: str,
+        token: str | None = None,
+        org_id: str | None = None,
+        timeout: float = 30.0,
+    ) -> None:
+        self._base_url = base_url.rstrip("/")
+        self._token = token
+        self._org_id = org_id
+        self._timeout = timeout
+
+    def _send(
+        self,
+        method: str,
+        path: str,
+        query: dict[str, Any] | None = None,
+        body: Any = None,
+    ) -> http.client.HTTPResponse:
+        url = self._base_url + path
+        params = {k: _query_value(v) for k, v in (query or {}).items() if v is not None}
+        if params:
+            url += "?" + urllib.parse.urlencode(params)
+        data = None if body is None else json.dumps(body).encode()
+        req = urllib.request.Request(url, data=data, method=method)
+        if self._token:
+            req.add_header("Authorization", f"Bearer {self._token}")
+        if self._org_id:
+            req.add_header("X-Org-ID", self._org_id)
+        if data is not None:
+            req.add_header("Content-Type", "application/json")
+        try:
+            return urllib.request.urlopen(req, timeout=self._timeout)
+        except urllib.error.HTTPError as e:
+            try:
+                err = json.load(e)
+            except ValueError:
+                err = None
+            raise ApiError(e.code, err) from None
+
+    def _json(
+        self,
+        method: str,
+        path: str,
+        query: dict[str, Any] | None = None,
+        body: Any = None,
+    ) -> Any:
+        with self._send(method, path, query, body) as resp:
+            raw = resp.read()
+        return json.loads(raw) if raw else None
+`
@@ -0,0 +1,259 @@
+// Package sdkgen renders TypeScript and Python client stubs from the API's OpenAPI
+// document: one typed method per operation and a type for each request and response
+// body. The runtime part is deliberately thin — base URL, bearer token, X-Org-ID and
+// decoding error bodies — so the stubs only depend on each language's standard library.
+package sdkgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+)
+
+// File is one generated file; Path is relative to the SDK root (sdk/ in this repo).
+type File struct {
+	Path    string
+	Content []byte
+}
+
+const (
+	TypeScriptPath = "typescript/src/index.ts"
+	PythonPath     = "python/dist_job_scheduler/__init__.py"
+)
+
+// Generate renders both stubs from an OpenAPI document as served at /openapi.json.
+func Generate(spec []byte) ([]File, error) {
+	api, err := parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return []File{
+		{Path: TypeScriptPath, Content: typeScript(api)},
+		{Path: PythonPath, Content: python(api)},
+	}, nil
+}
+
+// document is the subset of OpenAPI 3.1 that openapi.Build emits and the stubs need.
+type document struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths map[string]map[string]struct {
+		OperationID string `json:"operationId"`
+		Summary     string `json:"summary"`
+		Parameters  []struct {
+			Name   string              `json:"name"`
+			In     string              `json:"in"`
+			Schema *eventschema.Schema `json:"schema"`
+		} `json:"parameters"`
+		RequestBody *struct {
+			Content map[string]mediaType `json:"content"`
+		} `json:"requestBody"`
+		Responses map[string]struct {
+			Content map[string]mediaType `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+}
+
+type mediaType struct {
+	Schema *eventschema.Schema `json:"schema"`
+}
+
+// api is the document flattened into what both renderers walk.
+type api struct {
+	title   string
+	version string
+	errors  *eventschema.Schema // the shared "default" response body
+	ops     []operation
+}
+
+type operation struct {
+	id         string // the operationId as an identifier, e.g. post_jobs_id_pause
+	method     string
+	path       string // OpenAPI syntax, e.g. /jobs/{id}/pause
+	summary    string
+	pathParams []string
+	query      []queryParam
+	request    *eventschema.Schema // nil for none
+	response   *eventschema.Schema // the JSON body of a 2xx response; nil for none, empty if undocumented
+	optional   bool                // another 2xx response has no body, e.g. 202 next to 200
+	raw        bool                // a 2xx response is not JSON (event streams): return it unread
+}
+
+type queryParam struct {
+	name string
+	typ  string // "string", "integer" or "boolean"
+}
+
+var nonIdent = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// methodOrder keeps the operations of a path in a stable order.
+var methodOrder = map[string]int{"get": 0, "post": 1, "put": 2, "patch": 3, "delete": 4}
+
+func parse(spec []byte) (*api, error) {
+	var doc document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("parse openapi document: %w", err)
+	}
+
+	out := &api{title: doc.Info.Title, version: doc.Info.Version}
+	for path, methods := range doc.Paths {
+		for method, o := range methods {
+			op := operation{
+				id:      nonIdent.ReplaceAllString(o.OperationID, "_"),
+				method:  strings.ToUpper(method),
+				path:    path,
+				summary: o.Summary,
+			}
+			for _, p := range o.Parameters {
+				switch p.In {
+				case "path":
+					op.pathParams = append(op.pathParams, p.Name)
+				case "query":
+					typ := "string"
+					if p.Schema != nil && len(p.Schema.Type) > 0 {
+						typ = p.Schema.Type[0]
+					}
+					op.query = append(op.query, queryParam{name: p.Name, typ: typ})
+				}
+			}
+			if o.RequestBody != nil {
+				op.request = o.RequestBody.Content["application/json"].Schema
+			}
+
+			statuses := make([]string, 0, len(o.Responses))
+			for status := range o.Responses {
+				statuses = append(statuses, status)
+			}
+			sort.Strings(statuses)
+			bodiless, untyped := false, false
+			for _, status := range statuses {
+				r := o.Responses[status]
+				if status == "default" {
+					if out.errors == nil {
+						out.errors = r.Content["application/json"].Schema
+					}
+					continue
+				}
+				if !strings.HasPrefix(status, "2") {
+					continue
+				}
+				switch media, ok := r.Content["application/json"]; {
+				case ok && op.response == nil:
+					op.response = media.Schema
+				case len(r.Content) > 0 && !ok:
+					op.raw = true
+				case status == "202" || status == "204":
+					bodiless = true
+				default:
+					// Documented without a schema, like /openapi.json itself.
+					untyped = true
+				}
+			}
+			if op.response == nil && untyped {
+				op.response = &eventschema.Schema{}
+			}
+			op.optional = bodiless && op.response != nil
+			out.ops = append(out.ops, op)
+		}
+	}
+	sort.Slice(out.ops, func(i, j int) bool {
+		a, b := out.ops[i], out.ops[j]
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		return methodOrder[strings.ToLower(a.method)] < methodOrder[strings.ToLower(b.method)]
+	})
+	return out, nil
+}
+
+// pascal turns snake_case (or any non-identifier separators) into PascalCase.
+func pascal(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func camel(s string) string {
+	p := pascal(s)
+	if p == "" {
+		return p
+	}
+	return strings.ToLower(p[:1]) + p[1:]
+}
+
+// sortedKeys returns the schema's property names in a stable order.
+func sortedKeys(s *eventschema.Schema) []string {
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func required(s *eventschema.Schema, key string) bool {
+	for _, r := range s.Required {
+		if r == key {
+			return true
+		}
+	}
+	return false
+}
+
+// types splits the schema's type list into its non-null types and whether null is allowed.
+func types(s *eventschema.Schema) (ts []string, nullable bool) {
+	for _, t := range s.Type {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		ts = append(ts, t)
+	}
+	return ts, nullable
+}
+
+// additional returns the schema of an object's additional properties, or nil when they
+// are closed (false) or unconstrained.
+func additional(s *eventschema.Schema) *eventschema.Schema {
+	m, ok := s.AdditionalProperties.(map[string]any)
+	if !ok {
+		return nil
+	}
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	var out eventschema.Schema
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return &out
+}
+
+// literal renders an enum or const value as a JSON literal, which TypeScript accepts as
+// is and Python after mapping true/false/null.
+func literal(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return strconv.Quote(fmt.Sprint(v))
+	}
+	return string(b)
+}
+
+func enumValues(s *eventschema.Schema) []any {
+	if s.Const != nil {
+		return []any{s.Const}
+	}
+	return s.Enum
+}
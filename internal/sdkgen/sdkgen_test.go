@@ -0,0 +1,96 @@
+package sdkgen_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/http/handler"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/sdkgen"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	spec, err := json.Marshal(handler.OpenAPIDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := sdkgen.Generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		got, err := os.ReadFile(filepath.Join("..", "..", "sdk", f.Path))
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Path, err)
+		}
+		if !bytes.Equal(got, f.Content) {
+			t.Errorf("sdk/%s is stale; run: go run ./cmd/sdkgen", f.Path)
+		}
+	}
+}
+
+const testSpec = `{
+  "info": {"title": "test API", "version": "1"},
+  "paths": {
+    "/jobs/{id}/attempts/diff": {
+      "get": {
+        "operationId": "get_jobs_id_attempts_diff",
+        "summary": "Diff two attempts",
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "schema": {"type": ["string"]}},
+          {"name": "from", "in": "query", "schema": {"type": ["integer"]}}
+        ],
+        "responses": {
+          "200": {"description": "OK", "content": {"application/json": {"schema": {
+            "type": ["object"],
+            "properties": {
+              "status": {"type": ["string"], "enum": ["pending", "failed"]},
+              "note": {"type": ["string", "null"]}
+            },
+            "required": ["status"]
+          }}}},
+          "default": {"description": "Error", "content": {"application/json": {"schema": {"type": ["object"]}}}}
+        }
+      }
+    }
+  }
+}`
+
+func TestGenerate_Operation(t *testing.T) {
+	files, err := sdkgen.Generate([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := map[string]string{}
+	for _, f := range files {
+		out[f.Path] = string(f.Content)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{sdkgen.TypeScriptPath, []string{
+			`getJobsIdAttemptsDiff(id: string, query: { from?: number } = {}): Promise<GetJobsIdAttemptsDiffResponse>`,
+			"`/jobs/${encodeURIComponent(id)}/attempts/diff`",
+			`note?: string | null;`,
+			`status: "pending" | "failed";`,
+		}},
+		{sdkgen.PythonPath, []string{
+			`def get_jobs_id_attempts_diff(self, id: str, *, from_: int | None = None) -> GetJobsIdAttemptsDiffResponse:`,
+			`{"from": from_}`,
+			`note: NotRequired[str | None]`,
+			`status: Literal["pending", "failed"]`,
+		}},
+	}
+	for _, tt := range tests {
+		for _, want := range tt.want {
+			if !strings.Contains(out[tt.path], want) {
+				t.Errorf("%s lacks %q", tt.path, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package sdkgen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/eventschema"
+)
+
+var tsIdent = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func typeScript(a *api) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.\n")
+	fmt.Fprintf(&b, "// %s, version %s.\n\n", a.title, a.version)
+
+	fmt.Fprintf(&b, "/** The body of every failed request. */\n")
+	tsDecl(&b, "ErrorResponse", a.errors)
+	for _, op := range a.ops {
+		name := pascal(op.id)
+		if op.request != nil {
+			tsDecl(&b, name+"Request", op.request)
+		}
+		if op.response != nil {
+			tsDecl(&b, name+"Response", op.response)
+		}
+	}
+
+	b.WriteString(tsRuntime)
+
+	for _, op := range a.ops {
+		b.WriteString("\n")
+		tsMethod(&b, op)
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+// tsDecl declares objects with properties as interfaces and everything else as aliases.
+func tsDecl(b *strings.Builder, name string, s *eventschema.Schema) {
+	ts, nullable := types(s)
+	if len(ts) == 1 && ts[0] == "object" && !nullable && len(s.Properties) > 0 {
+		fmt.Fprintf(b, "export interface %s %s\n\n", name, tsObject(s, ""))
+		return
+	}
+	fmt.Fprintf(b, "export type %s = %s;\n\n", name, tsType(s, ""))
+}
+
+func tsType(s *eventschema.Schema, indent string) string {
+	if s == nil {
+		return "unknown"
+	}
+	ts, nullable := types(s)
+	var alts []string
+	if values := enumValues(s); len(values) > 0 {
+		for _, v := range values {
+			if v != nil {
+				alts = append(alts, literal(v))
+			}
+		}
+	} else {
+		for _, t := range ts {
+			switch t {
+			case "string":
+				alts = append(alts, "string")
+			case "integer", "number":
+				alts = append(alts, "number")
+			case "boolean":
+				alts = append(alts, "boolean")
+			case "array":
+				alts = append(alts, "Array<"+tsType(s.Items, indent)+">")
+			case "object":
+				switch {
+				case len(s.Properties) > 0:
+					alts = append(alts, tsObject(s, indent))
+				case additional(s) != nil:
+					alts = append(alts, "Record<string, "+tsType(additional(s), indent)+">")
+				default:
+					alts = append(alts, "Record<string, unknown>")
+				}
+			}
+		}
+	}
+	if len(alts) == 0 {
+		return "unknown"
+	}
+	if nullable {
+		alts = append(alts, "null")
+	}
+	return strings.Join(alts, " | ")
+}
+
+func tsObject(s *eventschema.Schema, indent string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, key := range sortedKeys(s) {
+		name := key
+		if !tsIdent.MatchString(name) {
+			name = literal(key)
+		}
+		opt := "?"
+		if required(s, key) {
+			opt = ""
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, name, opt, tsType(s.Properties[key], indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+func tsMethod(b *strings.Builder, op operation) {
+	var params []string
+	path := op.path
+	for _, p := range op.pathParams {
+		params = append(params, p+": string")
+		path = strings.ReplaceAll(path, "{"+p+"}", "${encodeURIComponent("+p+")}")
+	}
+	name := pascal(op.id)
+	body := "undefined"
+	if op.request != nil {
+		params = append(params, "body: "+name+"Request")
+		body = "body"
+	}
+	query := "undefined"
+	if len(op.query) > 0 {
+		fields := make([]string, len(op.query))
+		for i, q := range op.query {
+			typ := "string"
+			switch q.typ {
+			case "integer", "number":
+				typ = "number"
+			case "boolean":
+				typ = "boolean"
+			}
+			fields[i] = q.name + "?: " + typ
+		}
+		params = append(params, "query: { "+strings.Join(fields, "; ")+" } = {}")
+		query = "query"
+	}
+
+	var result, call string
+	switch {
+	case op.raw:
+		result, call = "Response", "send"
+	case op.response != nil && op.optional:
+		result, call = name+"Response | undefined", "json<"+name+"Response | undefined>"
+	case op.response != nil:
+		result, call = name+"Response", "json<"+name+"Response>"
+	default:
+		result, call = "void", "json<void>"
+	}
+
+	if op.summary != "" {
+		fmt.Fprintf(b, "  /** %s */\n", strings.ReplaceAll(op.summary, "*/", "*\\/"))
+	}
+	fmt.Fprintf(b, "  %s(%s): Promise<%s> {\n", camel(op.id), strings.Join(params, ", "), result)
+	fmt.Fprintf(b, "    return this.%s(%q, `%s`, %s, %s);\n", call, op.method, path, query, body)
+	b.WriteString("  }\n")
+}
+
+const tsRuntime = `/** Thrown for every non-2xx response; body is undefined when it wasn't JSON. */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly body: ErrorResponse | undefined,
+  ) {
+    super(body?.error?.message ?? ` + "`request failed with status ${status}`" + `);
+    this.name = "ApiError";
+  }
+}
+
+export interface ClientOptions {
+  /** e.g. https://scheduler.example.com */
+  baseUrl: string;
+  /** Bearer JWT sent as Authorization. */
+  token?: string;
+  /** Act in an organization (sent as X-Org-ID). */
+  orgId?: string;
+  /** Defaults to the global fetch. */
+  fetch?: typeof fetch;
+}
+
+type Query = Record<string, string | number | boolean | undefined>;
+
+export class Client {
+  private readonly baseUrl: string;
+
+  constructor(private readonly options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, "");
+  }
+
+  private async send(method: string, path: string, query?: Query, body?: unknown): Promise<Response> {
+    const url = new URL(this.baseUrl + path);
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value !== undefined) url.searchParams.set(key, String(value));
+    }
+    const headers: Record<string, string> = {};
+    if (this.options.token) headers["Authorization"] = ` + "`Bearer ${this.options.token}`" + `;
+    if (this.options.orgId) headers["X-Org-ID"] = this.options.orgId;
+    if (body !== undefined) headers["Content-Type"] = "application/json";
+
+    const res = await (this.options.fetch ?? fetch)(url, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    if (!res.ok) {
+      let err: ErrorResponse | undefined;
+      try {
+        err = (await res.json()) as ErrorResponse;
+      } catch {
+        err = undefined;
+      }
+      throw new ApiError(res.status, err);
+    }
+    return res;
+  }
+
+  private async json<T>(method: string, path: string, query?: Query, body?: unknown): Promise<T> {
+    const res = await this.send(method, path, query, body);
+    const text = await res.text();
+    return (text === "" ? undefined : JSON.parse(text)) as T;
+  }
+`
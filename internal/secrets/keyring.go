@@ -0,0 +1,80 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Keyring is a KeyProvider holding KEKs in memory, loaded from configuration. The first
+// key wraps new data keys; the rest stay available to open values sealed before a
+// rotation.
+type Keyring struct {
+	active string
+	keys   map[string][]byte
+}
+
+// ParseKeyring reads entries of the form "<id>:<base64 32-byte key>", e.g. from
+// ENCRYPTION_KEYS=2026-10:...,2026-01:... .
+func ParseKeyring(entries []string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string][]byte, len(entries))}
+	for i, entry := range entries {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			// Don't echo the entry: it may be a bare key.
+			return nil, fmt.Errorf("encryption key #%d: want <id>:<base64 key>", i+1)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("encryption key %q: want 32 bytes, base64-encoded", id)
+		}
+		if strings.HasPrefix(id, tenantKeyPrefix) {
+			return nil, fmt.Errorf("encryption key %q: the %s prefix is reserved for customer keys", id, tenantKeyPrefix)
+		}
+		if _, dup := k.keys[id]; dup {
+			return nil, fmt.Errorf("encryption key %q listed twice", id)
+		}
+		if k.active == "" {
+			k.active = id
+		}
+		k.keys[id] = key
+	}
+	if k.active == "" {
+		return nil, fmt.Errorf("no encryption keys given")
+	}
+	return k, nil
+}
+
+func (k *Keyring) ActiveKeyID(context.Context) (string, error) {
+	return k.active, nil
+}
+
+func (k *Keyring) WrapKey(_ context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	kek, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %q", keyID)
+	}
+	return seal(kek, dataKey)
+}
+
+func (k *Keyring) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	kek, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %q", keyID)
+	}
+	return open(kek, wrapped)
+}
+
+// NewKeyringBox returns a Box over the configured keys, or a nil Box when there are none
+// so that stored values stay in plaintext.
+func NewKeyringBox(entries []string) (*Box, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	keys, err := ParseKeyring(entries)
+	if err != nil {
+		return nil, err
+	}
+	return NewBox(keys), nil
+}
@@ -0,0 +1,192 @@
+// Package secrets encrypts sensitive column values — request header values, bodies and
+// signing secrets — at rest using envelope encryption. Each value is sealed with a fresh
+// AES-256-GCM data key, and the data key is wrapped by a key-encryption key (KEK) from a
+// KeyProvider, so KEKs can be rotated, or moved to a KMS, without re-encrypting data.
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// prefix marks a sealed value: enc:v1:<kek id>:<wrapped data key>:<nonce+ciphertext>,
+// both binary parts base64 (std, unpadded). Values without it are legacy plaintext.
+const prefix = domain.SealedPrefix
+
+var (
+	ErrNoKeys = errors.New("value is encrypted but no encryption keys are configured")
+
+	// ErrSealedPlaintext refuses to store plaintext that would read back as sealed; the
+	// usecases reject such values first (domain.ValidateUnsealed).
+	ErrSealedPlaintext = errors.New("plaintext value starts with the sealed-value prefix")
+)
+
+// KeyProvider wraps and unwraps data keys with a named KEK. ActiveKeyID picks the KEK
+// for a new value and may depend on ctx, as TenantKeys' choice depends on WithOwner.
+type KeyProvider interface {
+	ActiveKeyID(ctx context.Context) (string, error)
+	WrapKey(ctx context.Context, keyID string, dataKey []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Box seals and opens values. A nil *Box leaves new values in plaintext and only opens
+// plaintext, which is how a deployment without keys behaves.
+type Box struct {
+	keys KeyProvider
+}
+
+func NewBox(keys KeyProvider) *Box {
+	return &Box{keys: keys}
+}
+
+// IsSealed reports whether s was produced by Seal.
+func IsSealed(s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+func (b *Box) Seal(ctx context.Context, plaintext string) (string, error) {
+	if b == nil {
+		if IsSealed(plaintext) {
+			return "", ErrSealedPlaintext
+		}
+		return plaintext, nil
+	}
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("generate data key: %w", err)
+	}
+	keyID, err := b.keys.ActiveKeyID(ctx)
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := b.keys.WrapKey(ctx, keyID, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("wrap data key: %w", err)
+	}
+	sealed, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawStdEncoding
+	return prefix + keyID + ":" + enc.EncodeToString(wrapped) + ":" + enc.EncodeToString(sealed), nil
+}
+
+// Open returns the plaintext of a sealed value, or value itself if it isn't sealed.
+func (b *Box) Open(ctx context.Context, value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+	if b == nil {
+		return "", ErrNoKeys
+	}
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", errors.New("malformed sealed value")
+	}
+	enc := base64.RawStdEncoding
+	wrapped, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("decode data key: %w", err)
+	}
+	sealed, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("decode ciphertext: %w", err)
+	}
+	dataKey, err := b.keys.UnwrapKey(ctx, parts[0], wrapped)
+	if err != nil {
+		return "", fmt.Errorf("unwrap data key: %w", err)
+	}
+	plaintext, err := open(dataKey, sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// SealHeaders seals every header value; names stay readable.
+func (b *Box) SealHeaders(ctx context.Context, headers map[string]string) (map[string]string, error) {
+	return b.mapValues(ctx, headers, b.Seal)
+}
+
+func (b *Box) OpenHeaders(ctx context.Context, headers map[string]string) (map[string]string, error) {
+	return b.mapValues(ctx, headers, b.Open)
+}
+
+func (b *Box) mapValues(ctx context.Context, in map[string]string, fn func(context.Context, string) (string, error)) (map[string]string, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		var err error
+		if out[k], err = fn(ctx, v); err != nil {
+			return nil, fmt.Errorf("header %s: %w", k, err)
+		}
+	}
+	return out, nil
+}
+
+// SealPtr and OpenPtr handle nullable columns; nil stays nil.
+func (b *Box) SealPtr(ctx context.Context, s *string) (*string, error) {
+	return b.ptr(ctx, s, b.Seal)
+}
+
+func (b *Box) OpenPtr(ctx context.Context, s *string) (*string, error) {
+	return b.ptr(ctx, s, b.Open)
+}
+
+func (b *Box) ptr(ctx context.Context, s *string, fn func(context.Context, string) (string, error)) (*string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	v, err := fn(ctx, *s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// seal encrypts plaintext with AES-256-GCM under key, prepending the random nonce.
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("init cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,191 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/secrets"
+)
+
+func key(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func TestBox(t *testing.T) {
+	ctx := context.Background()
+	old, err := secrets.ParseKeyring([]string{"k1:" + key('a')})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := secrets.NewBox(old).Seal(ctx, "Bearer abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !secrets.IsSealed(sealed) || strings.Contains(sealed, "abc") {
+		t.Fatalf("Seal() = %q, want an opaque sealed value", sealed)
+	}
+
+	// After a rotation the old key still opens earlier values.
+	rotated, err := secrets.ParseKeyring([]string{"k2:" + key('b'), "k1:" + key('a')})
+	if err != nil {
+		t.Fatal(err)
+	}
+	box := secrets.NewBox(rotated)
+	if got, err := box.Open(ctx, sealed); err != nil || got != "Bearer abc" {
+		t.Errorf("Open() = %q, %v, want the plaintext", got, err)
+	}
+	if got, err := box.Open(ctx, "legacy plaintext"); err != nil || got != "legacy plaintext" {
+		t.Errorf("Open(plaintext) = %q, %v, want it unchanged", got, err)
+	}
+
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if _, err := box.Open(ctx, tampered); err == nil {
+		t.Error("Open(tampered) = nil error, want failure")
+	}
+	if _, err := (*secrets.Box)(nil).Open(ctx, sealed); !errors.Is(err, secrets.ErrNoKeys) {
+		t.Errorf("nil Box Open() = %v, want ErrNoKeys", err)
+	}
+	if _, err := secrets.NewBox(mustKeyring(t, "k3:"+key('c'))).Open(ctx, sealed); err == nil {
+		t.Error("Open() with an unknown key ID = nil error, want failure")
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	for _, entries := range [][]string{
+		nil,
+		{"nokey"},
+		{"k1:" + base64.StdEncoding.EncodeToString([]byte("short"))},
+		{"k1:" + key('a'), "k1:" + key('b')},
+		{"byok.k1:" + key('a')},
+	} {
+		if _, err := secrets.ParseKeyring(entries); err == nil {
+			t.Errorf("ParseKeyring(%q) = nil error, want failure", entries)
+		}
+	}
+}
+
+func mustKeyring(t *testing.T, entries ...string) *secrets.Keyring {
+	t.Helper()
+	k, err := secrets.ParseKeyring(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+// fakeKMS "encrypts" by prefixing the key reference, and refuses keys it doesn't have.
+type fakeKMS struct {
+	keys map[string]bool
+}
+
+func (f fakeKMS) Encrypt(_ context.Context, keyRef string, plaintext []byte) ([]byte, error) {
+	if !f.keys[keyRef] {
+		return nil, errors.New("permission denied")
+	}
+	return append([]byte(keyRef+"|"), plaintext...), nil
+}
+
+func (f fakeKMS) Decrypt(_ context.Context, keyRef string, ciphertext []byte) ([]byte, error) {
+	plaintext, ok := strings.CutPrefix(string(ciphertext), keyRef+"|")
+	if !f.keys[keyRef] || !ok {
+		return nil, errors.New("permission denied")
+	}
+	return []byte(plaintext), nil
+}
+
+func TestTenantKeys(t *testing.T) {
+	ctx := context.Background()
+	kms := fakeKMS{keys: map[string]bool{"acme-key": true}}
+	refs := map[string]string{"org_acme": "acme-key"}
+	keys := secrets.NewTenantKeys(mustKeyring(t, "k1:"+key('a')), kms, func(_ context.Context, owner string) (string, error) {
+		return refs[owner], nil
+	})
+	box := secrets.NewBox(keys)
+
+	acme, err := box.Seal(secrets.WithOwner(ctx, "org_acme"), "Bearer acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(acme, "enc:v1:byok.") {
+		t.Errorf("Seal() for an owner with a key = %q, want it sealed under the customer key", acme)
+	}
+	other, err := box.Seal(secrets.WithOwner(ctx, "user_1"), "Bearer other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(other, "enc:v1:k1:") {
+		t.Errorf("Seal() for an owner without a key = %q, want the keyring's active key", other)
+	}
+
+	// Opening needs no owner: the sealed value names its key.
+	for sealed, want := range map[string]string{acme: "Bearer acme", other: "Bearer other"} {
+		if got, err := box.Open(ctx, sealed); err != nil || got != want {
+			t.Errorf("Open() = %q, %v, want %q", got, err, want)
+		}
+	}
+
+	// Revoking the deployment's access to the key cuts off the data.
+	delete(kms.keys, "acme-key")
+	if _, err := box.Open(ctx, acme); err == nil {
+		t.Error("Open() after the key was revoked = nil error, want failure")
+	}
+	if err := keys.VerifyKeyRef(ctx, "acme-key"); err == nil {
+		t.Error("VerifyKeyRef() of a revoked key = nil error, want failure")
+	}
+}
+
+func TestTenantKeys_LookupFailure(t *testing.T) {
+	keys := secrets.NewTenantKeys(mustKeyring(t, "k1:"+key('a')), fakeKMS{}, func(context.Context, string) (string, error) {
+		return "", errors.New("connection refused")
+	})
+	// Falling back to the deployment key could seal an organization's data under a key it
+	// didn't choose.
+	if _, err := secrets.NewBox(keys).Seal(secrets.WithOwner(context.Background(), "org_acme"), "x"); err == nil {
+		t.Error("Seal() with a failing key lookup = nil error, want failure")
+	}
+}
+
+func TestVaultTransit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		var in map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/acme":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"ciphertext": "vault:v1:" + in["plaintext"]}})
+		case "/v1/transit/decrypt/acme":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"plaintext": strings.TrimPrefix(in["ciphertext"], "vault:v1:")}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	vault := secrets.NewVaultTransit(srv.URL+"/", "s.token", "transit")
+	wrapped, err := vault.Encrypt(ctx, "acme", []byte("data key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := vault.Decrypt(ctx, "acme", wrapped); err != nil || string(got) != "data key" {
+		t.Errorf("Decrypt() = %q, %v, want the data key", got, err)
+	}
+	if _, err := vault.Encrypt(ctx, "unknown", []byte("data key")); err == nil {
+		t.Error("Encrypt() with an unknown key = nil error, want failure")
+	}
+	if _, err := secrets.NewVaultTransit(srv.URL, "wrong", "transit").Encrypt(ctx, "acme", []byte("k")); err == nil {
+		t.Error("Encrypt() with a bad token = nil error, want failure")
+	}
+}
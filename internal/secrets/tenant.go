@@ -0,0 +1,166 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tenantKeyPrefix marks the key ID of a data key wrapped by a customer-managed key; the
+// rest of the ID is the key reference, base64url so it can't contain the ":" that
+// separates the parts of a sealed value.
+const tenantKeyPrefix = "byok."
+
+// keyRefTTL is how long TenantKeys caches an owner's key reference, and so how long a
+// change to it takes to reach every process.
+const keyRefTTL = time.Minute
+
+// KMS encrypts and decrypts data keys under a customer-managed key named by keyRef.
+type KMS interface {
+	Encrypt(ctx context.Context, keyRef string, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, keyRef string, ciphertext []byte) ([]byte, error)
+}
+
+// KeyRefLookup returns the customer-managed key reference configured for an owner, or ""
+// if it has none.
+type KeyRefLookup func(ctx context.Context, ownerID string) (string, error)
+
+type ownerKey struct{}
+
+// WithOwner records whose value is about to be sealed, so TenantKeys can pick that
+// owner's key. Repositories set it before sealing; opening doesn't need it, because a
+// sealed value names its key.
+func WithOwner(ctx context.Context, ownerID string) context.Context {
+	return context.WithValue(ctx, ownerKey{}, ownerID)
+}
+
+// TenantKeys is a KeyProvider that wraps an owner's data keys with the owner's own KMS
+// key when it has one, and with base otherwise. Values sealed under a key reference stay
+// readable after the owner switches keys, as long as the KMS still grants access to it.
+type TenantKeys struct {
+	base   KeyProvider
+	kms    KMS
+	lookup KeyRefLookup
+
+	mu   sync.Mutex
+	refs map[string]cachedKeyRef
+}
+
+type cachedKeyRef struct {
+	ref     string
+	expires time.Time
+}
+
+func NewTenantKeys(base KeyProvider, kms KMS, lookup KeyRefLookup) *TenantKeys {
+	return &TenantKeys{base: base, kms: kms, lookup: lookup, refs: make(map[string]cachedKeyRef)}
+}
+
+// NewTenantKeyringBox is NewKeyringBox with customer-managed keys layered over the
+// keyring, which still wraps the data keys of owners without one. It returns the
+// TenantKeys too, for verifying key references.
+func NewTenantKeyringBox(entries []string, kms KMS, lookup KeyRefLookup) (*Box, *TenantKeys, error) {
+	keyring, err := ParseKeyring(entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := NewTenantKeys(keyring, kms, lookup)
+	return NewBox(keys), keys, nil
+}
+
+func (t *TenantKeys) ActiveKeyID(ctx context.Context) (string, error) {
+	owner, _ := ctx.Value(ownerKey{}).(string)
+	if owner == "" {
+		return t.base.ActiveKeyID(ctx)
+	}
+	ref, err := t.keyRef(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+	if ref == "" {
+		return t.base.ActiveKeyID(ctx)
+	}
+	return tenantKeyPrefix + base64.RawURLEncoding.EncodeToString([]byte(ref)), nil
+}
+
+func (t *TenantKeys) WrapKey(ctx context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	ref, ok, err := parseTenantKeyID(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return t.base.WrapKey(ctx, keyID, dataKey)
+	}
+	return t.kms.Encrypt(ctx, ref, dataKey)
+}
+
+func (t *TenantKeys) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	ref, ok, err := parseTenantKeyID(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return t.base.UnwrapKey(ctx, keyID, wrapped)
+	}
+	return t.kms.Decrypt(ctx, ref, wrapped)
+}
+
+// VerifyKeyRef checks that a data key round-trips through the KMS under keyRef, so an
+// owner can't switch to a key the deployment can't use.
+func (t *TenantKeys) VerifyKeyRef(ctx context.Context, keyRef string) error {
+	probe := make([]byte, 32)
+	if _, err := rand.Read(probe); err != nil {
+		return fmt.Errorf("generate probe key: %w", err)
+	}
+	wrapped, err := t.kms.Encrypt(ctx, keyRef, probe)
+	if err != nil {
+		return err
+	}
+	unwrapped, err := t.kms.Decrypt(ctx, keyRef, wrapped)
+	if err != nil {
+		return err
+	}
+	if string(unwrapped) != string(probe) {
+		return errors.New("key returned different plaintext")
+	}
+	return nil
+}
+
+func (t *TenantKeys) keyRef(ctx context.Context, owner string) (string, error) {
+	now := time.Now()
+	t.mu.Lock()
+	cached, ok := t.refs[owner]
+	t.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.ref, nil
+	}
+
+	// Fail rather than fall back to the deployment key: an owner with its own key must
+	// never have values sealed under someone else's.
+	ref, err := t.lookup(ctx, owner)
+	if err != nil {
+		return "", fmt.Errorf("look up encryption key: %w", err)
+	}
+	t.mu.Lock()
+	t.refs[owner] = cachedKeyRef{ref: ref, expires: now.Add(keyRefTTL)}
+	t.mu.Unlock()
+	return ref, nil
+}
+
+// parseTenantKeyID returns the key reference in a customer-managed key ID, and false for
+// IDs of base keys.
+func parseTenantKeyID(keyID string) (string, bool, error) {
+	encoded, ok := strings.CutPrefix(keyID, tenantKeyPrefix)
+	if !ok {
+		return "", false, nil
+	}
+	ref, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(ref) == 0 {
+		return "", false, fmt.Errorf("malformed customer key ID %q", keyID)
+	}
+	return string(ref), true, nil
+}
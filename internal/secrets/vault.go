@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// vaultTimeout bounds one Vault request; sealing runs inside API requests.
+const vaultTimeout = 5 * time.Second
+
+// VaultTransit is a KMS backed by a HashiCorp Vault transit secrets engine. Key
+// references are transit key names; customers grant the deployment's token
+// encrypt/decrypt on their key, and can revoke it to cut off access to their data.
+type VaultTransit struct {
+	addr   string // e.g. https://vault.example.com:8200
+	token  string
+	mount  string // the engine's mount path, "transit" by default
+	client *http.Client
+}
+
+func NewVaultTransit(addr, token, mount string) *VaultTransit {
+	return &VaultTransit{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		mount:  strings.Trim(mount, "/"),
+		client: &http.Client{Timeout: vaultTimeout},
+	}
+}
+
+func (v *VaultTransit) Encrypt(ctx context.Context, keyRef string, plaintext []byte) ([]byte, error) {
+	var out struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := v.call(ctx, "encrypt", keyRef, map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+	}, &out); err != nil {
+		return nil, err
+	}
+	return []byte(out.Ciphertext), nil
+}
+
+func (v *VaultTransit) Decrypt(ctx context.Context, keyRef string, ciphertext []byte) ([]byte, error) {
+	var out struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := v.call(ctx, "decrypt", keyRef, map[string]string{
+		"ciphertext": string(ciphertext),
+	}, &out); err != nil {
+		return nil, err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(out.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("vault decrypt: decode plaintext: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (v *VaultTransit) call(ctx context.Context, op, keyRef string, in map[string]string, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("vault %s: marshal request: %w", op, err)
+	}
+	endpoint := v.addr + "/v1/" + v.mount + "/" + op + "/" + url.PathEscape(keyRef)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("vault %s: build request: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", v.token)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault %s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Vault's error bodies name the key and the policy problem, never key material.
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("vault %s with key %q returned status %d: %s", op, keyRef, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&envelope); err != nil {
+		return fmt.Errorf("vault %s: decode response: %w", op, err)
+	}
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return fmt.Errorf("vault %s: decode response data: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
+)
+
+func TestTraceParentRoundTrip(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	ctx := tracing.WithTraceParent(context.Background(), tp)
+	if got := tracing.TraceParent(ctx); got != tp {
+		t.Errorf("TraceParent() = %q, want %q", got, tp)
+	}
+
+	if got := tracing.TraceParent(context.Background()); got != "" {
+		t.Errorf("TraceParent() without a span = %q, want empty", got)
+	}
+	if got := tracing.TraceParent(tracing.WithTraceParent(context.Background(), "garbage")); got != "" {
+		t.Errorf("TraceParent() after malformed input = %q, want empty", got)
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// APIUsageRetention is how long hourly API usage buckets are kept.
+const APIUsageRetention = 30 * 24 * time.Hour
+
+type apiUsageKey struct {
+	userID      string
+	bucketStart time.Time
+	method      string
+	route       string
+}
+
+// APIUsageUsecase keeps rolling per-user, per-endpoint API usage. Requests are counted in
+// memory and flushed to the repository on an interval, so recording never adds a DB
+// round-trip to the request path. Counts buffered when a flush fails are dropped — the
+// stats are for quota decisions and investigations, not billing.
+type APIUsageUsecase struct {
+	repo          repository.APIUsageRepository
+	logger        *slog.Logger
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	pending map[apiUsageKey]*domain.APIUsage
+}
+
+func NewAPIUsageUsecase(repo repository.APIUsageRepository, logger *slog.Logger, flushInterval time.Duration) *APIUsageUsecase {
+	return &APIUsageUsecase{
+		repo:          repo,
+		logger:        logger.With("component", "api_usage"),
+		flushInterval: flushInterval,
+		pending:       make(map[apiUsageKey]*domain.APIUsage),
+	}
+}
+
+// Record counts one request. It is safe for concurrent use.
+func (u *APIUsageUsecase) Record(userID, method, route string, status int, duration time.Duration) {
+	key := apiUsageKey{
+		userID:      userID,
+		bucketStart: time.Now().UTC().Truncate(time.Hour),
+		method:      method,
+		route:       route,
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	e, ok := u.pending[key]
+	if !ok {
+		e = &domain.APIUsage{UserID: userID, BucketStart: key.bucketStart, Method: method, Route: route}
+		u.pending[key] = e
+	}
+	e.Requests++
+	e.TotalDurationMS += duration.Milliseconds()
+	switch {
+	case status >= 500:
+		e.ServerErrors++
+	case status >= 400:
+		e.ClientErrors++
+	}
+}
+
+// Start flushes buffered counts every flush interval and prunes expired buckets hourly.
+// It blocks until ctx is cancelled, then makes a final flush.
+func (u *APIUsageUsecase) Start(ctx context.Context) {
+	ticker := time.NewTicker(u.flushInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			u.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			u.flush(ctx)
+			if time.Since(lastPrune) >= time.Hour {
+				if err := u.repo.DeleteBefore(ctx, time.Now().Add(-APIUsageRetention)); err != nil {
+					u.logger.WarnContext(ctx, "prune api usage", "error", err)
+				}
+				lastPrune = time.Now()
+			}
+		}
+	}
+}
+
+func (u *APIUsageUsecase) flush(ctx context.Context) {
+	u.mu.Lock()
+	if len(u.pending) == 0 {
+		u.mu.Unlock()
+		return
+	}
+	entries := make([]domain.APIUsage, 0, len(u.pending))
+	for _, e := range u.pending {
+		entries = append(entries, *e)
+	}
+	u.pending = make(map[apiUsageKey]*domain.APIUsage)
+	u.mu.Unlock()
+
+	if err := u.repo.AddBatch(ctx, entries); err != nil {
+		u.logger.WarnContext(ctx, "flush api usage, dropping buffered counts", "entries", len(entries), "error", err)
+	}
+}
+
+// Stats returns the user's per-endpoint usage over the trailing window, busiest first.
+// Counts from the current flush interval are not included yet.
+func (u *APIUsageUsecase) Stats(ctx context.Context, userID string, window time.Duration) ([]domain.APIUsage, error) {
+	usage, err := u.repo.ListByUser(ctx, userID, time.Now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("list api usage: %w", err)
+	}
+	return usage, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// ApprovalSink is told when members' jobs start awaiting approval, so it can alert the
+// organization's approval.requested rules. It is called on the create path, so it must
+// not block.
+type ApprovalSink interface {
+	ApprovalRequested(ctx context.Context, r domain.ApprovalRequest)
+}
+
+// ApprovalUsecase runs organizations' approval mode: jobs that members other than owners
+// create wait in StatusAwaitingApproval until an owner approves or rejects them.
+type ApprovalUsecase struct {
+	orgs repository.OrgRepository
+	jobs repository.JobRepository
+	sink ApprovalSink
+}
+
+func NewApprovalUsecase(orgs repository.OrgRepository, jobs repository.JobRepository, sink ApprovalSink) *ApprovalUsecase {
+	return &ApprovalUsecase{orgs: orgs, jobs: jobs, sink: sink}
+}
+
+// SetRequired turns the organization's approval mode on or off. Jobs already awaiting
+// approval when it is turned off still need a review.
+func (u *ApprovalUsecase) SetRequired(ctx context.Context, orgID, userID string, on bool) error {
+	if err := authorizeOrg(ctx, u.orgs, orgID, userID, true); err != nil {
+		return err
+	}
+	return u.orgs.SetRequireApproval(ctx, orgID, on)
+}
+
+// List returns the organization's jobs awaiting approval, those due first, up to
+// domain.MaxPageSize. Every member can see them; only owners can review them.
+func (u *ApprovalUsecase) List(ctx context.Context, orgID, userID string) ([]*domain.Job, error) {
+	if err := authorizeOrg(ctx, u.orgs, orgID, userID, false); err != nil {
+		return nil, err
+	}
+	jobs, err := u.jobs.ListJobs(ctx, repository.ListJobsInput{
+		UserID: orgID,
+		Status: domain.StatusAwaitingApproval,
+		Order:  domain.SortAsc,
+		Limit:  domain.MaxPageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list jobs awaiting approval: %w", err)
+	}
+	return jobs, nil
+}
+
+// Review approves or rejects one of the organization's jobs awaiting approval.
+func (u *ApprovalUsecase) Review(ctx context.Context, orgID, userID, jobID string, approve bool) (*domain.Job, error) {
+	if err := authorizeOrg(ctx, u.orgs, orgID, userID, true); err != nil {
+		return nil, err
+	}
+	job, err := u.jobs.Review(ctx, jobID, orgID, userID, approve)
+	if err != nil {
+		return nil, fmt.Errorf("review job: %w", err)
+	}
+	return job, nil
+}
+
+// hold puts jobs that actorID is creating for ownerID into StatusAwaitingApproval when
+// ownerID is an organization whose approval mode applies to actorID. Jobs users create
+// for themselves are never held.
+func (u *ApprovalUsecase) hold(ctx context.Context, ownerID, actorID string, jobs ...*domain.Job) error {
+	if u == nil || actorID == "" || actorID == ownerID {
+		return nil
+	}
+	required, err := u.orgs.ApprovalRequired(ctx, ownerID, actorID)
+	if err != nil {
+		return fmt.Errorf("check approval mode: %w", err)
+	}
+	if required {
+		for _, job := range jobs {
+			job.Status = domain.StatusAwaitingApproval
+		}
+	}
+	return nil
+}
+
+// requested raises one approval request for the created jobs that await approval.
+// created may hold nils for batch duplicates.
+func (u *ApprovalUsecase) requested(ctx context.Context, ownerID, actorID string, created []*domain.Job) {
+	if u == nil {
+		return
+	}
+	var ids []string
+	for _, job := range created {
+		if job != nil && job.Status == domain.StatusAwaitingApproval {
+			ids = append(ids, job.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	u.sink.ApprovalRequested(ctx, domain.ApprovalRequest{
+		OrgID:       ownerID,
+		RequestedBy: actorID,
+		JobIDs:      ids,
+		RequestedAt: time.Now(),
+	})
+}
@@ -0,0 +1,164 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+)
+
+// fakeOrgRepo knows one organization's roles and approval mode; the methods these tests
+// don't reach panic through the nil embedded interface.
+type fakeOrgRepo struct {
+	repository.OrgRepository
+	roles           map[string]domain.OrgRole
+	requireApproval bool
+}
+
+func (f *fakeOrgRepo) Role(_ context.Context, _, userID string) (domain.OrgRole, error) {
+	role, ok := f.roles[userID]
+	if !ok {
+		return "", domain.ErrOrgNotFound
+	}
+	return role, nil
+}
+
+func (f *fakeOrgRepo) ApprovalRequired(_ context.Context, _, userID string) (bool, error) {
+	if _, ok := f.roles[userID]; !ok {
+		return false, domain.ErrOrgNotFound
+	}
+	return f.requireApproval && f.roles[userID] != domain.OrgRoleOwner, nil
+}
+
+type fakeJobRepo struct {
+	repository.JobRepository
+	reviewed []string
+}
+
+func (f *fakeJobRepo) Create(_ context.Context, job *domain.Job) (*domain.Job, error) {
+	created := *job
+	created.ID = "job-1"
+	return &created, nil
+}
+
+func (f *fakeJobRepo) Review(_ context.Context, jobID, _, _ string, approve bool) (*domain.Job, error) {
+	f.reviewed = append(f.reviewed, jobID)
+	status := domain.StatusCancelled
+	if approve {
+		status = domain.StatusPending
+	}
+	return &domain.Job{ID: jobID, Status: status}, nil
+}
+
+type fakeDefaultsRepo struct {
+	repository.DefaultsRepository
+}
+
+func (fakeDefaultsRepo) Get(context.Context, string) (*domain.JobDefaults, error) {
+	return &domain.JobDefaults{}, nil
+}
+
+type fakeApprovalSink struct {
+	requests []domain.ApprovalRequest
+}
+
+func (f *fakeApprovalSink) ApprovalRequested(_ context.Context, r domain.ApprovalRequest) {
+	f.requests = append(f.requests, r)
+}
+
+func newApprovalJobs(orgs *fakeOrgRepo) (*usecase.JobUsecase, *usecase.ApprovalUsecase, *fakeJobRepo, *fakeApprovalSink) {
+	jobs := &fakeJobRepo{}
+	sink := &fakeApprovalSink{}
+	approvals := usecase.NewApprovalUsecase(orgs, jobs, sink)
+	quotas := usecase.NewQuotaUsecase(&fakeUsageRepo{}, usecase.QuotaLimits{}, &fakeWarningSink{}, slog.Default())
+	uc := usecase.NewJobUsecase(jobs, nil, nil, quotas, usecase.NewDefaultsUsecase(fakeDefaultsRepo{}), approvals)
+	return uc, approvals, jobs, sink
+}
+
+func createInput(userID, actorID string) usecase.CreateJobInput {
+	return usecase.CreateJobInput{
+		UserID:         userID,
+		ActorID:        actorID,
+		IdempotencyKey: "key-1",
+		URL:            "https://example.com/hook",
+		Method:         "POST",
+		TimeoutSeconds: 30,
+		ScheduledAt:    time.Now(),
+	}
+}
+
+func TestCreateJob_ApprovalMode(t *testing.T) {
+	orgs := &fakeOrgRepo{
+		roles:           map[string]domain.OrgRole{"owner-1": domain.OrgRoleOwner, "editor-1": domain.OrgRoleEditor},
+		requireApproval: true,
+	}
+
+	tests := []struct {
+		name       string
+		orgs       *fakeOrgRepo
+		userID     string
+		actorID    string
+		wantStatus domain.Status
+	}{
+		{name: "editor's job is held", orgs: orgs, userID: "org-1", actorID: "editor-1", wantStatus: domain.StatusAwaitingApproval},
+		{name: "owner's job is not held", orgs: orgs, userID: "org-1", actorID: "owner-1", wantStatus: domain.StatusPending},
+		{name: "personal job is not held", orgs: orgs, userID: "editor-1", actorID: "editor-1", wantStatus: domain.StatusPending},
+		{name: "approval mode off", orgs: &fakeOrgRepo{roles: orgs.roles}, userID: "org-1", actorID: "editor-1", wantStatus: domain.StatusPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _, _, sink := newApprovalJobs(tt.orgs)
+
+			job, err := uc.CreateJob(context.Background(), createInput(tt.userID, tt.actorID))
+			if err != nil {
+				t.Fatalf("CreateJob: %v", err)
+			}
+			if job.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", job.Status, tt.wantStatus)
+			}
+
+			wantRequests := 0
+			if tt.wantStatus == domain.StatusAwaitingApproval {
+				wantRequests = 1
+			}
+			if len(sink.requests) != wantRequests {
+				t.Fatalf("approval requests = %d, want %d", len(sink.requests), wantRequests)
+			}
+			if wantRequests == 1 {
+				r := sink.requests[0]
+				if r.OrgID != tt.userID || r.RequestedBy != tt.actorID || len(r.JobIDs) != 1 || r.JobIDs[0] != job.ID {
+					t.Errorf("request = %+v, want org %s, requester %s, job %s", r, tt.userID, tt.actorID, job.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestApprovalReview_OwnersOnly(t *testing.T) {
+	orgs := &fakeOrgRepo{roles: map[string]domain.OrgRole{"owner-1": domain.OrgRoleOwner, "editor-1": domain.OrgRoleEditor}}
+	_, approvals, jobs, _ := newApprovalJobs(orgs)
+	ctx := context.Background()
+
+	if _, err := approvals.Review(ctx, "org-1", "editor-1", "job-1", true); !errors.Is(err, domain.ErrOrgForbidden) {
+		t.Fatalf("editor review: err = %v, want ErrOrgForbidden", err)
+	}
+	if _, err := approvals.Review(ctx, "org-1", "stranger", "job-1", true); !errors.Is(err, domain.ErrOrgNotFound) {
+		t.Fatalf("non-member review: err = %v, want ErrOrgNotFound", err)
+	}
+	if len(jobs.reviewed) != 0 {
+		t.Fatalf("reviewed %v before an owner did", jobs.reviewed)
+	}
+
+	job, err := approvals.Review(ctx, "org-1", "owner-1", "job-1", false)
+	if err != nil {
+		t.Fatalf("owner review: %v", err)
+	}
+	if job.Status != domain.StatusCancelled {
+		t.Errorf("rejected status = %q, want %q", job.Status, domain.StatusCancelled)
+	}
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// ClientCertUsecase manages the certificates jobs and schedules present for mutual TLS.
+type ClientCertUsecase struct {
+	repo repository.ClientCertRepository
+}
+
+func NewClientCertUsecase(repo repository.ClientCertRepository) *ClientCertUsecase {
+	return &ClientCertUsecase{repo: repo}
+}
+
+type CreateClientCertInput struct {
+	UserID  string
+	Name    string
+	CertPEM string
+	KeyPEM  string
+}
+
+func (u *ClientCertUsecase) Create(ctx context.Context, input CreateClientCertInput) (*domain.ClientCertificate, error) {
+	c, err := domain.NewClientCertificate(input.UserID, input.Name, input.CertPEM, input.KeyPEM, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	created, err := u.repo.Create(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("create client certificate: %w", err)
+	}
+	return created, nil
+}
+
+func (u *ClientCertUsecase) List(ctx context.Context, userID string) ([]*domain.ClientCertificate, error) {
+	certs, err := u.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list client certificates: %w", err)
+	}
+	return certs, nil
+}
+
+func (u *ClientCertUsecase) Delete(ctx context.Context, id, userID string) error {
+	if err := u.repo.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("delete client certificate: %w", err)
+	}
+	return nil
+}
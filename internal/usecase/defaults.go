@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// DefaultsUsecase manages the per-user defaults applied when job and schedule
+// creation requests omit timeout, retries, backoff, or headers.
+type DefaultsUsecase struct {
+	repo repository.DefaultsRepository
+}
+
+func NewDefaultsUsecase(repo repository.DefaultsRepository) *DefaultsUsecase {
+	return &DefaultsUsecase{repo: repo}
+}
+
+// Effective returns the user's defaults with system fallbacks applied.
+func (u *DefaultsUsecase) Effective(ctx context.Context, userID string) (domain.EffectiveJobDefaults, error) {
+	d, err := u.repo.Get(ctx, userID)
+	if err != nil {
+		return domain.EffectiveJobDefaults{}, fmt.Errorf("get defaults: %w", err)
+	}
+	return d.Effective(), nil
+}
+
+// Update applies the non-nil fields of patch to the user's stored defaults.
+func (u *DefaultsUsecase) Update(ctx context.Context, userID string, patch domain.JobDefaults) (domain.EffectiveJobDefaults, error) {
+	d, err := u.repo.Get(ctx, userID)
+	if err != nil {
+		return domain.EffectiveJobDefaults{}, fmt.Errorf("get defaults: %w", err)
+	}
+	if err := domain.ValidateUnsealed(patch.Headers); err != nil {
+		return domain.EffectiveJobDefaults{}, err
+	}
+	d.Merge(patch)
+	if err := u.repo.Upsert(ctx, userID, d); err != nil {
+		return domain.EffectiveJobDefaults{}, fmt.Errorf("update defaults: %w", err)
+	}
+	return d.Effective(), nil
+}
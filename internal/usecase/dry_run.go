@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// RequestRunner sends a job's request once, the way a worker executes an attempt.
+type RequestRunner interface {
+	DryRun(ctx context.Context, job *domain.Job) domain.DryRunResult
+}
+
+// DryRunUsecase executes job requests immediately without persisting a job, so users
+// can check a URL, headers and body before scheduling them.
+type DryRunUsecase struct {
+	runner    RequestRunner
+	defaults  *DefaultsUsecase
+	schedules repository.ScheduleRepository
+}
+
+func NewDryRunUsecase(runner RequestRunner, defaults *DefaultsUsecase, schedules repository.ScheduleRepository) *DryRunUsecase {
+	return &DryRunUsecase{runner: runner, defaults: defaults, schedules: schedules}
+}
+
+// DryRunJob validates input like CreateJob, applying the user's defaults, and sends the
+// resulting request. Scheduling and retry settings in input are ignored.
+func (u *DryRunUsecase) DryRunJob(ctx context.Context, input CreateJobInput) (domain.DryRunResult, error) {
+	defaults, err := u.defaults.Effective(ctx, input.UserID)
+	if err != nil {
+		return domain.DryRunResult{}, fmt.Errorf("resolve defaults: %w", err)
+	}
+	input.ScheduledAt = time.Now()
+	job, err := newJob(ctx, input, defaults)
+	if err != nil {
+		return domain.DryRunResult{}, err
+	}
+	return u.run(ctx, job), nil
+}
+
+// DryRunSchedule sends the request the schedule's next run would send.
+func (u *DryRunUsecase) DryRunSchedule(ctx context.Context, id, userID string) (domain.DryRunResult, error) {
+	s, err := u.schedules.GetByID(ctx, id, userID)
+	if err != nil {
+		return domain.DryRunResult{}, fmt.Errorf("get schedule: %w", err)
+	}
+	if s.JobType != domain.JobTypeHTTP {
+		return domain.DryRunResult{}, domain.ErrDryRunUnsupported
+	}
+	return u.run(ctx, &domain.Job{
+		UserID:         s.UserID,
+		URL:            s.URL,
+		Method:         s.Method,
+		Headers:        s.Headers,
+		Body:           s.Body,
+		BodyEncoding:   s.BodyEncoding,
+		ContentType:    s.ContentType,
+		TimeoutSeconds: s.TimeoutSeconds,
+		ScheduledAt:    time.Now(),
+		ScheduleID:     &s.ID,
+		SuccessCodes:   s.SuccessCodes,
+		Templated:      s.Templated,
+		SigningSecret:  s.SigningSecret,
+		ProxyURL:       s.ProxyURL,
+		ClientCertID:   s.ClientCertID,
+		CABundle:       s.CABundle,
+		TLSSkipVerify:  s.TLSSkipVerify,
+	}), nil
+}
+
+func (u *DryRunUsecase) run(ctx context.Context, job *domain.Job) domain.DryRunResult {
+	job.TimeoutSeconds = min(job.TimeoutSeconds, int(domain.MaxDryRunTimeout.Seconds()))
+	return u.runner.DryRun(ctx, job)
+}
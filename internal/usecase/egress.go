@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// MaxEgressWindow bounds egress queries, which scan raw attempt rows.
+const MaxEgressWindow = 30 * 24 * time.Hour
+
+type EgressUsecase struct {
+	attempts repository.AttemptRepository
+}
+
+func NewEgressUsecase(attempts repository.AttemptRepository) *EgressUsecase {
+	return &EgressUsecase{attempts: attempts}
+}
+
+// Stats returns the user's attempt traffic over the trailing window, grouped by schedule,
+// heaviest first. Ping checks keep no attempt rows and are not counted.
+func (u *EgressUsecase) Stats(ctx context.Context, userID string, window time.Duration) ([]domain.EgressUsage, error) {
+	usage, err := u.attempts.SumEgressByUser(ctx, userID, time.Now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("sum egress: %w", err)
+	}
+	return usage, nil
+}
@@ -9,70 +9,340 @@ import (
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/requestid"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
 )
 
 type JobUsecase struct {
-	repo     repository.JobRepository
-	attempts repository.AttemptRepository
+	repo      repository.JobRepository
+	attempts  repository.AttemptRepository
+	callbacks repository.CallbackRepository
+	quotas    *QuotaUsecase
+	defaults  *DefaultsUsecase
+	approvals *ApprovalUsecase // nil: no job is ever held for approval
 }
 
-func NewJobUsecase(repo repository.JobRepository, attempts repository.AttemptRepository) *JobUsecase {
-	return &JobUsecase{repo: repo, attempts: attempts}
+func NewJobUsecase(
+	repo repository.JobRepository,
+	attempts repository.AttemptRepository,
+	callbacks repository.CallbackRepository,
+	quotas *QuotaUsecase,
+	defaults *DefaultsUsecase,
+	approvals *ApprovalUsecase,
+) *JobUsecase {
+	return &JobUsecase{repo: repo, attempts: attempts, callbacks: callbacks, quotas: quotas, defaults: defaults, approvals: approvals}
 }
 
 type CreateJobInput struct {
-	UserID         string
-	IdempotencyKey string
-	URL            string
-	Method         string
-	Headers        map[string]string
-	Body           *string
-	TimeoutSeconds int
-	ScheduledAt    time.Time
-	MaxRetries     int
-	Backoff        domain.Backoff
+	UserID           string
+	ActorID          string // the member creating the job, when UserID is an organization
+	IdempotencyKey   string
+	JobType          domain.JobType // empty = domain.JobTypeHTTP
+	URL              string         // http jobs
+	Method           string         // http jobs
+	Topic            *string        // kafka jobs
+	MessageKey       *string        // kafka jobs; optional
+	EmailTo          *string        // email jobs
+	EmailSubject     *string        // email jobs
+	Headers          map[string]string
+	Body             *string
+	BodyEncoding     domain.BodyEncoding // empty = domain.BodyEncodingUTF8
+	ContentType      *string             // sent as Content-Type, overriding Headers
+	TimeoutSeconds   int
+	ScheduledAt      time.Time
+	MaxRetries       int
+	Backoff          domain.Backoff
+	RetryBaseSeconds int // 0 = domain.DefaultRetryBaseSeconds
+	RetryMaxSeconds  int // 0 = domain.DefaultRetryMaxSeconds
+	RetryJitter      domain.Jitter
+	Priority         int
+	Deadline         *time.Time
+	ExpiresAt        *time.Time
+	RetryDelays      []time.Duration
+	CallbackURL      *string
+	SuccessCodes     domain.SuccessCodes
+	Templated        bool
+	Debug            bool
+	DependsOn        *string                    // parent job ID; the job runs once it finishes
+	OnParentFailure  domain.ParentFailurePolicy // empty = domain.DefaultParentFailurePolicy
+	SigningSecret    *string                    // signs each request with an X-Signature header
+	ProxyURL         *string                    // outbound HTTP(S) or SOCKS5 proxy
+	ClientCertID     *string                    // one of the user's certificates, for mutual TLS
+	CABundle         *string                    // PEM roots replacing the system pool
+	TLSSkipVerify    bool                       // turns off verification of the target's certificate
+	Queue            string                     // empty = domain.DefaultQueue
 }
 
 func (u *JobUsecase) CreateJob(ctx context.Context, input CreateJobInput) (*domain.Job, error) {
-	if input.Headers == nil {
-		input.Headers = make(map[string]string)
+	ctx, span := tracing.Start(ctx, "JobUsecase.CreateJob")
+	defer span.End()
+
+	if err := u.quotas.CheckJobCreate(ctx, input.UserID); err != nil {
+		return nil, fmt.Errorf("check quota: %w", err)
+	}
+
+	defaults, err := u.defaults.Effective(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve defaults: %w", err)
+	}
+	job, err := newJob(ctx, input, defaults)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.approvals.hold(ctx, input.UserID, input.ActorID, job); err != nil {
+		return nil, err
+	}
+
+	created, err := u.repo.Create(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("create job: %w", err)
+	}
+	u.approvals.requested(ctx, input.UserID, input.ActorID, []*domain.Job{created})
+
+	return created, nil
+}
+
+// BatchJobResult is the outcome of one item of CreateJobBatch. Exactly one of Job and Err
+// is set; Err is domain.ErrDuplicateJob when the idempotency key already exists.
+type BatchJobResult struct {
+	Job *domain.Job
+	Err error
+}
+
+// CreateJobBatch creates jobs for all valid inputs in a single transaction. Invalid items
+// and duplicates are reported per item and don't affect the rest; the quota is checked
+// once for the whole batch. The result is index-aligned with inputs.
+func (u *JobUsecase) CreateJobBatch(ctx context.Context, userID, actorID string, inputs []CreateJobInput) ([]BatchJobResult, error) {
+	ctx, span := tracing.Start(ctx, "JobUsecase.CreateJobBatch")
+	defer span.End()
+
+	defaults, err := u.defaults.Effective(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve defaults: %w", err)
+	}
+
+	results := make([]BatchJobResult, len(inputs))
+	var (
+		jobs    []*domain.Job
+		indexes []int
+	)
+	for i, input := range inputs {
+		input.UserID, input.ActorID = userID, actorID
+		job, err := newJob(ctx, input, defaults)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		jobs = append(jobs, job)
+		indexes = append(indexes, i)
+	}
+	if len(jobs) == 0 {
+		return results, nil
+	}
+
+	if err := u.quotas.CheckJobBatchCreate(ctx, userID, len(jobs)); err != nil {
+		return nil, fmt.Errorf("check quota: %w", err)
+	}
+	if err := u.approvals.hold(ctx, userID, actorID, jobs...); err != nil {
+		return nil, err
+	}
+
+	created, err := u.repo.CreateBatch(ctx, jobs)
+	if err != nil {
+		return nil, fmt.Errorf("create jobs: %w", err)
+	}
+	u.approvals.requested(ctx, userID, actorID, created)
+	for k, job := range created {
+		i := indexes[k]
+		if job == nil {
+			results[i].Err = domain.ErrDuplicateJob
+			continue
+		}
+		results[i].Job = job
+	}
+	return results, nil
+}
+
+// newJob validates input and fills unset fields from the user's defaults.
+func newJob(ctx context.Context, input CreateJobInput, defaults domain.EffectiveJobDefaults) (*domain.Job, error) {
+	if input.Deadline != nil && !input.Deadline.After(input.ScheduledAt) {
+		return nil, domain.ErrInvalidDeadline
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(input.ScheduledAt) {
+		return nil, domain.ErrInvalidExpiry
+	}
+	if err := domain.ValidateRetryDelays(input.RetryDelays); err != nil {
+		return nil, err
+	}
+	if err := input.SuccessCodes.Validate(); err != nil {
+		return nil, err
+	}
+	if input.SigningSecret != nil {
+		if err := domain.ValidateSigningSecret(*input.SigningSecret); err != nil {
+			return nil, err
+		}
+	}
+	if input.ProxyURL != nil {
+		if err := domain.ValidateProxyURL(*input.ProxyURL); err != nil {
+			return nil, err
+		}
+	}
+	if err := domain.ValidateTLSOptions(input.CABundle, input.TLSSkipVerify); err != nil {
+		return nil, err
+	}
+
+	if input.Queue == "" {
+		input.Queue = domain.DefaultQueue
+	}
+	if err := domain.ValidateQueue(input.Queue); err != nil {
+		return nil, err
+	}
+
+	if input.JobType == "" {
+		input.JobType = domain.JobTypeHTTP
+	}
+	if err := domain.ValidateJobType(input.JobType); err != nil {
+		return nil, err
+	}
+
+	if input.BodyEncoding == "" {
+		input.BodyEncoding = domain.BodyEncodingUTF8
+	}
+	if err := domain.ValidateBody(input.Body, input.BodyEncoding, input.Templated); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateUnsealed(input.Headers, input.Body, input.SigningSecret); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateContentType(input.ContentType); err != nil {
+		return nil, err
 	}
 
+	input.Headers = defaults.MergeHeaders(input.Headers)
+	if input.Templated {
+		if err := domain.ValidatePayloadTemplate(input.URL, input.Headers, input.Body); err != nil {
+			return nil, err
+		}
+	}
 	if input.TimeoutSeconds == 0 {
-		input.TimeoutSeconds = 30
+		input.TimeoutSeconds = defaults.TimeoutSeconds
 	}
 	if input.MaxRetries == 0 {
-		input.MaxRetries = 3
+		// An explicit delay list implies one retry per entry.
+		input.MaxRetries = defaults.MaxRetries
+		if len(input.RetryDelays) > 0 {
+			input.MaxRetries = len(input.RetryDelays)
+		}
 	}
 	if input.Backoff == "" {
-		input.Backoff = domain.BackoffExponential
+		input.Backoff = defaults.Backoff
+	}
+	if err := resolveRetryBackoff(&input.RetryBaseSeconds, &input.RetryMaxSeconds, &input.RetryJitter); err != nil {
+		return nil, err
+	}
+	if input.OnParentFailure == "" {
+		input.OnParentFailure = domain.DefaultParentFailurePolicy
 	}
 
 	job := &domain.Job{
-		UserID:         input.UserID,
-		IdempotencyKey: input.IdempotencyKey,
-		URL:            input.URL,
-		Method:         input.Method,
-		Headers:        input.Headers,
-		Body:           input.Body,
-		TimeoutSeconds: input.TimeoutSeconds,
-		Status:         domain.StatusPending,
-		ScheduledAt:    input.ScheduledAt,
-		MaxRetries:     input.MaxRetries,
-		Backoff:        input.Backoff,
+		UserID:           input.UserID,
+		IdempotencyKey:   input.IdempotencyKey,
+		JobType:          input.JobType,
+		URL:              input.URL,
+		Method:           input.Method,
+		Topic:            input.Topic,
+		MessageKey:       input.MessageKey,
+		EmailTo:          input.EmailTo,
+		EmailSubject:     input.EmailSubject,
+		Headers:          input.Headers,
+		Body:             input.Body,
+		BodyEncoding:     input.BodyEncoding,
+		ContentType:      input.ContentType,
+		TimeoutSeconds:   input.TimeoutSeconds,
+		Status:           domain.StatusPending,
+		ScheduledAt:      input.ScheduledAt,
+		MaxRetries:       input.MaxRetries,
+		Backoff:          input.Backoff,
+		RetryBaseSeconds: input.RetryBaseSeconds,
+		RetryMaxSeconds:  input.RetryMaxSeconds,
+		RetryJitter:      input.RetryJitter,
+		Priority:         input.Priority,
+		Deadline:         input.Deadline,
+		ExpiresAt:        input.ExpiresAt,
+		RetryDelays:      input.RetryDelays,
+		CallbackURL:      input.CallbackURL,
+		SuccessCodes:     input.SuccessCodes,
+		Templated:        input.Templated,
+		Debug:            input.Debug,
+		DependsOn:        input.DependsOn,
+		OnParentFailure:  input.OnParentFailure,
+		SigningSecret:    input.SigningSecret,
+		ProxyURL:         input.ProxyURL,
+		ClientCertID:     input.ClientCertID,
+		CABundle:         input.CABundle,
+		TLSSkipVerify:    input.TLSSkipVerify,
+		Queue:            input.Queue,
+	}
+	if input.ActorID != "" && input.ActorID != input.UserID {
+		job.CreatedBy = &input.ActorID
+	}
+	if err := job.Target().Validate(); err != nil {
+		return nil, err
 	}
 
-	created, err := u.repo.Create(ctx, job)
+	// Persist the originating request ID so support can trace a job back to the API call.
+	if reqID := requestid.FromContext(ctx); reqID != "" {
+		job.RequestID = &reqID
+	}
+	if tp := tracing.TraceParent(ctx); tp != "" {
+		job.TraceParent = &tp
+	}
+	return job, nil
+}
+
+// resolveRetryBackoff fills unset retry backoff settings with the defaults and validates
+// the result. A default never conflicts with an explicit value: an unset base is lowered
+// to an explicit cap below it, and an unset cap is raised to an explicit base above it.
+func resolveRetryBackoff(baseSeconds, maxSeconds *int, jitter *domain.Jitter) error {
+	if *baseSeconds == 0 {
+		*baseSeconds = domain.DefaultRetryBaseSeconds
+		if *maxSeconds > 0 {
+			*baseSeconds = min(*baseSeconds, *maxSeconds)
+		}
+	}
+	if *maxSeconds == 0 {
+		*maxSeconds = max(domain.DefaultRetryMaxSeconds, *baseSeconds)
+	}
+	if *jitter == "" {
+		*jitter = domain.DefaultJitter
+	}
+	return domain.ValidateRetryBackoff(*baseSeconds, *maxSeconds)
+}
+
+// CancelJob cancels a pending or paused job immediately. For a running job it returns
+// requested = true: the cancellation has been recorded and the worker will abort the
+// execution within one heartbeat interval.
+func (u *JobUsecase) CancelJob(ctx context.Context, jobID, userID string) (requested bool, err error) {
+	ctx, span := tracing.Start(ctx, "JobUsecase.CancelJob")
+	defer span.End()
+
+	requested, err = u.repo.Cancel(ctx, jobID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("create job: %w", err)
+		return false, fmt.Errorf("cancel job: %w", err)
 	}
+	return requested, nil
+}
 
-	return created, nil
+func (u *JobUsecase) PauseJob(ctx context.Context, jobID, userID string) error {
+	if err := u.repo.SetPaused(ctx, jobID, userID, true); err != nil {
+		return fmt.Errorf("pause job: %w", err)
+	}
+	return nil
 }
 
-func (u *JobUsecase) CancelJob(ctx context.Context, jobID, userID string) error {
-	if err := u.repo.Cancel(ctx, jobID, userID); err != nil {
-		return fmt.Errorf("cancel job: %w", err)
+func (u *JobUsecase) ResumeJob(ctx context.Context, jobID, userID string) error {
+	if err := u.repo.SetPaused(ctx, jobID, userID, false); err != nil {
+		return fmt.Errorf("resume job: %w", err)
 	}
 	return nil
 }
@@ -85,55 +355,112 @@ func (u *JobUsecase) GetByID(ctx context.Context, jobID, userID string) (*domain
 	return job, nil
 }
 
+// RetryJob resubmits a finished job as a new pending job due now, linked to it by
+// RetriedFrom. The new job is attributed to this request and to actorID, not the
+// original's, and is held for approval like a new job would be.
+func (u *JobUsecase) RetryJob(ctx context.Context, jobID, userID, actorID string) (*domain.Job, error) {
+	ctx, span := tracing.Start(ctx, "JobUsecase.RetryJob")
+	defer span.End()
+
+	job, err := u.repo.GetByID(ctx, jobID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	clone, err := job.Resubmission(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.quotas.CheckJobCreate(ctx, userID); err != nil {
+		return nil, fmt.Errorf("check quota: %w", err)
+	}
+
+	if reqID := requestid.FromContext(ctx); reqID != "" {
+		clone.RequestID = &reqID
+	}
+	if tp := tracing.TraceParent(ctx); tp != "" {
+		clone.TraceParent = &tp
+	}
+	if actorID != "" && actorID != userID {
+		clone.CreatedBy = &actorID
+	}
+	if err := u.approvals.hold(ctx, userID, actorID, clone); err != nil {
+		return nil, err
+	}
+	created, err := u.repo.Create(ctx, clone)
+	if err != nil {
+		return nil, fmt.Errorf("create job: %w", err)
+	}
+	u.approvals.requested(ctx, userID, actorID, []*domain.Job{created})
+	return created, nil
+}
+
 type ListJobsInput struct {
-	UserID string
-	Status string
-	Cursor string // raw base64url from query param
-	Limit  int
+	UserID          string
+	Status          string
+	RequestID       string
+	URLContains     string
+	IdempotencyKey  string
+	ScheduleID      string
+	ScheduledAfter  *time.Time // inclusive
+	ScheduledBefore *time.Time // exclusive
+	Page            PageInput
 }
 
+// maxURLFilterLen matches the longest URL a job can have.
+const maxURLFilterLen = 2048
+
 type ListJobsResult struct {
 	Jobs       []*domain.Job
 	NextCursor *string
+	TotalCount *int64 // set when the input asked for it
 }
 
 type jobCursor struct {
-	ScheduledAt time.Time `json:"s"`
-	ID          string    `json:"i"`
+	ScheduledAt time.Time        `json:"s"`
+	ID          string           `json:"i"`
+	Order       domain.SortOrder `json:"o,omitempty"` // empty in cursors from before ordering
 }
 
-func decodeCursor(s string) (*time.Time, string, error) {
+// decodeCursor reads a job list cursor, which must have been made for a list in order.
+func decodeCursor(s string, order domain.SortOrder) (*time.Time, string, error) {
 	b, err := base64.RawURLEncoding.DecodeString(s)
 	if err != nil {
-		return nil, "", fmt.Errorf("decode cursor: %w", err)
+		return nil, "", fmt.Errorf("decode cursor: %w", domain.ErrInvalidCursor)
 	}
 	var c jobCursor
 	if err := json.Unmarshal(b, &c); err != nil {
-		return nil, "", fmt.Errorf("unmarshal cursor: %w", err)
+		return nil, "", fmt.Errorf("unmarshal cursor: %w", domain.ErrInvalidCursor)
+	}
+	if c.Order == "" {
+		c.Order = domain.SortDesc
+	}
+	if c.Order != order {
+		return nil, "", domain.ErrInvalidCursor
 	}
 	return &c.ScheduledAt, c.ID, nil
 }
 
-func encodeCursor(scheduledAt time.Time, id string) string {
-	b, _ := json.Marshal(jobCursor{ScheduledAt: scheduledAt, ID: id})
+func encodeCursor(scheduledAt time.Time, id string, order domain.SortOrder) string {
+	b, _ := json.Marshal(jobCursor{ScheduledAt: scheduledAt, ID: id, Order: order})
 	return base64.RawURLEncoding.EncodeToString(b)
 }
 
 var validStatuses = map[domain.Status]struct{}{
-	domain.StatusPending:   {},
-	domain.StatusRunning:   {},
-	domain.StatusCompleted: {},
-	domain.StatusFailed:    {},
-	domain.StatusCancelled: {},
+	domain.StatusPending:          {},
+	domain.StatusRunning:          {},
+	domain.StatusCompleted:        {},
+	domain.StatusFailed:           {},
+	domain.StatusCancelled:        {},
+	domain.StatusPaused:           {},
+	domain.StatusExpired:          {},
+	domain.StatusBlocked:          {},
+	domain.StatusAwaitingApproval: {},
 }
 
 func (u *JobUsecase) ListJobs(ctx context.Context, input ListJobsInput) (ListJobsResult, error) {
-	limit := input.Limit
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
+	limit, order, err := domain.ResolvePage(input.Page.Size, input.Page.Order)
+	if err != nil {
+		return ListJobsResult{}, err
 	}
 
 	var status domain.Status
@@ -144,16 +471,30 @@ func (u *JobUsecase) ListJobs(ctx context.Context, input ListJobsInput) (ListJob
 		}
 	}
 
+	if len(input.URLContains) > maxURLFilterLen {
+		return ListJobsResult{}, domain.ErrInvalidJobFilter
+	}
+	if input.ScheduledAfter != nil && input.ScheduledBefore != nil && !input.ScheduledAfter.Before(*input.ScheduledBefore) {
+		return ListJobsResult{}, domain.ErrInvalidJobFilter
+	}
+
 	repoInput := repository.ListJobsInput{
-		UserID: input.UserID,
-		Status: status,
-		Limit:  limit + 1,
+		UserID:          input.UserID,
+		Status:          status,
+		RequestID:       input.RequestID,
+		URLContains:     input.URLContains,
+		IdempotencyKey:  input.IdempotencyKey,
+		ScheduleID:      input.ScheduleID,
+		ScheduledAfter:  input.ScheduledAfter,
+		ScheduledBefore: input.ScheduledBefore,
+		Order:           order,
+		Limit:           limit + 1,
 	}
 
-	if input.Cursor != "" {
-		cursorTime, cursorID, err := decodeCursor(input.Cursor)
+	if input.Page.Cursor != "" {
+		cursorTime, cursorID, err := decodeCursor(input.Page.Cursor, order)
 		if err != nil {
-			return ListJobsResult{}, domain.ErrInvalidStatus
+			return ListJobsResult{}, err
 		}
 		repoInput.CursorTime = cursorTime
 		repoInput.CursorID = cursorID
@@ -166,17 +507,115 @@ func (u *JobUsecase) ListJobs(ctx context.Context, input ListJobsInput) (ListJob
 
 	var nextCursor *string
 	if len(jobs) == limit+1 {
-		last := jobs[limit]
-		s := encodeCursor(last.ScheduledAt, last.ID)
+		last := jobs[limit-1]
+		s := encodeCursor(last.ScheduledAt, last.ID, order)
 		nextCursor = &s
 		jobs = jobs[:limit]
 	}
 
-	return ListJobsResult{Jobs: jobs, NextCursor: nextCursor}, nil
+	result := ListJobsResult{Jobs: jobs, NextCursor: nextCursor}
+	if input.Page.IncludeTotal {
+		total, err := u.repo.CountJobs(ctx, repoInput)
+		if err != nil {
+			return ListJobsResult{}, fmt.Errorf("count jobs: %w", err)
+		}
+		result.TotalCount = &total
+	}
+	return result, nil
+}
+
+type ListAttemptsInput struct {
+	JobID  string
+	UserID string
+	Page   PageInput // Size 0 = domain.MaxPageSize; Order "" = domain.SortAsc
+}
+
+type ListAttemptsResult struct {
+	Attempts   []*domain.JobAttempt
+	NextCursor *string
+	TotalCount *int64 // set when the input asked for it
+}
+
+type attemptCursor struct {
+	AttemptNum int              `json:"n"`
+	Order      domain.SortOrder `json:"o"`
+}
+
+func decodeAttemptCursor(s string, order domain.SortOrder) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, fmt.Errorf("decode cursor: %w", domain.ErrInvalidCursor)
+	}
+	var c attemptCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return 0, fmt.Errorf("unmarshal cursor: %w", domain.ErrInvalidCursor)
+	}
+	if c.Order != order {
+		return 0, domain.ErrInvalidCursor
+	}
+	return c.AttemptNum, nil
+}
+
+func encodeAttemptCursor(attemptNum int, order domain.SortOrder) string {
+	b, _ := json.Marshal(attemptCursor{AttemptNum: attemptNum, Order: order})
+	return base64.RawURLEncoding.EncodeToString(b)
 }
 
-func (u *JobUsecase) ListAttempts(ctx context.Context, jobID, userID string) ([]*domain.JobAttempt, error) {
+// ListAttempts returns a page of the job's attempts. Unlike other lists they run oldest
+// first by default, and a page holds up to domain.MaxPageSize unless asked for fewer, so
+// most jobs' history still fits on one.
+func (u *JobUsecase) ListAttempts(ctx context.Context, input ListAttemptsInput) (ListAttemptsResult, error) {
+	size, order := input.Page.Size, input.Page.Order
+	if size == 0 {
+		size = domain.MaxPageSize
+	}
+	if order == "" {
+		order = domain.SortAsc
+	}
+	limit, order, err := domain.ResolvePage(size, order)
+	if err != nil {
+		return ListAttemptsResult{}, err
+	}
+
 	// Verify the job exists and belongs to this user before returning its attempts.
+	if _, err := u.repo.GetByID(ctx, input.JobID, input.UserID); err != nil {
+		return ListAttemptsResult{}, fmt.Errorf("get job: %w", err)
+	}
+
+	var after *int
+	if input.Page.Cursor != "" {
+		n, err := decodeAttemptCursor(input.Page.Cursor, order)
+		if err != nil {
+			return ListAttemptsResult{}, err
+		}
+		after = &n
+	}
+
+	attempts, err := u.attempts.ListPageByJobID(ctx, input.JobID, order, after, limit+1)
+	if err != nil {
+		return ListAttemptsResult{}, fmt.Errorf("list attempts: %w", err)
+	}
+
+	var nextCursor *string
+	if len(attempts) == limit+1 {
+		s := encodeAttemptCursor(attempts[limit-1].AttemptNum, order)
+		nextCursor = &s
+		attempts = attempts[:limit]
+	}
+
+	result := ListAttemptsResult{Attempts: attempts, NextCursor: nextCursor}
+	if input.Page.IncludeTotal {
+		total, err := u.attempts.CountByJobID(ctx, input.JobID)
+		if err != nil {
+			return ListAttemptsResult{}, fmt.Errorf("count attempts: %w", err)
+		}
+		result.TotalCount = &total
+	}
+	return result, nil
+}
+
+// allAttempts returns every attempt of the user's job, oldest first.
+func (u *JobUsecase) allAttempts(ctx context.Context, jobID, userID string) ([]*domain.JobAttempt, error) {
 	if _, err := u.repo.GetByID(ctx, jobID, userID); err != nil {
 		return nil, fmt.Errorf("get job: %w", err)
 	}
@@ -186,3 +625,64 @@ func (u *JobUsecase) ListAttempts(ctx context.Context, jobID, userID string) ([]
 	}
 	return attempts, nil
 }
+
+// GetAttempt returns one attempt of the user's job, including the request snapshot
+// recorded for debug jobs.
+func (u *JobUsecase) GetAttempt(ctx context.Context, jobID, attemptID, userID string) (*domain.JobAttempt, error) {
+	if _, err := u.repo.GetByID(ctx, jobID, userID); err != nil {
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	attempt, err := u.attempts.GetByID(ctx, jobID, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("get attempt: %w", err)
+	}
+	return attempt, nil
+}
+
+// DiffAttempts compares each attempt of a job with the one before it. When fromAttempt is
+// non-zero only the diff from that attempt to the next is returned.
+func (u *JobUsecase) DiffAttempts(ctx context.Context, jobID, userID string, fromAttempt int) ([]domain.AttemptDiff, error) {
+	attempts, err := u.allAttempts(ctx, jobID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]domain.AttemptDiff, 0, max(len(attempts)-1, 0))
+	for i := 1; i < len(attempts); i++ {
+		if fromAttempt != 0 && attempts[i-1].AttemptNum != fromAttempt {
+			continue
+		}
+		diffs = append(diffs, domain.DiffAttempts(attempts[i-1], attempts[i]))
+	}
+	if fromAttempt != 0 && len(diffs) == 0 {
+		return nil, domain.ErrAttemptNotFound
+	}
+	return diffs, nil
+}
+
+func (u *JobUsecase) ListCallbacks(ctx context.Context, jobID, userID string) ([]*domain.Callback, error) {
+	if _, err := u.repo.GetByID(ctx, jobID, userID); err != nil {
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	callbacks, err := u.callbacks.ListByJobID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list callbacks: %w", err)
+	}
+	return callbacks, nil
+}
+
+// RedeliverCallbacks queues every callback of the job for immediate delivery, including
+// ones already delivered. Receivers see the same X-Callback-ID as before.
+func (u *JobUsecase) RedeliverCallbacks(ctx context.Context, jobID, userID string) (int64, error) {
+	if _, err := u.repo.GetByID(ctx, jobID, userID); err != nil {
+		return 0, fmt.Errorf("get job: %w", err)
+	}
+	n, err := u.callbacks.Redeliver(ctx, jobID)
+	if err != nil {
+		return 0, fmt.Errorf("redeliver callbacks: %w", err)
+	}
+	if n == 0 {
+		return 0, domain.ErrNoCallbacks
+	}
+	return n, nil
+}
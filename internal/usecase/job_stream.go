@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+const (
+	// streamBuffer is how many events a subscriber may fall behind before it is dropped.
+	streamBuffer           = 64
+	streamReconnectFloor   = time.Second
+	streamReconnectCeiling = 30 * time.Second
+)
+
+// JobStreamHub fans job status transitions from a single database listener out to every
+// connected subscriber of the job's owner. Delivery is best-effort: a subscriber that
+// can't keep up is closed rather than allowed to stall the hub, and transitions that
+// happen while the listener reconnects are lost. Clients re-list jobs after reconnecting.
+type JobStreamHub struct {
+	listener repository.JobEventListener
+	logger   *slog.Logger
+
+	mu     sync.Mutex
+	subs   map[string]map[chan domain.JobStatusEvent]context.Context // keyed by user ID; values are the subscribers' contexts
+	closed bool
+}
+
+func NewJobStreamHub(listener repository.JobEventListener, logger *slog.Logger) *JobStreamHub {
+	return &JobStreamHub{
+		listener: listener,
+		logger:   logger.With("component", "job_stream"),
+		subs:     make(map[string]map[chan domain.JobStatusEvent]context.Context),
+	}
+}
+
+// Start listens until ctx is done, reconnecting with backoff whenever the listener fails.
+// On return every subscriber is closed, so open streams end and don't hold up shutdown.
+func (h *JobStreamHub) Start(ctx context.Context) {
+	defer h.closeAll()
+
+	backoff := streamReconnectFloor
+	for {
+		started := time.Now()
+		err := h.listener.ListenStatusChanges(ctx, h.publish)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > streamReconnectCeiling {
+			backoff = streamReconnectFloor
+		}
+		h.logger.ErrorContext(ctx, "job status listener stopped, reconnecting", "error", err, "backoff", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, streamReconnectCeiling)
+	}
+}
+
+// Subscribe returns a channel of the user's job transitions. The channel is closed when
+// the subscriber falls too far behind; cancel must be called once the caller is done.
+// ctx is the subscriber's, used to log on its behalf.
+func (h *JobStreamHub) Subscribe(ctx context.Context, userID string) (events <-chan domain.JobStatusEvent, cancel func()) {
+	ch := make(chan domain.JobStatusEvent, streamBuffer)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan domain.JobStatusEvent]context.Context)
+	}
+	h.subs[userID][ch] = ctx
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(userID, ch)
+	}
+}
+
+func (h *JobStreamHub) publish(e domain.JobStatusEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch, ctx := range h.subs[e.UserID] {
+		select {
+		case ch <- e:
+		default:
+			h.logger.WarnContext(ctx, "job stream subscriber too slow, disconnecting", "user_id", e.UserID)
+			h.remove(e.UserID, ch)
+		}
+	}
+}
+
+func (h *JobStreamHub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for userID, subs := range h.subs {
+		for ch := range subs {
+			h.remove(userID, ch)
+		}
+	}
+}
+
+// remove unregisters and closes ch if it is still registered. Callers hold h.mu.
+func (h *JobStreamHub) remove(userID string, ch chan domain.JobStatusEvent) {
+	subs := h.subs[userID]
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(h.subs, userID)
+	}
+}
@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+)
+
+// fakeJobEventListener emits whatever is sent on events until ctx is done.
+type fakeJobEventListener struct {
+	events chan domain.JobStatusEvent
+}
+
+func (f *fakeJobEventListener) ListenStatusChanges(ctx context.Context, fn func(domain.JobStatusEvent)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-f.events:
+			fn(e)
+		}
+	}
+}
+
+func (f *fakeJobEventListener) ListenReady(ctx context.Context, _ func()) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func startHub(t *testing.T) (*usecase.JobStreamHub, chan domain.JobStatusEvent) {
+	t.Helper()
+	listener := &fakeJobEventListener{events: make(chan domain.JobStatusEvent)}
+	hub := usecase.NewJobStreamHub(listener, slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go hub.Start(ctx)
+	return hub, listener.events
+}
+
+func TestJobStreamHub_DeliversOnlyOwnersEvents(t *testing.T) {
+	hub, emit := startHub(t)
+	events, cancel := hub.Subscribe(context.Background(), "user-1")
+	defer cancel()
+
+	emit <- domain.JobStatusEvent{JobID: "other", UserID: "user-2", Status: domain.StatusRunning}
+	emit <- domain.JobStatusEvent{JobID: "mine", UserID: "user-1", Status: domain.StatusRunning}
+
+	select {
+	case e := <-events:
+		if e.JobID != "mine" {
+			t.Fatalf("got event for job %q, want mine", e.JobID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event delivered")
+	}
+}
+
+func TestJobStreamHub_ClosesSlowSubscriber(t *testing.T) {
+	hub, emit := startHub(t)
+	events, cancel := hub.Subscribe(context.Background(), "user-1")
+	defer cancel()
+
+	// Never read: one more event than the buffer holds overflows it.
+	for i := 0; i <= 64; i++ {
+		emit <- domain.JobStatusEvent{JobID: "j", UserID: "user-1", Status: domain.StatusPending}
+	}
+	// The final event is handed to the listener before the hub publishes it; one more
+	// send guarantees that publish has run.
+	emit <- domain.JobStatusEvent{JobID: "j", UserID: "user-1", Status: domain.StatusPending}
+
+	for range 64 {
+		<-events
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("slow subscriber's channel still open")
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+type NoticeUsecase struct {
+	repo repository.NoticeRepository
+}
+
+func NewNoticeUsecase(repo repository.NoticeRepository) *NoticeUsecase {
+	return &NoticeUsecase{repo: repo}
+}
+
+type CreateNoticeInput struct {
+	Title     string
+	Body      string
+	Severity  domain.NoticeSeverity
+	StartsAt  *time.Time // nil = now
+	EndsAt    *time.Time
+	CreatedBy string
+}
+
+func (u *NoticeUsecase) Create(ctx context.Context, input CreateNoticeInput) (*domain.Notice, error) {
+	startsAt := time.Now()
+	if input.StartsAt != nil {
+		startsAt = *input.StartsAt
+	}
+	if input.EndsAt != nil && !input.EndsAt.After(startsAt) {
+		return nil, domain.ErrInvalidNoticeWindow
+	}
+
+	n, err := u.repo.Create(ctx, &domain.Notice{
+		Title:     input.Title,
+		Body:      input.Body,
+		Severity:  input.Severity,
+		StartsAt:  startsAt,
+		EndsAt:    input.EndsAt,
+		CreatedBy: input.CreatedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create notice: %w", err)
+	}
+	return n, nil
+}
+
+// Current returns active and upcoming notices, so clients can announce planned
+// maintenance before it starts.
+func (u *NoticeUsecase) Current(ctx context.Context) ([]*domain.Notice, error) {
+	notices, err := u.repo.ListCurrent(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("list current notices: %w", err)
+	}
+	return notices, nil
+}
+
+func (u *NoticeUsecase) List(ctx context.Context) ([]*domain.Notice, error) {
+	notices, err := u.repo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list notices: %w", err)
+	}
+	return notices, nil
+}
+
+func (u *NoticeUsecase) Delete(ctx context.Context, id string) error {
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete notice: %w", err)
+	}
+	return nil
+}
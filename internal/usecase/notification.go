@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+const (
+	defaultFailureThreshold = 3
+	maxFailureThreshold     = 100
+)
+
+type NotificationUsecase struct {
+	rules     repository.NotificationRuleRepository
+	schedules repository.ScheduleRepository
+}
+
+func NewNotificationUsecase(rules repository.NotificationRuleRepository, schedules repository.ScheduleRepository) *NotificationUsecase {
+	return &NotificationUsecase{rules: rules, schedules: schedules}
+}
+
+type CreateNotificationRuleInput struct {
+	UserID     string
+	ScheduleID *string // nil = all of the user's jobs or schedules
+	Event      domain.NotificationEvent
+	Channel    domain.NotificationChannel
+	Target     string
+	Threshold  int // schedule.failing only; 0 = defaultFailureThreshold
+}
+
+func (u *NotificationUsecase) CreateRule(ctx context.Context, input CreateNotificationRuleInput) (*domain.NotificationRule, error) {
+	if err := validateNotificationTarget(input.Channel, input.Target); err != nil {
+		return nil, err
+	}
+
+	threshold := 1
+	if input.Event == domain.NotifyScheduleFailing {
+		threshold = input.Threshold
+		if threshold == 0 {
+			threshold = defaultFailureThreshold
+		}
+		if threshold < 1 || threshold > maxFailureThreshold {
+			return nil, domain.ErrInvalidNotificationRule
+		}
+	}
+
+	// Quotas and approvals belong to the account, so their rules can't be narrowed to one
+	// schedule.
+	if (input.Event == domain.NotifyQuotaWarning || input.Event == domain.NotifyApprovalRequested) && input.ScheduleID != nil {
+		return nil, domain.ErrInvalidNotificationRule
+	}
+	if input.ScheduleID != nil {
+		if _, err := u.schedules.GetByID(ctx, *input.ScheduleID, input.UserID); err != nil {
+			return nil, fmt.Errorf("get schedule: %w", err)
+		}
+	}
+
+	rule, err := u.rules.Create(ctx, &domain.NotificationRule{
+		UserID:     input.UserID,
+		ScheduleID: input.ScheduleID,
+		Event:      input.Event,
+		Channel:    input.Channel,
+		Target:     input.Target,
+		Threshold:  threshold,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create notification rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (u *NotificationUsecase) ListRules(ctx context.Context, userID string) ([]*domain.NotificationRule, error) {
+	rules, err := u.rules.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list notification rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (u *NotificationUsecase) DeleteRule(ctx context.Context, id, userID string) error {
+	if err := u.rules.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("delete notification rule: %w", err)
+	}
+	return nil
+}
+
+// validateNotificationTarget checks the target is a bare email address for email rules
+// and an https URL for Slack webhooks.
+func validateNotificationTarget(channel domain.NotificationChannel, target string) error {
+	switch channel {
+	case domain.NotificationChannelEmail:
+		addr, err := mail.ParseAddress(target)
+		if err != nil || addr.Address != target {
+			return domain.ErrInvalidNotificationRule
+		}
+	case domain.NotificationChannelSlack:
+		u, err := url.Parse(target)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return domain.ErrInvalidNotificationRule
+		}
+	default:
+		return domain.ErrInvalidNotificationRule
+	}
+	return nil
+}
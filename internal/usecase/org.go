@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// invitationTokenBytes is the entropy of an invitation token.
+const invitationTokenBytes = 32
+
+// KeyRefVerifier checks that the deployment can encrypt and decrypt with a customer's
+// KMS key before an organization switches to it.
+type KeyRefVerifier interface {
+	VerifyKeyRef(ctx context.Context, keyRef string) error
+}
+
+type OrgUsecase struct {
+	repo repository.OrgRepository
+	keys KeyRefVerifier // nil when the deployment has no KMS
+}
+
+func NewOrgUsecase(repo repository.OrgRepository, keys KeyRefVerifier) *OrgUsecase {
+	return &OrgUsecase{repo: repo, keys: keys}
+}
+
+func (u *OrgUsecase) CreateOrg(ctx context.Context, userID, name string) (*domain.Org, error) {
+	org, err := u.repo.Create(ctx, name, userID)
+	if err != nil {
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+	return org, nil
+}
+
+func (u *OrgUsecase) ListOrgs(ctx context.Context, userID string) ([]domain.OrgMembership, error) {
+	orgs, err := u.repo.ListByMember(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// Role returns userID's role in orgID, or ErrOrgNotFound if they are not a member.
+func (u *OrgUsecase) Role(ctx context.Context, orgID, userID string) (domain.OrgRole, error) {
+	return u.repo.Role(ctx, orgID, userID)
+}
+
+func (u *OrgUsecase) ListMembers(ctx context.Context, orgID, userID string) ([]*domain.OrgMember, error) {
+	if err := u.authorize(ctx, orgID, userID, false); err != nil {
+		return nil, err
+	}
+	members, err := u.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("list members: %w", err)
+	}
+	return members, nil
+}
+
+func (u *OrgUsecase) SetMemberRole(ctx context.Context, orgID, userID, memberID string, role domain.OrgRole) error {
+	if !role.Valid() {
+		return domain.ErrInvalidOrgRole
+	}
+	if err := u.authorize(ctx, orgID, userID, true); err != nil {
+		return err
+	}
+	return u.repo.SetRole(ctx, orgID, memberID, role)
+}
+
+// RemoveMember removes memberID from the organization. Owners can remove anyone; other
+// members can only remove themselves.
+func (u *OrgUsecase) RemoveMember(ctx context.Context, orgID, userID, memberID string) error {
+	if err := u.authorize(ctx, orgID, userID, memberID != userID); err != nil {
+		return err
+	}
+	return u.repo.RemoveMember(ctx, orgID, memberID)
+}
+
+// SetKeyRef makes the organization's own KMS key wrap its newly sealed values, or with a
+// nil keyRef goes back to the deployment's keys. Values sealed before keep their key.
+func (u *OrgUsecase) SetKeyRef(ctx context.Context, orgID, userID string, keyRef *string) error {
+	if u.keys == nil {
+		return domain.ErrKeyRefUnsupported
+	}
+	if err := u.authorize(ctx, orgID, userID, true); err != nil {
+		return err
+	}
+	if keyRef != nil {
+		if err := u.keys.VerifyKeyRef(ctx, *keyRef); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalidKeyRef, err)
+		}
+	}
+	return u.repo.SetKeyRef(ctx, orgID, keyRef)
+}
+
+type CreateInvitationInput struct {
+	OrgID  string
+	UserID string // the inviting owner
+	Email  *string
+	Role   domain.OrgRole
+}
+
+// Invite creates an invitation and returns it with its token. The token is not stored
+// and can't be shown again; the inviter passes it on to the invitee.
+func (u *OrgUsecase) Invite(ctx context.Context, input CreateInvitationInput) (*domain.OrgInvitation, string, error) {
+	if !input.Role.Valid() {
+		return nil, "", domain.ErrInvalidOrgRole
+	}
+	if err := u.authorize(ctx, input.OrgID, input.UserID, true); err != nil {
+		return nil, "", err
+	}
+
+	raw := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", fmt.Errorf("generate invitation token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	inv, err := u.repo.CreateInvitation(ctx, &domain.OrgInvitation{
+		OrgID:     input.OrgID,
+		Email:     input.Email,
+		Role:      input.Role,
+		InvitedBy: input.UserID,
+		ExpiresAt: time.Now().Add(domain.InvitationTTL),
+	}, hashInvitationToken(token))
+	if err != nil {
+		return nil, "", fmt.Errorf("create invitation: %w", err)
+	}
+	return inv, token, nil
+}
+
+func (u *OrgUsecase) ListInvitations(ctx context.Context, orgID, userID string) ([]*domain.OrgInvitation, error) {
+	if err := u.authorize(ctx, orgID, userID, true); err != nil {
+		return nil, err
+	}
+	invitations, err := u.repo.ListInvitations(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("list invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (u *OrgUsecase) RevokeInvitation(ctx context.Context, orgID, userID, id string) error {
+	if err := u.authorize(ctx, orgID, userID, true); err != nil {
+		return err
+	}
+	return u.repo.DeleteInvitation(ctx, orgID, id)
+}
+
+func (u *OrgUsecase) AcceptInvitation(ctx context.Context, userID, token string) (*domain.OrgMember, error) {
+	return u.repo.AcceptInvitation(ctx, hashInvitationToken(token), userID)
+}
+
+func (u *OrgUsecase) authorize(ctx context.Context, orgID, userID string, owner bool) error {
+	return authorizeOrg(ctx, u.repo, orgID, userID, owner)
+}
+
+// authorizeOrg checks userID is a member of orgID, and an owner if owner is set.
+// Non-members get ErrOrgNotFound so organization IDs can't be probed.
+func authorizeOrg(ctx context.Context, orgs repository.OrgRepository, orgID, userID string, owner bool) error {
+	role, err := orgs.Role(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if owner && role != domain.OrgRoleOwner {
+		return domain.ErrOrgForbidden
+	}
+	return nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
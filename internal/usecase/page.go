@@ -0,0 +1,14 @@
+package usecase
+
+import "github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+
+// PageInput is the paging part of a list request. Lists are keyset-paginated: Cursor is
+// the previous page's NextCursor and only continues a list in the order it was made for.
+type PageInput struct {
+	Cursor string
+	Size   int              // 0 = domain.DefaultPageSize
+	Order  domain.SortOrder // "" = domain.SortDesc
+	// IncludeTotal also counts every match, ignoring the cursor. It costs a COUNT(*)
+	// over the user's rows, so it is opt-in.
+	IncludeTotal bool
+}
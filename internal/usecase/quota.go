@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// QuotaLimits holds the per-user hard limits. A zero value disables that quota.
+type QuotaLimits struct {
+	MaxPendingJobs     int
+	MaxSchedules       int
+	MaxDailyExecutions int
+}
+
+// QuotaWarningSink receives soft-limit warnings, e.g. to notify the user through their
+// quota.warning rules. It is called on the create path, so it must not block.
+type QuotaWarningSink interface {
+	QuotaWarning(ctx context.Context, w domain.QuotaWarning)
+}
+
+type QuotaUsecase struct {
+	repo     repository.UsageRepository
+	limits   QuotaLimits
+	warnings QuotaWarningSink
+	logger   *slog.Logger
+}
+
+func NewQuotaUsecase(repo repository.UsageRepository, limits QuotaLimits, warnings QuotaWarningSink, logger *slog.Logger) *QuotaUsecase {
+	return &QuotaUsecase{repo: repo, limits: limits, warnings: warnings, logger: logger.With("component", "quota")}
+}
+
+// Usage returns the user's consumption against every quota, flagging those at or above
+// the soft-limit warning ratio.
+func (u *QuotaUsecase) Usage(ctx context.Context, userID string) ([]domain.QuotaStatus, error) {
+	usage, err := u.repo.GetUsage(ctx, userID, startOfDay(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("get usage: %w", err)
+	}
+	return []domain.QuotaStatus{
+		quotaStatus(domain.QuotaPendingJobs, usage.PendingJobs, u.limits.MaxPendingJobs),
+		quotaStatus(domain.QuotaSchedules, usage.Schedules, u.limits.MaxSchedules),
+		quotaStatus(domain.QuotaDailyExecutions, usage.DailyExecutions, u.limits.MaxDailyExecutions),
+	}, nil
+}
+
+// CheckJobCreate rejects job creation once the pending-jobs or daily-executions quota is
+// exhausted, and raises a soft-limit warning when this job crosses the warning threshold.
+func (u *QuotaUsecase) CheckJobCreate(ctx context.Context, userID string) error {
+	return u.CheckJobBatchCreate(ctx, userID, 1)
+}
+
+// CheckJobBatchCreate is CheckJobCreate for n jobs created together: the batch is
+// rejected as a whole unless all n fit under the pending-jobs quota.
+func (u *QuotaUsecase) CheckJobBatchCreate(ctx context.Context, userID string, n int) error {
+	if u.limits.MaxPendingJobs == 0 && u.limits.MaxDailyExecutions == 0 {
+		return nil
+	}
+	usage, err := u.repo.GetUsage(ctx, userID, startOfDay(time.Now()))
+	if err != nil {
+		return fmt.Errorf("get usage: %w", err)
+	}
+	// Each created job is at least one execution to come, so the batch counts n towards the
+	// daily quota, for both the gate and the warning.
+	if err := u.check(ctx, userID, domain.QuotaDailyExecutions, usage.DailyExecutions, n, u.limits.MaxDailyExecutions, true); err != nil {
+		return err
+	}
+	return u.check(ctx, userID, domain.QuotaPendingJobs, usage.PendingJobs, n, u.limits.MaxPendingJobs, true)
+}
+
+// CheckScheduleCreate is the schedule-quota counterpart of CheckJobCreate.
+func (u *QuotaUsecase) CheckScheduleCreate(ctx context.Context, userID string) error {
+	if u.limits.MaxSchedules == 0 {
+		return nil
+	}
+	usage, err := u.repo.GetUsage(ctx, userID, startOfDay(time.Now()))
+	if err != nil {
+		return fmt.Errorf("get usage: %w", err)
+	}
+	return u.check(ctx, userID, domain.QuotaSchedules, usage.Schedules, 1, u.limits.MaxSchedules, true)
+}
+
+// check returns ErrQuotaExceeded when n more units would take used past limit. When warn
+// is set and those units cross the soft-limit threshold, it hands a warning to the sink.
+func (u *QuotaUsecase) check(ctx context.Context, userID string, kind domain.QuotaKind, used, n, limit int, warn bool) error {
+	if limit == 0 {
+		return nil
+	}
+	if used+n > limit {
+		return domain.ErrQuotaExceeded
+	}
+	threshold := warningThreshold(limit)
+	if warn && used < threshold && used+n >= threshold {
+		u.logger.WarnContext(ctx, "quota soft limit reached",
+			"user_id", userID,
+			"quota", kind,
+			"used", used+n,
+			"limit", limit,
+		)
+		u.warnings.QuotaWarning(ctx, domain.QuotaWarning{
+			UserID:   userID,
+			Kind:     kind,
+			Used:     used + n,
+			Limit:    limit,
+			RaisedAt: time.Now(),
+		})
+	}
+	return nil
+}
+
+func quotaStatus(kind domain.QuotaKind, used, limit int) domain.QuotaStatus {
+	s := domain.QuotaStatus{Kind: kind, Used: used, Limit: limit}
+	if limit > 0 {
+		s.Warning = used >= warningThreshold(limit)
+		s.Exceeded = used >= limit
+	}
+	return s
+}
+
+func warningThreshold(limit int) int {
+	return int(float64(limit) * domain.QuotaWarningRatio)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
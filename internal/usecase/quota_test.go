@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+)
+
+type fakeUsageRepo struct {
+	usage domain.Usage
+}
+
+func (f *fakeUsageRepo) GetUsage(_ context.Context, _ string, _ time.Time) (*domain.Usage, error) {
+	u := f.usage
+	return &u, nil
+}
+
+type fakeWarningSink struct {
+	warnings []domain.QuotaWarning
+}
+
+func (f *fakeWarningSink) QuotaWarning(_ context.Context, w domain.QuotaWarning) {
+	f.warnings = append(f.warnings, w)
+}
+
+func newQuotas(usage domain.Usage, limits usecase.QuotaLimits) *usecase.QuotaUsecase {
+	return usecase.NewQuotaUsecase(&fakeUsageRepo{usage: usage}, limits, &fakeWarningSink{}, slog.Default())
+}
+
+func TestCheckJobCreate_UnderLimit_Allows(t *testing.T) {
+	q := newQuotas(domain.Usage{PendingJobs: 5}, usecase.QuotaLimits{MaxPendingJobs: 10})
+
+	if err := q.CheckJobCreate(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckJobCreate_AtPendingLimit_Rejects(t *testing.T) {
+	q := newQuotas(domain.Usage{PendingJobs: 10}, usecase.QuotaLimits{MaxPendingJobs: 10})
+
+	err := q.CheckJobCreate(context.Background(), "user-1")
+	if !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("err = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestCheckJobCreate_DailyExecutionsExhausted_Rejects(t *testing.T) {
+	q := newQuotas(domain.Usage{DailyExecutions: 100}, usecase.QuotaLimits{MaxDailyExecutions: 100})
+
+	err := q.CheckJobCreate(context.Background(), "user-1")
+	if !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("err = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestCheckJobBatchCreate_BatchOverflowsPendingLimit_Rejects(t *testing.T) {
+	q := newQuotas(domain.Usage{PendingJobs: 8}, usecase.QuotaLimits{MaxPendingJobs: 10})
+
+	if err := q.CheckJobBatchCreate(context.Background(), "user-1", 2); err != nil {
+		t.Fatalf("batch that fits: unexpected error: %v", err)
+	}
+	err := q.CheckJobBatchCreate(context.Background(), "user-1", 3)
+	if !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("err = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestCheckScheduleCreate_ZeroLimit_IsUnlimited(t *testing.T) {
+	q := newQuotas(domain.Usage{Schedules: 1_000_000}, usecase.QuotaLimits{})
+
+	if err := q.CheckScheduleCreate(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckJobCreate_CrossingWarningRatio_RaisesWarningOnce(t *testing.T) {
+	sink := &fakeWarningSink{}
+	limits := usecase.QuotaLimits{MaxPendingJobs: 10}
+
+	for _, pending := range []int{6, 7, 8} {
+		q := usecase.NewQuotaUsecase(&fakeUsageRepo{usage: domain.Usage{PendingJobs: pending}}, limits, sink, slog.Default())
+		if err := q.CheckJobCreate(context.Background(), "user-1"); err != nil {
+			t.Fatalf("pending=%d: unexpected error: %v", pending, err)
+		}
+	}
+
+	if len(sink.warnings) != 1 {
+		t.Fatalf("warnings = %d, want 1", len(sink.warnings))
+	}
+	w := sink.warnings[0]
+	if w.UserID != "user-1" || w.Kind != domain.QuotaPendingJobs || w.Used != 8 || w.Limit != 10 {
+		t.Errorf("warning = %+v", w)
+	}
+}
+
+func TestCheckJobBatchCreate_CrossingDailyExecutionsRatio_RaisesWarning(t *testing.T) {
+	sink := &fakeWarningSink{}
+	q := usecase.NewQuotaUsecase(&fakeUsageRepo{usage: domain.Usage{DailyExecutions: 78}},
+		usecase.QuotaLimits{MaxDailyExecutions: 100}, sink, slog.Default())
+
+	if err := q.CheckJobBatchCreate(context.Background(), "user-1", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sink.warnings) != 1 || sink.warnings[0].Kind != domain.QuotaDailyExecutions || sink.warnings[0].Used != 81 {
+		t.Fatalf("warnings = %+v, want one daily_executions warning at 81", sink.warnings)
+	}
+}
+
+func TestCheckJobBatchCreate_BatchOverflowsDailyExecutions_Rejects(t *testing.T) {
+	q := newQuotas(domain.Usage{DailyExecutions: 98}, usecase.QuotaLimits{MaxDailyExecutions: 100})
+
+	err := q.CheckJobBatchCreate(context.Background(), "user-1", 3)
+	if !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("err = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestUsage_FlagsWarningAtEightyPercent(t *testing.T) {
+	q := newQuotas(
+		domain.Usage{PendingJobs: 80, Schedules: 79, DailyExecutions: 100},
+		usecase.QuotaLimits{MaxPendingJobs: 100, MaxSchedules: 100, MaxDailyExecutions: 100},
+	)
+
+	statuses, err := q.Usage(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[domain.QuotaKind]struct{ warning, exceeded bool }{
+		domain.QuotaPendingJobs:     {true, false},
+		domain.QuotaSchedules:       {false, false},
+		domain.QuotaDailyExecutions: {true, true},
+	}
+	for _, s := range statuses {
+		w := want[s.Kind]
+		if s.Warning != w.warning || s.Exceeded != w.exceeded {
+			t.Errorf("%s: warning=%v exceeded=%v, want warning=%v exceeded=%v",
+				s.Kind, s.Warning, s.Exceeded, w.warning, w.exceeded)
+		}
+	}
+}
@@ -9,72 +9,471 @@ import (
 
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
 	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/requestid"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
 	"github.com/robfig/cron/v3"
 )
 
 type ScheduleUsecase struct {
-	repo    repository.ScheduleRepository
-	jobRepo repository.JobRepository
+	repo     repository.ScheduleRepository
+	jobRepo  repository.JobRepository
+	pingRepo repository.PingRepository
+	quotas   *QuotaUsecase
+	defaults *DefaultsUsecase
 }
 
-func NewScheduleUsecase(repo repository.ScheduleRepository, jobRepo repository.JobRepository) *ScheduleUsecase {
-	return &ScheduleUsecase{repo: repo, jobRepo: jobRepo}
+func NewScheduleUsecase(repo repository.ScheduleRepository, jobRepo repository.JobRepository, pingRepo repository.PingRepository, quotas *QuotaUsecase, defaults *DefaultsUsecase) *ScheduleUsecase {
+	return &ScheduleUsecase{repo: repo, jobRepo: jobRepo, pingRepo: pingRepo, quotas: quotas, defaults: defaults}
 }
 
 type CreateScheduleInput struct {
-	UserID         string
-	Name           string
-	CronExpr       string
-	URL            string
-	Method         string
-	Headers        map[string]string
-	Body           *string
-	TimeoutSeconds int
-	MaxRetries     int
-	Backoff        domain.Backoff
+	UserID           string
+	Name             string
+	CronExpr         string
+	Timezone         string          // IANA name; empty = domain.DefaultTimezone
+	Every            domain.Interval // set instead of CronExpr for a fixed cadence
+	URL              string
+	Method           string
+	Headers          map[string]string
+	Body             *string
+	BodyEncoding     domain.BodyEncoding // empty = domain.BodyEncodingUTF8
+	ContentType      *string
+	TimeoutSeconds   int
+	MaxRetries       int
+	Backoff          domain.Backoff
+	RetryBaseSeconds int // 0 = domain.DefaultRetryBaseSeconds
+	RetryMaxSeconds  int // 0 = domain.DefaultRetryMaxSeconds
+	RetryJitter      domain.Jitter
+	OverlapPolicy    domain.OverlapPolicy // empty = domain.DefaultOverlapPolicy
+	JitterSeconds    int
+	Mode             domain.ScheduleMode
+	JobType          domain.JobType // passed on to fired jobs; empty = domain.JobTypeHTTP
+	Topic            *string        // kafka schedules
+	MessageKey       *string        // kafka schedules; optional
+	EmailTo          *string        // email schedules
+	EmailSubject     *string        // email schedules
+	SuccessCodes     domain.SuccessCodes
+	Templated        bool
+	SigningSecret    *string // passed on to fired jobs
+	ProxyURL         *string // passed on to fired jobs
+	ClientCertID     *string // passed on to fired jobs
+	CABundle         *string // passed on to fired jobs
+	TLSSkipVerify    bool    // passed on to fired jobs
+	Queue            string  // passed on to fired jobs; empty = domain.DefaultQueue
+	Paused           bool
+
+	PauseAfterFailures int // 0 = never pause automatically
 }
 
 func (u *ScheduleUsecase) CreateSchedule(ctx context.Context, input CreateScheduleInput) (*domain.Schedule, error) {
-	sched, err := cron.ParseStandard(input.CronExpr)
+	ctx, span := tracing.Start(ctx, "ScheduleUsecase.CreateSchedule")
+	defer span.End()
+
+	s, err := u.newSchedule(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return u.createDesired(ctx, s)
+}
+
+// newSchedule validates input and builds the schedule it describes, with defaults
+// applied, without saving it.
+func (u *ScheduleUsecase) newSchedule(ctx context.Context, input CreateScheduleInput) (*domain.Schedule, error) {
+	if input.Timezone == "" {
+		input.Timezone = domain.DefaultTimezone
+	}
+	nextRunAt, err := firstRun(input.CronExpr, input.Timezone, input.Every, time.Now())
 	if err != nil {
-		return nil, domain.ErrInvalidCronExpr
+		return nil, err
+	}
+
+	if err := input.SuccessCodes.Validate(); err != nil {
+		return nil, err
+	}
+
+	if input.JobType == "" {
+		input.JobType = domain.JobTypeHTTP
+	}
+	if err := domain.ValidateJobType(input.JobType); err != nil {
+		return nil, err
+	}
+	if input.Mode == "" {
+		input.Mode = domain.ScheduleModeStandard
+	}
+	if input.Mode == domain.ScheduleModePing {
+		if input.JobType != domain.JobTypeHTTP || (input.Method != "HEAD" && input.Method != "GET") || input.Body != nil {
+			return nil, domain.ErrInvalidPingSchedule
+		}
 	}
 
-	if input.Headers == nil {
-		input.Headers = make(map[string]string)
+	defaults, err := u.defaults.Effective(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve defaults: %w", err)
+	}
+	input.Headers = defaults.MergeHeaders(input.Headers)
+	if input.Templated {
+		if err := domain.ValidatePayloadTemplate(input.URL, input.Headers, input.Body); err != nil {
+			return nil, err
+		}
+	}
+	if input.BodyEncoding == "" {
+		input.BodyEncoding = domain.BodyEncodingUTF8
+	}
+	if err := domain.ValidateBody(input.Body, input.BodyEncoding, input.Templated); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateUnsealed(input.Headers, input.Body, input.SigningSecret); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateContentType(input.ContentType); err != nil {
+		return nil, err
 	}
 	if input.TimeoutSeconds == 0 {
-		input.TimeoutSeconds = 30
+		input.TimeoutSeconds = defaults.TimeoutSeconds
 	}
-	if input.MaxRetries == 0 {
-		input.MaxRetries = 3
+	// A failed ping is a data point, not something to retry.
+	if input.MaxRetries == 0 && input.Mode != domain.ScheduleModePing {
+		input.MaxRetries = defaults.MaxRetries
 	}
 	if input.Backoff == "" {
-		input.Backoff = domain.BackoffExponential
+		input.Backoff = defaults.Backoff
+	}
+	if err := resolveRetryBackoff(&input.RetryBaseSeconds, &input.RetryMaxSeconds, &input.RetryJitter); err != nil {
+		return nil, err
+	}
+	if input.OverlapPolicy == "" {
+		input.OverlapPolicy = domain.DefaultOverlapPolicy
+	}
+	if err := domain.ValidateScheduleJitter(input.JitterSeconds, input.Every); err != nil {
+		return nil, err
+	}
+	if input.SigningSecret != nil {
+		if err := domain.ValidateSigningSecret(*input.SigningSecret); err != nil {
+			return nil, err
+		}
+	}
+	if input.ProxyURL != nil {
+		if err := domain.ValidateProxyURL(*input.ProxyURL); err != nil {
+			return nil, err
+		}
+	}
+	if err := domain.ValidateTLSOptions(input.CABundle, input.TLSSkipVerify); err != nil {
+		return nil, err
+	}
+	if input.Queue == "" {
+		input.Queue = domain.DefaultQueue
+	}
+	if err := domain.ValidateQueue(input.Queue); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidatePauseAfterFailures(input.PauseAfterFailures, input.Mode); err != nil {
+		return nil, err
 	}
-
-	nextRunAt := sched.Next(time.Now())
 
 	s := &domain.Schedule{
-		UserID:         input.UserID,
-		Name:           input.Name,
-		CronExpr:       input.CronExpr,
-		URL:            input.URL,
-		Method:         input.Method,
-		Headers:        input.Headers,
-		Body:           input.Body,
-		TimeoutSeconds: input.TimeoutSeconds,
-		MaxRetries:     input.MaxRetries,
-		Backoff:        input.Backoff,
-		Paused:         false,
-		NextRunAt:      nextRunAt,
+		UserID:           input.UserID,
+		Name:             input.Name,
+		CronExpr:         input.CronExpr,
+		Timezone:         input.Timezone,
+		Every:            input.Every,
+		URL:              input.URL,
+		Method:           input.Method,
+		Headers:          input.Headers,
+		Body:             input.Body,
+		BodyEncoding:     input.BodyEncoding,
+		ContentType:      input.ContentType,
+		TimeoutSeconds:   input.TimeoutSeconds,
+		MaxRetries:       input.MaxRetries,
+		Backoff:          input.Backoff,
+		RetryBaseSeconds: input.RetryBaseSeconds,
+		RetryMaxSeconds:  input.RetryMaxSeconds,
+		RetryJitter:      input.RetryJitter,
+		OverlapPolicy:    input.OverlapPolicy,
+		JitterSeconds:    input.JitterSeconds,
+		SigningSecret:    input.SigningSecret,
+		ProxyURL:         input.ProxyURL,
+		ClientCertID:     input.ClientCertID,
+		CABundle:         input.CABundle,
+		TLSSkipVerify:    input.TLSSkipVerify,
+		Queue:            input.Queue,
+		Paused:           input.Paused,
+		Mode:             input.Mode,
+		JobType:          input.JobType,
+		Topic:            input.Topic,
+		MessageKey:       input.MessageKey,
+		EmailTo:          input.EmailTo,
+		EmailSubject:     input.EmailSubject,
+		SuccessCodes:     input.SuccessCodes,
+		Templated:        input.Templated,
+		NextRunAt:        nextRunAt,
+
+		PauseAfterFailures: input.PauseAfterFailures,
 	}
+	if err := s.Target().Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UpdateScheduleInput is a partial update: nil fields are left unchanged. Headers, when
+// set, replace the schedule's headers as a whole. Setting CronExpr or Every switches the
+// schedule to that kind of cadence, clearing the other.
+type UpdateScheduleInput struct {
+	Name             *string
+	CronExpr         *string
+	Timezone         *string
+	Every            *domain.Interval
+	URL              *string
+	Method           *string
+	Topic            *string
+	MessageKey       *string // "" removes it
+	EmailTo          *string
+	EmailSubject     *string
+	Headers          map[string]string
+	Body             *string
+	BodyEncoding     *domain.BodyEncoding
+	ContentType      *string // "" removes it
+	TimeoutSeconds   *int
+	MaxRetries       *int
+	Backoff          *domain.Backoff
+	RetryBaseSeconds *int
+	RetryMaxSeconds  *int
+	RetryJitter      *domain.Jitter
+	OverlapPolicy    *domain.OverlapPolicy
+	JitterSeconds    *int
+	SuccessCodes     *domain.SuccessCodes
+	Templated        *bool
+	SigningSecret    *string // "" removes the secret
+	ProxyURL         *string // "" removes the proxy
+	ClientCertID     *string // "" removes it
+	CABundle         *string // "" removes it
+	TLSSkipVerify    *bool
+	Queue            *string
+
+	PauseAfterFailures *int
+}
+
+// UpdateSchedule applies input to the schedule and records an update revision. next_run_at
+// is recomputed from now when the cron expression or timezone changes; otherwise the
+// pending run keeps its time. Job history stays linked since the schedule ID is unchanged.
+func (u *ScheduleUsecase) UpdateSchedule(ctx context.Context, id, userID string, input UpdateScheduleInput) (*domain.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "ScheduleUsecase.UpdateSchedule")
+	defer span.End()
 
-	created, err := u.repo.Create(ctx, s)
+	s, err := u.repo.GetByID(ctx, id, userID)
 	if err != nil {
-		return nil, fmt.Errorf("create schedule: %w", err)
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+
+	spec := s.Spec()
+	setIf(&spec.Name, input.Name)
+	setIf(&spec.CronExpr, input.CronExpr)
+	setIf(&spec.Timezone, input.Timezone)
+	setIf(&spec.Every, input.Every)
+	switch {
+	case input.CronExpr != nil && input.Every != nil:
+		return nil, domain.ErrInvalidCadence
+	case input.CronExpr != nil:
+		spec.Every = 0
+	case input.Every != nil:
+		spec.CronExpr = ""
+	}
+	setIf(&spec.URL, input.URL)
+	setIf(&spec.Method, input.Method)
+	if input.Topic != nil {
+		spec.Topic = input.Topic
+	}
+	if input.MessageKey != nil {
+		spec.MessageKey = input.MessageKey
+		if *input.MessageKey == "" {
+			spec.MessageKey = nil
+		}
+	}
+	if input.EmailTo != nil {
+		spec.EmailTo = input.EmailTo
+	}
+	if input.EmailSubject != nil {
+		spec.EmailSubject = input.EmailSubject
+	}
+	setIf(&spec.TimeoutSeconds, input.TimeoutSeconds)
+	setIf(&spec.MaxRetries, input.MaxRetries)
+	setIf(&spec.Backoff, input.Backoff)
+	setIf(&spec.RetryBaseSeconds, input.RetryBaseSeconds)
+	setIf(&spec.RetryMaxSeconds, input.RetryMaxSeconds)
+	setIf(&spec.RetryJitter, input.RetryJitter)
+	setIf(&spec.OverlapPolicy, input.OverlapPolicy)
+	setIf(&spec.JitterSeconds, input.JitterSeconds)
+	setIf(&spec.SuccessCodes, input.SuccessCodes)
+	setIf(&spec.Templated, input.Templated)
+	setIf(&spec.Queue, input.Queue)
+	setIf(&spec.PauseAfter, input.PauseAfterFailures)
+	if input.Headers != nil {
+		spec.Headers = input.Headers
+	}
+	if input.Body != nil {
+		spec.Body = input.Body
+	}
+	setIf(&spec.BodyEncoding, input.BodyEncoding)
+	if input.ContentType != nil {
+		spec.ContentType = input.ContentType
+		if *input.ContentType == "" {
+			spec.ContentType = nil
+		}
+	}
+	if input.ClientCertID != nil {
+		spec.ClientCertID = input.ClientCertID
+		if *input.ClientCertID == "" {
+			spec.ClientCertID = nil
+		}
+	}
+	if input.CABundle != nil {
+		spec.CABundle = input.CABundle
+		if *input.CABundle == "" {
+			spec.CABundle = nil
+		}
+	}
+	setIf(&spec.TLSSkipVerify, input.TLSSkipVerify)
+
+	if err := spec.SuccessCodes.Validate(); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateRetryBackoff(spec.RetryBaseSeconds, spec.RetryMaxSeconds); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateScheduleJitter(spec.JitterSeconds, spec.Every); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateQueue(spec.Queue); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidatePauseAfterFailures(spec.PauseAfter, s.Mode); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateBody(spec.Body, spec.BodyEncoding, spec.Templated); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateUnsealed(spec.Headers, spec.Body, input.SigningSecret); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateContentType(spec.ContentType); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateTLSOptions(spec.CABundle, spec.TLSSkipVerify); err != nil {
+		return nil, err
+	}
+	if spec.Templated {
+		if err := domain.ValidatePayloadTemplate(spec.URL, spec.Headers, spec.Body); err != nil {
+			return nil, err
+		}
+	}
+	if s.Mode == domain.ScheduleModePing {
+		if (spec.Method != "HEAD" && spec.Method != "GET") || spec.Body != nil {
+			return nil, domain.ErrInvalidPingSchedule
+		}
+	}
+	if input.SigningSecret != nil && *input.SigningSecret != "" {
+		if err := domain.ValidateSigningSecret(*input.SigningSecret); err != nil {
+			return nil, err
+		}
+	}
+	if input.ProxyURL != nil && *input.ProxyURL != "" {
+		if err := domain.ValidateProxyURL(*input.ProxyURL); err != nil {
+			return nil, err
+		}
+	}
+	if cadenceChanged(s.Spec(), spec) {
+		if s.NextRunAt, err = firstRun(spec.CronExpr, spec.Timezone, spec.Every, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	s.ApplySpec(spec)
+	if input.SigningSecret != nil {
+		s.SigningSecret = nil
+		if *input.SigningSecret != "" {
+			s.SigningSecret = input.SigningSecret
+		}
+	}
+	if input.ProxyURL != nil {
+		s.ProxyURL = nil
+		if *input.ProxyURL != "" {
+			s.ProxyURL = input.ProxyURL
+		}
+	}
+	if err := s.Target().Validate(); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.repo.Update(ctx, s, domain.RevisionActionUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("update schedule: %w", err)
+	}
+	return updated, nil
+}
+
+// PreviewRuns validates a cadence like CreateSchedule and returns the next n times a
+// schedule with it would fire, in its timezone, without creating anything. Schedule
+// jitter is not applied.
+func (u *ScheduleUsecase) PreviewRuns(cronExpr, timezone string, every domain.Interval, n int) ([]time.Time, error) {
+	return nextRuns(cronExpr, timezone, every, time.Now(), n)
+}
+
+// firstRun validates a schedule's cadence — a cron expression evaluated in timezone, or
+// a fixed interval — and returns its first run after now.
+func firstRun(cronExpr, timezone string, every domain.Interval, now time.Time) (time.Time, error) {
+	runs, err := nextRuns(cronExpr, timezone, every, now, 1)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(runs) == 0 {
+		return time.Time{}, domain.ErrInvalidCronExpr
+	}
+	return runs[0], nil
+}
+
+// nextRuns validates a cadence and returns its first n runs after now, stepping the way
+// the dispatcher does. A cron expression that never matches again, like "0 0 30 2 *",
+// yields fewer.
+func nextRuns(cronExpr, timezone string, every domain.Interval, now time.Time, n int) ([]time.Time, error) {
+	if (cronExpr == "") == (every == 0) {
+		return nil, domain.ErrInvalidCadence
+	}
+	loc, err := domain.LoadTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+	next := func(t time.Time) time.Time { return t.Add(time.Duration(every)) }
+	if every != 0 {
+		if err := every.Validate(); err != nil {
+			return nil, err
+		}
+	} else {
+		sched, err := cron.ParseStandard(cronExpr)
+		if err != nil {
+			return nil, domain.ErrInvalidCronExpr
+		}
+		next = sched.Next
+	}
+
+	runs := make([]time.Time, 0, n)
+	for t := now.In(loc); len(runs) < n; {
+		if t = next(t); t.IsZero() {
+			break
+		}
+		runs = append(runs, t)
+	}
+	return runs, nil
+}
+
+// cadenceChanged reports whether next_run_at must be recomputed going from before to after.
+func cadenceChanged(before, after domain.ScheduleSpec) bool {
+	return before.CronExpr != after.CronExpr || before.Timezone != after.Timezone || before.Every != after.Every
+}
+
+// setIf overwrites *dst with *src when src is set.
+func setIf[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
 	}
-	return created, nil
 }
 
 func (u *ScheduleUsecase) GetSchedule(ctx context.Context, id, userID string) (*domain.Schedule, error) {
@@ -87,55 +486,62 @@ func (u *ScheduleUsecase) GetSchedule(ctx context.Context, id, userID string) (*
 
 type ListSchedulesInput struct {
 	UserID string
-	Cursor string
-	Limit  int
+	Page   PageInput
 }
 
 type ListSchedulesResult struct {
 	Schedules  []*domain.Schedule
 	NextCursor *string
+	TotalCount *int64 // set when the input asked for it
 }
 
 type scheduleCursor struct {
-	CreatedAt time.Time `json:"c"`
-	ID        string    `json:"i"`
+	CreatedAt time.Time        `json:"c"`
+	ID        string           `json:"i"`
+	Order     domain.SortOrder `json:"o,omitempty"` // empty in cursors from before ordering
 }
 
-func decodeScheduleCursor(s string) (*time.Time, string, error) {
+// decodeScheduleCursor reads a schedule list cursor, which must have been made for a
+// list in order.
+func decodeScheduleCursor(s string, order domain.SortOrder) (*time.Time, string, error) {
 	b, err := base64.RawURLEncoding.DecodeString(s)
 	if err != nil {
-		return nil, "", fmt.Errorf("decode cursor: %w", err)
+		return nil, "", fmt.Errorf("decode cursor: %w", domain.ErrInvalidCursor)
 	}
 	var c scheduleCursor
 	if err := json.Unmarshal(b, &c); err != nil {
-		return nil, "", fmt.Errorf("unmarshal cursor: %w", err)
+		return nil, "", fmt.Errorf("unmarshal cursor: %w", domain.ErrInvalidCursor)
+	}
+	if c.Order == "" {
+		c.Order = domain.SortDesc
+	}
+	if c.Order != order {
+		return nil, "", domain.ErrInvalidCursor
 	}
 	return &c.CreatedAt, c.ID, nil
 }
 
-func encodeScheduleCursor(createdAt time.Time, id string) string {
-	b, _ := json.Marshal(scheduleCursor{CreatedAt: createdAt, ID: id})
+func encodeScheduleCursor(createdAt time.Time, id string, order domain.SortOrder) string {
+	b, _ := json.Marshal(scheduleCursor{CreatedAt: createdAt, ID: id, Order: order})
 	return base64.RawURLEncoding.EncodeToString(b)
 }
 
 func (u *ScheduleUsecase) ListSchedules(ctx context.Context, input ListSchedulesInput) (ListSchedulesResult, error) {
-	limit := input.Limit
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
+	limit, order, err := domain.ResolvePage(input.Page.Size, input.Page.Order)
+	if err != nil {
+		return ListSchedulesResult{}, err
 	}
 
 	repoInput := repository.ListSchedulesInput{
 		UserID: input.UserID,
+		Order:  order,
 		Limit:  limit + 1,
 	}
 
-	if input.Cursor != "" {
-		cursorTime, cursorID, err := decodeScheduleCursor(input.Cursor)
+	if input.Page.Cursor != "" {
+		cursorTime, cursorID, err := decodeScheduleCursor(input.Page.Cursor, order)
 		if err != nil {
-			return ListSchedulesResult{}, domain.ErrInvalidCronExpr // reuse as generic bad cursor
+			return ListSchedulesResult{}, err
 		}
 		repoInput.CursorTime = cursorTime
 		repoInput.CursorID = cursorID
@@ -148,13 +554,21 @@ func (u *ScheduleUsecase) ListSchedules(ctx context.Context, input ListSchedules
 
 	var nextCursor *string
 	if len(schedules) == limit+1 {
-		last := schedules[limit]
-		s := encodeScheduleCursor(last.CreatedAt, last.ID)
+		last := schedules[limit-1]
+		s := encodeScheduleCursor(last.CreatedAt, last.ID, order)
 		nextCursor = &s
 		schedules = schedules[:limit]
 	}
 
-	return ListSchedulesResult{Schedules: schedules, NextCursor: nextCursor}, nil
+	result := ListSchedulesResult{Schedules: schedules, NextCursor: nextCursor}
+	if input.Page.IncludeTotal {
+		total, err := u.repo.Count(ctx, input.UserID)
+		if err != nil {
+			return ListSchedulesResult{}, fmt.Errorf("count schedules: %w", err)
+		}
+		result.TotalCount = &total
+	}
+	return result, nil
 }
 
 func (u *ScheduleUsecase) PauseSchedule(ctx context.Context, id, userID string) error {
@@ -171,6 +585,68 @@ func (u *ScheduleUsecase) ResumeSchedule(ctx context.Context, id, userID string)
 	return nil
 }
 
+// TriggerSchedule fires a one-off job from the schedule's current definition, due now.
+// It works on paused schedules and leaves next_run_at and last_run_at alone, so the cron
+// cadence is unaffected. Ping schedules fire a standard job so the run has attempt history.
+func (u *ScheduleUsecase) TriggerSchedule(ctx context.Context, id, userID string) (*domain.Job, error) {
+	ctx, span := tracing.Start(ctx, "ScheduleUsecase.TriggerSchedule")
+	defer span.End()
+
+	s, err := u.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	if err := u.quotas.CheckJobCreate(ctx, userID); err != nil {
+		return nil, fmt.Errorf("check quota: %w", err)
+	}
+
+	now := time.Now()
+	job := &domain.Job{
+		UserID:           s.UserID,
+		IdempotencyKey:   fmt.Sprintf("sched:%s:manual:%d", s.ID, now.UnixMilli()),
+		JobType:          s.JobType,
+		URL:              s.URL,
+		Method:           s.Method,
+		Topic:            s.Topic,
+		MessageKey:       s.MessageKey,
+		EmailTo:          s.EmailTo,
+		EmailSubject:     s.EmailSubject,
+		Headers:          s.Headers,
+		Body:             s.Body,
+		BodyEncoding:     s.BodyEncoding,
+		ContentType:      s.ContentType,
+		TimeoutSeconds:   s.TimeoutSeconds,
+		Status:           domain.StatusPending,
+		ScheduledAt:      now,
+		MaxRetries:       s.MaxRetries,
+		Backoff:          s.Backoff,
+		RetryBaseSeconds: s.RetryBaseSeconds,
+		RetryMaxSeconds:  s.RetryMaxSeconds,
+		RetryJitter:      s.RetryJitter,
+		ScheduleID:       &s.ID,
+		SuccessCodes:     s.SuccessCodes,
+		Templated:        s.Templated,
+		SigningSecret:    s.SigningSecret,
+		ProxyURL:         s.ProxyURL,
+		ClientCertID:     s.ClientCertID,
+		CABundle:         s.CABundle,
+		TLSSkipVerify:    s.TLSSkipVerify,
+		Queue:            s.Queue,
+	}
+	if reqID := requestid.FromContext(ctx); reqID != "" {
+		job.RequestID = &reqID
+	}
+	if tp := tracing.TraceParent(ctx); tp != "" {
+		job.TraceParent = &tp
+	}
+
+	created, err := u.jobRepo.Create(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("create job: %w", err)
+	}
+	return created, nil
+}
+
 func (u *ScheduleUsecase) DeleteSchedule(ctx context.Context, id, userID string) error {
 	if err := u.repo.Delete(ctx, id, userID); err != nil {
 		return fmt.Errorf("delete schedule: %w", err)
@@ -181,48 +657,128 @@ func (u *ScheduleUsecase) DeleteSchedule(ctx context.Context, id, userID string)
 type ListScheduleJobsInput struct {
 	ScheduleID string
 	UserID     string
-	Cursor     string
-	Limit      int
+	Page       PageInput
 }
 
 func (u *ScheduleUsecase) ListScheduleJobs(ctx context.Context, input ListScheduleJobsInput) (ListJobsResult, error) {
+	limit, order, err := domain.ResolvePage(input.Page.Size, input.Page.Order)
+	if err != nil {
+		return ListJobsResult{}, err
+	}
+
 	// Verify ownership
 	if _, err := u.repo.GetByID(ctx, input.ScheduleID, input.UserID); err != nil {
 		return ListJobsResult{}, fmt.Errorf("get schedule: %w", err)
 	}
 
-	limit := input.Limit
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
-	}
-
 	var cursorTime *time.Time
 	var cursorID string
 
-	if input.Cursor != "" {
-		ct, cid, err := decodeCursor(input.Cursor)
+	if input.Page.Cursor != "" {
+		ct, cid, err := decodeCursor(input.Page.Cursor, order)
 		if err != nil {
-			return ListJobsResult{}, domain.ErrInvalidStatus
+			return ListJobsResult{}, err
 		}
 		cursorTime = ct
 		cursorID = cid
 	}
 
-	jobs, err := u.jobRepo.ListByScheduleID(ctx, input.ScheduleID, limit+1, cursorTime, cursorID)
+	jobs, err := u.jobRepo.ListByScheduleID(ctx, input.ScheduleID, order, limit+1, cursorTime, cursorID)
 	if err != nil {
 		return ListJobsResult{}, fmt.Errorf("list schedule jobs: %w", err)
 	}
 
 	var nextCursor *string
 	if len(jobs) == limit+1 {
-		last := jobs[limit]
-		s := encodeCursor(last.ScheduledAt, last.ID)
+		last := jobs[limit-1]
+		s := encodeCursor(last.ScheduledAt, last.ID, order)
 		nextCursor = &s
 		jobs = jobs[:limit]
 	}
 
-	return ListJobsResult{Jobs: jobs, NextCursor: nextCursor}, nil
+	result := ListJobsResult{Jobs: jobs, NextCursor: nextCursor}
+	if input.Page.IncludeTotal {
+		total, err := u.jobRepo.CountByScheduleID(ctx, input.ScheduleID)
+		if err != nil {
+			return ListJobsResult{}, fmt.Errorf("count schedule jobs: %w", err)
+		}
+		result.TotalCount = &total
+	}
+	return result, nil
+}
+
+// Uptime summarises a ping schedule's checks and downtime over the trailing window.
+func (u *ScheduleUsecase) Uptime(ctx context.Context, id, userID string, window time.Duration) (*domain.ScheduleUptime, error) {
+	s, err := u.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	if s.Mode != domain.ScheduleModePing {
+		return nil, domain.ErrNotPingSchedule
+	}
+
+	uptime, err := u.pingRepo.GetUptime(ctx, id, time.Now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("get uptime: %w", err)
+	}
+	return uptime, nil
+}
+
+// maxRevisions caps GET /schedules/:id/revisions; older revisions stay in the table.
+const maxRevisions = 100
+
+// ListRevisions returns the schedule's most recent revisions, newest first.
+func (u *ScheduleUsecase) ListRevisions(ctx context.Context, id, userID string) ([]*domain.ScheduleRevision, error) {
+	if _, err := u.repo.GetByID(ctx, id, userID); err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	revisions, err := u.repo.ListRevisions(ctx, id, maxRevisions)
+	if err != nil {
+		return nil, fmt.Errorf("list revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// RevertSchedule restores the configuration the schedule had as of revision, recorded as
+// a new revert revision. The paused state is kept as-is — pause and resume own it.
+func (u *ScheduleUsecase) RevertSchedule(ctx context.Context, id, userID string, revision int) (*domain.Schedule, error) {
+	s, err := u.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	rev, err := u.repo.GetRevision(ctx, id, revision)
+	if err != nil {
+		return nil, fmt.Errorf("get revision: %w", err)
+	}
+
+	spec := rev.After
+	spec.Paused = s.Paused
+	// Revisions recorded before schedules had a timezone were all evaluated in UTC.
+	if spec.Timezone == "" {
+		spec.Timezone = domain.DefaultTimezone
+	}
+	// Nor a tunable retry backoff; the defaults are what they used.
+	if spec.RetryBaseSeconds == 0 {
+		spec.RetryBaseSeconds = domain.DefaultRetryBaseSeconds
+		spec.RetryMaxSeconds = domain.DefaultRetryMaxSeconds
+	}
+	if spec.RetryJitter == "" {
+		spec.RetryJitter = domain.DefaultJitter
+	}
+	// Nor an overlap policy; they always queued.
+	if spec.OverlapPolicy == "" {
+		spec.OverlapPolicy = domain.DefaultOverlapPolicy
+	}
+	if cadenceChanged(s.Spec(), spec) {
+		if s.NextRunAt, err = firstRun(spec.CronExpr, spec.Timezone, spec.Every, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	s.ApplySpec(spec)
+
+	updated, err := u.repo.Update(ctx, s, domain.RevisionActionRevert)
+	if err != nil {
+		return nil, fmt.Errorf("revert schedule: %w", err)
+	}
+	return updated, nil
 }
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/tracing"
+)
+
+// SyncAction is what converging on a desired set of schedules does to one schedule.
+type SyncAction string
+
+const (
+	SyncCreate    SyncAction = "create"
+	SyncUpdate    SyncAction = "update"
+	SyncReplace   SyncAction = "replace" // mode or job type changed, which only a new schedule can
+	SyncDelete    SyncAction = "delete"
+	SyncUnchanged SyncAction = "unchanged"
+)
+
+// SyncPlanEntry is the plan for one schedule, matched by name. Existing is the schedule
+// the name belongs to now (nil for create), Desired the validated schedule from the
+// document (nil for delete), and Schedule the one saved when the plan was applied.
+type SyncPlanEntry struct {
+	Name     string
+	Action   SyncAction
+	Changed  []string // update and replace: fields that differ from Existing
+	Existing *domain.Schedule
+	Desired  *domain.Schedule
+	Schedule *domain.Schedule
+	Err      error // the entry is invalid, or applying it failed
+}
+
+// SyncResult is a plan and whether it was applied.
+type SyncResult struct {
+	Entries []SyncPlanEntry
+	Applied bool
+}
+
+// SyncSchedules plans the changes that make the user's schedules match inputs exactly —
+// creating new names, updating changed ones and deleting the ones not listed — and,
+// unless dryRun, applies them. A plan with an invalid entry is not applied at all, but
+// applying is not atomic: each change is its own write, and one that fails is reported
+// on its entry while the rest go ahead. Deletes run first, so they free quota and names
+// for the creates.
+func (u *ScheduleUsecase) SyncSchedules(ctx context.Context, userID string, inputs []CreateScheduleInput, dryRun bool) (*SyncResult, error) {
+	ctx, span := tracing.Start(ctx, "ScheduleUsecase.SyncSchedules")
+	defer span.End()
+
+	current, err := u.listAllSchedules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.Schedule, len(current))
+	for _, s := range current {
+		byName[s.Name] = s
+	}
+
+	result := &SyncResult{Entries: make([]SyncPlanEntry, 0, len(inputs))}
+	valid := true
+	listed := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		e := SyncPlanEntry{Name: input.Name, Existing: byName[input.Name]}
+		if listed[input.Name] {
+			e.Err = domain.ErrScheduleNameConflict // listed twice
+		} else {
+			e.Desired, e.Err = u.newSchedule(ctx, input)
+		}
+		listed[input.Name] = true
+		if e.Err != nil {
+			valid = false
+			result.Entries = append(result.Entries, e)
+			continue
+		}
+
+		switch {
+		case e.Existing == nil:
+			e.Action = SyncCreate
+		case e.Existing.Mode != e.Desired.Mode || e.Existing.JobType != e.Desired.JobType:
+			e.Action = SyncReplace
+			e.Changed = scheduleDiff(e.Existing, e.Desired)
+		default:
+			e.Changed = scheduleDiff(e.Existing, e.Desired)
+			e.Action = SyncUpdate
+			if len(e.Changed) == 0 {
+				e.Action = SyncUnchanged
+			}
+		}
+		result.Entries = append(result.Entries, e)
+	}
+	for _, s := range current {
+		if !listed[s.Name] {
+			result.Entries = append(result.Entries, SyncPlanEntry{Name: s.Name, Action: SyncDelete, Existing: s})
+		}
+	}
+
+	if dryRun || !valid {
+		return result, nil
+	}
+	u.applySync(ctx, result.Entries)
+	result.Applied = true
+	return result, nil
+}
+
+// applySync carries out a valid plan: deletes (including the old half of replaces),
+// then updates, then creates.
+func (u *ScheduleUsecase) applySync(ctx context.Context, entries []SyncPlanEntry) {
+	for i := range entries {
+		e := &entries[i]
+		if e.Action == SyncDelete || e.Action == SyncReplace {
+			e.Err = u.DeleteSchedule(ctx, e.Existing.ID, e.Existing.UserID)
+		}
+	}
+	for i := range entries {
+		e := &entries[i]
+		switch e.Action {
+		case SyncUpdate:
+			e.Schedule, e.Err = u.syncUpdate(ctx, e.Existing, e.Desired)
+		case SyncUnchanged:
+			e.Schedule = e.Existing
+		}
+	}
+	for i := range entries {
+		e := &entries[i]
+		if (e.Action == SyncCreate || e.Action == SyncReplace) && e.Err == nil {
+			e.Schedule, e.Err = u.createDesired(ctx, e.Desired)
+		}
+	}
+}
+
+// syncUpdate overwrites have with want, recorded as a sync revision. The pending run
+// keeps its time unless the cadence changed.
+func (u *ScheduleUsecase) syncUpdate(ctx context.Context, have, want *domain.Schedule) (*domain.Schedule, error) {
+	s := *have
+	if cadenceChanged(have.Spec(), want.Spec()) {
+		s.NextRunAt = want.NextRunAt
+	}
+	s.ApplySpec(want.Spec())
+	s.SigningSecret = want.SigningSecret
+	s.ProxyURL = want.ProxyURL
+
+	updated, err := u.repo.Update(ctx, &s, domain.RevisionActionSync)
+	if err != nil {
+		return nil, fmt.Errorf("update schedule: %w", err)
+	}
+	return updated, nil
+}
+
+// createDesired saves a schedule built by newSchedule, if the user's quota allows.
+func (u *ScheduleUsecase) createDesired(ctx context.Context, s *domain.Schedule) (*domain.Schedule, error) {
+	if err := u.quotas.CheckScheduleCreate(ctx, s.UserID); err != nil {
+		return nil, fmt.Errorf("check quota: %w", err)
+	}
+	created, err := u.repo.Create(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("create schedule: %w", err)
+	}
+	return created, nil
+}
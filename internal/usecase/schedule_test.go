@@ -0,0 +1,60 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+)
+
+func TestScheduleUsecase_PreviewRuns(t *testing.T) {
+	uc := usecase.NewScheduleUsecase(nil, nil, nil, nil, nil)
+
+	runs, err := uc.PreviewRuns("0 9 * * 1-5", "Europe/Berlin", 0, 7)
+	if err != nil {
+		t.Fatalf("cron preview: %v", err)
+	}
+	if len(runs) != 7 {
+		t.Fatalf("got %d runs, want 7", len(runs))
+	}
+	for i, r := range runs {
+		if r.Location().String() != "Europe/Berlin" || r.Hour() != 9 || r.Minute() != 0 {
+			t.Errorf("run %d = %v, want 09:00 Europe/Berlin", i, r)
+		}
+		if wd := r.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			t.Errorf("run %d falls on %v", i, wd)
+		}
+		if i > 0 && !r.After(runs[i-1]) {
+			t.Errorf("run %d = %v is not after %v", i, r, runs[i-1])
+		}
+	}
+
+	runs, err = uc.PreviewRuns("", "", domain.Interval(90*time.Minute), 3)
+	if err != nil {
+		t.Fatalf("interval preview: %v", err)
+	}
+	if len(runs) != 3 || runs[2].Sub(runs[0]) != 3*time.Hour {
+		t.Errorf("interval runs = %v, want 3 runs 90m apart", runs)
+	}
+
+	if runs, err := uc.PreviewRuns("0 0 30 2 *", "", 0, 5); err != nil || len(runs) != 0 {
+		t.Errorf("never-matching cron: runs = %v, err = %v; want none", runs, err)
+	}
+
+	for _, tt := range []struct {
+		cron, timezone string
+		every          domain.Interval
+		want           error
+	}{
+		{"0 9 * * *", "Mars/Olympus", 0, domain.ErrInvalidTimezone},
+		{"not cron", "", 0, domain.ErrInvalidCronExpr},
+		{"", "", domain.Interval(time.Second), domain.ErrInvalidInterval},
+		{"0 9 * * *", "", domain.Interval(time.Hour), domain.ErrInvalidCadence},
+	} {
+		if _, err := uc.PreviewRuns(tt.cron, tt.timezone, tt.every, 5); !errors.Is(err, tt.want) {
+			t.Errorf("PreviewRuns(%q, %q, %v) err = %v, want %v", tt.cron, tt.timezone, tt.every, err, tt.want)
+		}
+	}
+}
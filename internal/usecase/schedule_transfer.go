@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// exportPageSize bounds each List call while walking all of a user's schedules.
+const exportPageSize = 100
+
+// ExportedSchedule is a schedule plus, optionally, a summary of the jobs it has fired.
+type ExportedSchedule struct {
+	Schedule *domain.Schedule
+	Summary  *domain.ScheduleRunSummary // nil when history wasn't requested or the schedule never fired
+}
+
+// ExportSchedules returns every schedule the user owns, newest first.
+func (u *ScheduleUsecase) ExportSchedules(ctx context.Context, userID string, includeHistory bool) ([]ExportedSchedule, error) {
+	var summaries map[string]*domain.ScheduleRunSummary
+	if includeHistory {
+		var err error
+		summaries, err = u.jobRepo.SummarizeBySchedule(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("summarize schedule runs: %w", err)
+		}
+	}
+
+	schedules, err := u.listAllSchedules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]ExportedSchedule, len(schedules))
+	for i, s := range schedules {
+		exported[i] = ExportedSchedule{Schedule: s, Summary: summaries[s.ID]}
+	}
+	return exported, nil
+}
+
+// listAllSchedules walks every schedule the user owns, newest first.
+func (u *ScheduleUsecase) listAllSchedules(ctx context.Context, userID string) ([]*domain.Schedule, error) {
+	var all []*domain.Schedule
+	input := repository.ListSchedulesInput{UserID: userID, Limit: exportPageSize}
+	for {
+		page, err := u.repo.List(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("list schedules: %w", err)
+		}
+		all = append(all, page...)
+		if len(page) < exportPageSize {
+			return all, nil
+		}
+		last := page[len(page)-1]
+		input.CursorTime = &last.CreatedAt
+		input.CursorID = last.ID
+	}
+}
+
+// ImportMode says whether an import writes anything.
+type ImportMode string
+
+const (
+	// ImportApply creates the schedules whose names are new and skips the rest.
+	ImportApply ImportMode = "apply"
+	// ImportDryRun validates every entry and reports what ImportApply would do, without
+	// writing. Quotas are checked per entry, not for the document as a whole.
+	ImportDryRun ImportMode = "dry_run"
+	// ImportDiff is ImportDryRun plus, for names that exist, the fields that differ.
+	ImportDiff ImportMode = "diff"
+)
+
+// ImportScheduleResult is the outcome of importing one schedule. Exactly one of
+// Schedule, Existing and Err is set: Schedule is the created schedule (unsaved, without an
+// ID, outside ImportApply), Existing the schedule the name is taken by.
+type ImportScheduleResult struct {
+	Name     string
+	Schedule *domain.Schedule
+	Existing *domain.Schedule
+	Changed  []string // ImportDiff only: fields in which the entry differs from Existing
+	Err      error
+}
+
+// ImportSchedules creates each schedule independently so one bad entry doesn't block the
+// rest. Every entry goes through CreateSchedule, or the same validation for a dry run, so
+// validation and quotas still apply.
+func (u *ScheduleUsecase) ImportSchedules(ctx context.Context, userID string, inputs []CreateScheduleInput, mode ImportMode) ([]ImportScheduleResult, error) {
+	results := make([]ImportScheduleResult, len(inputs))
+	if mode == ImportApply {
+		for i, input := range inputs {
+			s, err := u.CreateSchedule(ctx, input)
+			results[i] = ImportScheduleResult{Name: input.Name, Schedule: s, Err: err}
+		}
+		return results, nil
+	}
+
+	current, err := u.listAllSchedules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.Schedule, len(current))
+	for _, s := range current {
+		byName[s.Name] = s
+	}
+
+	for i, input := range inputs {
+		results[i].Name = input.Name
+		s, err := u.newSchedule(ctx, input)
+		if err == nil && byName[input.Name] == nil {
+			err = u.quotas.CheckScheduleCreate(ctx, userID)
+		}
+		switch existing := byName[input.Name]; {
+		case err != nil:
+			results[i].Err = err
+		case existing != nil:
+			results[i].Existing = existing
+			if mode == ImportDiff {
+				results[i].Changed = scheduleDiff(existing, s)
+			}
+		default:
+			results[i].Schedule = s
+		}
+	}
+	return results, nil
+}
+
+// scheduleDiff lists the fields in which want differs from the schedule it would replace:
+// the spec fields, plus the ones revisions don't snapshot.
+func scheduleDiff(have, want *domain.Schedule) []string {
+	changed := domain.ChangedFields(have.Spec(), want.Spec())
+	if have.Mode != want.Mode {
+		changed = append(changed, "mode")
+	}
+	if have.JobType != want.JobType {
+		changed = append(changed, "job_type")
+	}
+	if !slices.Contains(changed, "signed") && deref(have.SigningSecret) != deref(want.SigningSecret) {
+		changed = append(changed, "signing_secret")
+	}
+	if !slices.Contains(changed, "proxied") && deref(have.ProxyURL) != deref(want.ProxyURL) {
+		changed = append(changed, "proxy_url")
+	}
+	return changed
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
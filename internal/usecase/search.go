@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// searchLimit is per kind, so a search returns at most twice this many results.
+const searchLimit = 10
+
+type SearchUsecase struct {
+	jobs      repository.JobRepository
+	schedules repository.ScheduleRepository
+}
+
+func NewSearchUsecase(jobs repository.JobRepository, schedules repository.ScheduleRepository) *SearchUsecase {
+	return &SearchUsecase{jobs: jobs, schedules: schedules}
+}
+
+type SearchResult struct {
+	Schedules []*domain.Schedule
+	Jobs      []*domain.Job
+}
+
+// Search looks up the user's schedules by name and jobs by ID prefix, idempotency key
+// prefix or URL substring.
+func (u *SearchUsecase) Search(ctx context.Context, userID, q string) (SearchResult, error) {
+	q = strings.TrimSpace(q)
+	if n := utf8.RuneCountInString(q); n < domain.MinSearchQueryLength || n > domain.MaxSearchQueryLength {
+		return SearchResult{}, domain.ErrInvalidSearchQuery
+	}
+
+	schedules, err := u.schedules.SearchByName(ctx, userID, q, searchLimit)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("search schedules: %w", err)
+	}
+	jobs, err := u.jobs.Search(ctx, userID, q, searchLimit)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("search jobs: %w", err)
+	}
+	return SearchResult{Schedules: schedules, Jobs: jobs}, nil
+}
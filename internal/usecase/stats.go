@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/repository"
+)
+
+// MaxStatsWindow bounds stats queries, which aggregate raw job and attempt rows.
+const MaxStatsWindow = 30 * 24 * time.Hour
+
+type StatsUsecase struct {
+	repo      repository.StatsRepository
+	schedules repository.ScheduleRepository
+}
+
+func NewStatsUsecase(repo repository.StatsRepository, schedules repository.ScheduleRepository) *StatsUsecase {
+	return &StatsUsecase{repo: repo, schedules: schedules}
+}
+
+// UserStats summarises the user's jobs scheduled over the trailing window. Ping checks
+// keep no attempt rows; their outcomes are in the schedule's uptime instead.
+func (u *StatsUsecase) UserStats(ctx context.Context, userID string, window time.Duration) (*domain.JobStats, error) {
+	stats, err := u.repo.JobStats(ctx, userID, nil, time.Now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("job stats: %w", err)
+	}
+	return stats, nil
+}
+
+// ScheduleStats is UserStats restricted to the jobs one of the user's schedules fired.
+func (u *StatsUsecase) ScheduleStats(ctx context.Context, id, userID string, window time.Duration) (*domain.JobStats, error) {
+	if _, err := u.schedules.GetByID(ctx, id, userID); err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	stats, err := u.repo.JobStats(ctx, userID, &id, time.Now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("job stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+type TemplateUsecase struct {
+	jobs      *JobUsecase
+	schedules *ScheduleUsecase
+}
+
+func NewTemplateUsecase(jobs *JobUsecase, schedules *ScheduleUsecase) *TemplateUsecase {
+	return &TemplateUsecase{jobs: jobs, schedules: schedules}
+}
+
+func (u *TemplateUsecase) Catalog() []domain.Template {
+	return templateCatalog
+}
+
+type InstantiateTemplateInput struct {
+	UserID     string
+	TemplateID string
+	Params     map[string]string
+
+	// Job templates
+	IdempotencyKey string
+	ScheduledAt    time.Time
+
+	// Schedule templates
+	Name     string
+	CronExpr string // overrides the template default when set
+	Timezone string
+}
+
+// InstantiateTemplateResult holds whichever resource the template kind produced.
+type InstantiateTemplateResult struct {
+	Job      *domain.Job
+	Schedule *domain.Schedule
+}
+
+func (u *TemplateUsecase) Instantiate(ctx context.Context, input InstantiateTemplateInput) (InstantiateTemplateResult, error) {
+	tmpl, ok := findTemplate(input.TemplateID)
+	if !ok {
+		return InstantiateTemplateResult{}, domain.ErrTemplateNotFound
+	}
+
+	params, err := resolveTemplateParams(tmpl, input.Params)
+	if err != nil {
+		return InstantiateTemplateResult{}, err
+	}
+
+	switch tmpl.Kind {
+	case domain.TemplateKindSchedule:
+		if input.Name == "" {
+			return InstantiateTemplateResult{}, fmt.Errorf("%w: schedule templates require a name", domain.ErrInvalidTemplateParams)
+		}
+	default:
+		if input.IdempotencyKey == "" || input.ScheduledAt.IsZero() {
+			return InstantiateTemplateResult{}, fmt.Errorf("%w: job templates require idempotency_key and scheduled_at", domain.ErrInvalidTemplateParams)
+		}
+	}
+
+	targetURL, err := renderTemplate(tmpl.URL, params)
+	if err != nil {
+		return InstantiateTemplateResult{}, err
+	}
+	if u, err := url.Parse(targetURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return InstantiateTemplateResult{}, fmt.Errorf("%w: rendered URL is not a valid http(s) URL", domain.ErrInvalidTemplateParams)
+	}
+	headers := make(map[string]string, len(tmpl.Headers))
+	for k, v := range tmpl.Headers {
+		if headers[k], err = renderTemplate(v, params); err != nil {
+			return InstantiateTemplateResult{}, err
+		}
+	}
+	var body *string
+	if tmpl.Body != "" {
+		b, err := renderTemplate(tmpl.Body, params)
+		if err != nil {
+			return InstantiateTemplateResult{}, err
+		}
+		body = &b
+	}
+
+	switch tmpl.Kind {
+	case domain.TemplateKindSchedule:
+		cronExpr := input.CronExpr
+		if cronExpr == "" {
+			cronExpr = tmpl.CronExpr
+		}
+		s, err := u.schedules.CreateSchedule(ctx, CreateScheduleInput{
+			UserID:         input.UserID,
+			Name:           input.Name,
+			CronExpr:       cronExpr,
+			Timezone:       input.Timezone,
+			URL:            targetURL,
+			Method:         tmpl.Method,
+			Headers:        headers,
+			Body:           body,
+			TimeoutSeconds: tmpl.TimeoutSeconds,
+			MaxRetries:     tmpl.MaxRetries,
+			Backoff:        tmpl.Backoff,
+		})
+		if err != nil {
+			return InstantiateTemplateResult{}, fmt.Errorf("instantiate schedule template: %w", err)
+		}
+		return InstantiateTemplateResult{Schedule: s}, nil
+	default:
+		j, err := u.jobs.CreateJob(ctx, CreateJobInput{
+			UserID:         input.UserID,
+			IdempotencyKey: input.IdempotencyKey,
+			URL:            targetURL,
+			Method:         tmpl.Method,
+			Headers:        headers,
+			Body:           body,
+			TimeoutSeconds: tmpl.TimeoutSeconds,
+			ScheduledAt:    input.ScheduledAt,
+			MaxRetries:     tmpl.MaxRetries,
+			Backoff:        tmpl.Backoff,
+		})
+		if err != nil {
+			return InstantiateTemplateResult{}, fmt.Errorf("instantiate job template: %w", err)
+		}
+		return InstantiateTemplateResult{Job: j}, nil
+	}
+}
+
+func findTemplate(id string) (domain.Template, bool) {
+	for _, t := range templateCatalog {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return domain.Template{}, false
+}
+
+// resolveTemplateParams applies defaults and rejects missing required or unknown parameters.
+func resolveTemplateParams(tmpl domain.Template, given map[string]string) (map[string]string, error) {
+	known := make(map[string]struct{}, len(tmpl.Params))
+	resolved := make(map[string]string, len(tmpl.Params))
+	for _, p := range tmpl.Params {
+		known[p.Name] = struct{}{}
+		v, ok := given[p.Name]
+		if !ok || v == "" {
+			if p.Required {
+				return nil, fmt.Errorf("%w: missing %q", domain.ErrInvalidTemplateParams, p.Name)
+			}
+			v = p.Default
+		}
+		if p.Pattern != nil && v != "" && !p.Pattern.MatchString(v) {
+			return nil, fmt.Errorf("%w: %q must match %s", domain.ErrInvalidTemplateParams, p.Name, p.Pattern)
+		}
+		resolved[p.Name] = v
+	}
+	for name := range given {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown %q", domain.ErrInvalidTemplateParams, name)
+		}
+	}
+	return resolved, nil
+}
+
+var templateFuncs = template.FuncMap{
+	// json renders a value as a JSON literal so parameters can be embedded in JSON bodies safely.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func renderTemplate(text string, params map[string]string) (string, error) {
+	t, err := template.New("").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, params); err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidTemplateParams, err)
+	}
+	return sb.String(), nil
+}
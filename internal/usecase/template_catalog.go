@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"regexp"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+)
+
+// templateCatalog is the built-in integration library served at GET /templates/catalog.
+var templateCatalog = []domain.Template{
+	{
+		ID:          "slack-webhook-message",
+		Name:        "Slack webhook message",
+		Description: "Post a message to a Slack channel via an incoming webhook.",
+		Kind:        domain.TemplateKindJob,
+		Params: []domain.TemplateParam{
+			{Name: "webhook_url", Description: "Slack incoming webhook URL", Required: true},
+			{Name: "text", Description: "Message text (Slack mrkdwn)", Required: true},
+		},
+		URL:     "{{.webhook_url}}",
+		Method:  "POST",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"text": {{json .text}}}`,
+	},
+	{
+		ID:          "stripe-invoice-finalize",
+		Name:        "Stripe invoice finalize",
+		Description: "Finalize a draft Stripe invoice at a given time.",
+		Kind:        domain.TemplateKindJob,
+		Params: []domain.TemplateParam{
+			{Name: "invoice_id", Description: "Stripe invoice ID (in_...)", Required: true, Pattern: regexp.MustCompile(`^in_[A-Za-z0-9]+$`)},
+			{Name: "api_key", Description: "Stripe secret key", Required: true},
+		},
+		URL:    "https://api.stripe.com/v1/invoices/{{.invoice_id}}/finalize",
+		Method: "POST",
+		Headers: map[string]string{
+			"Authorization": "Bearer {{.api_key}}",
+			// Stripe rejects retried finalizations unless they carry the same key.
+			"Idempotency-Key": "finalize-{{.invoice_id}}",
+		},
+		MaxRetries: 5,
+	},
+	{
+		ID:          "keep-warm-ping",
+		Name:        "Keep-warm ping",
+		Description: "Periodically GET an endpoint to keep a serverless function or cache warm.",
+		Kind:        domain.TemplateKindSchedule,
+		Params: []domain.TemplateParam{
+			{Name: "url", Description: "Endpoint to ping", Required: true},
+		},
+		URL:            "{{.url}}",
+		Method:         "GET",
+		CronExpr:       "*/5 * * * *",
+		TimeoutSeconds: 10,
+		MaxRetries:     1,
+		Backoff:        domain.BackoffLinear,
+	},
+}
@@ -0,0 +1,28 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/domain"
+	"github.com/ErlanBelekov/dist-job-scheduler/internal/usecase"
+)
+
+func TestInstantiate_RejectsParamNotMatchingPattern(t *testing.T) {
+	u := usecase.NewTemplateUsecase(nil, nil)
+
+	for _, id := range []string{"in_1/../../customers", "in_1?expand=x", "cus_123", "in_"} {
+		_, err := u.Instantiate(context.Background(), usecase.InstantiateTemplateInput{
+			UserID:         "user-1",
+			TemplateID:     "stripe-invoice-finalize",
+			Params:         map[string]string{"invoice_id": id, "api_key": "sk_test_123"},
+			IdempotencyKey: "key-1",
+			ScheduledAt:    time.Now(),
+		})
+		if !errors.Is(err, domain.ErrInvalidTemplateParams) {
+			t.Errorf("invoice_id %q: err = %v, want ErrInvalidTemplateParams", id, err)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Request ID of the API call that created the job. NULL for schedule-fired jobs.
+ALTER TABLE jobs ADD COLUMN request_id TEXT;
+
+-- Covers GET /jobs?request_id= support lookups.
+CREATE INDEX idx_jobs_user_request_id ON jobs (user_id, request_id)
+    WHERE request_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_jobs_user_request_id;
+ALTER TABLE jobs DROP COLUMN request_id;
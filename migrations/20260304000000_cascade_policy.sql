@@ -0,0 +1,38 @@
+-- +goose Up
+-- Explicit cascade policy. Rules for this and all future tables:
+--   * Rows owned by a user (jobs, schedules, ...)  → ON DELETE CASCADE from users
+--   * Per-job history (attempts, callbacks, ...)    → ON DELETE CASCADE from jobs
+--   * Provenance links to schedules (jobs.schedule_id, fires, ...) → ON DELETE SET NULL,
+--     so deleting a schedule never erases execution history.
+-- Retention/archival deletes jobs and relies on the job cascade to take history with them.
+
+ALTER TABLE job_attempts
+    DROP CONSTRAINT job_attempts_job_id_fkey,
+    ADD CONSTRAINT job_attempts_job_id_fkey
+        FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE;
+
+ALTER TABLE jobs
+    DROP CONSTRAINT jobs_user_id_fkey,
+    ADD CONSTRAINT jobs_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE schedules
+    DROP CONSTRAINT schedules_user_id_fkey,
+    ADD CONSTRAINT schedules_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- +goose Down
+ALTER TABLE schedules
+    DROP CONSTRAINT schedules_user_id_fkey,
+    ADD CONSTRAINT schedules_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE jobs
+    DROP CONSTRAINT jobs_user_id_fkey,
+    ADD CONSTRAINT jobs_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE job_attempts
+    DROP CONSTRAINT job_attempts_job_id_fkey,
+    ADD CONSTRAINT job_attempts_job_id_fkey
+        FOREIGN KEY (job_id) REFERENCES jobs(id);
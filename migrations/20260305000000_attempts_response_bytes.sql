@@ -0,0 +1,7 @@
+-- +goose Up
+-- Response body size seen by the executor. Beyond the drain limit this is a lower bound
+-- (or Content-Length when the target sent one).
+ALTER TABLE job_attempts ADD COLUMN response_bytes BIGINT;
+
+-- +goose Down
+ALTER TABLE job_attempts DROP COLUMN response_bytes;
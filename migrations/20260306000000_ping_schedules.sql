@@ -0,0 +1,36 @@
+-- +goose Up
+ALTER TABLE schedules ADD COLUMN mode TEXT NOT NULL DEFAULT 'standard';
+
+-- Ping jobs skip attempt records; the worker rolls them up below and deletes the job row.
+ALTER TABLE jobs ADD COLUMN ping BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Hourly check counters per ping schedule. Per-schedule aggregates cascade with the schedule.
+CREATE TABLE schedule_ping_rollups (
+    schedule_id       TEXT        NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    bucket_start      TIMESTAMPTZ NOT NULL,
+    checks            INT         NOT NULL DEFAULT 0,
+    failures          INT         NOT NULL DEFAULT 0,
+    total_duration_ms BIGINT      NOT NULL DEFAULT 0,
+    PRIMARY KEY (schedule_id, bucket_start)
+);
+
+-- Contiguous runs of failed checks. ended_at IS NULL while the target is still down.
+CREATE TABLE schedule_ping_incidents (
+    id          TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    schedule_id TEXT        NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    started_at  TIMESTAMPTZ NOT NULL,
+    ended_at    TIMESTAMPTZ,
+    last_error  TEXT
+);
+
+-- At most one open incident per schedule.
+CREATE UNIQUE INDEX idx_ping_incidents_open ON schedule_ping_incidents (schedule_id)
+    WHERE ended_at IS NULL;
+
+CREATE INDEX idx_ping_incidents_schedule ON schedule_ping_incidents (schedule_id, started_at DESC);
+
+-- +goose Down
+DROP TABLE schedule_ping_incidents;
+DROP TABLE schedule_ping_rollups;
+ALTER TABLE jobs DROP COLUMN ping;
+ALTER TABLE schedules DROP COLUMN mode;
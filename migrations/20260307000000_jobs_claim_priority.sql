@@ -0,0 +1,23 @@
+-- +goose Up
+-- Higher priority bands are always claimed first, whatever the claim policy.
+ALTER TABLE jobs ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0
+    CHECK (priority BETWEEN 0 AND 9);
+
+-- first_due_at keeps the original due time once a retry moves scheduled_at forward,
+-- so the "overdue" claim policy can rank retried jobs by how long they've been owed.
+-- NULL means the job has never been rescheduled and scheduled_at is still the original.
+ALTER TABLE jobs ADD COLUMN first_due_at TIMESTAMPTZ;
+
+DROP INDEX idx_jobs_due;
+CREATE INDEX idx_jobs_due ON jobs (priority DESC, scheduled_at)
+  WHERE status = 'pending';
+CREATE INDEX idx_jobs_overdue ON jobs (priority DESC, COALESCE(first_due_at, scheduled_at))
+  WHERE status = 'pending';
+
+-- +goose Down
+DROP INDEX idx_jobs_overdue;
+DROP INDEX idx_jobs_due;
+CREATE INDEX idx_jobs_due ON jobs (scheduled_at)
+  WHERE status = 'pending';
+ALTER TABLE jobs DROP COLUMN first_due_at;
+ALTER TABLE jobs DROP COLUMN priority;
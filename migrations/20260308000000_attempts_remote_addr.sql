@@ -0,0 +1,7 @@
+-- +goose Up
+-- Address of the connection that served the final response (after redirects).
+-- Lets the attempt diff show when a target's DNS or load balancer moved traffic between attempts.
+ALTER TABLE job_attempts ADD COLUMN remote_addr TEXT;
+
+-- +goose Down
+ALTER TABLE job_attempts DROP COLUMN remote_addr;
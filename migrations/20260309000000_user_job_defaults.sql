@@ -0,0 +1,13 @@
+-- +goose Up
+-- Per-user defaults for job/schedule creation. NULL columns fall back to the system defaults.
+CREATE TABLE user_job_defaults (
+    user_id         TEXT        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timeout_seconds INT,
+    max_retries     INT,
+    backoff         TEXT,
+    headers         JSONB,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE user_job_defaults;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Hourly per-user, per-endpoint API request counters. Servers buffer counts in memory and
+-- flush them here periodically, so the table lags live traffic by up to one flush interval.
+CREATE TABLE api_usage_hourly (
+    user_id           TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    bucket_start      TIMESTAMPTZ NOT NULL,
+    method            TEXT        NOT NULL,
+    route             TEXT        NOT NULL,
+    requests          BIGINT      NOT NULL DEFAULT 0,
+    client_errors     BIGINT      NOT NULL DEFAULT 0,
+    server_errors     BIGINT      NOT NULL DEFAULT 0,
+    total_duration_ms BIGINT      NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, bucket_start, method, route)
+);
+
+-- Retention pruning scans by age across all users.
+CREATE INDEX idx_api_usage_bucket ON api_usage_hourly (bucket_start);
+
+-- +goose Down
+DROP TABLE api_usage_hourly;
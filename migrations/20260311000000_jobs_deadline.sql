@@ -0,0 +1,7 @@
+-- +goose Up
+-- Absolute time by which a job must reach a terminal state. Past it, workers and the
+-- reaper stop retrying and fail the job. NULL = no deadline.
+ALTER TABLE jobs ADD COLUMN deadline TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN deadline;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Explicit per-job retry delays in milliseconds, indexed by retry number. Retries past
+-- the end of the list fall back to the job's backoff policy. NULL = backoff only.
+ALTER TABLE jobs ADD COLUMN retry_delays_ms BIGINT[];
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN retry_delays_ms;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Operator-authored notices (planned maintenance, degraded execution) shown to all users.
+-- ends_at NULL = open-ended. Not user-owned, so no user FK: deleting the admin's account
+-- must not take the notice with it.
+CREATE TABLE system_notices (
+    id         TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    title      TEXT        NOT NULL,
+    body       TEXT        NOT NULL DEFAULT '',
+    severity   TEXT        NOT NULL CHECK (severity IN ('info', 'warning', 'critical')),
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ,
+    created_by TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_system_notices_ends_at ON system_notices (ends_at);
+
+-- +goose Down
+DROP TABLE system_notices;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- Optional URL notified once a job reaches a terminal state.
+ALTER TABLE jobs ADD COLUMN callback_url TEXT;
+
+-- One row per (job, event): the outbox the callback dispatcher drains. The unique key
+-- makes enqueueing idempotent, and the row ID is sent as X-Callback-ID on every
+-- delivery attempt so receivers can deduplicate retries and manual redeliveries.
+CREATE TABLE job_callbacks (
+    id              TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    job_id          TEXT        NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    event           TEXT        NOT NULL CHECK (event IN ('job.completed', 'job.failed')),
+    url             TEXT        NOT NULL,
+    status          TEXT        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error      TEXT,
+    delivered_at    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (job_id, event)
+);
+
+CREATE INDEX idx_job_callbacks_due ON job_callbacks (next_attempt_at) WHERE status = 'pending';
+
+-- Delivery log: one row per HTTP attempt, automatic or manual.
+CREATE TABLE job_callback_attempts (
+    id               TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    callback_id      TEXT        NOT NULL REFERENCES job_callbacks(id) ON DELETE CASCADE,
+    attempt_num      INT         NOT NULL,
+    status_code      INT,
+    error            TEXT,
+    response_snippet TEXT,
+    duration_ms      BIGINT      NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (callback_id, attempt_num)
+);
+
+-- +goose Down
+DROP TABLE job_callback_attempts;
+DROP TABLE job_callbacks;
+ALTER TABLE jobs DROP COLUMN callback_url;
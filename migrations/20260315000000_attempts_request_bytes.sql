@@ -0,0 +1,7 @@
+-- +goose Up
+-- Request body bytes sent per attempt. Together with response_bytes this gives per-user
+-- and per-schedule egress accounting (GET /account/egress). NULL when no connection was made.
+ALTER TABLE job_attempts ADD COLUMN request_bytes BIGINT;
+
+-- +goose Down
+ALTER TABLE job_attempts DROP COLUMN request_bytes;
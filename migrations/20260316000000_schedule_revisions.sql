@@ -0,0 +1,19 @@
+-- +goose Up
+-- Every schedule mutation (create, pause/resume, update, revert) as a numbered revision.
+-- before/after hold the user-editable configuration (domain.ScheduleSpec); before is NULL
+-- for the create revision. Revisions are per-schedule history and cascade with it.
+-- actor_id is not an FK so a revision keeps its author after that user is gone.
+CREATE TABLE schedule_revisions (
+    id          TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    schedule_id TEXT        NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    revision    INT         NOT NULL,
+    action      TEXT        NOT NULL CHECK (action IN ('create', 'update', 'pause', 'resume', 'revert')),
+    actor_id    TEXT        NOT NULL,
+    before      JSONB,
+    after       JSONB       NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (schedule_id, revision)
+);
+
+-- +goose Down
+DROP TABLE schedule_revisions;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- HTTP statuses that count as success: exact codes ('204') or classes ('2xx').
+-- NULL keeps the original rule of 200 only. Schedules copy theirs onto every fired job.
+ALTER TABLE jobs      ADD COLUMN success_codes TEXT[];
+ALTER TABLE schedules ADD COLUMN success_codes TEXT[];
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN success_codes;
+ALTER TABLE jobs      DROP COLUMN success_codes;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- IANA zone the cron expression is evaluated in, so "0 9 * * *" means 09:00 local time
+-- for the schedule's owner across DST changes. Existing schedules keep UTC.
+ALTER TABLE schedules ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN timezone;
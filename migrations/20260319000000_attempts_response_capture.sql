@@ -0,0 +1,9 @@
+-- +goose Up
+-- The start of the target's response, for debugging failed webhooks without replaying
+-- them. Both are NULL when capture was disabled or no response arrived.
+ALTER TABLE job_attempts ADD COLUMN response_headers JSONB;
+ALTER TABLE job_attempts ADD COLUMN response_body    TEXT;
+
+-- +goose Down
+ALTER TABLE job_attempts DROP COLUMN response_body;
+ALTER TABLE job_attempts DROP COLUMN response_headers;
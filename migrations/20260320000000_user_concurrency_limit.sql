@@ -0,0 +1,7 @@
+-- +goose Up
+-- Per-user override of USER_MAX_CONCURRENT_JOBS, set by operators. NULL uses the
+-- deployment default; 0 exempts the user from the per-user limit.
+ALTER TABLE users ADD COLUMN max_concurrent_jobs INT CHECK (max_concurrent_jobs >= 0);
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN max_concurrent_jobs;
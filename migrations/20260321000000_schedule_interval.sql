@@ -0,0 +1,10 @@
+-- +goose Up
+-- Fixed-interval schedules ("every 90s") as an alternative to cron. Exactly one cadence is
+-- set: interval schedules store an empty cron_expr.
+ALTER TABLE schedules ADD COLUMN every_ms BIGINT CHECK (every_ms > 0);
+ALTER TABLE schedules ADD CONSTRAINT schedules_one_cadence
+    CHECK ((cron_expr = '') <> (every_ms IS NULL));
+
+-- +goose Down
+ALTER TABLE schedules DROP CONSTRAINT schedules_one_cadence;
+ALTER TABLE schedules DROP COLUMN every_ms;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Per-user notification rules. A rule says which event to watch (a job failing
+-- permanently, or a schedule failing `threshold` runs in a row), which channel to alert on
+-- and where (an email address or a Slack webhook URL). schedule_id narrows the rule to one
+-- schedule; NULL means every job or schedule the user owns.
+CREATE TABLE notification_rules (
+    id          TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    user_id     TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    schedule_id TEXT        REFERENCES schedules(id) ON DELETE CASCADE,
+    event       TEXT        NOT NULL CHECK (event IN ('job.failed', 'schedule.failing')),
+    channel     TEXT        NOT NULL CHECK (channel IN ('email', 'slack')),
+    target      TEXT        NOT NULL,
+    threshold   INT         NOT NULL DEFAULT 1 CHECK (threshold >= 1),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_rules_user_id ON notification_rules (user_id);
+
+-- +goose Down
+DROP TABLE notification_rules;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Publishes every job status transition on the job_status channel so API servers can
+-- stream them to clients (GET /jobs/stream). NOTIFY is delivered on commit, so listeners
+-- never see a transition that was rolled back. The payload is small on purpose — the
+-- 8000-byte NOTIFY limit rules out sending the whole row.
+-- +goose StatementBegin
+CREATE FUNCTION notify_job_status() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('job_status', json_build_object(
+        'id',              NEW.id,
+        'user_id',         NEW.user_id,
+        'schedule_id',     NEW.schedule_id,
+        'status',          NEW.status,
+        'previous_status', CASE WHEN TG_OP = 'UPDATE' THEN OLD.status END,
+        'at',              NEW.updated_at
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER jobs_notify_insert
+    AFTER INSERT ON jobs
+    FOR EACH ROW EXECUTE FUNCTION notify_job_status();
+
+CREATE TRIGGER jobs_notify_status
+    AFTER UPDATE OF status ON jobs
+    FOR EACH ROW WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION notify_job_status();
+
+-- +goose Down
+DROP TRIGGER jobs_notify_status ON jobs;
+DROP TRIGGER jobs_notify_insert ON jobs;
+DROP FUNCTION notify_job_status();
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Wakes idle workers as soon as a job becomes claimable instead of on their next poll:
+-- a new due job, a resumed job, or a stale job the reaper put back. Retries scheduled in
+-- the future don't fire — polling picks them up when due. The payload is empty so
+-- Postgres folds the notifications of one transaction (e.g. a batch insert) into one.
+-- +goose StatementBegin
+CREATE FUNCTION notify_jobs_ready() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('jobs_ready', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER jobs_notify_ready
+    AFTER INSERT OR UPDATE OF status, scheduled_at ON jobs
+    FOR EACH ROW WHEN (NEW.status = 'pending' AND NEW.scheduled_at <= NOW())
+    EXECUTE FUNCTION notify_jobs_ready();
+
+-- +goose Down
+DROP TRIGGER jobs_notify_ready ON jobs;
+DROP FUNCTION notify_jobs_ready();
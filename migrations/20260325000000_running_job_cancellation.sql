@@ -0,0 +1,11 @@
+-- +goose Up
+-- Cancelling a running job only records the request; the worker running it sees the flag
+-- on its next heartbeat, aborts the HTTP call and moves the job to cancelled itself.
+ALTER TABLE jobs ADD COLUMN cancel_requested_at TIMESTAMPTZ;
+
+-- Marks the attempt that was aborted by a cancellation rather than failed on its own.
+ALTER TABLE job_attempts ADD COLUMN cancelled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE job_attempts DROP COLUMN cancelled;
+ALTER TABLE jobs DROP COLUMN cancel_requested_at;
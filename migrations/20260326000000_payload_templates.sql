@@ -0,0 +1,11 @@
+-- +goose Up
+-- Opt-in payload templating: placeholders such as {{.ScheduledAt}} in a templated job's
+-- URL, headers and body are expanded by the worker before each attempt. Opt-in so that
+-- existing payloads containing literal "{{" keep being sent verbatim. Schedules pass the
+-- flag on to the jobs they fire.
+ALTER TABLE jobs      ADD COLUMN templated BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE schedules ADD COLUMN templated BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN templated;
+ALTER TABLE jobs      DROP COLUMN templated;
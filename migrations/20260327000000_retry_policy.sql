@@ -0,0 +1,24 @@
+-- +goose Up
+-- Tunable backoff: the base delay and cap that backoff grows from and stops at, and how
+-- the computed delay is randomized. Defaults match the previously hardcoded 30s base and
+-- 1h cap, with 'auto' keeping the old ±25% spread on exponential backoff.
+ALTER TABLE jobs
+    ADD COLUMN retry_base_seconds INT  NOT NULL DEFAULT 30,
+    ADD COLUMN retry_max_seconds  INT  NOT NULL DEFAULT 3600,
+    ADD COLUMN retry_jitter       TEXT NOT NULL DEFAULT 'auto';
+
+ALTER TABLE schedules
+    ADD COLUMN retry_base_seconds INT  NOT NULL DEFAULT 30,
+    ADD COLUMN retry_max_seconds  INT  NOT NULL DEFAULT 3600,
+    ADD COLUMN retry_jitter       TEXT NOT NULL DEFAULT 'auto';
+
+-- +goose Down
+ALTER TABLE schedules
+    DROP COLUMN retry_jitter,
+    DROP COLUMN retry_max_seconds,
+    DROP COLUMN retry_base_seconds;
+
+ALTER TABLE jobs
+    DROP COLUMN retry_jitter,
+    DROP COLUMN retry_max_seconds,
+    DROP COLUMN retry_base_seconds;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Debug jobs record the request as sent on every attempt (secret headers redacted, body
+-- truncated), so support can see exactly what reached the target. The request columns
+-- are NULL for attempts of non-debug jobs.
+ALTER TABLE jobs ADD COLUMN debug BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE job_attempts ADD COLUMN request_method  TEXT;
+ALTER TABLE job_attempts ADD COLUMN request_url     TEXT;
+ALTER TABLE job_attempts ADD COLUMN request_headers JSONB;
+ALTER TABLE job_attempts ADD COLUMN request_body    TEXT;
+
+-- +goose Down
+ALTER TABLE job_attempts DROP COLUMN request_body;
+ALTER TABLE job_attempts DROP COLUMN request_headers;
+ALTER TABLE job_attempts DROP COLUMN request_url;
+ALTER TABLE job_attempts DROP COLUMN request_method;
+
+ALTER TABLE jobs DROP COLUMN debug;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- A pending job still unclaimed at expires_at is moved to 'expired' by the reaper instead
+-- of running late. Workers never claim a job past its expiry, so the sweep interval only
+-- affects when the status flips, not whether the job runs.
+ALTER TABLE jobs ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_jobs_pending_expiry ON jobs (expires_at)
+    WHERE status = 'pending' AND expires_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_jobs_pending_expiry;
+ALTER TABLE jobs DROP COLUMN expires_at;
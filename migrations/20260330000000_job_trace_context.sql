@@ -0,0 +1,7 @@
+-- +goose Up
+-- The W3C traceparent of the API call that created the job, so its execution is recorded
+-- in the same trace. NULL for jobs fired by the dispatcher or created outside a trace.
+ALTER TABLE jobs ADD COLUMN trace_parent TEXT;
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN trace_parent;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- When the mover last pushed the job onto the Redis claim queue (CLAIM_MODE=redis). A
+-- pending job whose push is older than the redelivery window is pushed again, so IDs
+-- lost from Redis are recovered. Unused in the default Postgres claim mode.
+ALTER TABLE jobs ADD COLUMN enqueued_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN enqueued_at;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- History retention. The janitor deletes finished attempts and terminal jobs older than
+-- their windows; deleting a job cascades to its attempts and callbacks. With
+-- RETENTION_ARCHIVE=true each row is first copied here as JSONB, which keeps the archive
+-- readable across later column changes to jobs and job_attempts.
+CREATE TABLE jobs_archive (
+    id          TEXT        PRIMARY KEY,
+    user_id     TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    job         JSONB       NOT NULL
+);
+
+CREATE TABLE job_attempts_archive (
+    id          TEXT        PRIMARY KEY,
+    job_id      TEXT        NOT NULL,
+    user_id     TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempt     JSONB       NOT NULL
+);
+
+CREATE INDEX idx_jobs_archive_user ON jobs_archive (user_id);
+CREATE INDEX idx_job_attempts_archive_job ON job_attempts_archive (job_id);
+
+CREATE INDEX idx_jobs_terminal_updated ON jobs (updated_at)
+    WHERE status IN ('completed', 'failed', 'cancelled', 'expired');
+CREATE INDEX idx_attempts_completed_at ON job_attempts (completed_at)
+    WHERE completed_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_attempts_completed_at;
+DROP INDEX idx_jobs_terminal_updated;
+DROP TABLE job_attempts_archive;
+DROP TABLE jobs_archive;
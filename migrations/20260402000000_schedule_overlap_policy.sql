@@ -0,0 +1,8 @@
+-- +goose Up
+-- What a fire does while the schedule's previous job is still pending or running:
+-- 'queue' fires anyway (the old behaviour), 'skip' drops the fire, 'replace' cancels the
+-- previous job and fires.
+ALTER TABLE schedules ADD COLUMN overlap_policy TEXT NOT NULL DEFAULT 'queue';
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN overlap_policy;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Upper bound of a random delay added to each fired job's scheduled_at, so schedules that
+-- share a cron expression don't all hit their targets in the same second. next_run_at
+-- stays on the nominal cadence. 0 = fire exactly on time.
+ALTER TABLE schedules ADD COLUMN jitter_seconds INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN jitter_seconds;
@@ -0,0 +1,39 @@
+-- +goose Up
+-- Job chaining: a job with depends_on waits in 'blocked' until its parent finishes. The
+-- trigger below releases children in the same transaction as the parent's terminal
+-- transition, so no path that finishes a job (worker, reaper, cancel, expiry) can strand
+-- them. A cancelled ("skipped") child fires the trigger in turn, cascading down the chain.
+ALTER TABLE jobs
+    ADD COLUMN depends_on        TEXT REFERENCES jobs(id) ON DELETE SET NULL,
+    ADD COLUMN on_parent_failure TEXT NOT NULL DEFAULT 'skip';
+
+CREATE INDEX idx_jobs_blocked_parent ON jobs (depends_on) WHERE status = 'blocked';
+
+-- +goose StatementBegin
+CREATE FUNCTION release_job_dependents() RETURNS trigger AS $$
+BEGIN
+    UPDATE jobs
+    SET    status     = CASE WHEN NEW.status = 'completed' OR on_parent_failure = 'run'
+                             THEN 'pending' ELSE 'cancelled' END,
+           last_error = CASE WHEN NEW.status = 'completed' OR on_parent_failure = 'run'
+                             THEN last_error ELSE 'skipped: parent job ' || NEW.id || ' ' || NEW.status END,
+           updated_at = NOW()
+    WHERE  depends_on = NEW.id AND status = 'blocked';
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER jobs_release_dependents
+    AFTER UPDATE OF status ON jobs
+    FOR EACH ROW WHEN (OLD.status IS DISTINCT FROM NEW.status
+                       AND NEW.status IN ('completed', 'failed', 'cancelled', 'expired'))
+    EXECUTE FUNCTION release_job_dependents();
+
+-- +goose Down
+DROP TRIGGER jobs_release_dependents ON jobs;
+DROP FUNCTION release_job_dependents();
+DROP INDEX idx_jobs_blocked_parent;
+ALTER TABLE jobs
+    DROP COLUMN on_parent_failure,
+    DROP COLUMN depends_on;
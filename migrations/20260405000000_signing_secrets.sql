@@ -0,0 +1,9 @@
+-- +goose Up
+-- Per-job and per-schedule HMAC secrets. When set, the executor signs each request with
+-- an X-Signature header; schedules pass their secret on to the jobs they fire.
+ALTER TABLE jobs ADD COLUMN signing_secret TEXT;
+ALTER TABLE schedules ADD COLUMN signing_secret TEXT;
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN signing_secret;
+ALTER TABLE jobs DROP COLUMN signing_secret;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Jobs are claimed only by workers serving their queue (WORKER_QUEUES), e.g. to keep
+-- EU-pinned jobs on EU workers or CPU-heavy targets on a dedicated pool. Schedules pass
+-- their queue on to the jobs they fire.
+ALTER TABLE jobs ADD COLUMN queue TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE schedules ADD COLUMN queue TEXT NOT NULL DEFAULT 'default';
+
+-- Lets a worker serving a small queue find its due jobs without walking the backlog of
+-- the others; idx_jobs_due still serves claims across several queues.
+CREATE INDEX idx_jobs_due_queue ON jobs (queue, priority DESC, scheduled_at)
+  WHERE status = 'pending';
+
+-- +goose Down
+DROP INDEX idx_jobs_due_queue;
+ALTER TABLE schedules DROP COLUMN queue;
+ALTER TABLE jobs DROP COLUMN queue;
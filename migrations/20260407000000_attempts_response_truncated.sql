@@ -0,0 +1,7 @@
+-- +goose Up
+-- Set when the target's response body exceeded the worker's MAX_RESPONSE_BYTES and the
+-- executor stopped reading it; response_bytes is then a lower bound.
+ALTER TABLE job_attempts ADD COLUMN response_truncated BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE job_attempts DROP COLUMN response_truncated;
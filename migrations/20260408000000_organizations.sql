@@ -0,0 +1,41 @@
+-- +goose Up
+-- Team workspaces. An organization's id is also a row in users — the account that owns
+-- the organization's jobs, schedules and settings — so every table scoped by user_id is
+-- scoped by organization without a second owner column. Members act in the
+-- organization by sending X-Org-ID; the API swaps their user ID for the org's.
+CREATE TABLE organizations (
+    id         TEXT        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE org_members (
+    org_id     TEXT        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id    TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role       TEXT        NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX idx_org_members_user_id ON org_members (user_id);
+
+-- Invitations are bearer tokens: whoever accepts one first joins with its role. Only
+-- the SHA-256 of the token is stored.
+CREATE TABLE org_invitations (
+    id          TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    org_id      TEXT        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email       TEXT,
+    role        TEXT        NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    token_hash  TEXT        UNIQUE NOT NULL,
+    invited_by  TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_org_invitations_org_id ON org_invitations (org_id);
+
+-- +goose Down
+DROP TABLE org_invitations;
+DROP TABLE org_members;
+DROP TABLE organizations;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- GET /jobs?url_contains= matches anywhere in the URL, which a btree can't serve.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_jobs_url_trgm ON jobs USING gin (url gin_trgm_ops);
+
+-- GET /jobs?schedule_id= and GET /schedules/:id/jobs page a schedule's jobs newest first;
+-- the single-column index made them sort every job the schedule ever fired.
+DROP INDEX idx_jobs_schedule_id;
+CREATE INDEX idx_jobs_schedule_scheduled ON jobs (schedule_id, scheduled_at DESC, id DESC)
+    WHERE schedule_id IS NOT NULL;
+
+-- idempotency_key is covered by the (user_id, idempotency_key) unique constraint and
+-- scheduled_after/before by idx_jobs_user_scheduled.
+
+-- +goose Down
+DROP INDEX idx_jobs_schedule_scheduled;
+CREATE INDEX idx_jobs_schedule_id ON jobs (schedule_id)
+    WHERE schedule_id IS NOT NULL;
+DROP INDEX idx_jobs_url_trgm;
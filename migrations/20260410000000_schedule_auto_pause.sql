@@ -0,0 +1,62 @@
+-- +goose Up
+-- consecutive_failures counts the schedule's permanently failed runs since its last
+-- completed one (or since it was last resumed). With pause_after_failures > 0 the trigger
+-- below pauses the schedule once the count reaches it, so a broken target stops being
+-- called. Both are kept in the same transaction as the job's terminal transition, so no
+-- path that finishes a job (worker, reaper) can miss one.
+ALTER TABLE schedules
+    ADD COLUMN pause_after_failures INT NOT NULL DEFAULT 0 CHECK (pause_after_failures BETWEEN 0 AND 100),
+    ADD COLUMN consecutive_failures INT NOT NULL DEFAULT 0;
+
+-- +goose StatementBegin
+CREATE FUNCTION track_schedule_failures() RETURNS trigger AS $$
+DECLARE
+    auto_paused BOOLEAN;
+BEGIN
+    IF NEW.status = 'completed' THEN
+        UPDATE schedules SET consecutive_failures = 0
+        WHERE  id = NEW.schedule_id AND consecutive_failures > 0;
+        RETURN NULL;
+    END IF;
+
+    UPDATE schedules
+    SET    consecutive_failures = consecutive_failures + 1,
+           paused     = TRUE,
+           updated_at = NOW()
+    WHERE  id = NEW.schedule_id
+      AND  NOT paused
+      AND  pause_after_failures > 0
+      AND  consecutive_failures + 1 >= pause_after_failures
+    RETURNING TRUE INTO auto_paused;
+
+    IF auto_paused THEN
+        -- Revisions hold sealed specs the database can't build, so the pause revision
+        -- copies the latest one; every mutation records a revision, so it is current.
+        INSERT INTO schedule_revisions (schedule_id, revision, action, actor_id, before, after)
+        SELECT schedule_id, revision + 1, 'pause', 'system', after, jsonb_set(after, '{paused}', 'true')
+        FROM   schedule_revisions
+        WHERE  schedule_id = NEW.schedule_id
+        ORDER BY revision DESC
+        LIMIT 1;
+    ELSE
+        UPDATE schedules SET consecutive_failures = consecutive_failures + 1
+        WHERE  id = NEW.schedule_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER jobs_track_schedule_failures
+    AFTER UPDATE OF status ON jobs
+    FOR EACH ROW WHEN (OLD.status IS DISTINCT FROM NEW.status
+                       AND NEW.status IN ('completed', 'failed')
+                       AND NEW.schedule_id IS NOT NULL)
+    EXECUTE FUNCTION track_schedule_failures();
+
+-- +goose Down
+DROP TRIGGER jobs_track_schedule_failures ON jobs;
+DROP FUNCTION track_schedule_failures();
+ALTER TABLE schedules
+    DROP COLUMN consecutive_failures,
+    DROP COLUMN pause_after_failures;
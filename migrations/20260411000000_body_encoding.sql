@@ -0,0 +1,14 @@
+-- +goose Up
+-- Bodies are stored as text; body_encoding = 'base64' marks a binary payload the executor
+-- decodes before sending. content_type, when set, is sent as the Content-Type header and
+-- wins over one in headers. Schedules pass both on to the jobs they fire.
+ALTER TABLE jobs
+    ADD COLUMN body_encoding TEXT NOT NULL DEFAULT 'utf8' CHECK (body_encoding IN ('utf8', 'base64')),
+    ADD COLUMN content_type  TEXT;
+ALTER TABLE schedules
+    ADD COLUMN body_encoding TEXT NOT NULL DEFAULT 'utf8' CHECK (body_encoding IN ('utf8', 'base64')),
+    ADD COLUMN content_type  TEXT;
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN content_type, DROP COLUMN body_encoding;
+ALTER TABLE jobs      DROP COLUMN content_type, DROP COLUMN body_encoding;
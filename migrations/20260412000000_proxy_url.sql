@@ -0,0 +1,10 @@
+-- +goose Up
+-- proxy_url routes a job's requests through an outbound HTTP(S) or SOCKS5 proxy. It may
+-- carry credentials, so the repositories seal it like signing_secret. Schedules pass it on
+-- to the jobs they fire.
+ALTER TABLE jobs      ADD COLUMN proxy_url TEXT;
+ALTER TABLE schedules ADD COLUMN proxy_url TEXT;
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN proxy_url;
+ALTER TABLE jobs      DROP COLUMN proxy_url;
@@ -0,0 +1,36 @@
+-- +goose Up
+-- Client certificates for mutual TLS. key_pem is sealed by the repository like signing
+-- secrets; the other columns describe the leaf so listings needn't parse the PEM.
+-- UNIQUE (id, user_id) backs the composite foreign keys below, which keep a job or
+-- schedule from referencing another user's certificate.
+CREATE TABLE client_certificates (
+    id          TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    user_id     TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name        TEXT        NOT NULL,
+    cert_pem    TEXT        NOT NULL,
+    key_pem     TEXT        NOT NULL,
+    subject     TEXT        NOT NULL,
+    fingerprint TEXT        NOT NULL,
+    not_after   TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name),
+    UNIQUE (id, user_id)
+);
+
+-- Deleting a certificate is refused while unfinished jobs or schedules use it; finished
+-- jobs just lose the reference.
+ALTER TABLE jobs
+    ADD COLUMN client_cert_id TEXT,
+    ADD FOREIGN KEY (client_cert_id, user_id) REFERENCES client_certificates (id, user_id)
+        ON DELETE SET NULL (client_cert_id);
+ALTER TABLE schedules
+    ADD COLUMN client_cert_id TEXT,
+    ADD FOREIGN KEY (client_cert_id, user_id) REFERENCES client_certificates (id, user_id);
+
+CREATE INDEX idx_jobs_client_cert_id ON jobs (client_cert_id) WHERE client_cert_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_jobs_client_cert_id;
+ALTER TABLE schedules DROP COLUMN client_cert_id;
+ALTER TABLE jobs      DROP COLUMN client_cert_id;
+DROP TABLE client_certificates;
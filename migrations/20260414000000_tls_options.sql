@@ -0,0 +1,16 @@
+-- +goose Up
+-- Per-job TLS verification for targets behind private CAs: ca_bundle (PEM) replaces the
+-- system roots, tls_skip_verify turns verification off. They are mutually exclusive.
+-- Schedules pass both on to the jobs they fire.
+ALTER TABLE jobs
+    ADD COLUMN ca_bundle       TEXT,
+    ADD COLUMN tls_skip_verify BOOLEAN NOT NULL DEFAULT false,
+    ADD CONSTRAINT jobs_tls_options_check CHECK (NOT (tls_skip_verify AND ca_bundle IS NOT NULL));
+ALTER TABLE schedules
+    ADD COLUMN ca_bundle       TEXT,
+    ADD COLUMN tls_skip_verify BOOLEAN NOT NULL DEFAULT false,
+    ADD CONSTRAINT schedules_tls_options_check CHECK (NOT (tls_skip_verify AND ca_bundle IS NOT NULL));
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN tls_skip_verify, DROP COLUMN ca_bundle;
+ALTER TABLE jobs      DROP COLUMN tls_skip_verify, DROP COLUMN ca_bundle;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- job_type picks the executor a worker runs a job with. Existing jobs and schedules are
+-- all HTTP requests. Schedules pass the type on to the jobs they fire.
+ALTER TABLE jobs      ADD COLUMN job_type TEXT NOT NULL DEFAULT 'http';
+ALTER TABLE schedules ADD COLUMN job_type TEXT NOT NULL DEFAULT 'http';
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN job_type;
+ALTER TABLE jobs      DROP COLUMN job_type;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- kafka jobs publish their body to topic, keyed by message_key, instead of calling a URL;
+-- their url and method are empty. Schedules pass both on to the jobs they fire.
+ALTER TABLE jobs
+    ADD COLUMN topic       TEXT,
+    ADD COLUMN message_key TEXT;
+ALTER TABLE schedules
+    ADD COLUMN topic       TEXT,
+    ADD COLUMN message_key TEXT;
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN message_key, DROP COLUMN topic;
+ALTER TABLE jobs      DROP COLUMN message_key, DROP COLUMN topic;
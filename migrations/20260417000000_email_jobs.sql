@@ -0,0 +1,14 @@
+-- +goose Up
+-- email jobs send their body as the HTML of a message to email_to with subject
+-- email_subject; their url and method are empty. Schedules pass both on to the jobs they
+-- fire.
+ALTER TABLE jobs
+    ADD COLUMN email_to      TEXT,
+    ADD COLUMN email_subject TEXT;
+ALTER TABLE schedules
+    ADD COLUMN email_to      TEXT,
+    ADD COLUMN email_subject TEXT;
+
+-- +goose Down
+ALTER TABLE schedules DROP COLUMN email_subject, DROP COLUMN email_to;
+ALTER TABLE jobs      DROP COLUMN email_subject, DROP COLUMN email_to;
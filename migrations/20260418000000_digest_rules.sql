@@ -0,0 +1,22 @@
+-- +goose Up
+-- digest.daily and digest.weekly rules send a summary of the user's schedule runs after
+-- each UTC day or week. last_digest_at is the end of the last period sent; the digester
+-- claims a rule by moving it forward, so replicas never send the same period twice. It
+-- starts at the rule's creation, so a new rule's first digest waits for the next boundary.
+ALTER TABLE notification_rules
+    DROP CONSTRAINT notification_rules_event_check,
+    ADD CONSTRAINT notification_rules_event_check
+        CHECK (event IN ('job.failed', 'schedule.failing', 'digest.daily', 'digest.weekly')),
+    ADD COLUMN last_digest_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_notification_rules_digest ON notification_rules (event, last_digest_at)
+    WHERE event IN ('digest.daily', 'digest.weekly');
+
+-- +goose Down
+DROP INDEX idx_notification_rules_digest;
+DELETE FROM notification_rules WHERE event IN ('digest.daily', 'digest.weekly');
+ALTER TABLE notification_rules
+    DROP COLUMN last_digest_at,
+    DROP CONSTRAINT notification_rules_event_check,
+    ADD CONSTRAINT notification_rules_event_check
+        CHECK (event IN ('job.failed', 'schedule.failing'));
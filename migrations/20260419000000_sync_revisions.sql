@@ -0,0 +1,14 @@
+-- +goose Up
+-- Updates made by PUT /schedules/sync are recorded as 'sync' revisions, so history shows
+-- which changes came from a declarative sync rather than an edit.
+ALTER TABLE schedule_revisions
+    DROP CONSTRAINT schedule_revisions_action_check,
+    ADD CONSTRAINT schedule_revisions_action_check
+        CHECK (action IN ('create', 'update', 'pause', 'resume', 'revert', 'sync'));
+
+-- +goose Down
+UPDATE schedule_revisions SET action = 'update' WHERE action = 'sync';
+ALTER TABLE schedule_revisions
+    DROP CONSTRAINT schedule_revisions_action_check,
+    ADD CONSTRAINT schedule_revisions_action_check
+        CHECK (action IN ('create', 'update', 'pause', 'resume', 'revert'));
@@ -0,0 +1,11 @@
+-- +goose Up
+-- A job resubmitted with POST /jobs/:id/retry points at the job it was cloned from. The
+-- index keeps retention deletes, which null the reference, from scanning the table.
+ALTER TABLE jobs
+    ADD COLUMN retried_from TEXT REFERENCES jobs(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_jobs_retried_from ON jobs (retried_from) WHERE retried_from IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_jobs_retried_from;
+ALTER TABLE jobs DROP COLUMN retried_from;
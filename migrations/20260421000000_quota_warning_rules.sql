@@ -0,0 +1,14 @@
+-- +goose Up
+-- quota.warning rules alert the user when a job or schedule create crosses the soft-limit
+-- ratio of a quota. They apply to the whole account, so they never name a schedule.
+ALTER TABLE notification_rules
+    DROP CONSTRAINT notification_rules_event_check,
+    ADD CONSTRAINT notification_rules_event_check
+        CHECK (event IN ('job.failed', 'schedule.failing', 'digest.daily', 'digest.weekly', 'quota.warning'));
+
+-- +goose Down
+DELETE FROM notification_rules WHERE event = 'quota.warning';
+ALTER TABLE notification_rules
+    DROP CONSTRAINT notification_rules_event_check,
+    ADD CONSTRAINT notification_rules_event_check
+        CHECK (event IN ('job.failed', 'schedule.failing', 'digest.daily', 'digest.weekly'));
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Covers the daily-executions count in UsageRepository.GetUsage, run on every job and
+-- schedule create: the user's jobs come from idx_jobs_user_scheduled, and each job's
+-- attempts since midnight are a range scan here rather than its whole history. The
+-- leading job_id makes the single-column idx_attempts_job_id redundant.
+CREATE INDEX idx_attempts_job_started ON job_attempts (job_id, started_at);
+DROP INDEX idx_attempts_job_id;
+
+-- +goose Down
+CREATE INDEX idx_attempts_job_id ON job_attempts (job_id);
+DROP INDEX idx_attempts_job_started;
@@ -0,0 +1,102 @@
+-- +goose Up
+-- notify_job_status sent a NOTIFY per changed row, and every notifying transaction takes
+-- a cluster-wide lock at commit, so claims, completions and purges serialized on it. The
+-- triggers are now per statement: one NOTIFY carries a JSON array of up to 25 transitions
+-- (well under the 8000-byte payload limit), and a statement that changed no status —
+-- heartbeats, empty claim polls — sends nothing and takes no lock. Transition tables can't
+-- be combined with a column list, so the update trigger fires for every UPDATE and the
+-- function keeps only rows whose status changed.
+DROP TRIGGER jobs_notify_status ON jobs;
+DROP TRIGGER jobs_notify_insert ON jobs;
+DROP FUNCTION notify_job_status();
+
+-- +goose StatementBegin
+CREATE FUNCTION notify_job_status() RETURNS trigger AS $$
+DECLARE
+    payload TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        FOR payload IN
+            SELECT json_agg(e)::text
+            FROM (
+                SELECT json_build_object(
+                           'id',              n.id,
+                           'user_id',         n.user_id,
+                           'schedule_id',     n.schedule_id,
+                           'status',          n.status,
+                           'previous_status', NULL::text,
+                           'at',              n.updated_at
+                       ) AS e,
+                       (row_number() OVER () - 1) / 25 AS chunk
+                FROM new_rows n
+            ) t
+            GROUP BY chunk
+        LOOP
+            PERFORM pg_notify('job_status', payload);
+        END LOOP;
+    ELSE
+        FOR payload IN
+            SELECT json_agg(e)::text
+            FROM (
+                SELECT json_build_object(
+                           'id',              n.id,
+                           'user_id',         n.user_id,
+                           'schedule_id',     n.schedule_id,
+                           'status',          n.status,
+                           'previous_status', o.status,
+                           'at',              n.updated_at
+                       ) AS e,
+                       (row_number() OVER () - 1) / 25 AS chunk
+                FROM new_rows n
+                JOIN old_rows o ON o.id = n.id
+                WHERE o.status IS DISTINCT FROM n.status
+            ) t
+            GROUP BY chunk
+        LOOP
+            PERFORM pg_notify('job_status', payload);
+        END LOOP;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER jobs_notify_insert
+    AFTER INSERT ON jobs
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_job_status();
+
+CREATE TRIGGER jobs_notify_status
+    AFTER UPDATE ON jobs
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_job_status();
+
+-- +goose Down
+DROP TRIGGER jobs_notify_status ON jobs;
+DROP TRIGGER jobs_notify_insert ON jobs;
+DROP FUNCTION notify_job_status();
+
+-- +goose StatementBegin
+CREATE FUNCTION notify_job_status() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('job_status', json_build_object(
+        'id',              NEW.id,
+        'user_id',         NEW.user_id,
+        'schedule_id',     NEW.schedule_id,
+        'status',          NEW.status,
+        'previous_status', CASE WHEN TG_OP = 'UPDATE' THEN OLD.status END,
+        'at',              NEW.updated_at
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER jobs_notify_insert
+    AFTER INSERT ON jobs
+    FOR EACH ROW EXECUTE FUNCTION notify_job_status();
+
+CREATE TRIGGER jobs_notify_status
+    AFTER UPDATE OF status ON jobs
+    FOR EACH ROW WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION notify_job_status();
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Bring-your-own-key: an organization's KMS key reference. New header values, bodies and
+-- secrets of the organization are sealed with data keys wrapped by this key instead of
+-- the deployment's ENCRYPTION_KEYS. Sealed values name their key, so changing or
+-- clearing it leaves existing rows readable for as long as the KMS allows.
+ALTER TABLE organizations ADD COLUMN kms_key_ref TEXT;
+
+-- +goose Down
+ALTER TABLE organizations DROP COLUMN kms_key_ref;
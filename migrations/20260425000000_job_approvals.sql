@@ -0,0 +1,35 @@
+-- +goose Up
+-- Approval mode: with require_approval on, jobs that members other than owners create in
+-- the organization start in 'awaiting_approval', which workers never claim, until an
+-- owner approves (the job is released as if just created) or rejects (it is cancelled).
+ALTER TABLE organizations ADD COLUMN require_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- created_by is the member who created a job in an organization (NULL for personal and
+-- fired jobs); reviewed_by/reviewed_at record the owner's decision on an approval.
+ALTER TABLE jobs
+    ADD COLUMN created_by  TEXT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN reviewed_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN reviewed_at TIMESTAMPTZ;
+
+CREATE INDEX idx_jobs_awaiting_approval ON jobs (user_id, scheduled_at, id) WHERE status = 'awaiting_approval';
+
+-- approval.requested rules alert an organization's owners when members' jobs await them.
+ALTER TABLE notification_rules
+    DROP CONSTRAINT notification_rules_event_check,
+    ADD CONSTRAINT notification_rules_event_check
+        CHECK (event IN ('job.failed', 'schedule.failing', 'digest.daily', 'digest.weekly', 'quota.warning', 'approval.requested'));
+
+-- +goose Down
+DELETE FROM notification_rules WHERE event = 'approval.requested';
+ALTER TABLE notification_rules
+    DROP CONSTRAINT notification_rules_event_check,
+    ADD CONSTRAINT notification_rules_event_check
+        CHECK (event IN ('job.failed', 'schedule.failing', 'digest.daily', 'digest.weekly', 'quota.warning'));
+
+UPDATE jobs SET status = 'cancelled', updated_at = NOW() WHERE status = 'awaiting_approval';
+DROP INDEX idx_jobs_awaiting_approval;
+ALTER TABLE jobs
+    DROP COLUMN reviewed_at,
+    DROP COLUMN reviewed_by,
+    DROP COLUMN created_by;
+ALTER TABLE organizations DROP COLUMN require_approval;
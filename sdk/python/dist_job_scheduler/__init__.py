@@ -0,0 +1,1763 @@
+# Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.
+# dist-job-scheduler API, version 1.
+
+from __future__ import annotations
+
+import http.client
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from typing import Any, Literal, NotRequired, TypedDict
+
+
+class ErrorResponseErrorFieldErrorsItem(TypedDict):
+    field: str
+    message: str
+    rule: str
+
+
+class ErrorResponseError(TypedDict):
+    code: str
+    field_errors: NotRequired[list[ErrorResponseErrorFieldErrorsItem]]
+    message: str
+    request_id: NotRequired[str]
+
+
+class ErrorResponse(TypedDict):
+    error: ErrorResponseError
+
+
+class GetAccountApiUsageResponseEndpointsItem(TypedDict):
+    avg_duration_ms: int
+    client_errors: int
+    method: str
+    requests: int
+    route: str
+    server_errors: int
+
+
+class GetAccountApiUsageResponse(TypedDict):
+    endpoints: list[GetAccountApiUsageResponseEndpointsItem]
+    since: str
+    total_requests: int
+
+
+class GetAccountDefaultsResponse(TypedDict):
+    backoff: str
+    headers: dict[str, str]
+    max_retries: int
+    timeout_seconds: int
+
+
+class PatchAccountDefaultsRequest(TypedDict):
+    backoff: NotRequired[Literal["exponential", "linear"] | None]
+    headers: NotRequired[dict[str, str]]
+    max_retries: NotRequired[int | None]
+    timeout_seconds: NotRequired[int | None]
+
+
+class PatchAccountDefaultsResponse(TypedDict):
+    backoff: str
+    headers: dict[str, str]
+    max_retries: int
+    timeout_seconds: int
+
+
+class GetAccountEgressResponseSchedulesItem(TypedDict):
+    attempts: int
+    request_bytes: int
+    response_bytes: int
+    schedule_id: str | None
+    schedule_name: str | None
+
+
+class GetAccountEgressResponse(TypedDict):
+    attempts: int
+    request_bytes: int
+    response_bytes: int
+    schedules: list[GetAccountEgressResponseSchedulesItem]
+    since: str
+
+
+class GetAccountUsageResponseQuotasItem(TypedDict):
+    exceeded: bool
+    limit: int
+    quota: str
+    used: int
+    warning: bool
+
+
+class GetAccountUsageResponse(TypedDict):
+    quotas: list[GetAccountUsageResponseQuotasItem]
+    warnings: list[str]
+
+
+class GetAdminNoticesResponseNoticesItem(TypedDict):
+    active: bool
+    body: str
+    ends_at: str | None
+    id: str
+    severity: str
+    starts_at: str
+    title: str
+
+
+class GetAdminNoticesResponse(TypedDict):
+    notices: list[GetAdminNoticesResponseNoticesItem]
+
+
+class PostAdminNoticesRequest(TypedDict):
+    body: NotRequired[str]
+    ends_at: NotRequired[str | None]
+    severity: Literal["info", "warning", "critical"]
+    starts_at: NotRequired[str | None]
+    title: str
+
+
+class PostAdminNoticesResponse(TypedDict):
+    active: bool
+    body: str
+    ends_at: str | None
+    id: str
+    severity: str
+    starts_at: str
+    title: str
+
+
+class GetCertificatesResponseCertificatesItem(TypedDict):
+    created_at: str
+    fingerprint: str
+    id: str
+    name: str
+    not_after: str
+    subject: str
+
+
+class GetCertificatesResponse(TypedDict):
+    certificates: list[GetCertificatesResponseCertificatesItem]
+
+
+class PostCertificatesRequest(TypedDict):
+    cert_pem: str
+    key_pem: str
+    name: str
+
+
+class PostCertificatesResponse(TypedDict):
+    created_at: str
+    fingerprint: str
+    id: str
+    name: str
+    not_after: str
+    subject: str
+
+
+class GetJobsResponseJobsItem(TypedDict):
+    completed_at: NotRequired[str | None]
+    created_at: str
+    id: str
+    last_error: NotRequired[str | None]
+    method: str
+    schedule_id: NotRequired[str | None]
+    scheduled_at: str
+    status: str
+    url: str
+
+
+class GetJobsResponse(TypedDict):
+    has_more: bool
+    jobs: list[GetJobsResponseJobsItem]
+    next_cursor: str | None
+    total_count: NotRequired[int | None]
+
+
+class PostJobsRequest(TypedDict):
+    backoff: NotRequired[Literal["exponential", "linear"]]
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[Literal["utf8", "base64"]]
+    ca_bundle: NotRequired[str | None]
+    callback_url: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    deadline: NotRequired[str | None]
+    debug: NotRequired[bool]
+    depends_on: NotRequired[str | None]
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    expires_at: NotRequired[str | None]
+    headers: NotRequired[dict[str, str]]
+    idempotency_key: str
+    job_type: NotRequired[str]
+    max_retries: NotRequired[int]
+    message_key: NotRequired[str | None]
+    method: NotRequired[Literal["GET", "POST", "PUT", "PATCH", "DELETE"]]
+    on_parent_failure: NotRequired[Literal["skip", "run"]]
+    priority: NotRequired[int]
+    proxy_url: NotRequired[str | None]
+    queue: NotRequired[str]
+    retry_base_seconds: NotRequired[int]
+    retry_delays: NotRequired[list[str]]
+    retry_jitter: NotRequired[Literal["auto", "none", "full", "equal"]]
+    retry_max_seconds: NotRequired[int]
+    scheduled_at: str
+    signing_secret: NotRequired[str | None]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: NotRequired[int]
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: NotRequired[str]
+
+
+class PostJobsResponse(TypedDict):
+    created_at: str
+    id: str
+    status: str
+
+
+class PostJobsBatchRequestJobsItem(TypedDict):
+    backoff: NotRequired[Literal["exponential", "linear"]]
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[Literal["utf8", "base64"]]
+    ca_bundle: NotRequired[str | None]
+    callback_url: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    deadline: NotRequired[str | None]
+    debug: NotRequired[bool]
+    depends_on: NotRequired[str | None]
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    expires_at: NotRequired[str | None]
+    headers: NotRequired[dict[str, str]]
+    idempotency_key: str
+    job_type: NotRequired[str]
+    max_retries: NotRequired[int]
+    message_key: NotRequired[str | None]
+    method: NotRequired[Literal["GET", "POST", "PUT", "PATCH", "DELETE"]]
+    on_parent_failure: NotRequired[Literal["skip", "run"]]
+    priority: NotRequired[int]
+    proxy_url: NotRequired[str | None]
+    queue: NotRequired[str]
+    retry_base_seconds: NotRequired[int]
+    retry_delays: NotRequired[list[str]]
+    retry_jitter: NotRequired[Literal["auto", "none", "full", "equal"]]
+    retry_max_seconds: NotRequired[int]
+    scheduled_at: str
+    signing_secret: NotRequired[str | None]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: NotRequired[int]
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: NotRequired[str]
+
+
+class PostJobsBatchRequest(TypedDict):
+    jobs: list[PostJobsBatchRequestJobsItem]
+
+
+class PostJobsBatchResponseResultsItemError(TypedDict):
+    code: str
+    message: str
+
+
+class PostJobsBatchResponseResultsItem(TypedDict):
+    created_at: NotRequired[str | None]
+    error: NotRequired[PostJobsBatchResponseResultsItemError | None]
+    id: NotRequired[str | None]
+    idempotency_key: str
+    index: int
+    job_status: NotRequired[str | None]
+    status: str
+
+
+class PostJobsBatchResponse(TypedDict):
+    created: int
+    duplicates: int
+    errors: int
+    results: list[PostJobsBatchResponseResultsItem]
+
+
+class PostJobsDryRunRequest(TypedDict):
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[Literal["utf8", "base64"]]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    headers: NotRequired[dict[str, str]]
+    method: Literal["GET", "POST", "PUT", "PATCH", "DELETE"]
+    proxy_url: NotRequired[str | None]
+    signing_secret: NotRequired[str | None]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: NotRequired[int]
+    tls_skip_verify: NotRequired[bool]
+    url: str
+
+
+class PostJobsDryRunResponse(TypedDict):
+    duration_ms: int
+    error: str | None
+    response_body: str | None
+    response_bytes: int | None
+    response_headers: dict[str, str]
+    response_truncated: bool
+    status_code: int | None
+    succeeded: bool
+
+
+class GetJobsIdResponse(TypedDict):
+    body_encoding: str
+    ca_bundle: NotRequired[str | None]
+    callback_url: NotRequired[str | None]
+    cancel_requested_at: NotRequired[str | None]
+    claimed_at: NotRequired[str | None]
+    claimed_by: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    completed_at: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    created_at: str
+    created_by: NotRequired[str | None]
+    deadline: NotRequired[str | None]
+    debug: bool
+    depends_on: NotRequired[str | None]
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    expires_at: NotRequired[str | None]
+    heartbeat_at: NotRequired[str | None]
+    id: str
+    job_type: str
+    last_error: NotRequired[str | None]
+    message_key: NotRequired[str | None]
+    on_parent_failure: NotRequired[str | None]
+    priority: int
+    proxy_url: NotRequired[str | None]
+    queue: str
+    request_id: NotRequired[str | None]
+    retried_from: NotRequired[str | None]
+    retry_base_seconds: int
+    retry_delays: NotRequired[list[str]]
+    retry_jitter: str
+    retry_max_seconds: int
+    reviewed_at: NotRequired[str | None]
+    reviewed_by: NotRequired[str | None]
+    schedule_id: NotRequired[str | None]
+    scheduled_at: str
+    seconds_since_last_heartbeat: NotRequired[float | None]
+    signed: bool
+    status: str
+    success_codes: NotRequired[list[str]]
+    templated: bool
+    tls_skip_verify: bool
+    topic: NotRequired[str | None]
+    updated_at: str
+
+
+class GetJobsIdAttemptsResponseAttemptsItem(TypedDict):
+    attempt_num: int
+    cancelled: bool
+    completed_at: str | None
+    duration_ms: int | None
+    error: str | None
+    id: str
+    idempotency_key: str
+    job_id: str
+    remote_addr: str | None
+    request_bytes: int | None
+    response_body: str | None
+    response_bytes: int | None
+    response_headers: dict[str, str]
+    response_truncated: bool
+    started_at: str
+    status_code: int | None
+    worker_id: str
+
+
+class GetJobsIdAttemptsResponse(TypedDict):
+    attempts: list[GetJobsIdAttemptsResponseAttemptsItem]
+    has_more: bool
+    next_cursor: str | None
+    total_count: NotRequired[int | None]
+
+
+GetJobsIdAttemptsDiffResponseDiffsItemChangesItem = TypedDict(
+    "GetJobsIdAttemptsDiffResponseDiffsItemChangesItem",
+    {
+        "field": str,
+        "from": Any,
+        "to": Any,
+    },
+)
+
+
+class GetJobsIdAttemptsDiffResponseDiffsItem(TypedDict):
+    changes: list[GetJobsIdAttemptsDiffResponseDiffsItemChangesItem]
+    from_attempt: int
+    latency_delta_ms: int | None
+    to_attempt: int
+
+
+class GetJobsIdAttemptsDiffResponse(TypedDict):
+    diffs: list[GetJobsIdAttemptsDiffResponseDiffsItem]
+    job_id: str
+
+
+class GetJobsIdAttemptsAttemptIdResponseRequest(TypedDict):
+    body: str | None
+    headers: dict[str, str]
+    method: str
+    url: str
+
+
+class GetJobsIdAttemptsAttemptIdResponse(TypedDict):
+    attempt_num: int
+    cancelled: bool
+    completed_at: str | None
+    duration_ms: int | None
+    error: str | None
+    id: str
+    idempotency_key: str
+    job_id: str
+    remote_addr: str | None
+    request: GetJobsIdAttemptsAttemptIdResponseRequest | None
+    request_bytes: int | None
+    response_body: str | None
+    response_bytes: int | None
+    response_headers: dict[str, str]
+    response_truncated: bool
+    started_at: str
+    status_code: int | None
+    worker_id: str
+
+
+class GetJobsIdCallbacksResponseCallbacksItemHistoryItem(TypedDict):
+    attempt_num: int
+    created_at: str
+    duration_ms: int
+    error: str | None
+    response_snippet: str | None
+    status_code: int | None
+
+
+class GetJobsIdCallbacksResponseCallbacksItem(TypedDict):
+    attempts: int
+    created_at: str
+    delivered_at: NotRequired[str | None]
+    event: str
+    history: list[GetJobsIdCallbacksResponseCallbacksItemHistoryItem]
+    id: str
+    last_error: NotRequired[str | None]
+    next_attempt_at: NotRequired[str | None]
+    status: str
+    url: str
+
+
+class GetJobsIdCallbacksResponse(TypedDict):
+    callbacks: list[GetJobsIdCallbacksResponseCallbacksItem]
+    job_id: str
+
+
+class PostJobsIdCallbacksRetryResponse(TypedDict):
+    job_id: str
+    queued: int
+
+
+class PostJobsIdRetryResponse(TypedDict):
+    created_at: str
+    id: str
+    idempotency_key: str
+    retried_from: str
+    scheduled_at: str
+
+
+class GetNoticesResponseNoticesItem(TypedDict):
+    active: bool
+    body: str
+    ends_at: str | None
+    id: str
+    severity: str
+    starts_at: str
+    title: str
+
+
+class GetNoticesResponse(TypedDict):
+    notices: list[GetNoticesResponseNoticesItem]
+
+
+class GetNotificationsRulesResponseRulesItem(TypedDict):
+    channel: str
+    created_at: str
+    event: str
+    id: str
+    schedule_id: str | None
+    target: str
+    threshold: int
+
+
+class GetNotificationsRulesResponse(TypedDict):
+    rules: list[GetNotificationsRulesResponseRulesItem]
+
+
+class PostNotificationsRulesRequest(TypedDict):
+    channel: Literal["email", "slack"]
+    event: Literal["job.failed", "schedule.failing", "digest.daily", "digest.weekly", "quota.warning", "approval.requested"]
+    schedule_id: NotRequired[str | None]
+    target: str
+    threshold: NotRequired[int]
+
+
+class PostNotificationsRulesResponse(TypedDict):
+    channel: str
+    created_at: str
+    event: str
+    id: str
+    schedule_id: str | None
+    target: str
+    threshold: int
+
+
+GetOpenapiJsonResponse = Any
+
+
+class GetOrgsResponseOrganizationsItem(TypedDict):
+    created_at: str
+    id: str
+    kms_key_ref: str | None
+    name: str
+    require_approval: bool
+    role: str
+
+
+class GetOrgsResponse(TypedDict):
+    organizations: list[GetOrgsResponseOrganizationsItem]
+
+
+class PostOrgsRequest(TypedDict):
+    name: str
+
+
+class PostOrgsResponse(TypedDict):
+    created_at: str
+    id: str
+    kms_key_ref: str | None
+    name: str
+    require_approval: bool
+    role: str
+
+
+class PostOrgsInvitationsAcceptRequest(TypedDict):
+    token: str
+
+
+class PostOrgsInvitationsAcceptResponse(TypedDict):
+    org_id: str
+    role: str
+
+
+class PutOrgsIdApprovalModeRequest(TypedDict):
+    require_approval: bool | None
+
+
+class GetOrgsIdApprovalsResponseApprovalsItem(TypedDict):
+    created_at: str
+    created_by: str | None
+    id: str
+    job_type: str
+    method: str
+    scheduled_at: str
+    url: str
+
+
+class GetOrgsIdApprovalsResponse(TypedDict):
+    approvals: list[GetOrgsIdApprovalsResponseApprovalsItem]
+
+
+class PostOrgsIdApprovalsJobIdApproveResponse(TypedDict):
+    id: str
+    reviewed_at: str | None
+    reviewed_by: str | None
+    status: str
+
+
+class PostOrgsIdApprovalsJobIdRejectResponse(TypedDict):
+    id: str
+    reviewed_at: str | None
+    reviewed_by: str | None
+    status: str
+
+
+class PutOrgsIdEncryptionKeyRequest(TypedDict):
+    key_ref: str
+
+
+class GetOrgsIdInvitationsResponseInvitationsItem(TypedDict):
+    created_at: str
+    email: str | None
+    expires_at: str
+    id: str
+    invited_by: str
+    role: str
+
+
+class GetOrgsIdInvitationsResponse(TypedDict):
+    invitations: list[GetOrgsIdInvitationsResponseInvitationsItem]
+
+
+class PostOrgsIdInvitationsRequest(TypedDict):
+    email: NotRequired[str | None]
+    role: Literal["owner", "editor", "viewer"]
+
+
+class PostOrgsIdInvitationsResponse(TypedDict):
+    created_at: str
+    email: str | None
+    expires_at: str
+    id: str
+    invited_by: str
+    role: str
+    token: str
+
+
+class GetOrgsIdMembersResponseMembersItem(TypedDict):
+    created_at: str
+    email: str | None
+    role: str
+    user_id: str
+
+
+class GetOrgsIdMembersResponse(TypedDict):
+    members: list[GetOrgsIdMembersResponseMembersItem]
+
+
+class PatchOrgsIdMembersUserIdRequest(TypedDict):
+    role: Literal["owner", "editor", "viewer"]
+
+
+class GetSchedulesResponseSchedulesItem(TypedDict):
+    backoff: str
+    body_encoding: str
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    consecutive_failures: int
+    content_type: NotRequired[str | None]
+    created_at: str
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    id: str
+    jitter_seconds: int
+    job_type: str
+    last_run_at: NotRequired[str | None]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    mode: str
+    name: str
+    next_run_at: str
+    overlap_policy: str
+    pause_after_failures: int
+    paused: bool
+    proxy_url: NotRequired[str | None]
+    queue: str
+    retry_base_seconds: int
+    retry_jitter: str
+    retry_max_seconds: int
+    signed: bool
+    success_codes: NotRequired[list[str]]
+    templated: bool
+    timeout_seconds: int
+    timezone: str
+    tls_skip_verify: bool
+    topic: NotRequired[str | None]
+    url: str
+
+
+class GetSchedulesResponse(TypedDict):
+    has_more: bool
+    next_cursor: str | None
+    schedules: list[GetSchedulesResponseSchedulesItem]
+    total_count: NotRequired[int | None]
+
+
+class PostSchedulesRequest(TypedDict):
+    backoff: NotRequired[Literal["exponential", "linear"]]
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[Literal["utf8", "base64"]]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    cron_expr: NotRequired[str]
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    headers: NotRequired[dict[str, str]]
+    jitter_seconds: NotRequired[int]
+    job_type: NotRequired[str]
+    max_retries: NotRequired[int]
+    message_key: NotRequired[str | None]
+    method: NotRequired[Literal["GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"]]
+    mode: NotRequired[Literal["standard", "ping"]]
+    name: str
+    overlap_policy: NotRequired[Literal["queue", "skip", "replace"]]
+    pause_after_failures: NotRequired[int]
+    proxy_url: NotRequired[str | None]
+    queue: NotRequired[str]
+    retry_base_seconds: NotRequired[int]
+    retry_jitter: NotRequired[Literal["auto", "none", "full", "equal"]]
+    retry_max_seconds: NotRequired[int]
+    signing_secret: NotRequired[str | None]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: NotRequired[int]
+    timezone: NotRequired[str]
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: NotRequired[str]
+
+
+class PostSchedulesResponse(TypedDict):
+    backoff: str
+    body_encoding: str
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    consecutive_failures: int
+    content_type: NotRequired[str | None]
+    created_at: str
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    id: str
+    jitter_seconds: int
+    job_type: str
+    last_run_at: NotRequired[str | None]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    mode: str
+    name: str
+    next_run_at: str
+    overlap_policy: str
+    pause_after_failures: int
+    paused: bool
+    proxy_url: NotRequired[str | None]
+    queue: str
+    retry_base_seconds: int
+    retry_jitter: str
+    retry_max_seconds: int
+    signed: bool
+    success_codes: NotRequired[list[str]]
+    templated: bool
+    timeout_seconds: int
+    timezone: str
+    tls_skip_verify: bool
+    topic: NotRequired[str | None]
+    url: str
+
+
+class GetSchedulesExportResponseSchedulesItemHistory(TypedDict):
+    completed_runs: int
+    failed_runs: int
+    last_error: NotRequired[str | None]
+    last_finished_at: NotRequired[str | None]
+    last_run_at: NotRequired[str | None]
+    last_status: NotRequired[str | None]
+    total_runs: int
+
+
+class GetSchedulesExportResponseSchedulesItem(TypedDict):
+    backoff: str
+    body: str | None
+    body_encoding: NotRequired[str]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    headers: dict[str, str]
+    history: NotRequired[GetSchedulesExportResponseSchedulesItemHistory | None]
+    jitter_seconds: int
+    job_type: NotRequired[str]
+    last_run_at: NotRequired[str | None]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    mode: str
+    name: str
+    overlap_policy: str
+    pause_after_failures: NotRequired[int]
+    paused: bool
+    proxy_url: NotRequired[str | None]
+    queue: NotRequired[str]
+    retry_base_seconds: int
+    retry_jitter: str
+    retry_max_seconds: int
+    signing_secret: NotRequired[str | None]
+    success_codes: list[str]
+    templated: bool
+    timeout_seconds: int
+    timezone: str
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: str
+
+
+class GetSchedulesExportResponse(TypedDict):
+    exported_at: str
+    schedules: list[GetSchedulesExportResponseSchedulesItem]
+    version: int
+
+
+class PostSchedulesImportRequestSchedulesItemHistory(TypedDict):
+    completed_runs: NotRequired[int]
+    failed_runs: NotRequired[int]
+    last_error: NotRequired[str | None]
+    last_finished_at: NotRequired[str | None]
+    last_run_at: NotRequired[str | None]
+    last_status: NotRequired[str | None]
+    total_runs: NotRequired[int]
+
+
+class PostSchedulesImportRequestSchedulesItem(TypedDict):
+    backoff: NotRequired[Literal["exponential", "linear"]]
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[Literal["utf8", "base64"]]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    cron_expr: NotRequired[str]
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    headers: NotRequired[dict[str, str]]
+    history: NotRequired[PostSchedulesImportRequestSchedulesItemHistory | None]
+    jitter_seconds: NotRequired[int]
+    job_type: NotRequired[str]
+    last_run_at: NotRequired[str | None]
+    max_retries: NotRequired[int]
+    message_key: NotRequired[str | None]
+    method: NotRequired[Literal["GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"]]
+    mode: NotRequired[Literal["standard", "ping"]]
+    name: str
+    overlap_policy: NotRequired[Literal["queue", "skip", "replace"]]
+    pause_after_failures: NotRequired[int]
+    paused: NotRequired[bool]
+    proxy_url: NotRequired[str | None]
+    queue: NotRequired[str]
+    retry_base_seconds: NotRequired[int]
+    retry_jitter: NotRequired[Literal["auto", "none", "full", "equal"]]
+    retry_max_seconds: NotRequired[int]
+    signing_secret: NotRequired[str | None]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: NotRequired[int]
+    timezone: NotRequired[str]
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: NotRequired[str]
+
+
+class PostSchedulesImportRequest(TypedDict):
+    schedules: list[PostSchedulesImportRequestSchedulesItem]
+    version: int
+
+
+class PostSchedulesImportResponseResultsItemError(TypedDict):
+    code: str
+    message: str
+
+
+class PostSchedulesImportResponseResultsItem(TypedDict):
+    changed: NotRequired[list[str]]
+    error: NotRequired[PostSchedulesImportResponseResultsItemError | None]
+    id: NotRequired[str | None]
+    name: str
+    status: str
+
+
+class PostSchedulesImportResponse(TypedDict):
+    created: int
+    failed: int
+    mode: str
+    results: list[PostSchedulesImportResponseResultsItem]
+    skipped: int
+
+
+class PostSchedulesPreviewRequest(TypedDict):
+    count: NotRequired[int]
+    cron_expr: NotRequired[str]
+    every: NotRequired[str]
+    timezone: NotRequired[str]
+
+
+class PostSchedulesPreviewResponse(TypedDict):
+    runs: list[str]
+    timezone: str
+
+
+class PutSchedulesSyncRequestSchedulesItemHistory(TypedDict):
+    completed_runs: NotRequired[int]
+    failed_runs: NotRequired[int]
+    last_error: NotRequired[str | None]
+    last_finished_at: NotRequired[str | None]
+    last_run_at: NotRequired[str | None]
+    last_status: NotRequired[str | None]
+    total_runs: NotRequired[int]
+
+
+class PutSchedulesSyncRequestSchedulesItem(TypedDict):
+    backoff: NotRequired[Literal["exponential", "linear"]]
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[Literal["utf8", "base64"]]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    cron_expr: NotRequired[str]
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    headers: NotRequired[dict[str, str]]
+    history: NotRequired[PutSchedulesSyncRequestSchedulesItemHistory | None]
+    jitter_seconds: NotRequired[int]
+    job_type: NotRequired[str]
+    last_run_at: NotRequired[str | None]
+    max_retries: NotRequired[int]
+    message_key: NotRequired[str | None]
+    method: NotRequired[Literal["GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"]]
+    mode: NotRequired[Literal["standard", "ping"]]
+    name: str
+    overlap_policy: NotRequired[Literal["queue", "skip", "replace"]]
+    pause_after_failures: NotRequired[int]
+    paused: NotRequired[bool]
+    proxy_url: NotRequired[str | None]
+    queue: NotRequired[str]
+    retry_base_seconds: NotRequired[int]
+    retry_jitter: NotRequired[Literal["auto", "none", "full", "equal"]]
+    retry_max_seconds: NotRequired[int]
+    signing_secret: NotRequired[str | None]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: NotRequired[int]
+    timezone: NotRequired[str]
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: NotRequired[str]
+
+
+class PutSchedulesSyncRequest(TypedDict):
+    schedules: list[PutSchedulesSyncRequestSchedulesItem]
+    version: int
+
+
+class PutSchedulesSyncResponseActionsItemError(TypedDict):
+    code: str
+    message: str
+
+
+class PutSchedulesSyncResponseActionsItem(TypedDict):
+    action: NotRequired[str]
+    changed: NotRequired[list[str]]
+    error: NotRequired[PutSchedulesSyncResponseActionsItemError | None]
+    id: NotRequired[str | None]
+    name: str
+
+
+class PutSchedulesSyncResponse(TypedDict):
+    actions: list[PutSchedulesSyncResponseActionsItem]
+    applied: bool
+    created: int
+    deleted: int
+    failed: int
+    replaced: int
+    unchanged: int
+    updated: int
+
+
+class GetSchedulesIdResponse(TypedDict):
+    backoff: str
+    body_encoding: str
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    consecutive_failures: int
+    content_type: NotRequired[str | None]
+    created_at: str
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    id: str
+    jitter_seconds: int
+    job_type: str
+    last_run_at: NotRequired[str | None]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    mode: str
+    name: str
+    next_run_at: str
+    overlap_policy: str
+    pause_after_failures: int
+    paused: bool
+    proxy_url: NotRequired[str | None]
+    queue: str
+    retry_base_seconds: int
+    retry_jitter: str
+    retry_max_seconds: int
+    signed: bool
+    success_codes: NotRequired[list[str]]
+    templated: bool
+    timeout_seconds: int
+    timezone: str
+    tls_skip_verify: bool
+    topic: NotRequired[str | None]
+    url: str
+
+
+class PatchSchedulesIdRequest(TypedDict):
+    backoff: NotRequired[Literal["exponential", "linear"] | None]
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[Literal["utf8", "base64"] | None]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    cron_expr: NotRequired[str | None]
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str | None]
+    headers: NotRequired[dict[str, str]]
+    jitter_seconds: NotRequired[int | None]
+    max_retries: NotRequired[int | None]
+    message_key: NotRequired[str | None]
+    method: NotRequired[Literal["GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"] | None]
+    name: NotRequired[str | None]
+    overlap_policy: NotRequired[Literal["queue", "skip", "replace"] | None]
+    pause_after_failures: NotRequired[int | None]
+    proxy_url: NotRequired[str | None]
+    queue: NotRequired[str | None]
+    retry_base_seconds: NotRequired[int | None]
+    retry_jitter: NotRequired[Literal["auto", "none", "full", "equal"] | None]
+    retry_max_seconds: NotRequired[int | None]
+    signing_secret: NotRequired[str | None]
+    success_codes: NotRequired[list[str] | None]
+    templated: NotRequired[bool | None]
+    timeout_seconds: NotRequired[int | None]
+    timezone: NotRequired[str | None]
+    tls_skip_verify: NotRequired[bool | None]
+    topic: NotRequired[str | None]
+    url: NotRequired[str | None]
+
+
+class PatchSchedulesIdResponse(TypedDict):
+    backoff: str
+    body_encoding: str
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    consecutive_failures: int
+    content_type: NotRequired[str | None]
+    created_at: str
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    id: str
+    jitter_seconds: int
+    job_type: str
+    last_run_at: NotRequired[str | None]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    mode: str
+    name: str
+    next_run_at: str
+    overlap_policy: str
+    pause_after_failures: int
+    paused: bool
+    proxy_url: NotRequired[str | None]
+    queue: str
+    retry_base_seconds: int
+    retry_jitter: str
+    retry_max_seconds: int
+    signed: bool
+    success_codes: NotRequired[list[str]]
+    templated: bool
+    timeout_seconds: int
+    timezone: str
+    tls_skip_verify: bool
+    topic: NotRequired[str | None]
+    url: str
+
+
+class PostSchedulesIdDryRunResponse(TypedDict):
+    duration_ms: int
+    error: str | None
+    response_body: str | None
+    response_bytes: int | None
+    response_headers: dict[str, str]
+    response_truncated: bool
+    status_code: int | None
+    succeeded: bool
+
+
+class GetSchedulesIdJobsResponseJobsItem(TypedDict):
+    completed_at: NotRequired[str | None]
+    created_at: str
+    id: str
+    last_error: NotRequired[str | None]
+    method: str
+    schedule_id: NotRequired[str | None]
+    scheduled_at: str
+    status: str
+    url: str
+
+
+class GetSchedulesIdJobsResponse(TypedDict):
+    has_more: bool
+    jobs: list[GetSchedulesIdJobsResponseJobsItem]
+    next_cursor: str | None
+    total_count: NotRequired[int | None]
+
+
+class GetSchedulesIdRevisionsResponseRevisionsItemAfter(TypedDict):
+    backoff: str
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[str]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    headers: NotRequired[dict[str, str]]
+    jitter_seconds: NotRequired[int]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    name: str
+    overlap_policy: NotRequired[str]
+    pause_after_failures: NotRequired[int]
+    paused: bool
+    proxied: NotRequired[bool]
+    queue: NotRequired[str]
+    retry_base_seconds: NotRequired[int]
+    retry_jitter: NotRequired[str]
+    retry_max_seconds: NotRequired[int]
+    signed: NotRequired[bool]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: int
+    timezone: NotRequired[str]
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: str
+
+
+class GetSchedulesIdRevisionsResponseRevisionsItemBefore(TypedDict):
+    backoff: str
+    body: NotRequired[str | None]
+    body_encoding: NotRequired[str]
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    content_type: NotRequired[str | None]
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    headers: NotRequired[dict[str, str]]
+    jitter_seconds: NotRequired[int]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    name: str
+    overlap_policy: NotRequired[str]
+    pause_after_failures: NotRequired[int]
+    paused: bool
+    proxied: NotRequired[bool]
+    queue: NotRequired[str]
+    retry_base_seconds: NotRequired[int]
+    retry_jitter: NotRequired[str]
+    retry_max_seconds: NotRequired[int]
+    signed: NotRequired[bool]
+    success_codes: NotRequired[list[str]]
+    templated: NotRequired[bool]
+    timeout_seconds: int
+    timezone: NotRequired[str]
+    tls_skip_verify: NotRequired[bool]
+    topic: NotRequired[str | None]
+    url: str
+
+
+class GetSchedulesIdRevisionsResponseRevisionsItem(TypedDict):
+    action: str
+    actor_id: str
+    after: GetSchedulesIdRevisionsResponseRevisionsItemAfter
+    before: GetSchedulesIdRevisionsResponseRevisionsItemBefore | None
+    changed: NotRequired[list[str]]
+    created_at: str
+    revision: int
+
+
+class GetSchedulesIdRevisionsResponse(TypedDict):
+    revisions: list[GetSchedulesIdRevisionsResponseRevisionsItem]
+    schedule_id: str
+
+
+class PostSchedulesIdRevisionsRevisionRevertResponse(TypedDict):
+    backoff: str
+    body_encoding: str
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    consecutive_failures: int
+    content_type: NotRequired[str | None]
+    created_at: str
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    id: str
+    jitter_seconds: int
+    job_type: str
+    last_run_at: NotRequired[str | None]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    mode: str
+    name: str
+    next_run_at: str
+    overlap_policy: str
+    pause_after_failures: int
+    paused: bool
+    proxy_url: NotRequired[str | None]
+    queue: str
+    retry_base_seconds: int
+    retry_jitter: str
+    retry_max_seconds: int
+    signed: bool
+    success_codes: NotRequired[list[str]]
+    templated: bool
+    timeout_seconds: int
+    timezone: str
+    tls_skip_verify: bool
+    topic: NotRequired[str | None]
+    url: str
+
+
+class GetSchedulesIdStatsResponse(TypedDict):
+    attempts: int
+    counts: dict[str, int]
+    failed_attempts: int
+    jobs: int
+    p50_duration_ms: int | None
+    p95_duration_ms: int | None
+    schedule_id: NotRequired[str | None]
+    since: str
+    success_rate: float | None
+
+
+class PostSchedulesIdTriggerResponse(TypedDict):
+    created_at: str
+    id: str
+    idempotency_key: str
+    schedule_id: str
+    scheduled_at: str
+
+
+class GetSchedulesIdUptimeResponseIncidentsItem(TypedDict):
+    ended_at: str | None
+    last_error: NotRequired[str | None]
+    started_at: str
+
+
+class GetSchedulesIdUptimeResponse(TypedDict):
+    avg_duration_ms: int
+    checks: int
+    downtime_seconds: float
+    failures: int
+    incidents: list[GetSchedulesIdUptimeResponseIncidentsItem]
+    schedule_id: str
+    since: str
+    success_ratio: float | None
+    uptime_ratio: float
+
+
+class GetSchemasEventsResponseEventsItem(TypedDict):
+    event: str
+    schema: str
+
+
+class GetSchemasEventsResponse(TypedDict):
+    events: list[GetSchemasEventsResponseEventsItem]
+
+
+GetSchemasEventsFileResponse = Any
+
+
+class GetSearchResponseResultsItem(TypedDict):
+    created_at: str
+    id: str
+    kind: str
+    matched_on: str
+    status: str
+    subtitle: str
+    title: str
+
+
+class GetSearchResponse(TypedDict):
+    query: str
+    results: list[GetSearchResponseResultsItem]
+
+
+class GetStatsResponse(TypedDict):
+    attempts: int
+    counts: dict[str, int]
+    failed_attempts: int
+    jobs: int
+    p50_duration_ms: int | None
+    p95_duration_ms: int | None
+    schedule_id: NotRequired[str | None]
+    since: str
+    success_rate: float | None
+
+
+class GetTemplatesCatalogResponseTemplatesItemParamsItem(TypedDict):
+    default: NotRequired[str]
+    description: str
+    name: str
+    pattern: NotRequired[str]
+    required: bool
+
+
+class GetTemplatesCatalogResponseTemplatesItem(TypedDict):
+    cron_expr: NotRequired[str]
+    description: str
+    id: str
+    kind: str
+    method: str
+    name: str
+    params: list[GetTemplatesCatalogResponseTemplatesItemParamsItem]
+
+
+class GetTemplatesCatalogResponse(TypedDict):
+    templates: list[GetTemplatesCatalogResponseTemplatesItem]
+
+
+class PostTemplatesIdInstantiateRequest(TypedDict):
+    cron_expr: NotRequired[str]
+    idempotency_key: NotRequired[str]
+    name: NotRequired[str]
+    params: NotRequired[dict[str, str]]
+    scheduled_at: NotRequired[str]
+    timezone: NotRequired[str]
+
+
+class PostTemplatesIdInstantiateResponseJob(TypedDict):
+    created_at: str
+    id: str
+    status: str
+
+
+class PostTemplatesIdInstantiateResponseSchedule(TypedDict):
+    backoff: str
+    body_encoding: str
+    ca_bundle: NotRequired[str | None]
+    client_cert_id: NotRequired[str | None]
+    consecutive_failures: int
+    content_type: NotRequired[str | None]
+    created_at: str
+    cron_expr: str
+    email_subject: NotRequired[str | None]
+    email_to: NotRequired[str | None]
+    every: NotRequired[str]
+    id: str
+    jitter_seconds: int
+    job_type: str
+    last_run_at: NotRequired[str | None]
+    max_retries: int
+    message_key: NotRequired[str | None]
+    method: str
+    mode: str
+    name: str
+    next_run_at: str
+    overlap_policy: str
+    pause_after_failures: int
+    paused: bool
+    proxy_url: NotRequired[str | None]
+    queue: str
+    retry_base_seconds: int
+    retry_jitter: str
+    retry_max_seconds: int
+    signed: bool
+    success_codes: NotRequired[list[str]]
+    templated: bool
+    timeout_seconds: int
+    timezone: str
+    tls_skip_verify: bool
+    topic: NotRequired[str | None]
+    url: str
+
+
+class PostTemplatesIdInstantiateResponse(TypedDict):
+    job: NotRequired[PostTemplatesIdInstantiateResponseJob | None]
+    kind: str
+    schedule: NotRequired[PostTemplatesIdInstantiateResponseSchedule | None]
+
+
+class ApiError(Exception):
+    """Raised for every non-2xx response; body is None when it wasn't JSON."""
+
+    def __init__(self, status: int, body: ErrorResponse | None) -> None:
+        message = body["error"]["message"] if body else f"request failed with status {status}"
+        super().__init__(message)
+        self.status = status
+        self.body = body
+
+
+def _quote(value: str) -> str:
+    return urllib.parse.quote(value, safe="")
+
+
+def _query_value(value: str | int | bool) -> str:
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    return str(value)
+
+
+class Client:
+    """Calls the API as the token's user, or in the organization org_id names."""
+
+    def __init__(
+        self,
+        base_url: str,
+        token: str | None = None,
+        org_id: str | None = None,
+        timeout: float = 30.0,
+    ) -> None:
+        self._base_url = base_url.rstrip("/")
+        self._token = token
+        self._org_id = org_id
+        self._timeout = timeout
+
+    def _send(
+        self,
+        method: str,
+        path: str,
+        query: dict[str, Any] | None = None,
+        body: Any = None,
+    ) -> http.client.HTTPResponse:
+        url = self._base_url + path
+        params = {k: _query_value(v) for k, v in (query or {}).items() if v is not None}
+        if params:
+            url += "?" + urllib.parse.urlencode(params)
+        data = None if body is None else json.dumps(body).encode()
+        req = urllib.request.Request(url, data=data, method=method)
+        if self._token:
+            req.add_header("Authorization", f"Bearer {self._token}")
+        if self._org_id:
+            req.add_header("X-Org-ID", self._org_id)
+        if data is not None:
+            req.add_header("Content-Type", "application/json")
+        try:
+            return urllib.request.urlopen(req, timeout=self._timeout)
+        except urllib.error.HTTPError as e:
+            try:
+                err = json.load(e)
+            except ValueError:
+                err = None
+            raise ApiError(e.code, err) from None
+
+    def _json(
+        self,
+        method: str,
+        path: str,
+        query: dict[str, Any] | None = None,
+        body: Any = None,
+    ) -> Any:
+        with self._send(method, path, query, body) as resp:
+            raw = resp.read()
+        return json.loads(raw) if raw else None
+
+    def get_account_api_usage(self, *, window: str | None = None) -> GetAccountApiUsageResponse:
+        """Per-endpoint request counts"""
+        return self._json("GET", "/account/api-usage", {"window": window}, None)
+
+    def get_account_defaults(self) -> GetAccountDefaultsResponse:
+        """Job defaults"""
+        return self._json("GET", "/account/defaults", None, None)
+
+    def patch_account_defaults(self, body: PatchAccountDefaultsRequest) -> PatchAccountDefaultsResponse:
+        """Update job defaults; omitted fields are unchanged"""
+        return self._json("PATCH", "/account/defaults", None, body)
+
+    def get_account_egress(self, *, window: str | None = None) -> GetAccountEgressResponse:
+        """Bytes sent and received by job attempts"""
+        return self._json("GET", "/account/egress", {"window": window}, None)
+
+    def get_account_usage(self) -> GetAccountUsageResponse:
+        """Quota usage"""
+        return self._json("GET", "/account/usage", None, None)
+
+    def get_admin_notices(self) -> GetAdminNoticesResponse:
+        """List all notices (admin)"""
+        return self._json("GET", "/admin/notices", None, None)
+
+    def post_admin_notices(self, body: PostAdminNoticesRequest) -> PostAdminNoticesResponse:
+        """Create a notice (admin)"""
+        return self._json("POST", "/admin/notices", None, body)
+
+    def delete_admin_notices_id(self, id: str) -> None:
+        """Delete a notice (admin)"""
+        return self._json("DELETE", f"/admin/notices/{_quote(id)}", None, None)
+
+    def get_certificates(self) -> GetCertificatesResponse:
+        """List your client certificates for mutual TLS"""
+        return self._json("GET", "/certificates", None, None)
+
+    def post_certificates(self, body: PostCertificatesRequest) -> PostCertificatesResponse:
+        """Upload a client certificate and key; reference it from jobs as client_cert_id"""
+        return self._json("POST", "/certificates", None, body)
+
+    def delete_certificates_id(self, id: str) -> None:
+        """Delete a client certificate no unfinished job or schedule uses"""
+        return self._json("DELETE", f"/certificates/{_quote(id)}", None, None)
+
+    def get_jobs(self, *, status: str | None = None, request_id: str | None = None, url_contains: str | None = None, idempotency_key: str | None = None, schedule_id: str | None = None, scheduled_after: str | None = None, scheduled_before: str | None = None, page_size: int | None = None, order: str | None = None, cursor: str | None = None, include_total: bool | None = None) -> GetJobsResponse:
+        """List jobs"""
+        return self._json("GET", "/jobs", {"status": status, "request_id": request_id, "url_contains": url_contains, "idempotency_key": idempotency_key, "schedule_id": schedule_id, "scheduled_after": scheduled_after, "scheduled_before": scheduled_before, "page_size": page_size, "order": order, "cursor": cursor, "include_total": include_total}, None)
+
+    def post_jobs(self, body: PostJobsRequest) -> PostJobsResponse:
+        """Create a job"""
+        return self._json("POST", "/jobs", None, body)
+
+    def post_jobs_batch(self, body: PostJobsBatchRequest) -> PostJobsBatchResponse:
+        """Create up to 100 jobs in one transaction"""
+        return self._json("POST", "/jobs/batch", None, body)
+
+    def post_jobs_dry_run(self, body: PostJobsDryRunRequest) -> PostJobsDryRunResponse:
+        """Send a job's request once without creating the job"""
+        return self._json("POST", "/jobs/dry-run", None, body)
+
+    def get_jobs_stream(self) -> http.client.HTTPResponse:
+        """Stream job status transitions as Server-Sent Events named \"status\""""
+        # The caller reads and closes the response.
+        return self._send("GET", "/jobs/stream", None, None)
+
+    def get_jobs_id(self, id: str) -> GetJobsIdResponse:
+        """Get a job; send If-None-Match with its ETag to poll"""
+        return self._json("GET", f"/jobs/{_quote(id)}", None, None)
+
+    def delete_jobs_id(self, id: str) -> None:
+        """Cancel a job"""
+        return self._json("DELETE", f"/jobs/{_quote(id)}", None, None)
+
+    def get_jobs_id_attempts(self, id: str, *, page_size: int | None = None, order: str | None = None, cursor: str | None = None, include_total: bool | None = None) -> GetJobsIdAttemptsResponse:
+        """List a job's attempts, oldest first by default"""
+        return self._json("GET", f"/jobs/{_quote(id)}/attempts", {"page_size": page_size, "order": order, "cursor": cursor, "include_total": include_total}, None)
+
+    def get_jobs_id_attempts_diff(self, id: str, *, from_: int | None = None) -> GetJobsIdAttemptsDiffResponse:
+        """Diff consecutive attempts"""
+        return self._json("GET", f"/jobs/{_quote(id)}/attempts/diff", {"from": from_}, None)
+
+    def get_jobs_id_attempts_attempt_id(self, id: str, attempt_id: str) -> GetJobsIdAttemptsAttemptIdResponse:
+        """Get one attempt with its request snapshot"""
+        return self._json("GET", f"/jobs/{_quote(id)}/attempts/{_quote(attempt_id)}", None, None)
+
+    def get_jobs_id_callbacks(self, id: str) -> GetJobsIdCallbacksResponse:
+        """List a job's completion callbacks"""
+        return self._json("GET", f"/jobs/{_quote(id)}/callbacks", None, None)
+
+    def post_jobs_id_callbacks_retry(self, id: str) -> PostJobsIdCallbacksRetryResponse:
+        """Redeliver failed callbacks"""
+        return self._json("POST", f"/jobs/{_quote(id)}/callbacks/retry", None, None)
+
+    def post_jobs_id_pause(self, id: str) -> None:
+        """Pause a pending job"""
+        return self._json("POST", f"/jobs/{_quote(id)}/pause", None, None)
+
+    def post_jobs_id_resume(self, id: str) -> None:
+        """Resume a paused job"""
+        return self._json("POST", f"/jobs/{_quote(id)}/resume", None, None)
+
+    def post_jobs_id_retry(self, id: str) -> PostJobsIdRetryResponse:
+        """Resubmit a finished job as a new job"""
+        return self._json("POST", f"/jobs/{_quote(id)}/retry", None, None)
+
+    def get_notices(self) -> GetNoticesResponse:
+        """Active service notices"""
+        return self._json("GET", "/notices", None, None)
+
+    def get_notifications_rules(self) -> GetNotificationsRulesResponse:
+        """List notification rules"""
+        return self._json("GET", "/notifications/rules", None, None)
+
+    def post_notifications_rules(self, body: PostNotificationsRulesRequest) -> PostNotificationsRulesResponse:
+        """Create a notification rule"""
+        return self._json("POST", "/notifications/rules", None, body)
+
+    def delete_notifications_rules_id(self, id: str) -> None:
+        """Delete a notification rule"""
+        return self._json("DELETE", f"/notifications/rules/{_quote(id)}", None, None)
+
+    def get_openapi_json(self) -> GetOpenapiJsonResponse:
+        """This document"""
+        return self._json("GET", "/openapi.json", None, None)
+
+    def get_orgs(self) -> GetOrgsResponse:
+        """List your organizations and your role in each"""
+        return self._json("GET", "/orgs", None, None)
+
+    def post_orgs(self, body: PostOrgsRequest) -> PostOrgsResponse:
+        """Create an organization, with you as its owner"""
+        return self._json("POST", "/orgs", None, body)
+
+    def post_orgs_invitations_accept(self, body: PostOrgsInvitationsAcceptRequest) -> PostOrgsInvitationsAcceptResponse:
+        """Join an organization with an invitation token"""
+        return self._json("POST", "/orgs/invitations/accept", None, body)
+
+    def put_orgs_id_approval_mode(self, id: str, body: PutOrgsIdApprovalModeRequest) -> None:
+        """Hold jobs that members other than owners create until an owner approves them (owners only)"""
+        return self._json("PUT", f"/orgs/{_quote(id)}/approval-mode", None, body)
+
+    def get_orgs_id_approvals(self, id: str) -> GetOrgsIdApprovalsResponse:
+        """List the organization's jobs awaiting approval, those due first"""
+        return self._json("GET", f"/orgs/{_quote(id)}/approvals", None, None)
+
+    def post_orgs_id_approvals_job_id_approve(self, id: str, job_id: str) -> PostOrgsIdApprovalsJobIdApproveResponse:
+        """Approve a job awaiting approval (owners only)"""
+        return self._json("POST", f"/orgs/{_quote(id)}/approvals/{_quote(job_id)}/approve", None, None)
+
+    def post_orgs_id_approvals_job_id_reject(self, id: str, job_id: str) -> PostOrgsIdApprovalsJobIdRejectResponse:
+        """Reject a job awaiting approval, cancelling it (owners only)"""
+        return self._json("POST", f"/orgs/{_quote(id)}/approvals/{_quote(job_id)}/reject", None, None)
+
+    def put_orgs_id_encryption_key(self, id: str, body: PutOrgsIdEncryptionKeyRequest) -> None:
+        """Encrypt the organization's new secrets with its own KMS key (owners only)"""
+        return self._json("PUT", f"/orgs/{_quote(id)}/encryption-key", None, body)
+
+    def delete_orgs_id_encryption_key(self, id: str) -> None:
+        """Go back to the deployment's encryption keys for new secrets (owners only)"""
+        return self._json("DELETE", f"/orgs/{_quote(id)}/encryption-key", None, None)
+
+    def get_orgs_id_invitations(self, id: str) -> GetOrgsIdInvitationsResponse:
+        """List pending invitations (owners only)"""
+        return self._json("GET", f"/orgs/{_quote(id)}/invitations", None, None)
+
+    def post_orgs_id_invitations(self, id: str, body: PostOrgsIdInvitationsRequest) -> PostOrgsIdInvitationsResponse:
+        """Invite a member (owners only); the token is shown only in this response"""
+        return self._json("POST", f"/orgs/{_quote(id)}/invitations", None, body)
+
+    def delete_orgs_id_invitations_invitation_id(self, id: str, invitation_id: str) -> None:
+        """Revoke a pending invitation (owners only)"""
+        return self._json("DELETE", f"/orgs/{_quote(id)}/invitations/{_quote(invitation_id)}", None, None)
+
+    def get_orgs_id_members(self, id: str) -> GetOrgsIdMembersResponse:
+        """List an organization's members"""
+        return self._json("GET", f"/orgs/{_quote(id)}/members", None, None)
+
+    def patch_orgs_id_members_user_id(self, id: str, user_id: str, body: PatchOrgsIdMembersUserIdRequest) -> None:
+        """Change a member's role (owners only)"""
+        return self._json("PATCH", f"/orgs/{_quote(id)}/members/{_quote(user_id)}", None, body)
+
+    def delete_orgs_id_members_user_id(self, id: str, user_id: str) -> None:
+        """Remove a member (owners), or leave the organization"""
+        return self._json("DELETE", f"/orgs/{_quote(id)}/members/{_quote(user_id)}", None, None)
+
+    def get_schedules(self, *, page_size: int | None = None, order: str | None = None, cursor: str | None = None, include_total: bool | None = None) -> GetSchedulesResponse:
+        """List schedules"""
+        return self._json("GET", "/schedules", {"page_size": page_size, "order": order, "cursor": cursor, "include_total": include_total}, None)
+
+    def post_schedules(self, body: PostSchedulesRequest) -> PostSchedulesResponse:
+        """Create a schedule"""
+        return self._json("POST", "/schedules", None, body)
+
+    def get_schedules_export(self, *, include_history: bool | None = None, format: str | None = None) -> GetSchedulesExportResponse:
+        """Export all schedules, secrets included (viewers get 403)"""
+        return self._json("GET", "/schedules/export", {"include_history": include_history, "format": format}, None)
+
+    def post_schedules_import(self, body: PostSchedulesImportRequest, *, mode: str | None = None) -> PostSchedulesImportResponse:
+        """Import an export document (JSON, or YAML with a YAML Content-Type)"""
+        return self._json("POST", "/schedules/import", {"mode": mode}, body)
+
+    def post_schedules_preview(self, body: PostSchedulesPreviewRequest) -> PostSchedulesPreviewResponse:
+        """List the next fire times of a cron expression or interval"""
+        return self._json("POST", "/schedules/preview", None, body)
+
+    def put_schedules_sync(self, body: PutSchedulesSyncRequest, *, dry_run: bool | None = None) -> PutSchedulesSyncResponse:
+        """Create, update and delete schedules to match a document (JSON, or YAML with a YAML Content-Type)"""
+        return self._json("PUT", "/schedules/sync", {"dry_run": dry_run}, body)
+
+    def get_schedules_id(self, id: str) -> GetSchedulesIdResponse:
+        """Get a schedule; send If-None-Match with its ETag to poll"""
+        return self._json("GET", f"/schedules/{_quote(id)}", None, None)
+
+    def patch_schedules_id(self, id: str, body: PatchSchedulesIdRequest) -> PatchSchedulesIdResponse:
+        """Update a schedule; omitted fields are unchanged"""
+        return self._json("PATCH", f"/schedules/{_quote(id)}", None, body)
+
+    def delete_schedules_id(self, id: str) -> None:
+        """Delete a schedule"""
+        return self._json("DELETE", f"/schedules/{_quote(id)}", None, None)
+
+    def post_schedules_id_dry_run(self, id: str) -> PostSchedulesIdDryRunResponse:
+        """Send the schedule's request once without firing it"""
+        return self._json("POST", f"/schedules/{_quote(id)}/dry-run", None, None)
+
+    def get_schedules_id_jobs(self, id: str, *, page_size: int | None = None, order: str | None = None, cursor: str | None = None, include_total: bool | None = None) -> GetSchedulesIdJobsResponse:
+        """List the jobs a schedule fired"""
+        return self._json("GET", f"/schedules/{_quote(id)}/jobs", {"page_size": page_size, "order": order, "cursor": cursor, "include_total": include_total}, None)
+
+    def post_schedules_id_pause(self, id: str) -> None:
+        """Pause a schedule"""
+        return self._json("POST", f"/schedules/{_quote(id)}/pause", None, None)
+
+    def post_schedules_id_resume(self, id: str) -> None:
+        """Resume a schedule"""
+        return self._json("POST", f"/schedules/{_quote(id)}/resume", None, None)
+
+    def get_schedules_id_revisions(self, id: str) -> GetSchedulesIdRevisionsResponse:
+        """List a schedule's revisions"""
+        return self._json("GET", f"/schedules/{_quote(id)}/revisions", None, None)
+
+    def post_schedules_id_revisions_revision_revert(self, id: str, revision: str) -> PostSchedulesIdRevisionsRevisionRevertResponse:
+        """Restore a schedule to a revision"""
+        return self._json("POST", f"/schedules/{_quote(id)}/revisions/{_quote(revision)}/revert", None, None)
+
+    def get_schedules_id_stats(self, id: str, *, window: str | None = None) -> GetSchedulesIdStatsResponse:
+        """Job counts, success rate and durations for a schedule"""
+        return self._json("GET", f"/schedules/{_quote(id)}/stats", {"window": window}, None)
+
+    def post_schedules_id_trigger(self, id: str) -> PostSchedulesIdTriggerResponse:
+        """Fire a schedule now"""
+        return self._json("POST", f"/schedules/{_quote(id)}/trigger", None, None)
+
+    def get_schedules_id_uptime(self, id: str, *, window: str | None = None) -> GetSchedulesIdUptimeResponse:
+        """Uptime of a ping schedule"""
+        return self._json("GET", f"/schedules/{_quote(id)}/uptime", {"window": window}, None)
+
+    def get_schemas_events(self) -> GetSchemasEventsResponse:
+        """List callback event schemas"""
+        return self._json("GET", "/schemas/events", None, None)
+
+    def get_schemas_events_file(self, file: str) -> GetSchemasEventsFileResponse:
+        """JSON Schema of a callback event, e.g. job.completed.json"""
+        return self._json("GET", f"/schemas/events/{_quote(file)}", None, None)
+
+    def get_search(self, *, q: str | None = None) -> GetSearchResponse:
+        """Search jobs and schedules"""
+        return self._json("GET", "/search", {"q": q}, None)
+
+    def get_stats(self, *, window: str | None = None) -> GetStatsResponse:
+        """Job counts, success rate and durations across the account"""
+        return self._json("GET", "/stats", {"window": window}, None)
+
+    def get_templates_catalog(self) -> GetTemplatesCatalogResponse:
+        """List job and schedule templates"""
+        return self._json("GET", "/templates/catalog", None, None)
+
+    def post_templates_id_instantiate(self, id: str, body: PostTemplatesIdInstantiateRequest) -> PostTemplatesIdInstantiateResponse:
+        """Create a job or schedule from a template"""
+        return self._json("POST", f"/templates/{_quote(id)}/instantiate", None, body)
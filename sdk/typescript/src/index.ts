@@ -0,0 +1,1718 @@
+// Code generated by cmd/sdkgen from the OpenAPI document. DO NOT EDIT.
+// dist-job-scheduler API, version 1.
+
+/** The body of every failed request. */
+export interface ErrorResponse {
+  error: {
+    code: string;
+    field_errors?: Array<{
+      field: string;
+      message: string;
+      rule: string;
+    }>;
+    message: string;
+    request_id?: string;
+  };
+}
+
+export interface GetAccountApiUsageResponse {
+  endpoints: Array<{
+    avg_duration_ms: number;
+    client_errors: number;
+    method: string;
+    requests: number;
+    route: string;
+    server_errors: number;
+  }>;
+  since: string;
+  total_requests: number;
+}
+
+export interface GetAccountDefaultsResponse {
+  backoff: string;
+  headers: Record<string, string>;
+  max_retries: number;
+  timeout_seconds: number;
+}
+
+export interface PatchAccountDefaultsRequest {
+  backoff?: "exponential" | "linear" | null;
+  headers?: Record<string, string>;
+  max_retries?: number | null;
+  timeout_seconds?: number | null;
+}
+
+export interface PatchAccountDefaultsResponse {
+  backoff: string;
+  headers: Record<string, string>;
+  max_retries: number;
+  timeout_seconds: number;
+}
+
+export interface GetAccountEgressResponse {
+  attempts: number;
+  request_bytes: number;
+  response_bytes: number;
+  schedules: Array<{
+    attempts: number;
+    request_bytes: number;
+    response_bytes: number;
+    schedule_id: string | null;
+    schedule_name: string | null;
+  }>;
+  since: string;
+}
+
+export interface GetAccountUsageResponse {
+  quotas: Array<{
+    exceeded: boolean;
+    limit: number;
+    quota: string;
+    used: number;
+    warning: boolean;
+  }>;
+  warnings: Array<string>;
+}
+
+export interface GetAdminNoticesResponse {
+  notices: Array<{
+    active: boolean;
+    body: string;
+    ends_at: string | null;
+    id: string;
+    severity: string;
+    starts_at: string;
+    title: string;
+  }>;
+}
+
+export interface PostAdminNoticesRequest {
+  body?: string;
+  ends_at?: string | null;
+  severity: "info" | "warning" | "critical";
+  starts_at?: string | null;
+  title: string;
+}
+
+export interface PostAdminNoticesResponse {
+  active: boolean;
+  body: string;
+  ends_at: string | null;
+  id: string;
+  severity: string;
+  starts_at: string;
+  title: string;
+}
+
+export interface GetCertificatesResponse {
+  certificates: Array<{
+    created_at: string;
+    fingerprint: string;
+    id: string;
+    name: string;
+    not_after: string;
+    subject: string;
+  }>;
+}
+
+export interface PostCertificatesRequest {
+  cert_pem: string;
+  key_pem: string;
+  name: string;
+}
+
+export interface PostCertificatesResponse {
+  created_at: string;
+  fingerprint: string;
+  id: string;
+  name: string;
+  not_after: string;
+  subject: string;
+}
+
+export interface GetJobsResponse {
+  has_more: boolean;
+  jobs: Array<{
+    completed_at?: string | null;
+    created_at: string;
+    id: string;
+    last_error?: string | null;
+    method: string;
+    schedule_id?: string | null;
+    scheduled_at: string;
+    status: string;
+    url: string;
+  }>;
+  next_cursor: string | null;
+  total_count?: number | null;
+}
+
+export interface PostJobsRequest {
+  backoff?: "exponential" | "linear";
+  body?: string | null;
+  body_encoding?: "utf8" | "base64";
+  ca_bundle?: string | null;
+  callback_url?: string | null;
+  client_cert_id?: string | null;
+  content_type?: string | null;
+  deadline?: string | null;
+  debug?: boolean;
+  depends_on?: string | null;
+  email_subject?: string | null;
+  email_to?: string | null;
+  expires_at?: string | null;
+  headers?: Record<string, string>;
+  idempotency_key: string;
+  job_type?: string;
+  max_retries?: number;
+  message_key?: string | null;
+  method?: "GET" | "POST" | "PUT" | "PATCH" | "DELETE";
+  on_parent_failure?: "skip" | "run";
+  priority?: number;
+  proxy_url?: string | null;
+  queue?: string;
+  retry_base_seconds?: number;
+  retry_delays?: Array<string>;
+  retry_jitter?: "auto" | "none" | "full" | "equal";
+  retry_max_seconds?: number;
+  scheduled_at: string;
+  signing_secret?: string | null;
+  success_codes?: Array<string>;
+  templated?: boolean;
+  timeout_seconds?: number;
+  tls_skip_verify?: boolean;
+  topic?: string | null;
+  url?: string;
+}
+
+export interface PostJobsResponse {
+  created_at: string;
+  id: string;
+  status: string;
+}
+
+export interface PostJobsBatchRequest {
+  jobs: Array<{
+    backoff?: "exponential" | "linear";
+    body?: string | null;
+    body_encoding?: "utf8" | "base64";
+    ca_bundle?: string | null;
+    callback_url?: string | null;
+    client_cert_id?: string | null;
+    content_type?: string | null;
+    deadline?: string | null;
+    debug?: boolean;
+    depends_on?: string | null;
+    email_subject?: string | null;
+    email_to?: string | null;
+    expires_at?: string | null;
+    headers?: Record<string, string>;
+    idempotency_key: string;
+    job_type?: string;
+    max_retries?: number;
+    message_key?: string | null;
+    method?: "GET" | "POST" | "PUT" | "PATCH" | "DELETE";
+    on_parent_failure?: "skip" | "run";
+    priority?: number;
+    proxy_url?: string | null;
+    queue?: string;
+    retry_base_seconds?: number;
+    retry_delays?: Array<string>;
+    retry_jitter?: "auto" | "none" | "full" | "equal";
+    retry_max_seconds?: number;
+    scheduled_at: string;
+    signing_secret?: string | null;
+    success_codes?: Array<string>;
+    templated?: boolean;
+    timeout_seconds?: number;
+    tls_skip_verify?: boolean;
+    topic?: string | null;
+    url?: string;
+  }>;
+}
+
+export interface PostJobsBatchResponse {
+  created: number;
+  duplicates: number;
+  errors: number;
+  results: Array<{
+    created_at?: string | null;
+    error?: {
+      code: string;
+      message: string;
+    } | null;
+    id?: string | null;
+    idempotency_key: string;
+    index: number;
+    job_status?: string | null;
+    status: string;
+  }>;
+}
+
+export interface PostJobsDryRunRequest {
+  body?: string | null;
+  body_encoding?: "utf8" | "base64";
+  ca_bundle?: string | null;
+  client_cert_id?: string | null;
+  content_type?: string | null;
+  headers?: Record<string, string>;
+  method: "GET" | "POST" | "PUT" | "PATCH" | "DELETE";
+  proxy_url?: string | null;
+  signing_secret?: string | null;
+  success_codes?: Array<string>;
+  templated?: boolean;
+  timeout_seconds?: number;
+  tls_skip_verify?: boolean;
+  url: string;
+}
+
+export interface PostJobsDryRunResponse {
+  duration_ms: number;
+  error: string | null;
+  response_body: string | null;
+  response_bytes: number | null;
+  response_headers: Record<string, string>;
+  response_truncated: boolean;
+  status_code: number | null;
+  succeeded: boolean;
+}
+
+export interface GetJobsIdResponse {
+  body_encoding: string;
+  ca_bundle?: string | null;
+  callback_url?: string | null;
+  cancel_requested_at?: string | null;
+  claimed_at?: string | null;
+  claimed_by?: string | null;
+  client_cert_id?: string | null;
+  completed_at?: string | null;
+  content_type?: string | null;
+  created_at: string;
+  created_by?: string | null;
+  deadline?: string | null;
+  debug: boolean;
+  depends_on?: string | null;
+  email_subject?: string | null;
+  email_to?: string | null;
+  expires_at?: string | null;
+  heartbeat_at?: string | null;
+  id: string;
+  job_type: string;
+  last_error?: string | null;
+  message_key?: string | null;
+  on_parent_failure?: string | null;
+  priority: number;
+  proxy_url?: string | null;
+  queue: string;
+  request_id?: string | null;
+  retried_from?: string | null;
+  retry_base_seconds: number;
+  retry_delays?: Array<string>;
+  retry_jitter: string;
+  retry_max_seconds: number;
+  reviewed_at?: string | null;
+  reviewed_by?: string | null;
+  schedule_id?: string | null;
+  scheduled_at: string;
+  seconds_since_last_heartbeat?: number | null;
+  signed: boolean;
+  status: string;
+  success_codes?: Array<string>;
+  templated: boolean;
+  tls_skip_verify: boolean;
+  topic?: string | null;
+  updated_at: string;
+}
+
+export interface GetJobsIdAttemptsResponse {
+  attempts: Array<{
+    attempt_num: number;
+    cancelled: boolean;
+    completed_at: string | null;
+    duration_ms: number | null;
+    error: string | null;
+    id: string;
+    idempotency_key: string;
+    job_id: string;
+    remote_addr: string | null;
+    request_bytes: number | null;
+    response_body: string | null;
+    response_bytes: number | null;
+    response_headers: Record<string, string>;
+    response_truncated: boolean;
+    started_at: string;
+    status_code: number | null;
+    worker_id: string;
+  }>;
+  has_more: boolean;
+  next_cursor: string | null;
+  total_count?: number | null;
+}
+
+export interface GetJobsIdAttemptsDiffResponse {
+  diffs: Array<{
+    changes: Array<{
+      field: string;
+      from: unknown;
+      to: unknown;
+    }>;
+    from_attempt: number;
+    latency_delta_ms: number | null;
+    to_attempt: number;
+  }>;
+  job_id: string;
+}
+
+export interface GetJobsIdAttemptsAttemptIdResponse {
+  attempt_num: number;
+  cancelled: boolean;
+  completed_at: string | null;
+  duration_ms: number | null;
+  error: string | null;
+  id: string;
+  idempotency_key: string;
+  job_id: string;
+  remote_addr: string | null;
+  request: {
+    body: string | null;
+    headers: Record<string, string>;
+    method: string;
+    url: string;
+  } | null;
+  request_bytes: number | null;
+  response_body: string | null;
+  response_bytes: number | null;
+  response_headers: Record<string, string>;
+  response_truncated: boolean;
+  started_at: string;
+  status_code: number | null;
+  worker_id: string;
+}
+
+export interface GetJobsIdCallbacksResponse {
+  callbacks: Array<{
+    attempts: number;
+    created_at: string;
+    delivered_at?: string | null;
+    event: string;
+    history: Array<{
+      attempt_num: number;
+      created_at: string;
+      duration_ms: number;
+      error: string | null;
+      response_snippet: string | null;
+      status_code: number | null;
+    }>;
+    id: string;
+    last_error?: string | null;
+    next_attempt_at?: string | null;
+    status: string;
+    url: string;
+  }>;
+  job_id: string;
+}
+
+export interface PostJobsIdCallbacksRetryResponse {
+  job_id: string;
+  queued: number;
+}
+
+export interface PostJobsIdRetryResponse {
+  created_at: string;
+  id: string;
+  idempotency_key: string;
+  retried_from: string;
+  scheduled_at: string;
+}
+
+export interface GetNoticesResponse {
+  notices: Array<{
+    active: boolean;
+    body: string;
+    ends_at: string | null;
+    id: string;
+    severity: string;
+    starts_at: string;
+    title: string;
+  }>;
+}
+
+export interface GetNotificationsRulesResponse {
+  rules: Array<{
+    channel: string;
+    created_at: string;
+    event: string;
+    id: string;
+    schedule_id: string | null;
+    target: string;
+    threshold: number;
+  }>;
+}
+
+export interface PostNotificationsRulesRequest {
+  channel: "email" | "slack";
+  event: "job.failed" | "schedule.failing" | "digest.daily" | "digest.weekly" | "quota.warning" | "approval.requested";
+  schedule_id?: string | null;
+  target: string;
+  threshold?: number;
+}
+
+export interface PostNotificationsRulesResponse {
+  channel: string;
+  created_at: string;
+  event: string;
+  id: string;
+  schedule_id: string | null;
+  target: string;
+  threshold: number;
+}
+
+export type GetOpenapiJsonResponse = unknown;
+
+export interface GetOrgsResponse {
+  organizations: Array<{
+    created_at: string;
+    id: string;
+    kms_key_ref: string | null;
+    name: string;
+    require_approval: boolean;
+    role: string;
+  }>;
+}
+
+export interface PostOrgsRequest {
+  name: string;
+}
+
+export interface PostOrgsResponse {
+  created_at: string;
+  id: string;
+  kms_key_ref: string | null;
+  name: string;
+  require_approval: boolean;
+  role: string;
+}
+
+export interface PostOrgsInvitationsAcceptRequest {
+  token: string;
+}
+
+export interface PostOrgsInvitationsAcceptResponse {
+  org_id: string;
+  role: string;
+}
+
+export interface PutOrgsIdApprovalModeRequest {
+  require_approval: boolean | null;
+}
+
+export interface GetOrgsIdApprovalsResponse {
+  approvals: Array<{
+    created_at: string;
+    created_by: string | null;
+    id: string;
+    job_type: string;
+    method: string;
+    scheduled_at: string;
+    url: string;
+  }>;
+}
+
+export interface PostOrgsIdApprovalsJobIdApproveResponse {
+  id: string;
+  reviewed_at: string | null;
+  reviewed_by: string | null;
+  status: string;
+}
+
+export interface PostOrgsIdApprovalsJobIdRejectResponse {
+  id: string;
+  reviewed_at: string | null;
+  reviewed_by: string | null;
+  status: string;
+}
+
+export interface PutOrgsIdEncryptionKeyRequest {
+  key_ref: string;
+}
+
+export interface GetOrgsIdInvitationsResponse {
+  invitations: Array<{
+    created_at: string;
+    email: string | null;
+    expires_at: string;
+    id: string;
+    invited_by: string;
+    role: string;
+  }>;
+}
+
+export interface PostOrgsIdInvitationsRequest {
+  email?: string | null;
+  role: "owner" | "editor" | "viewer";
+}
+
+export interface PostOrgsIdInvitationsResponse {
+  created_at: string;
+  email: string | null;
+  expires_at: string;
+  id: string;
+  invited_by: string;
+  role: string;
+  token: string;
+}
+
+export interface GetOrgsIdMembersResponse {
+  members: Array<{
+    created_at: string;
+    email: string | null;
+    role: string;
+    user_id: string;
+  }>;
+}
+
+export interface PatchOrgsIdMembersUserIdRequest {
+  role: "owner" | "editor" | "viewer";
+}
+
+export interface GetSchedulesResponse {
+  has_more: boolean;
+  next_cursor: string | null;
+  schedules: Array<{
+    backoff: string;
+    body_encoding: string;
+    ca_bundle?: string | null;
+    client_cert_id?: string | null;
+    consecutive_failures: number;
+    content_type?: string | null;
+    created_at: string;
+    cron_expr: string;
+    email_subject?: string | null;
+    email_to?: string | null;
+    every?: string;
+    id: string;
+    jitter_seconds: number;
+    job_type: string;
+    last_run_at?: string | null;
+    max_retries: number;
+    message_key?: string | null;
+    method: string;
+    mode: string;
+    name: string;
+    next_run_at: string;
+    overlap_policy: string;
+    pause_after_failures: number;
+    paused: boolean;
+    proxy_url?: string | null;
+    queue: string;
+    retry_base_seconds: number;
+    retry_jitter: string;
+    retry_max_seconds: number;
+    signed: boolean;
+    success_codes?: Array<string>;
+    templated: boolean;
+    timeout_seconds: number;
+    timezone: string;
+    tls_skip_verify: boolean;
+    topic?: string | null;
+    url: string;
+  }>;
+  total_count?: number | null;
+}
+
+export interface PostSchedulesRequest {
+  backoff?: "exponential" | "linear";
+  body?: string | null;
+  body_encoding?: "utf8" | "base64";
+  ca_bundle?: string | null;
+  client_cert_id?: string | null;
+  content_type?: string | null;
+  cron_expr?: string;
+  email_subject?: string | null;
+  email_to?: string | null;
+  every?: string;
+  headers?: Record<string, string>;
+  jitter_seconds?: number;
+  job_type?: string;
+  max_retries?: number;
+  message_key?: string | null;
+  method?: "GET" | "HEAD" | "POST" | "PUT" | "PATCH" | "DELETE";
+  mode?: "standard" | "ping";
+  name: string;
+  overlap_policy?: "queue" | "skip" | "replace";
+  pause_after_failures?: number;
+  proxy_url?: string | null;
+  queue?: string;
+  retry_base_seconds?: number;
+  retry_jitter?: "auto" | "none" | "full" | "equal";
+  retry_max_seconds?: number;
+  signing_secret?: string | null;
+  success_codes?: Array<string>;
+  templated?: boolean;
+  timeout_seconds?: number;
+  timezone?: string;
+  tls_skip_verify?: boolean;
+  topic?: string | null;
+  url?: string;
+}
+
+export interface PostSchedulesResponse {
+  backoff: string;
+  body_encoding: string;
+  ca_bundle?: string | null;
+  client_cert_id?: string | null;
+  consecutive_failures: number;
+  content_type?: string | null;
+  created_at: string;
+  cron_expr: string;
+  email_subject?: string | null;
+  email_to?: string | null;
+  every?: string;
+  id: string;
+  jitter_seconds: number;
+  job_type: string;
+  last_run_at?: string | null;
+  max_retries: number;
+  message_key?: string | null;
+  method: string;
+  mode: string;
+  name: string;
+  next_run_at: string;
+  overlap_policy: string;
+  pause_after_failures: number;
+  paused: boolean;
+  proxy_url?: string | null;
+  queue: string;
+  retry_base_seconds: number;
+  retry_jitter: string;
+  retry_max_seconds: number;
+  signed: boolean;
+  success_codes?: Array<string>;
+  templated: boolean;
+  timeout_seconds: number;
+  timezone: string;
+  tls_skip_verify: boolean;
+  topic?: string | null;
+  url: string;
+}
+
+export interface GetSchedulesExportResponse {
+  exported_at: string;
+  schedules: Array<{
+    backoff: string;
+    body: string | null;
+    body_encoding?: string;
+    ca_bundle?: string | null;
+    client_cert_id?: string | null;
+    content_type?: string | null;
+    cron_expr: string;
+    email_subject?: string | null;
+    email_to?: string | null;
+    every?: string;
+    headers: Record<string, string>;
+    history?: {
+      completed_runs: number;
+      failed_runs: number;
+      last_error?: string | null;
+      last_finished_at?: string | null;
+      last_run_at?: string | null;
+      last_status?: string | null;
+      total_runs: number;
+    } | null;
+    jitter_seconds: number;
+    job_type?: string;
+    last_run_at?: string | null;
+    max_retries: number;
+    message_key?: string | null;
+    method: string;
+    mode: string;
+    name: string;
+    overlap_policy: string;
+    pause_after_failures?: number;
+    paused: boolean;
+    proxy_url?: string | null;
+    queue?: string;
+    retry_base_seconds: number;
+    retry_jitter: string;
+    retry_max_seconds: number;
+    signing_secret?: string | null;
+    success_codes: Array<string>;
+    templated: boolean;
+    timeout_seconds: number;
+    timezone: string;
+    tls_skip_verify?: boolean;
+    topic?: string | null;
+    url: string;
+  }>;
+  version: number;
+}
+
+export interface PostSchedulesImportRequest {
+  schedules: Array<{
+    backoff?: "exponential" | "linear";
+    body?: string | null;
+    body_encoding?: "utf8" | "base64";
+    ca_bundle?: string | null;
+    client_cert_id?: string | null;
+    content_type?: string | null;
+    cron_expr?: string;
+    email_subject?: string | null;
+    email_to?: string | null;
+    every?: string;
+    headers?: Record<string, string>;
+    history?: {
+      completed_runs?: number;
+      failed_runs?: number;
+      last_error?: string | null;
+      last_finished_at?: string | null;
+      last_run_at?: string | null;
+      last_status?: string | null;
+      total_runs?: number;
+    } | null;
+    jitter_seconds?: number;
+    job_type?: string;
+    last_run_at?: string | null;
+    max_retries?: number;
+    message_key?: string | null;
+    method?: "GET" | "HEAD" | "POST" | "PUT" | "PATCH" | "DELETE";
+    mode?: "standard" | "ping";
+    name: string;
+    overlap_policy?: "queue" | "skip" | "replace";
+    pause_after_failures?: number;
+    paused?: boolean;
+    proxy_url?: string | null;
+    queue?: string;
+    retry_base_seconds?: number;
+    retry_jitter?: "auto" | "none" | "full" | "equal";
+    retry_max_seconds?: number;
+    signing_secret?: string | null;
+    success_codes?: Array<string>;
+    templated?: boolean;
+    timeout_seconds?: number;
+    timezone?: string;
+    tls_skip_verify?: boolean;
+    topic?: string | null;
+    url?: string;
+  }>;
+  version: number;
+}
+
+export interface PostSchedulesImportResponse {
+  created: number;
+  failed: number;
+  mode: string;
+  results: Array<{
+    changed?: Array<string>;
+    error?: {
+      code: string;
+      message: string;
+    } | null;
+    id?: string | null;
+    name: string;
+    status: string;
+  }>;
+  skipped: number;
+}
+
+export interface PostSchedulesPreviewRequest {
+  count?: number;
+  cron_expr?: string;
+  every?: string;
+  timezone?: string;
+}
+
+export interface PostSchedulesPreviewResponse {
+  runs: Array<string>;
+  timezone: string;
+}
+
+export interface PutSchedulesSyncRequest {
+  schedules: Array<{
+    backoff?: "exponential" | "linear";
+    body?: string | null;
+    body_encoding?: "utf8" | "base64";
+    ca_bundle?: string | null;
+    client_cert_id?: string | null;
+    content_type?: string | null;
+    cron_expr?: string;
+    email_subject?: string | null;
+    email_to?: string | null;
+    every?: string;
+    headers?: Record<string, string>;
+    history?: {
+      completed_runs?: number;
+      failed_runs?: number;
+      last_error?: string | null;
+      last_finished_at?: string | null;
+      last_run_at?: string | null;
+      last_status?: string | null;
+      total_runs?: number;
+    } | null;
+    jitter_seconds?: number;
+    job_type?: string;
+    last_run_at?: string | null;
+    max_retries?: number;
+    message_key?: string | null;
+    method?: "GET" | "HEAD" | "POST" | "PUT" | "PATCH" | "DELETE";
+    mode?: "standard" | "ping";
+    name: string;
+    overlap_policy?: "queue" | "skip" | "replace";
+    pause_after_failures?: number;
+    paused?: boolean;
+    proxy_url?: string | null;
+    queue?: string;
+    retry_base_seconds?: number;
+    retry_jitter?: "auto" | "none" | "full" | "equal";
+    retry_max_seconds?: number;
+    signing_secret?: string | null;
+    success_codes?: Array<string>;
+    templated?: boolean;
+    timeout_seconds?: number;
+    timezone?: string;
+    tls_skip_verify?: boolean;
+    topic?: string | null;
+    url?: string;
+  }>;
+  version: number;
+}
+
+export interface PutSchedulesSyncResponse {
+  actions: Array<{
+    action?: string;
+    changed?: Array<string>;
+    error?: {
+      code: string;
+      message: string;
+    } | null;
+    id?: string | null;
+    name: string;
+  }>;
+  applied: boolean;
+  created: number;
+  deleted: number;
+  failed: number;
+  replaced: number;
+  unchanged: number;
+  updated: number;
+}
+
+export interface GetSchedulesIdResponse {
+  backoff: string;
+  body_encoding: string;
+  ca_bundle?: string | null;
+  client_cert_id?: string | null;
+  consecutive_failures: number;
+  content_type?: string | null;
+  created_at: string;
+  cron_expr: string;
+  email_subject?: string | null;
+  email_to?: string | null;
+  every?: string;
+  id: string;
+  jitter_seconds: number;
+  job_type: string;
+  last_run_at?: string | null;
+  max_retries: number;
+  message_key?: string | null;
+  method: string;
+  mode: string;
+  name: string;
+  next_run_at: string;
+  overlap_policy: string;
+  pause_after_failures: number;
+  paused: boolean;
+  proxy_url?: string | null;
+  queue: string;
+  retry_base_seconds: number;
+  retry_jitter: string;
+  retry_max_seconds: number;
+  signed: boolean;
+  success_codes?: Array<string>;
+  templated: boolean;
+  timeout_seconds: number;
+  timezone: string;
+  tls_skip_verify: boolean;
+  topic?: string | null;
+  url: string;
+}
+
+export interface PatchSchedulesIdRequest {
+  backoff?: "exponential" | "linear" | null;
+  body?: string | null;
+  body_encoding?: "utf8" | "base64" | null;
+  ca_bundle?: string | null;
+  client_cert_id?: string | null;
+  content_type?: string | null;
+  cron_expr?: string | null;
+  email_subject?: string | null;
+  email_to?: string | null;
+  every?: string | null;
+  headers?: Record<string, string>;
+  jitter_seconds?: number | null;
+  max_retries?: number | null;
+  message_key?: string | null;
+  method?: "GET" | "HEAD" | "POST" | "PUT" | "PATCH" | "DELETE" | null;
+  name?: string | null;
+  overlap_policy?: "queue" | "skip" | "replace" | null;
+  pause_after_failures?: number | null;
+  proxy_url?: string | null;
+  queue?: string | null;
+  retry_base_seconds?: number | null;
+  retry_jitter?: "auto" | "none" | "full" | "equal" | null;
+  retry_max_seconds?: number | null;
+  signing_secret?: string | null;
+  success_codes?: Array<string> | null;
+  templated?: boolean | null;
+  timeout_seconds?: number | null;
+  timezone?: string | null;
+  tls_skip_verify?: boolean | null;
+  topic?: string | null;
+  url?: string | null;
+}
+
+export interface PatchSchedulesIdResponse {
+  backoff: string;
+  body_encoding: string;
+  ca_bundle?: string | null;
+  client_cert_id?: string | null;
+  consecutive_failures: number;
+  content_type?: string | null;
+  created_at: string;
+  cron_expr: string;
+  email_subject?: string | null;
+  email_to?: string | null;
+  every?: string;
+  id: string;
+  jitter_seconds: number;
+  job_type: string;
+  last_run_at?: string | null;
+  max_retries: number;
+  message_key?: string | null;
+  method: string;
+  mode: string;
+  name: string;
+  next_run_at: string;
+  overlap_policy: string;
+  pause_after_failures: number;
+  paused: boolean;
+  proxy_url?: string | null;
+  queue: string;
+  retry_base_seconds: number;
+  retry_jitter: string;
+  retry_max_seconds: number;
+  signed: boolean;
+  success_codes?: Array<string>;
+  templated: boolean;
+  timeout_seconds: number;
+  timezone: string;
+  tls_skip_verify: boolean;
+  topic?: string | null;
+  url: string;
+}
+
+export interface PostSchedulesIdDryRunResponse {
+  duration_ms: number;
+  error: string | null;
+  response_body: string | null;
+  response_bytes: number | null;
+  response_headers: Record<string, string>;
+  response_truncated: boolean;
+  status_code: number | null;
+  succeeded: boolean;
+}
+
+export interface GetSchedulesIdJobsResponse {
+  has_more: boolean;
+  jobs: Array<{
+    completed_at?: string | null;
+    created_at: string;
+    id: string;
+    last_error?: string | null;
+    method: string;
+    schedule_id?: string | null;
+    scheduled_at: string;
+    status: string;
+    url: string;
+  }>;
+  next_cursor: string | null;
+  total_count?: number | null;
+}
+
+export interface GetSchedulesIdRevisionsResponse {
+  revisions: Array<{
+    action: string;
+    actor_id: string;
+    after: {
+      backoff: string;
+      body?: string | null;
+      body_encoding?: string;
+      ca_bundle?: string | null;
+      client_cert_id?: string | null;
+      content_type?: string | null;
+      cron_expr: string;
+      email_subject?: string | null;
+      email_to?: string | null;
+      every?: string;
+      headers?: Record<string, string>;
+      jitter_seconds?: number;
+      max_retries: number;
+      message_key?: string | null;
+      method: string;
+      name: string;
+      overlap_policy?: string;
+      pause_after_failures?: number;
+      paused: boolean;
+      proxied?: boolean;
+      queue?: string;
+      retry_base_seconds?: number;
+      retry_jitter?: string;
+      retry_max_seconds?: number;
+      signed?: boolean;
+      success_codes?: Array<string>;
+      templated?: boolean;
+      timeout_seconds: number;
+      timezone?: string;
+      tls_skip_verify?: boolean;
+      topic?: string | null;
+      url: string;
+    };
+    before: {
+      backoff: string;
+      body?: string | null;
+      body_encoding?: string;
+      ca_bundle?: string | null;
+      client_cert_id?: string | null;
+      content_type?: string | null;
+      cron_expr: string;
+      email_subject?: string | null;
+      email_to?: string | null;
+      every?: string;
+      headers?: Record<string, string>;
+      jitter_seconds?: number;
+      max_retries: number;
+      message_key?: string | null;
+      method: string;
+      name: string;
+      overlap_policy?: string;
+      pause_after_failures?: number;
+      paused: boolean;
+      proxied?: boolean;
+      queue?: string;
+      retry_base_seconds?: number;
+      retry_jitter?: string;
+      retry_max_seconds?: number;
+      signed?: boolean;
+      success_codes?: Array<string>;
+      templated?: boolean;
+      timeout_seconds: number;
+      timezone?: string;
+      tls_skip_verify?: boolean;
+      topic?: string | null;
+      url: string;
+    } | null;
+    changed?: Array<string>;
+    created_at: string;
+    revision: number;
+  }>;
+  schedule_id: string;
+}
+
+export interface PostSchedulesIdRevisionsRevisionRevertResponse {
+  backoff: string;
+  body_encoding: string;
+  ca_bundle?: string | null;
+  client_cert_id?: string | null;
+  consecutive_failures: number;
+  content_type?: string | null;
+  created_at: string;
+  cron_expr: string;
+  email_subject?: string | null;
+  email_to?: string | null;
+  every?: string;
+  id: string;
+  jitter_seconds: number;
+  job_type: string;
+  last_run_at?: string | null;
+  max_retries: number;
+  message_key?: string | null;
+  method: string;
+  mode: string;
+  name: string;
+  next_run_at: string;
+  overlap_policy: string;
+  pause_after_failures: number;
+  paused: boolean;
+  proxy_url?: string | null;
+  queue: string;
+  retry_base_seconds: number;
+  retry_jitter: string;
+  retry_max_seconds: number;
+  signed: boolean;
+  success_codes?: Array<string>;
+  templated: boolean;
+  timeout_seconds: number;
+  timezone: string;
+  tls_skip_verify: boolean;
+  topic?: string | null;
+  url: string;
+}
+
+export interface GetSchedulesIdStatsResponse {
+  attempts: number;
+  counts: Record<string, number>;
+  failed_attempts: number;
+  jobs: number;
+  p50_duration_ms: number | null;
+  p95_duration_ms: number | null;
+  schedule_id?: string | null;
+  since: string;
+  success_rate: number | null;
+}
+
+export interface PostSchedulesIdTriggerResponse {
+  created_at: string;
+  id: string;
+  idempotency_key: string;
+  schedule_id: string;
+  scheduled_at: string;
+}
+
+export interface GetSchedulesIdUptimeResponse {
+  avg_duration_ms: number;
+  checks: number;
+  downtime_seconds: number;
+  failures: number;
+  incidents: Array<{
+    ended_at: string | null;
+    last_error?: string | null;
+    started_at: string;
+  }>;
+  schedule_id: string;
+  since: string;
+  success_ratio: number | null;
+  uptime_ratio: number;
+}
+
+export interface GetSchemasEventsResponse {
+  events: Array<{
+    event: string;
+    schema: string;
+  }>;
+}
+
+export type GetSchemasEventsFileResponse = unknown;
+
+export interface GetSearchResponse {
+  query: string;
+  results: Array<{
+    created_at: string;
+    id: string;
+    kind: string;
+    matched_on: string;
+    status: string;
+    subtitle: string;
+    title: string;
+  }>;
+}
+
+export interface GetStatsResponse {
+  attempts: number;
+  counts: Record<string, number>;
+  failed_attempts: number;
+  jobs: number;
+  p50_duration_ms: number | null;
+  p95_duration_ms: number | null;
+  schedule_id?: string | null;
+  since: string;
+  success_rate: number | null;
+}
+
+export interface GetTemplatesCatalogResponse {
+  templates: Array<{
+    cron_expr?: string;
+    description: string;
+    id: string;
+    kind: string;
+    method: string;
+    name: string;
+    params: Array<{
+      default?: string;
+      description: string;
+      name: string;
+      pattern?: string;
+      required: boolean;
+    }>;
+  }>;
+}
+
+export interface PostTemplatesIdInstantiateRequest {
+  cron_expr?: string;
+  idempotency_key?: string;
+  name?: string;
+  params?: Record<string, string>;
+  scheduled_at?: string;
+  timezone?: string;
+}
+
+export interface PostTemplatesIdInstantiateResponse {
+  job?: {
+    created_at: string;
+    id: string;
+    status: string;
+  } | null;
+  kind: string;
+  schedule?: {
+    backoff: string;
+    body_encoding: string;
+    ca_bundle?: string | null;
+    client_cert_id?: string | null;
+    consecutive_failures: number;
+    content_type?: string | null;
+    created_at: string;
+    cron_expr: string;
+    email_subject?: string | null;
+    email_to?: string | null;
+    every?: string;
+    id: string;
+    jitter_seconds: number;
+    job_type: string;
+    last_run_at?: string | null;
+    max_retries: number;
+    message_key?: string | null;
+    method: string;
+    mode: string;
+    name: string;
+    next_run_at: string;
+    overlap_policy: string;
+    pause_after_failures: number;
+    paused: boolean;
+    proxy_url?: string | null;
+    queue: string;
+    retry_base_seconds: number;
+    retry_jitter: string;
+    retry_max_seconds: number;
+    signed: boolean;
+    success_codes?: Array<string>;
+    templated: boolean;
+    timeout_seconds: number;
+    timezone: string;
+    tls_skip_verify: boolean;
+    topic?: string | null;
+    url: string;
+  } | null;
+}
+
+/** Thrown for every non-2xx response; body is undefined when it wasn't JSON. */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly body: ErrorResponse | undefined,
+  ) {
+    super(body?.error?.message ?? `request failed with status ${status}`);
+    this.name = "ApiError";
+  }
+}
+
+export interface ClientOptions {
+  /** e.g. https://scheduler.example.com */
+  baseUrl: string;
+  /** Bearer JWT sent as Authorization. */
+  token?: string;
+  /** Act in an organization (sent as X-Org-ID). */
+  orgId?: string;
+  /** Defaults to the global fetch. */
+  fetch?: typeof fetch;
+}
+
+type Query = Record<string, string | number | boolean | undefined>;
+
+export class Client {
+  private readonly baseUrl: string;
+
+  constructor(private readonly options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, "");
+  }
+
+  private async send(method: string, path: string, query?: Query, body?: unknown): Promise<Response> {
+    const url = new URL(this.baseUrl + path);
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value !== undefined) url.searchParams.set(key, String(value));
+    }
+    const headers: Record<string, string> = {};
+    if (this.options.token) headers["Authorization"] = `Bearer ${this.options.token}`;
+    if (this.options.orgId) headers["X-Org-ID"] = this.options.orgId;
+    if (body !== undefined) headers["Content-Type"] = "application/json";
+
+    const res = await (this.options.fetch ?? fetch)(url, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    if (!res.ok) {
+      let err: ErrorResponse | undefined;
+      try {
+        err = (await res.json()) as ErrorResponse;
+      } catch {
+        err = undefined;
+      }
+      throw new ApiError(res.status, err);
+    }
+    return res;
+  }
+
+  private async json<T>(method: string, path: string, query?: Query, body?: unknown): Promise<T> {
+    const res = await this.send(method, path, query, body);
+    const text = await res.text();
+    return (text === "" ? undefined : JSON.parse(text)) as T;
+  }
+
+  /** Per-endpoint request counts */
+  getAccountApiUsage(query: { window?: string } = {}): Promise<GetAccountApiUsageResponse> {
+    return this.json<GetAccountApiUsageResponse>("GET", `/account/api-usage`, query, undefined);
+  }
+
+  /** Job defaults */
+  getAccountDefaults(): Promise<GetAccountDefaultsResponse> {
+    return this.json<GetAccountDefaultsResponse>("GET", `/account/defaults`, undefined, undefined);
+  }
+
+  /** Update job defaults; omitted fields are unchanged */
+  patchAccountDefaults(body: PatchAccountDefaultsRequest): Promise<PatchAccountDefaultsResponse> {
+    return this.json<PatchAccountDefaultsResponse>("PATCH", `/account/defaults`, undefined, body);
+  }
+
+  /** Bytes sent and received by job attempts */
+  getAccountEgress(query: { window?: string } = {}): Promise<GetAccountEgressResponse> {
+    return this.json<GetAccountEgressResponse>("GET", `/account/egress`, query, undefined);
+  }
+
+  /** Quota usage */
+  getAccountUsage(): Promise<GetAccountUsageResponse> {
+    return this.json<GetAccountUsageResponse>("GET", `/account/usage`, undefined, undefined);
+  }
+
+  /** List all notices (admin) */
+  getAdminNotices(): Promise<GetAdminNoticesResponse> {
+    return this.json<GetAdminNoticesResponse>("GET", `/admin/notices`, undefined, undefined);
+  }
+
+  /** Create a notice (admin) */
+  postAdminNotices(body: PostAdminNoticesRequest): Promise<PostAdminNoticesResponse> {
+    return this.json<PostAdminNoticesResponse>("POST", `/admin/notices`, undefined, body);
+  }
+
+  /** Delete a notice (admin) */
+  deleteAdminNoticesId(id: string): Promise<void> {
+    return this.json<void>("DELETE", `/admin/notices/${encodeURIComponent(id)}`, undefined, undefined);
+  }
+
+  /** List your client certificates for mutual TLS */
+  getCertificates(): Promise<GetCertificatesResponse> {
+    return this.json<GetCertificatesResponse>("GET", `/certificates`, undefined, undefined);
+  }
+
+  /** Upload a client certificate and key; reference it from jobs as client_cert_id */
+  postCertificates(body: PostCertificatesRequest): Promise<PostCertificatesResponse> {
+    return this.json<PostCertificatesResponse>("POST", `/certificates`, undefined, body);
+  }
+
+  /** Delete a client certificate no unfinished job or schedule uses */
+  deleteCertificatesId(id: string): Promise<void> {
+    return this.json<void>("DELETE", `/certificates/${encodeURIComponent(id)}`, undefined, undefined);
+  }
+
+  /** List jobs */
+  getJobs(query: { status?: string; request_id?: string; url_contains?: string; idempotency_key?: string; schedule_id?: string; scheduled_after?: string; scheduled_before?: string; page_size?: number; order?: string; cursor?: string; include_total?: boolean } = {}): Promise<GetJobsResponse> {
+    return this.json<GetJobsResponse>("GET", `/jobs`, query, undefined);
+  }
+
+  /** Create a job */
+  postJobs(body: PostJobsRequest): Promise<PostJobsResponse> {
+    return this.json<PostJobsResponse>("POST", `/jobs`, undefined, body);
+  }
+
+  /** Create up to 100 jobs in one transaction */
+  postJobsBatch(body: PostJobsBatchRequest): Promise<PostJobsBatchResponse> {
+    return this.json<PostJobsBatchResponse>("POST", `/jobs/batch`, undefined, body);
+  }
+
+  /** Send a job's request once without creating the job */
+  postJobsDryRun(body: PostJobsDryRunRequest): Promise<PostJobsDryRunResponse> {
+    return this.json<PostJobsDryRunResponse>("POST", `/jobs/dry-run`, undefined, body);
+  }
+
+  /** Stream job status transitions as Server-Sent Events named "status" */
+  getJobsStream(): Promise<Response> {
+    return this.send("GET", `/jobs/stream`, undefined, undefined);
+  }
+
+  /** Get a job; send If-None-Match with its ETag to poll */
+  getJobsId(id: string): Promise<GetJobsIdResponse> {
+    return this.json<GetJobsIdResponse>("GET", `/jobs/${encodeURIComponent(id)}`, undefined, undefined);
+  }
+
+  /** Cancel a job */
+  deleteJobsId(id: string): Promise<void> {
+    return this.json<void>("DELETE", `/jobs/${encodeURIComponent(id)}`, undefined, undefined);
+  }
+
+  /** List a job's attempts, oldest first by default */
+  getJobsIdAttempts(id: string, query: { page_size?: number; order?: string; cursor?: string; include_total?: boolean } = {}): Promise<GetJobsIdAttemptsResponse> {
+    return this.json<GetJobsIdAttemptsResponse>("GET", `/jobs/${encodeURIComponent(id)}/attempts`, query, undefined);
+  }
+
+  /** Diff consecutive attempts */
+  getJobsIdAttemptsDiff(id: string, query: { from?: number } = {}): Promise<GetJobsIdAttemptsDiffResponse> {
+    return this.json<GetJobsIdAttemptsDiffResponse>("GET", `/jobs/${encodeURIComponent(id)}/attempts/diff`, query, undefined);
+  }
+
+  /** Get one attempt with its request snapshot */
+  getJobsIdAttemptsAttemptId(id: string, attempt_id: string): Promise<GetJobsIdAttemptsAttemptIdResponse> {
+    return this.json<GetJobsIdAttemptsAttemptIdResponse>("GET", `/jobs/${encodeURIComponent(id)}/attempts/${encodeURIComponent(attempt_id)}`, undefined, undefined);
+  }
+
+  /** List a job's completion callbacks */
+  getJobsIdCallbacks(id: string): Promise<GetJobsIdCallbacksResponse> {
+    return this.json<GetJobsIdCallbacksResponse>("GET", `/jobs/${encodeURIComponent(id)}/callbacks`, undefined, undefined);
+  }
+
+  /** Redeliver failed callbacks */
+  postJobsIdCallbacksRetry(id: string): Promise<PostJobsIdCallbacksRetryResponse> {
+    return this.json<PostJobsIdCallbacksRetryResponse>("POST", `/jobs/${encodeURIComponent(id)}/callbacks/retry`, undefined, undefined);
+  }
+
+  /** Pause a pending job */
+  postJobsIdPause(id: string): Promise<void> {
+    return this.json<void>("POST", `/jobs/${encodeURIComponent(id)}/pause`, undefined, undefined);
+  }
+
+  /** Resume a paused job */
+  postJobsIdResume(id: string): Promise<void> {
+    return this.json<void>("POST", `/jobs/${encodeURIComponent(id)}/resume`, undefined, undefined);
+  }
+
+  /** Resubmit a finished job as a new job */
+  postJobsIdRetry(id: string): Promise<PostJobsIdRetryResponse> {
+    return this.json<PostJobsIdRetryResponse>("POST", `/jobs/${encodeURIComponent(id)}/retry`, undefined, undefined);
+  }
+
+  /** Active service notices */
+  getNotices(): Promise<GetNoticesResponse> {
+    return this.json<GetNoticesResponse>("GET", `/notices`, undefined, undefined);
+  }
+
+  /** List notification rules */
+  getNotificationsRules(): Promise<GetNotificationsRulesResponse> {
+    return this.json<GetNotificationsRulesResponse>("GET", `/notifications/rules`, undefined, undefined);
+  }
+
+  /** Create a notification rule */
+  postNotificationsRules(body: PostNotificationsRulesRequest): Promise<PostNotificationsRulesResponse> {
+    return this.json<PostNotificationsRulesResponse>("POST", `/notifications/rules`, undefined, body);
+  }
+
+  /** Delete a notification rule */
+  deleteNotificationsRulesId(id: string): Promise<void> {
+    return this.json<void>("DELETE", `/notifications/rules/${encodeURIComponent(id)}`, undefined, undefined);
+  }
+
+  /** This document */
+  getOpenapiJson(): Promise<GetOpenapiJsonResponse> {
+    return this.json<GetOpenapiJsonResponse>("GET", `/openapi.json`, undefined, undefined);
+  }
+
+  /** List your organizations and your role in each */
+  getOrgs(): Promise<GetOrgsResponse> {
+    return this.json<GetOrgsResponse>("GET", `/orgs`, undefined, undefined);
+  }
+
+  /** Create an organization, with you as its owner */
+  postOrgs(body: PostOrgsRequest): Promise<PostOrgsResponse> {
+    return this.json<PostOrgsResponse>("POST", `/orgs`, undefined, body);
+  }
+
+  /** Join an organization with an invitation token */
+  postOrgsInvitationsAccept(body: PostOrgsInvitationsAcceptRequest): Promise<PostOrgsInvitationsAcceptResponse> {
+    return this.json<PostOrgsInvitationsAcceptResponse>("POST", `/orgs/invitations/accept`, undefined, body);
+  }
+
+  /** Hold jobs that members other than owners create until an owner approves them (owners only) */
+  putOrgsIdApprovalMode(id: string, body: PutOrgsIdApprovalModeRequest): Promise<void> {
+    return this.json<void>("PUT", `/orgs/${encodeURIComponent(id)}/approval-mode`, undefined, body);
+  }
+
+  /** List the organization's jobs awaiting approval, those due first */
+  getOrgsIdApprovals(id: string): Promise<GetOrgsIdApprovalsResponse> {
+    return this.json<GetOrgsIdApprovalsResponse>("GET", `/orgs/${encodeURIComponent(id)}/approvals`, undefined, undefined);
+  }
+
+  /** Approve a job awaiting approval (owners only) */
+  postOrgsIdApprovalsJobIdApprove(id: string, job_id: string): Promise<PostOrgsIdApprovalsJobIdApproveResponse> {
+    return this.json<PostOrgsIdApprovalsJobIdApproveResponse>("POST", `/orgs/${encodeURIComponent(id)}/approvals/${encodeURIComponent(job_id)}/approve`, undefined, undefined);
+  }
+
+  /** Reject a job awaiting approval, cancelling it (owners only) */
+  postOrgsIdApprovalsJobIdReject(id: string, job_id: string): Promise<PostOrgsIdApprovalsJobIdRejectResponse> {
+    return this.json<PostOrgsIdApprovalsJobIdRejectResponse>("POST", `/orgs/${encodeURIComponent(id)}/approvals/${encodeURIComponent(job_id)}/reject`, undefined, undefined);
+  }
+
+  /** Encrypt the organization's new secrets with its own KMS key (owners only) */
+  putOrgsIdEncryptionKey(id: string, body: PutOrgsIdEncryptionKeyRequest): Promise<void> {
+    return this.json<void>("PUT", `/orgs/${encodeURIComponent(id)}/encryption-key`, undefined, body);
+  }
+
+  /** Go back to the deployment's encryption keys for new secrets (owners only) */
+  deleteOrgsIdEncryptionKey(id: string): Promise<void> {
+    return this.json<void>("DELETE", `/orgs/${encodeURIComponent(id)}/encryption-key`, undefined, undefined);
+  }
+
+  /** List pending invitations (owners only) */
+  getOrgsIdInvitations(id: string): Promise<GetOrgsIdInvitationsResponse> {
+    return this.json<GetOrgsIdInvitationsResponse>("GET", `/orgs/${encodeURIComponent(id)}/invitations`, undefined, undefined);
+  }
+
+  /** Invite a member (owners only); the token is shown only in this response */
+  postOrgsIdInvitations(id: string, body: PostOrgsIdInvitationsRequest): Promise<PostOrgsIdInvitationsResponse> {
+    return this.json<PostOrgsIdInvitationsResponse>("POST", `/orgs/${encodeURIComponent(id)}/invitations`, undefined, body);
+  }
+
+  /** Revoke a pending invitation (owners only) */
+  deleteOrgsIdInvitationsInvitationId(id: string, invitation_id: string): Promise<void> {
+    return this.json<void>("DELETE", `/orgs/${encodeURIComponent(id)}/invitations/${encodeURIComponent(invitation_id)}`, undefined, undefined);
+  }
+
+  /** List an organization's members */
+  getOrgsIdMembers(id: string): Promise<GetOrgsIdMembersResponse> {
+    return this.json<GetOrgsIdMembersResponse>("GET", `/orgs/${encodeURIComponent(id)}/members`, undefined, undefined);
+  }
+
+  /** Change a member's role (owners only) */
+  patchOrgsIdMembersUserId(id: string, user_id: string, body: PatchOrgsIdMembersUserIdRequest): Promise<void> {
+    return this.json<void>("PATCH", `/orgs/${encodeURIComponent(id)}/members/${encodeURIComponent(user_id)}`, undefined, body);
+  }
+
+  /** Remove a member (owners), or leave the organization */
+  deleteOrgsIdMembersUserId(id: string, user_id: string): Promise<void> {
+    return this.json<void>("DELETE", `/orgs/${encodeURIComponent(id)}/members/${encodeURIComponent(user_id)}`, undefined, undefined);
+  }
+
+  /** List schedules */
+  getSchedules(query: { page_size?: number; order?: string; cursor?: string; include_total?: boolean } = {}): Promise<GetSchedulesResponse> {
+    return this.json<GetSchedulesResponse>("GET", `/schedules`, query, undefined);
+  }
+
+  /** Create a schedule */
+  postSchedules(body: PostSchedulesRequest): Promise<PostSchedulesResponse> {
+    return this.json<PostSchedulesResponse>("POST", `/schedules`, undefined, body);
+  }
+
+  /** Export all schedules, secrets included (viewers get 403) */
+  getSchedulesExport(query: { include_history?: boolean; format?: string } = {}): Promise<GetSchedulesExportResponse> {
+    return this.json<GetSchedulesExportResponse>("GET", `/schedules/export`, query, undefined);
+  }
+
+  /** Import an export document (JSON, or YAML with a YAML Content-Type) */
+  postSchedulesImport(body: PostSchedulesImportRequest, query: { mode?: string } = {}): Promise<PostSchedulesImportResponse> {
+    return this.json<PostSchedulesImportResponse>("POST", `/schedules/import`, query, body);
+  }
+
+  /** List the next fire times of a cron expression or interval */
+  postSchedulesPreview(body: PostSchedulesPreviewRequest): Promise<PostSchedulesPreviewResponse> {
+    return this.json<PostSchedulesPreviewResponse>("POST", `/schedules/preview`, undefined, body);
+  }
+
+  /** Create, update and delete schedules to match a document (JSON, or YAML with a YAML Content-Type) */
+  putSchedulesSync(body: PutSchedulesSyncRequest, query: { dry_run?: boolean } = {}): Promise<PutSchedulesSyncResponse> {
+    return this.json<PutSchedulesSyncResponse>("PUT", `/schedules/sync`, query, body);
+  }
+
+  /** Get a schedule; send If-None-Match with its ETag to poll */
+  getSchedulesId(id: string): Promise<GetSchedulesIdResponse> {
+    return this.json<GetSchedulesIdResponse>("GET", `/schedules/${encodeURIComponent(id)}`, undefined, undefined);
+  }
+
+  /** Update a schedule; omitted fields are unchanged */
+  patchSchedulesId(id: string, body: PatchSchedulesIdRequest): Promise<PatchSchedulesIdResponse> {
+    return this.json<PatchSchedulesIdResponse>("PATCH", `/schedules/${encodeURIComponent(id)}`, undefined, body);
+  }
+
+  /** Delete a schedule */
+  deleteSchedulesId(id: string): Promise<void> {
+    return this.json<void>("DELETE", `/schedules/${encodeURIComponent(id)}`, undefined, undefined);
+  }
+
+  /** Send the schedule's request once without firing it */
+  postSchedulesIdDryRun(id: string): Promise<PostSchedulesIdDryRunResponse> {
+    return this.json<PostSchedulesIdDryRunResponse>("POST", `/schedules/${encodeURIComponent(id)}/dry-run`, undefined, undefined);
+  }
+
+  /** List the jobs a schedule fired */
+  getSchedulesIdJobs(id: string, query: { page_size?: number; order?: string; cursor?: string; include_total?: boolean } = {}): Promise<GetSchedulesIdJobsResponse> {
+    return this.json<GetSchedulesIdJobsResponse>("GET", `/schedules/${encodeURIComponent(id)}/jobs`, query, undefined);
+  }
+
+  /** Pause a schedule */
+  postSchedulesIdPause(id: string): Promise<void> {
+    return this.json<void>("POST", `/schedules/${encodeURIComponent(id)}/pause`, undefined, undefined);
+  }
+
+  /** Resume a schedule */
+  postSchedulesIdResume(id: string): Promise<void> {
+    return this.json<void>("POST", `/schedules/${encodeURIComponent(id)}/resume`, undefined, undefined);
+  }
+
+  /** List a schedule's revisions */
+  getSchedulesIdRevisions(id: string): Promise<GetSchedulesIdRevisionsResponse> {
+    return this.json<GetSchedulesIdRevisionsResponse>("GET", `/schedules/${encodeURIComponent(id)}/revisions`, undefined, undefined);
+  }
+
+  /** Restore a schedule to a revision */
+  postSchedulesIdRevisionsRevisionRevert(id: string, revision: string): Promise<PostSchedulesIdRevisionsRevisionRevertResponse> {
+    return this.json<PostSchedulesIdRevisionsRevisionRevertResponse>("POST", `/schedules/${encodeURIComponent(id)}/revisions/${encodeURIComponent(revision)}/revert`, undefined, undefined);
+  }
+
+  /** Job counts, success rate and durations for a schedule */
+  getSchedulesIdStats(id: string, query: { window?: string } = {}): Promise<GetSchedulesIdStatsResponse> {
+    return this.json<GetSchedulesIdStatsResponse>("GET", `/schedules/${encodeURIComponent(id)}/stats`, query, undefined);
+  }
+
+  /** Fire a schedule now */
+  postSchedulesIdTrigger(id: string): Promise<PostSchedulesIdTriggerResponse> {
+    return this.json<PostSchedulesIdTriggerResponse>("POST", `/schedules/${encodeURIComponent(id)}/trigger`, undefined, undefined);
+  }
+
+  /** Uptime of a ping schedule */
+  getSchedulesIdUptime(id: string, query: { window?: string } = {}): Promise<GetSchedulesIdUptimeResponse> {
+    return this.json<GetSchedulesIdUptimeResponse>("GET", `/schedules/${encodeURIComponent(id)}/uptime`, query, undefined);
+  }
+
+  /** List callback event schemas */
+  getSchemasEvents(): Promise<GetSchemasEventsResponse> {
+    return this.json<GetSchemasEventsResponse>("GET", `/schemas/events`, undefined, undefined);
+  }
+
+  /** JSON Schema of a callback event, e.g. job.completed.json */
+  getSchemasEventsFile(file: string): Promise<GetSchemasEventsFileResponse> {
+    return this.json<GetSchemasEventsFileResponse>("GET", `/schemas/events/${encodeURIComponent(file)}`, undefined, undefined);
+  }
+
+  /** Search jobs and schedules */
+  getSearch(query: { q?: string } = {}): Promise<GetSearchResponse> {
+    return this.json<GetSearchResponse>("GET", `/search`, query, undefined);
+  }
+
+  /** Job counts, success rate and durations across the account */
+  getStats(query: { window?: string } = {}): Promise<GetStatsResponse> {
+    return this.json<GetStatsResponse>("GET", `/stats`, query, undefined);
+  }
+
+  /** List job and schedule templates */
+  getTemplatesCatalog(): Promise<GetTemplatesCatalogResponse> {
+    return this.json<GetTemplatesCatalogResponse>("GET", `/templates/catalog`, undefined, undefined);
+  }
+
+  /** Create a job or schedule from a template */
+  postTemplatesIdInstantiate(id: string, body: PostTemplatesIdInstantiateRequest): Promise<PostTemplatesIdInstantiateResponse> {
+    return this.json<PostTemplatesIdInstantiateResponse>("POST", `/templates/${encodeURIComponent(id)}/instantiate`, undefined, body);
+  }
+}